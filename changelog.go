@@ -0,0 +1,9 @@
+// Package discordbot はリポジトリ直下のファイルをバイナリに埋め込むためのパッケージ
+package discordbot
+
+import _ "embed"
+
+// Changelog は CHANGELOG.md の内容
+//
+//go:embed CHANGELOG.md
+var Changelog string
@@ -5,38 +5,55 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"runtime/debug"
 	"syscall"
+	"time"
 
+	discordbot "github.com/aktnb/discord-bot-go"
+	auditapp "github.com/aktnb/discord-bot-go/internal/application/audit"
 	"github.com/aktnb/discord-bot-go/internal/application/cat"
 	"github.com/aktnb/discord-bot-go/internal/application/collatz"
 	"github.com/aktnb/discord-bot-go/internal/application/dog"
-	"github.com/aktnb/discord-bot-go/internal/application/faker"
-	"github.com/aktnb/discord-bot-go/internal/application/ichiro"
-	"github.com/aktnb/discord-bot-go/internal/application/jeffdean"
+	"github.com/aktnb/discord-bot-go/internal/application/guildcommand"
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
 	"github.com/aktnb/discord-bot-go/internal/application/mahjong"
 	"github.com/aktnb/discord-bot-go/internal/application/omikuji"
 	"github.com/aktnb/discord-bot-go/internal/application/ping"
+	"github.com/aktnb/discord-bot-go/internal/application/random"
+	appschedule "github.com/aktnb/discord-bot-go/internal/application/schedule"
 	versionapp "github.com/aktnb/discord-bot-go/internal/application/version"
 	"github.com/aktnb/discord-bot-go/internal/application/voicetext"
-	"github.com/aktnb/discord-bot-go/internal/application/yamada"
 	"github.com/aktnb/discord-bot-go/internal/config"
+	domainmahjong "github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	domainversion "github.com/aktnb/discord-bot-go/internal/domain/version"
 	"github.com/aktnb/discord-bot-go/internal/infrastructure/catapi"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/chartimage"
 	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord"
 	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	admincmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/admin"
 	catcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/cat"
 	collatzcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/collatz"
 	dogcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/dog"
 	fakercmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/faker"
+	helpcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/help"
 	ichirocmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/ichiro"
 	jeffdeancmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/jeffdean"
+	legendcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/legend"
 	mahjongcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/mahjong"
 	omikujicmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/omikuji"
 	pingcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/ping"
+	randomcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/random"
+	schedulecmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/schedule"
 	versioncmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/version"
 	yamadacmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/yamada"
 	"github.com/aktnb/discord-bot-go/internal/infrastructure/dogapi"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/httpclient"
 	"github.com/aktnb/discord-bot-go/internal/infrastructure/mahjongapi"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/mahjonglocal"
 	"github.com/aktnb/discord-bot-go/internal/infrastructure/persistence"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/tileimage"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/logging"
 	"github.com/bwmarrin/discordgo"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -46,9 +63,16 @@ import (
 // デフォルトは "develop" です。
 var version = "develop"
 
+// buildTime はビルド日時（RFC 3339 形式）です。
+// ビルド時に ldflags で設定します: go build -ldflags "-X main.buildTime=2025-01-01T00:00:00Z"
+var buildTime = ""
+
 func main() {
+	startedAt := time.Now()
 	ctx := context.Background()
+	log.SetOutput(logging.NewFilterWriter(os.Stderr))
 	cfg := config.Load()
+	logging.SetLevel(cfg.LogLevel)
 
 	// Initialize Discord session
 	session, err := discordgo.New("Bot " + cfg.DiscordToken)
@@ -72,71 +96,149 @@ func main() {
 	discordAdapter := discord.NewDiscordAdapter(session)
 	vtlService := voicetext.NewVoiceTextService(vtlRepositories, txm, discordAdapter)
 
+	guildCommandService := guildcommand.NewGuildCommandService(persistence.NewGuildCommandSettingRepositoryFactory(), txm)
+
 	// Command registry
 	registry := commands.NewCommandRegistry()
+	commandRegistrar := commands.NewRegistrar(session, registry, guildCommandService)
+	paginator := commands.NewPaginator(commands.DefaultPaginatorTimeout)
 
 	// Version command
-	versionService := versionapp.NewVersionService(version)
+	debugInfo, _ := debug.ReadBuildInfo()
+	versionService := versionapp.NewVersionService(
+		domainversion.NewBuildInfo(version, buildTime, debugInfo),
+		discordbot.Changelog,
+		persistence.NewAnnouncedVersionRepositoryFactory(),
+		txm,
+		discordAdapter,
+	)
 	versionCmd := versioncmd.NewVersionCommand(versionService)
 	registry.Register(versionCmd)
 
 	// Ping command
-	pingService := ping.NewPingService()
+	pingService := ping.NewPingService(txm)
 	pingCmd := pingcmd.NewPingCommand(pingService)
 	registry.Register(pingCmd)
 
+	// Outbound HTTP client shared by external API clients (circuit breaker, retries and fallback cache)
+	httpOptions := httpclient.DefaultOptions()
+	httpOptions.UserAgent = "discord-bot-go/" + version
+	if cfg.HTTPUserAgent != "" {
+		httpOptions.UserAgent = cfg.HTTPUserAgent
+	}
+	httpOptions.Hooks = httpclient.LoggingHooks()
+	httpClient := httpclient.New(httpOptions)
+
 	// Cat command
-	catAPIClient := catapi.NewCatAPIClient()
-	catService := cat.NewCatService(catAPIClient)
+	catAPIClient := catapi.NewCatAPIClient(httpClient, catapi.DefaultBaseURL, cfg.CatAPIKey)
+	catService := cat.NewCatService(catAPIClient, catAPIClient)
 	catCmd := catcmd.NewCatCommand(catService)
 	registry.Register(catCmd)
 
 	// Dog command
-	dogAPIClient := dogapi.NewDogAPIClient()
-	dogService := dog.NewDogService(dogAPIClient)
+	dogAPIClient := dogapi.NewDogAPIClient(httpClient, dogapi.DefaultBaseURL, cfg.DogAPIKey)
+	dogService := dog.NewDogService(dogAPIClient, dogAPIClient)
 	dogCmd := dogcmd.NewDogCommand(dogService)
 	registry.Register(dogCmd)
 
-	// Mahjong command
-	mahjongAPIClient := mahjongapi.NewMahjongAPIClient()
-	mahjongService := mahjong.NewMahjongService(mahjongAPIClient)
-	mahjongCmd := mahjongcmd.NewMahjongCommand(mahjongService)
+	// Mahjong command (starting hands are dealt locally unless MAHJONG_BACKEND=api, quiz results are stored in the database)
+	tileRenderer, err := tileimage.NewRenderer()
+	if err != nil {
+		log.Fatalf("failed to load mahjong tile sprites: %v", err)
+	}
+	var mahjongRepository domainmahjong.MahjongRepository
+	switch cfg.MahjongBackend {
+	case config.MahjongBackendAPI:
+		mahjongRepository = mahjongapi.NewMahjongAPIClient(httpClient, mahjongapi.DefaultURL)
+	default:
+		mahjongRepository = mahjonglocal.NewGenerator(tileRenderer)
+	}
+	mahjongService := mahjong.NewMahjongService(mahjongRepository)
+	mahjongQuizService := mahjong.NewQuizService(persistence.NewMahjongQuizRepositoryFactory(), txm, tileRenderer)
+	mahjongTableService := mahjong.NewTableService(persistence.NewMahjongTableRepositoryFactory(), txm, discordAdapter)
+	mahjongCmd := mahjongcmd.NewMahjongCommand(mahjongService, mahjongQuizService, mahjongTableService)
 	registry.Register(mahjongCmd)
 
 	// Omikuji command
-	omikujiService := omikuji.NewOmikujiService()
+	omikujiService := omikuji.NewOmikujiService(
+		persistence.NewOmikujiDrawRepositoryFactory(),
+		persistence.NewOmikujiSettingsRepositoryFactory(),
+		txm,
+	)
 	omikujiCmd := omikujicmd.NewOmikujiCommand(omikujiService)
 	registry.Register(omikujiCmd)
 
 	// Collatz command
-	collatzService := collatz.NewCollatzService()
-	collatzCmd := collatzcmd.NewCollatzCommand(collatzService)
+	collatzService := collatz.NewCollatzService(chartimage.NewCollatzChartRenderer())
+	collatzCmd := collatzcmd.NewCollatzCommand(collatzService, paginator)
 	registry.Register(collatzCmd)
 
+	// Random command (teams are split from the invoker's voice channel members)
+	randomService := random.NewRandomService(discordAdapter)
+	randomCmd := randomcmd.NewRandomCommand(randomService)
+	registry.Register(randomCmd)
+
+	// Legend commands (episodes and guild-defined legends are stored in the database)
+	legendService := applegend.NewLegendService(
+		persistence.NewLegendEntryRepositoryFactory(),
+		persistence.NewLegendDefinitionRepositoryFactory(),
+		persistence.NewLegendRotationRepositoryFactory(),
+		txm,
+	)
+	legendCmd := legendcmd.NewLegendCommand(legendService, cfg.OwnerIDs, registry, commandRegistrar)
+	registry.Register(legendCmd)
+	registry.RegisterSource(legendcmd.NewSource(legendService))
+
 	// Faker command
-	fakerService := faker.NewFakerService()
-	fakerCmd := fakercmd.NewFakerCommand(fakerService)
+	fakerCmd := fakercmd.NewFakerCommand(legendService)
 	registry.Register(fakerCmd)
 
 	// Ichiro command
-	ichiroService := ichiro.NewIchiroService()
-	ichiroCmd := ichirocmd.NewIchiroCommand(ichiroService)
+	ichiroCmd := ichirocmd.NewIchiroCommand(legendService)
 	registry.Register(ichiroCmd)
 
 	// Jeff Dean command
-	jeffDeanService := jeffdean.NewJeffDeanService()
-	jeffDeanCmd := jeffdeancmd.NewJeffDeanCommand(jeffDeanService)
+	jeffDeanCmd := jeffdeancmd.NewJeffDeanCommand(legendService)
 	registry.Register(jeffDeanCmd)
 
 	// Yamada command (guild-specific)
-	yamadaService := yamada.NewYamadaService()
-	yamadaCmd := yamadacmd.NewYamadaCommand(yamadaService)
+	yamadaCmd := yamadacmd.NewYamadaCommand(legendService)
 	registry.Register(yamadaCmd)
 
+	// Schedule command (scheduled legend posts, guild timezone is shared with omikuji settings)
+	scheduleService := appschedule.NewScheduleService(
+		persistence.NewScheduleRepositoryFactory(),
+		txm,
+		legendService,
+		omikujiService,
+		legendcmd.NewPublisher(legendService, registry, discordAdapter),
+	)
+	scheduleCmd := schedulecmd.NewScheduleCommand(scheduleService, legendService)
+	registry.Register(scheduleCmd)
+
+	// Admin command (owner only)
+	auditService := auditapp.NewAuditService(persistence.NewAuditEntryRepositoryFactory(), txm)
+	adminCmd := admincmd.NewAdminCommand(
+		cfg.OwnerIDs,
+		registry,
+		commandRegistrar,
+		guildCommandService,
+		vtlService,
+		omikujiService,
+		scheduleService,
+		auditService,
+		pool,
+		startedAt,
+	)
+	registry.Register(adminCmd)
+
+	// Help command
+	helpCmd := helpcmd.NewHelpCommand(commandRegistrar, paginator)
+	registry.Register(helpCmd)
+
 	// Register handlers before opening session
-	commandRegistrar := commands.NewRegistrar(session, registry)
 	readyHandler := discord.NewReadyHandler(vtlService, commandRegistrar)
-	interactionHandler := discord.NewInteractionCreateHandler(registry)
+	interactionHandler := discord.NewInteractionCreateHandler(registry, paginator)
 	voiceStateHandler := discord.NewVoiceStateUpdateHandler(vtlService)
 
 	session.AddHandlerOnce(readyHandler.Handle())
@@ -151,6 +253,23 @@ func main() {
 	}
 	defer session.Close()
 
+	// ハートビート遅延をバックグラウンドで記録（/ping detailed で表示）
+	samplerCtx, stopSampler := context.WithCancel(ctx)
+	defer stopSampler()
+	go pingService.RunSampler(samplerCtx, time.Minute, session.HeartbeatLatency)
+
+	// 予約投稿を毎分確認する（起動直後に停止中に逃した投稿も処理する）
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	defer stopScheduler()
+	go scheduleService.Run(schedulerCtx, time.Minute)
+
+	// 前回の起動からバージョンが変わっていれば告知する
+	if cfg.VersionAnnounceChannelID != "" {
+		if _, err := versionService.AnnounceRelease(ctx, discordid.TextChannelID(cfg.VersionAnnounceChannelID)); err != nil {
+			log.Printf("failed to announce version: %v", err)
+		}
+	}
+
 	log.Println("Bot is now running. Press CTRL+C to exit.")
 
 	// Wait for interrupt signal
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_guild_command_settings_command;
+DROP TABLE IF EXISTS guild_command_settings;
@@ -0,0 +1,15 @@
+CREATE TABLE guild_command_settings (
+    guild_id TEXT NOT NULL,
+    command_name TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, command_name)
+);
+
+CREATE INDEX idx_guild_command_settings_command
+    ON guild_command_settings (command_name);
+
+-- これまでコードに埋め込まれていた /yamada の登録先ギルドを初期データとして移行
+INSERT INTO guild_command_settings (guild_id, command_name, enabled)
+VALUES ('1128971828644294666', 'yamada', TRUE);
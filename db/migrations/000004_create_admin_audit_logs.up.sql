@@ -0,0 +1,13 @@
+CREATE TABLE admin_audit_logs (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    guild_id TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    arguments TEXT NOT NULL DEFAULT '',
+    outcome TEXT NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_audit_logs_created_at
+    ON admin_audit_logs (created_at);
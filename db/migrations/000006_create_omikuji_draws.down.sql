@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_omikuji_draws_guild_date;
+DROP TABLE IF EXISTS omikuji_draws;
@@ -0,0 +1,11 @@
+CREATE TABLE omikuji_draws (
+    guild_id TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL,
+    draw_date DATE NOT NULL,
+    level TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, user_id, draw_date)
+);
+
+CREATE INDEX idx_omikuji_draws_guild_date
+    ON omikuji_draws (guild_id, draw_date);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_omikuji_weight_overrides_guild;
+DROP TABLE IF EXISTS omikuji_weight_overrides;
+DROP TABLE IF EXISTS omikuji_guild_settings;
@@ -0,0 +1,22 @@
+CREATE TABLE omikuji_guild_settings (
+    guild_id TEXT PRIMARY KEY,
+    timezone TEXT NOT NULL,
+    -- 運勢レベル（超大吉〜大凶）の順の千分率。合計は 1000
+    weights INTEGER[] NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE omikuji_weight_overrides (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    weights INTEGER[] NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    CHECK (start_date <= end_date)
+);
+
+CREATE INDEX idx_omikuji_weight_overrides_guild
+    ON omikuji_weight_overrides (guild_id, start_date);
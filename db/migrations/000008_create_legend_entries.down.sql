@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_legend_entries_status;
+DROP TABLE IF EXISTS legend_entries;
@@ -0,0 +1,374 @@
+CREATE TABLE legend_entries (
+    id TEXT PRIMARY KEY,
+    -- 伝説コマンドのコマンド名（faker, ichiro, jeff-dean, yamada など）
+    legend TEXT NOT NULL,
+    -- 承認済みのエピソードの伝説ごとの通し番号。未承認の間は NULL
+    number INTEGER,
+    text TEXT NOT NULL,
+    -- pending（審査待ち）/ approved（承認済み）/ rejected（却下）
+    status TEXT NOT NULL,
+    -- 投稿者と投稿元のギルド。初期データは空
+    author_id TEXT NOT NULL DEFAULT '',
+    guild_id TEXT NOT NULL DEFAULT '',
+    reviewer_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT NOW(),
+    reviewed_at TIMESTAMP,
+    UNIQUE (legend, number)
+);
+
+CREATE INDEX idx_legend_entries_status
+    ON legend_entries (status, created_at);
+
+-- 既存の faker のエピソード（リリースに埋め込まれていた一覧）を承認済みとして登録
+INSERT INTO legend_entries (id, legend, number, text, status, reviewed_at) VALUES
+    (gen_random_uuid()::text, 'faker', 1, '1vs1でペンタキルは当たり前', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 2, '世界大会試合中にハースストーンをプレイし、 どちらも優勝', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 3, 'バロンが懐いて付いてきた', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 4, 'Ban Pick画面でファーストブラッド', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 5, '生まれて初めて発した言葉が 「GGWP」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 6, 'グッとガッツポーズしただけでタワーが折れた', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 7, '韓国鯖のpingはFakerからの距離に比例して上昇する', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 8, 'あまりにも強すぎる為、 パソコンを使わずにプレイしている', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 9, 'シーズン3時点でオレリオン・ソルを使用', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 10, '片手間で大統領選に出馬予定', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 11, 'サモナーレベルが10の時にチャレンジャーになる', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 12, '欲しい物リストで届いた物量がウォルマートを超える', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 13, 'LoL配信の最多同時視聴者数は85億人 (視聴率106%)', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 14, 'TFのUltでEUサーバーに飛ぶ', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 15, '1つのウェーブで50CSは当たり前、 自軍のミニオンも殺す', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 16, 'イグナイトを打たれた相手がリスポーン後もう一度死んだ', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 17, 'TPを使うより走った方が速い', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 18, 'Fakerに怯えて切断した相手を再接続させた事も', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 19, 'Azirの兵士が50万人出てMAPを埋め尽くした', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 20, '現実世界でZedの動きをファンサービスとして披露した', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 21, '無線マウスを用いて食卓から画面を見ずに勝利', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 22, '新チャンピオンとして実装予定があった', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 23, '韓国サーバーチャレンジャーの90%はFakerのSmurf', 'approved', NOW()),
+    (gen_random_uuid()::text, 'faker', 24, '相手ADCが積んだサッシュを千切って捨てる', 'approved', NOW());
+
+-- 既存の ichiro のエピソード（リリースに埋め込まれていた一覧）を承認済みとして登録
+INSERT INTO legend_entries (id, legend, number, text, status, reviewed_at) VALUES
+    (gen_random_uuid()::text, 'ichiro', 1, '３打数５安打は当たり前、３打数８安打も', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 2, '先頭打者満塁ホームランを頻発', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 3, 'イチローにとってのホームランは内野安打の打ちそこない', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 4, '先頭打者サイクルヒットも日常茶飯', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 5, '９回裏100点差、チームメイト全員負傷の状況から１人で逆転', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 6, 'バントでホームランが特技', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 7, '打席に立つだけで相手投手が泣いて謝った、心臓発作を起こす投手も', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 8, 'ピッチャーを一睨みしただけでボールが二遊間に飛んでいく', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 9, '試合の無い移動日でも2安打', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 10, '湾岸戦争が始まったきっかけはイチローの場外ホームラン', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 11, 'WBC決勝で自らのヒットにより２ちゃんの鯖を落とした', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 12, 'スイングでハリケーンが起きたことは有名', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 13, 'ワンバウンドも余裕でヒット', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 14, '打球が投手と外野手を同時に場外に吹き飛ばした', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 15, 'レーザービームで外野手の手袋を焦がした', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 16, '全盛期のイチローは守備でもホームランを打てた', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 17, '全盛期のイチローは走塁中にホームランを打てた', 'approved', NOW()),
+    (gen_random_uuid()::text, 'ichiro', 18, 'かつてイチローの打球を受け止めたグラブは今でも煙を上げている', 'approved', NOW());
+
+-- 既存の jeff-dean のエピソード（リリースに埋め込まれていた一覧）を承認済みとして登録
+INSERT INTO legend_entries (id, legend, number, text, status, reviewed_at) VALUES
+    (gen_random_uuid()::text, 'jeff-dean', 1, 'Jeff DeanがGoogleの採用面接を受けたときに、もしP=NPが成り立つとしたらどうなるかを問われて「P=0かN=1ですね」と答えた。試験官が笑い終わりさえしないうちに彼はGoogleのpublic keyを突き止め、private keyをホワイトボードに書き終わった。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 2, 'Jeff DeanはGoogleの全てのコードを一人で書いた。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 3, 'Jeff DeanにとってはNPは''No Problem''をあらわす。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 4, 'Jeff Deanのキーボードには2つしかキーがない。1と0だ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 5, 'Jeff Deanのコードを書く速度は2000年にUSB2.0が出たときに40倍になった。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 6, 'Jeff Deanはコミットする前に自分のコードをコンパイルして動作させるが、それはコンパイラとCPUのバグをチェックするためである。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 7, 'Jeff Deanは一度だけO(n^2)のアルゴリズムを書いたことがある。巡回セールスマン問題のために。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 8, 'コンパイラはJeff Deanに警告を出さない。Jeff Deanがコンパイラに警告を出すのだ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 9, 'Jeff Deanは抽象クラスをインスタンス化できる。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 10, 'Jeff DeanのIDEはコードの分析(analysis)をしない。ただ賞賛(appreciate)するだけである。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 11, 'Jeff Deanはhtmlを正規表現でパースできる。。。正確に。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 12, 'eff Deanがプロファイラを立ち上げると、ループたちは恐怖のあまり勝手にアンロールする。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 13, 'Jeff Deanが一度リストを順序付け(order)ると、以後リストはずっと彼に従う。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 14, 'もしあなたのプログラムがSIGJEFFによって終了させられたら、二度と動くことはないだろう。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 15, 'コンパイルエラーはJeff Deanを警告として扱う。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 16, 'Jeff Deanは`cat > /dev/mem`してからプログラムを書き始める。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 17, 'Jeff Deanは邪悪なコンストラクタを恐れない。コンストラクタが彼を恐れるのだ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 18, 'Jeff Deanはバグを出さない。彼はあなたの理解できない仕様を追加しただけなのだ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 19, 'あなたのプログラムが未定義な動作に陥ると、segfaultが発生し、データは壊れてしまうだろう。一方Jeff Deanのプログラムが未定義な動作に陥ると、ユニコーンが虹の橋を渡ってやってきてみんなに無料のアイスクリームを配るだろう。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 20, 'Jeff Deanのコードはとても速いので、終了させるためにHALTコードを3回も呼び出す必要がある。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 21, 'Jeff DeanのバブルソートプログラムはO(1)で動作する。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 22, 'Jeff Deanはあるときbitをあまりにもshiftしすぎたため、最後には隣のマシンに移動してしまった。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 23, 'gccの最適化オプション
+> gcc -O1: コンパイラは、 コードのサイズと実行時間を削減するよう試みます。
+> gcc -O2: さらに最適化を行います。
+> gcc -O3: さらに一層、 最適化を行います。
+> gcc -O4: 完全に書きなおしてもらうために、あなたのコードをJeff Deanに送信します。
+', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 24, 'Emacsが、いちばん好きなエディターはJeff Dean。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 25, 'あるときJeff Deanが蜘蛛に噛まれたことがあった。その蜘蛛は超常的な力とCを読む能力を手に入れた。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 26, 'Jeff Deanは203番目のフィボナッチ数を問われて1秒以内に答えてしまったので、チューリングテストに失敗したことがある。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 27, 'Jeff Deanはπの最初の2万桁を5時間で暗唱できる。それを暗記しているわけではない：彼はそれを高々O(log n)の容量を使って再計算しているのだ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 28, 'Jeff Deanはたった1つしかパスワードを覚えていない。彼はそれをサイト名と結合してsha-256でハッシュを計算した結果を打ち込んでいるのだ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 29, 'Jeff Deanは眠るのではない。宇宙に対してSIGSUSPENDを送るのだ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 30, 'Jeff Deanの腕時計は1970/1/1 00:00:00からの経過秒数を表示するが、彼は決して遅刻しない。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 31, 'Jeff Deanはスピーカーやヘッドホンを必要としない。彼はcat *.mp3して、画面を眺める。そうすると彼の脳はそれをバックグラウンドでデコードして再生する。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 32, 'あるときドナルドクヌースがTAOCPをJeff Deanに送ったことがあった。Jeff Deanはそれにサインをして送り返した。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 33, 'Jeff Deanがインターネット通信をするとき、遅延は最適化される。ルーターはもし彼のIPパケットを破棄したら、彼がインターネットを破棄することを知っているからだ。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 34, 'Jeff Deanの履歴書には、彼がやってないことだけが書いてある。その方が短いから。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 35, 'やったことがあまりにも多いため、Jeff Deanの履歴書には目次が付いている。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 36, 'Jeff DeanがBig Tableを作ったのは、彼の履歴書の項目が多すぎて記録しておく場所がなかったから。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 37, 'Jeff DeanはファンからのメールをソートするためにMapReduceを発明した。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 38, '2002年の初頭、Googleの検索サーバーが落ちたことがあった。Jeff Deanは2時間にわたってユーザーのQueryに手動で答えたが、検索結果の質は5ポイント改善されたと評価された。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 39, '定数オーダーのアルゴリズムでは飽き足らず、Jeff Deanは世界で始めてO(1/n)のアルゴリズムを開発した。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 40, 'あなたは自分の脳の10%しか使うことができない。残りの90%はJeff DeanがMapReduce jobの1つを動かすのに使っているから。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 41, '神が''光あれ''とおっしゃたとき、Jeff Deanはコードレビューするためにそこにいた。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 42, 'ある日Graham Bellがついに電話を発明すると、そこにはJeff Deanから不在着信が来ていた。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 43, 'Jeff Deanは自分の1週間分のコードスニペットを格納するためにBig Tableを発明した。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 44, 'Jeff Deanはある日、一回のprintfの呼び出しだけからなるウェブサーバーを実装した。他のエンジニアが数千行に及ぶ解説のコメントを書いたが、それでも正確にはどのように動いているかはわからなかった。今日GWSと呼ばれているプログラムである。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 45, 'Jeff Deanは自分がπの桁の中に隠したジョークを数学者が発見するのを未だに待ち続けているらしい。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 46, 'Google App Engineの全体はJeff Deanの持っているNexus Sでホストされている。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 47, 'Jeff Deanは1969/12/31 23:48に生まれた。そして最初の計時システムを実装するのに12分を要した。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 48, 'Jeff Deanは20%プロジェクトとしてAIを開発したことがある。それによってUrs Hoelzleが作られた。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 49, 'ウェブ検索はJeff Deanの本当のアプリのための巨大なユニットテストプログラムである。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 50, 'Jeff Deanがうっかり検索インデクスを圧縮しすぎてブラックホールが発生したため、Googleはデータセンターを移動させなければならなかったことがある。', 'approved', NOW()),
+    (gen_random_uuid()::text, 'jeff-dean', 51, '真空中の光の速さはかつて時速35マイル(約56km)だったが、Jeff Deanが週末を使って物理法則を最適化した結果現在のスピードになった。', 'approved', NOW());
+
+-- 既存の yamada のエピソード（リリースに埋め込まれていた一覧）を承認済みとして登録
+INSERT INTO legend_entries (id, legend, number, text, status, reviewed_at) VALUES
+    (gen_random_uuid()::text, 'yamada', 1, '山田、どんぐりたちの行進を目撃「一人、鳥に連れ去られていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 2, '山田、宇宙人との交信に成功「好物はたい焼きらしい」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 3, '山田、目からビームを出すことに成功「鍛錬方法は企業秘密」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 4, '山田、お腹が空かなくなる「克服した」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 5, '山田、宇宙一周旅行を計画「20年くらいかける予定」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 6, '山田、新しい季節「ごふ」を発見', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 7, '山田、マイクロソフトと契約「およそ10億」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 8, '山田、太る', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 9, '山田、空を飛ぶ「思ったより寒かった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 10, '山田、新元素を発見「名前は『やまジウム』に決定」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 11, '山田、海底でコーヒーショップを開業「客は魚だけ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 12, '山田、地球の重力を軽くする「ちょっとやりすぎた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 13, '山田、夢の中でノーベル賞受賞「賞金は夢の中で使い切った」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 14, '山田、猫語を習得「猫の意見は手厳しい」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 15, '山田、山田を発見「別人だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 16, '山田、月に住み始める「家賃は格安」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 17, '山田、時間を止める「止まったまま帰れなくなる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 18, '山田、新しい数字「じゅうぴゃく」を発明', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 19, '山田、全人類の夢に同時出演「謝罪コメントを発表」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 20, '山田、鳥と和解「さきほど連れ去られた仲間も無事」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 21, '山田、一日で国家資格を100個取得「まだ余裕」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 22, '山田、太陽に直談判「少し涼しくなる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 23, '山田、睡眠を攻略「完全に克服した」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 24, '山田、世界の果てに到達「普通の壁だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 25, '山田、自分を量産することに成功「全員が別の意見」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 26, '山田、牛乳を飲み続けた結果「どうなったかは企業秘密」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 27, '山田、現在地球に18人存在することが判明「本人も驚く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 28, '山田、5億年後の未来から帰還「特に面白いことはなかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 29, '山田、毛細血管に電車を走らせる「定時運行を達成」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 30, '山田、「山田」という概念を特許申請', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 31, '山田、雲を食べる「綿あめに似ていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 32, '山田、言語を新たに5つ発明「全部山田語」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 33, '山田、地下鉄の路線図を暗記「東京、大阪、ロンドン、宇宙」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 34, '山田、蚊に刺される「相手の方が重症」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 35, '山田、歩幅が一致した結果、地球を一周してしまう', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 36, '山田、笑いが止まらなくなる「原因は不明」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 37, '山田、AI開発に着手「山田を学習させる予定」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 38, '山田、影に話しかける「影の方が先に話しかけてきた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 39, '山田、水中で火をおこす「消し方は分からない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 40, '山田、宇宙のどこかにもう一つの山田が存在すると発表', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 41, '山田、虹の端を見つける「ただの壁の角だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 42, '山田、ピザを注文する「届いたのは3日後だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 43, '山田、深海魚と友達になる「相手は顔を忘れていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 44, '山田、夕焼けを瓶に詰める「翌朝には消えていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 45, '山田、地震を予知する「自分が原因だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 46, '山田、光速を超える「体が少し透ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 47, '山田、全ての素数を記憶する「最後の一つが見つからない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 48, '山田、砂漠でラーメンを作る「具は砂だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 49, '山田、天気を操作する「晴れにするつもりが雪になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 50, '山田、龍と交渉する「どちらが本当の龍か揉める」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 51, '山田、恐竜の化石を発掘する「生きていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 52, '山田、宇宙最速の走者になる「帰り道に迷う」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 53, '山田、植物語を習得する「植物側は迷惑そう」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 54, '山田、全国の自動販売機を把握する「宇宙分は未調査」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 55, '山田、鏡の中に入る「出口を見つけるまで3日かかる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 56, '山田、太陽系を掃除する「冥王星が邪魔だと判断」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 57, '山田、コーヒーを飲む「世界が少し傾く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 58, '山田、空白を発明する「何もない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 59, '山田、山に登る「山が先に降りてきた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 60, '山田、人類初の海底マラソンを完走「水の抵抗を計算していなかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 61, '山田、インターネットを歩き回る「道に迷う」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 62, '山田、宇宙で焼き肉をする「煙で星が隠れる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 63, '山田、タコの気持ちが理解できるようになる「怒っていることが多い」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 64, '山田、無限を数える「途中で飽きる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 65, '山田、月面に庭を作る「植物は育たなかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 66, '山田、自分の名前を忘れる「山田で合っていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 67, '山田、ブラックホールを覗く「向こうも覗いていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 68, '山田、歴史を書き直す「面白くしすぎて誰も信じない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 69, '山田、全ての言語を瞬時に翻訳できるようになる「山田語だけ不可能」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 70, '山田、木に話しかける「返事が来た」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 71, '山田、オーロラを手で触る「少しよれる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 72, '山田、気圧を変える「耳が痛い」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 73, '山田、海を一口飲む「しょっぱかっただけ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 74, '山田、リスと縄張り争いをする「引き分けに終わる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 75, '山田、虫の言葉を解析する「全員が帰宅したがっていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 76, '山田、一つの点を10年間凝視する「まだ続けている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 77, '山田、夕食を食べ忘れる「代わりに夕焼けを食べる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 78, '山田、宇宙の端を折り返す「少し小さくなる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 79, '山田、地球の自転を手で止める「一秒だけ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 80, '山田、全ての石の名前を知っている「石も山田を知っている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 81, '山田、氷山の下を確認する「予想の5倍だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 82, '山田、惑星を並べ直す「天文学者が困惑」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 83, '山田、音速で話す「誰も聞き取れない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 84, '山田、海溝の底から手紙を送る「3年後に届く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 85, '山田、自分の細胞に名前をつける「全員が山田」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 86, '山田、論文を毎秒1本提出する「全て採択される」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 87, '山田、電子レンジと会話する「かみ合わない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 88, '山田、火山に砂糖を入れる「溶岩がキャラメル色になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 89, '山田、光を手で曲げる「物陰に入れなくなる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 90, '山田、原子核に飛び込む「狭かった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 91, '山田、地図に自分の家を中心として書き直す「世界地図が変わる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 92, '山田、鍵をなくす「宇宙の法則が一時停止していた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 93, '山田、川の流れを逆にする「魚が混乱」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 94, '山田、パスポートの国籍欄に「山田」と記入「通る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 95, '山田、全国の信号機に挨拶する「全部青になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 96, '山田、雨粒を数える「途中でやめる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 97, '山田、素粒子に話しかける「意外に礼儀正しかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 98, '山田、一週間眠り続ける「記録更新」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 99, '山田、自分の声をエコーでハモらせる「コンサートを開く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 100, '山田、古代文明を発掘する「既知の文明だった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 101, '山田、暗黒物質をつかむ「取れなかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 102, '山田、枯れた木を一晩で復活させる「木は特に感謝しない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 103, '山田、空気を味わう「季節によって味が違う」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 104, '山田、星座に新しいものを追加する「名前は『山田座』」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 105, '山田、ひらがなを新たに3文字追加する「誰も覚えない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 106, '山田、エベレストの山頂に看板を立てる「内容は『山田参上』」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 107, '山田、腸内細菌と仲良くなる「腸内が平和になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 108, '山田、自分の影を3つ増やす「増やし方は秘密」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 109, '山田、幻の第8音階を発見「音楽理論が書き直される」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 110, '山田、睡眠中に難問を解く「起きたら答えを忘れている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 111, '山田、砂時計を逆にする「時間が戻る気がした」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 112, '山田、虫歯を説得して帰す「交渉成立」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 113, '山田、図書館の本を全部読む「感想は『まあまあ』」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 114, '山田、地層から自分の名前を見つける「億年前から記録されていた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 115, '山田、クマに道を譲られる「お礼を言われる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 116, '山田、ドーナツの穴を集める「瓶に入らない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 117, '山田、夢の設計図を提出する「特許庁が困惑」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 118, '山田、消えかけた虹を補修する「材料は不明」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 119, '山田、1000年後の天気予報を立てる「大体晴れ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 120, '山田、雪の結晶を素手で作る「全部同じ形になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 121, '山田、磁石を素手で引き離す「ちょっと痛い」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 122, '山田、蝶の羽ばたきが嵐になる前に止める「3件の台風を未然に防ぐ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 123, '山田、地球に耳をあてる「音楽が聴こえた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 124, '山田、電線の上を歩く「スズメに怒られる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 125, '山田、生まれた年を変更する「手続き中」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 126, '山田、光の速さで謝る「相手がまだ怒る前に到着する」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 127, '山田、宇宙の音を録音する「真空なので何も取れない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 128, '山田、感情に税金をかける「喜びが高額」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 129, '山田、夜明けを遅らせる「寝坊の言い訳に使う」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 130, '山田、雷を瓶に保存する「使い道は未定」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 131, '山田、百科事典の『山田』の項目を書き換える「全ページになる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 132, '山田、流れ星に乗る「揺れが激しかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 133, '山田、砂糖で地図を作る「アリに食べられる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 134, '山田、宇宙の法則に異議申し立てをする「審議中」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 135, '山田、彗星に手紙をくくりつける「宛先不明で返ってくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 136, '山田、方程式を体で解く「解けた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 137, '山田、記憶を整理整頓する「3割が山田に関係なかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 138, '山田、新種のきのこを踏む「何も起きない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 139, '山田、北極点に旗を立てる「すでに山田の旗があった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 140, '山田、自分の誕生日を三回祝う「理由は不明」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 141, '山田、どんぐりを巡る「森の小さなレストランを発見」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 142, '山田、アリ専用のカートレース場を設立、今週末オープン「予算内で建設できた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 143, '山田、来月末にアリ用カートレース場を閉場「利用者がこない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 144, '山田、落ちていたガムを踏み、そのまま地面と同化する「米科学者が驚愕」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 145, '山田、将棋に新しい駒『山田』を追加する「強すぎて即日使用禁止」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 146, '山田、プールで泳いでいたら水が逃げる「プール側に非はない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 147, '山田、手相を見てもらう「占い師が途中で引退」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 148, '山田、読んでいた本が勝手にページを戻す「続きを読ませたくないらしい」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 149, '山田、カレーを食べる「辛さのスケールが書き換えられる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 150, '山田、エスカレーターに乗る「止まっていても上の階に着く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 151, '山田、くしゃみをする「体が8cm浮く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 152, '山田、財布を忘れたまま買い物を完了させる「店員は何も言わなかった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 153, '山田、ロボットに間違えられる「本人も否定しない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 154, '山田、温泉に入る「源泉が山田を避ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 155, '山田、音楽を聴く「曲が照れて途中で止まる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 156, '山田、自分の体重を計る「はかりが混乱する」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 157, '山田、じゃんけんで144連勝「グーしか出していない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 158, '山田、電柱に話しかける「電気代の相談に乗ってもらう」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 159, '山田、宝くじを当てる「当選金より印鑑代の方が高かった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 160, '山田、毎朝新聞を読む「自分の名前が毎日どこかに載っている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 161, '山田、コンビニのレジ袋を断る「エコすぎて表彰される」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 162, '山田、ジェットコースターに乗る「ジェットコースターの方が怖がる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 163, '山田、傘を持たずに外出する「雨が山田を避ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 164, '山田、公園のベンチに座る「ベンチが少し沈む」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 165, '山田、水族館に行く「サメが挨拶してくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 166, '山田、スーパーで試食する「全種類が山田の好みに変わる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 167, '山田、新しい運動を発明する「名前は『山田体操』」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 168, '山田、鏡の前で練習する「鏡がさきに笑う」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 169, '山田、タイムカプセルを埋める「掘り返したらすでに開封済みだった」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 170, '山田、バスに乗り遅れる「バスが引き返してくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 171, '山田、迷路を解く「壁の方が道を作る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 172, '山田、日記をつける「日記が勝手に続きを書いている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 173, '山田、風船を飛ばす「5年後に戻ってくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 174, '山田、スパゲッティをすする「麺が逃げる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 175, '山田、川でつり糸を垂らす「魚が自分から針に刺さろうとする」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 176, '山田、階段を数える「毎回違う数になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 177, '山田、ピアノを弾く「聴衆が消える」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 178, '山田、ギターを弾く「弦が増える」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 179, '山田、博物館で展示品を触る「展示品が固まる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 180, '山田、観葉植物を育てる「植物が丁寧なメモを残して枯れる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 181, '山田、靴の紐を結ぶ「片方が勝手に解ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 182, '山田、目覚まし時計をセットする「時計が山田より先に起きて止める」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 183, '山田、パズルを完成させる「最後の1ピースが自分の体から出てくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 184, '山田、料理に挑戦する「完成品が分子構造ごと別物になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 185, '山田、自転車の空気を入れる「タイヤが空気を返してくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 186, '山田、シャボン玉を吹く「割れずに衛星になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 187, '山田、万歩計をつける「0歩でも1万歩を記録」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 188, '山田、口笛を吹く「近隣の犬が全員集合する」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 189, '山田、野球のボールを投げる「ボールが弧を描かずに届く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 190, '山田、マラソンを完走する「ゴールが山田に合わせて移動していた」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 191, '山田、サッカーのシュートを放つ「ゴールが避ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 192, '山田、バスケのフリースローを放つ「ボールがリングを避けて入る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 193, '山田、テニスのサーブを打つ「ラケットが礼を言って去る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 194, '山田、将棋で対局する「相手の駒が山田側に移動してくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 195, '山田、チェスで対局する「駒が自己判断で動く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 196, '山田、ボードゲームをする「サイコロが山田の望む数しか出ない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 197, '山田、折り紙で鶴を折る「飛んでいく」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 198, '山田、本棚を整理する「本が勝手に並び直している」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 199, '山田、引き出しを閉める「必要な時に自動で開く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 200, '山田、鍵をかける「どの鍵でも開く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 201, '山田、パソコンを起動する「山田専用のOSが立ち上がる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 202, '山田、プリンターを使う「用紙が先に逃げる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 203, '山田、ヘッドフォンをつける「音楽が耳に直接届く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 204, '山田、帽子をかぶる「帽子が自分で調整する」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 205, '山田、手袋をはめる「片方が自分で歩き去る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 206, '山田、コートを着る「コートが生き返る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 207, '山田、靴を磨く「翌朝には元の状態に戻っている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 208, '山田、洗濯物を干す「洗濯物が自分でたたまれている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 209, '山田、布団を干す「布団が空を飛ぶ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 210, '山田、郵便ポストに手紙を投函する「返信が即日届く」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 211, '山田、宅配便を受け取る「頼んでいないものが入っている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 212, '山田、エレベーターに乗る「行き先を言わなくても目的の階に止まる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 213, '山田、回転寿司に行く「皿が全部山田の前で止まる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 214, '山田、焼き肉を焼く「煙が文字を作る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 215, '山田、鍋料理を作る「全員分が一人前で十分になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 216, '山田、お茶を飲む「急須が満タンに戻る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 217, '山田、ケーキを切る「全ピースが同じ大きさになる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 218, '山田、アイスを食べる「溶けない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 219, '山田、ガムを噛む「風船が勝手に膨らむ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 220, '山田、スープを冷ます「息が氷になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 221, '山田、おにぎりを握る「米が自動的に三角になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 222, '山田、お弁当を食べる「箸が頑張る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 223, '山田、蕎麦を食べる「麺が計算された長さに切れている」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 224, '山田、うどんを食べる「麺が一本しか入っていないが十分な量」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 225, '山田、天丼を食べる「海老が山田に気を使って大きくなる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 226, '山田、ラムネを飲む「ビー玉が返ってくる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 227, '山田、みかんを剥く「一房も切れない」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 228, '山田、バナナを食べる「皮が丁寧に畳んである」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 229, '山田、スイカを切る「種が整列している」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 230, '山田、栗を剥く「自動で剥ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 231, '山田、グミを食べる「全部同じ硬さ」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 232, '山田、チョコレートを割る「綺麗に分かれる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 233, '山田、プリンを食べる「カラメルが最後まで残る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 234, '山田、わたあめを買う「量が増え続ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 235, '山田、たこ焼きを食べる「全部同じ温度」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 236, '山田、お好み焼きを焼く「ひっくり返さなくても焼ける」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 237, '山田、餃子を焼く「羽が完璧に焼き上がる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 238, '山田、漬物を漬ける「翌朝には本場の味になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 239, '山田、味噌汁を作る「出汁が自動で出る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 240, '山田、卵焼きを作る「巻かなくても巻いてある」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 241, '山田、白米を炊く「炊飯器が喋る」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 242, '山田、インスタント麺を作る「お湯が3分待たずに仕上げる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 243, '山田、ホットケーキを焼く「ハートの形になる」', 'approved', NOW()),
+    (gen_random_uuid()::text, 'yamada', 244, '山田、クッキーを焼く「全部同じ形」', 'approved', NOW());
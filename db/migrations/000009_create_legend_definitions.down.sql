@@ -0,0 +1,10 @@
+DELETE FROM legend_entries WHERE legend_guild_id <> '';
+
+ALTER TABLE legend_entries
+    DROP CONSTRAINT legend_entries_legend_number_key,
+    ADD CONSTRAINT legend_entries_legend_number_key UNIQUE (legend, number);
+
+ALTER TABLE legend_entries
+    DROP COLUMN legend_guild_id;
+
+DROP TABLE IF EXISTS legend_definitions;
@@ -0,0 +1,19 @@
+CREATE TABLE legend_definitions (
+    guild_id TEXT NOT NULL,
+    -- コマンド名（ギルド内で一意、組み込みの伝説と同じ名前は使えない）
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    -- エピソードの見出しに使う名前（「〇〇伝説 その1」の〇〇）
+    prefix TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, name)
+);
+
+-- ギルドで定義された伝説のエピソードは、伝説を定義したギルドで区別する（組み込みの伝説は空）
+ALTER TABLE legend_entries
+    ADD COLUMN legend_guild_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE legend_entries
+    DROP CONSTRAINT legend_entries_legend_number_key,
+    ADD CONSTRAINT legend_entries_legend_number_key UNIQUE (legend_guild_id, legend, number);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS legend_rotations;
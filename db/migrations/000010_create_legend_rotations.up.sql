@@ -0,0 +1,10 @@
+-- ギルドごとの伝説エピソードの出題状況（一巡するまで同じエピソードを出さないためのシャッフルバッグ）
+CREATE TABLE legend_rotations (
+    guild_id TEXT NOT NULL,
+    legend_guild_id TEXT NOT NULL DEFAULT '',
+    legend TEXT NOT NULL,
+    -- 今の周回で既に出したエピソードの通し番号（出した順）
+    seen INTEGER[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, legend_guild_id, legend)
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_legend_schedules_next_run_at;
+DROP INDEX IF EXISTS idx_legend_schedules_guild_id;
+DROP TABLE IF EXISTS legend_schedules;
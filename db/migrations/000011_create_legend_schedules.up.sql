@@ -0,0 +1,22 @@
+-- 伝説エピソードの予約投稿（cron 形式の式に従って、ギルドのタイムゾーンで定期的に投稿する）
+CREATE TABLE legend_schedules (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    -- 投稿する伝説コマンドの名前
+    legend TEXT NOT NULL,
+    -- 「分 時 日 月 曜日」の cron 形式の式
+    spec TEXT NOT NULL,
+    -- 停止中に逃した実行の扱い（skip または latest）
+    catch_up TEXT NOT NULL,
+    -- 時刻はギルドのタイムゾーンで計算するため、タイムゾーン付きで保存する
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_legend_schedules_guild_id
+    ON legend_schedules (guild_id);
+CREATE INDEX idx_legend_schedules_next_run_at
+    ON legend_schedules (next_run_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_mahjong_quiz_rounds_guild_answered;
+DROP TABLE IF EXISTS mahjong_quiz_rounds;
@@ -0,0 +1,20 @@
+-- 麻雀の何切る問題（出題した手牌と回答の採点結果）
+CREATE TABLE mahjong_quiz_rounds (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    -- 出題したユーザー（このユーザーだけが回答できる）
+    user_id TEXT NOT NULL,
+    -- "123m406p789s1122z3z" 形式の 14 枚の手牌
+    hand TEXT NOT NULL,
+    -- 回答した打牌の種類（0〜33）。未回答なら NULL
+    discard SMALLINT,
+    points INTEGER NOT NULL DEFAULT 0,
+    correct BOOLEAN NOT NULL DEFAULT FALSE,
+    answered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- ランキングは回答済みの問題だけを集計する
+CREATE INDEX idx_mahjong_quiz_rounds_guild_answered
+    ON mahjong_quiz_rounds (guild_id, user_id)
+    WHERE answered_at IS NOT NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS mahjong_table_hands;
+DROP INDEX IF EXISTS idx_mahjong_tables_guild_finished;
+DROP INDEX IF EXISTS idx_mahjong_tables_channel_playing;
+DROP TABLE IF EXISTS mahjong_tables;
@@ -0,0 +1,45 @@
+-- 麻雀の対局（参加者とルール）。持ち点・親・本場は局の結果から計算する
+CREATE TABLE mahjong_tables (
+    id TEXT PRIMARY KEY,
+    guild_id TEXT NOT NULL,
+    -- 局の結果を記録するテキストチャンネル
+    channel_id TEXT NOT NULL,
+    -- 起家から順に並べた参加者（3 人または 4 人）
+    players TEXT[] NOT NULL,
+    starting_points INTEGER NOT NULL,
+    return_points INTEGER NOT NULL,
+    -- 順位ごとのウマ（点）
+    uma INTEGER[] NOT NULL,
+    -- 'playing' または 'finished'
+    status TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+-- 1つのチャンネルで同時に進行できる対局は1つ
+CREATE UNIQUE INDEX idx_mahjong_tables_channel_playing
+    ON mahjong_tables (guild_id, channel_id)
+    WHERE status = 'playing';
+
+-- 成績は終えた対局だけを集計する
+CREATE INDEX idx_mahjong_tables_guild_finished
+    ON mahjong_tables (guild_id)
+    WHERE status = 'finished';
+
+-- 対局の各局の結果。席は players の添字（0 始まり）
+CREATE TABLE mahjong_table_hands (
+    table_id TEXT NOT NULL REFERENCES mahjong_tables (id) ON DELETE CASCADE,
+    -- 対局の中での局の順番（1 始まり）
+    number INTEGER NOT NULL,
+    -- 和了した人の席。流局なら NULL
+    winner SMALLINT,
+    -- 放銃した人の席。ツモと流局なら NULL
+    loser SMALLINT,
+    ron_points INTEGER NOT NULL DEFAULT 0,
+    tsumo_non_dealer INTEGER NOT NULL DEFAULT 0,
+    tsumo_dealer INTEGER NOT NULL DEFAULT 0,
+    riichi INTEGER[] NOT NULL DEFAULT '{}',
+    tenpai INTEGER[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (table_id, number)
+);
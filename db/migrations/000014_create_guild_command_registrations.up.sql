@@ -0,0 +1,5 @@
+-- ギルド固有コマンドを登録したギルド。登録するコマンドが無くなったギルドを起動時に空で上書きするために使う
+CREATE TABLE guild_command_registrations (
+    guild_id TEXT PRIMARY KEY,
+    registered_at TIMESTAMP DEFAULT NOW()
+);
@@ -5,6 +5,8 @@ go 1.24.3
 require (
 	github.com/bwmarrin/discordgo v0.29.0
 	github.com/google/uuid v1.6.0
+	golang.org/x/image v0.25.0
+	golang.org/x/text v0.32.0
 )
 
 require (
@@ -12,7 +14,6 @@ require (
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	golang.org/x/sync v0.19.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
 )
 
 require (
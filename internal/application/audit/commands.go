@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"github.com/aktnb/discord-bot-go/internal/domain/audit"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+type RecordCommand struct {
+	UserID    discordid.UserID
+	GuildID   discordid.GuildID
+	Action    string
+	Arguments string
+	Outcome   audit.Outcome
+	Detail    string
+}
@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"context"
+	"log"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/audit"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+)
+
+type Service struct {
+	repositories audit.Repositories
+	txm          db.TxManager
+}
+
+func NewAuditService(repositories audit.Repositories, txm db.TxManager) *Service {
+	return &Service{
+		repositories: repositories,
+		txm:          txm,
+	}
+}
+
+// Record は管理操作をログと DB の両方に記録する
+// DB への保存に失敗してもログには残るため、呼び出し側は操作を継続してよい
+func (s *Service) Record(ctx context.Context, cmd RecordCommand) error {
+	log.Printf("[INFO] Audit: user=%s guild=%s action=%q args=%q outcome=%s detail=%q",
+		cmd.UserID, cmd.GuildID, cmd.Action, cmd.Arguments, cmd.Outcome, cmd.Detail)
+
+	entry, err := audit.NewEntry(cmd.UserID, cmd.GuildID, cmd.Action, cmd.Arguments, cmd.Outcome, cmd.Detail)
+	if err != nil {
+		return err
+	}
+
+	return s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		return s.repositories.AuditEntry(tx).Save(ctx, entry)
+	})
+}
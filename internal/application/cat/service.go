@@ -2,22 +2,67 @@ package cat
 
 import (
 	"context"
+	"time"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/cat"
+	"github.com/aktnb/discord-bot-go/internal/shared/cache"
 )
 
+// BreedCacheTTL は猫種の一覧を API から取得し直すまでの時間
+const BreedCacheTTL = 24 * time.Hour
+
 type Service struct {
-	repo cat.CatImageRepository
+	repo   cat.CatImageRepository
+	breeds *cache.Value[[]cat.Breed]
+}
+
+func NewCatService(repo cat.CatImageRepository, breeds cat.BreedRepository) *Service {
+	return &Service{
+		repo:   repo,
+		breeds: cache.NewValue(BreedCacheTTL, breeds.FetchBreeds),
+	}
+}
+
+// GetRandomCatImages はランダムな猫画像を count 枚返す。breedID を指定するとその猫種に限る
+func (s *Service) GetRandomCatImages(ctx context.Context, breedID string, count int) ([]*cat.CatImage, error) {
+	if count < 1 || count > cat.MaxImages {
+		return nil, cat.ErrInvalidCount
+	}
+	if breedID != "" {
+		if _, err := s.Breed(ctx, breedID); err != nil {
+			return nil, err
+		}
+	}
+
+	images, err := s.repo.FetchRandomImages(ctx, breedID, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, cat.ErrImageNotFound
+	}
+	return images, nil
 }
 
-func NewCatService(repo cat.CatImageRepository) *Service {
-	return &Service{repo: repo}
+// Breed は ID で猫種を返す
+func (s *Service) Breed(ctx context.Context, id string) (cat.Breed, error) {
+	breeds, err := s.breeds.Get(ctx)
+	if err != nil {
+		return cat.Breed{}, err
+	}
+	for _, breed := range breeds {
+		if breed.ID == id {
+			return breed, nil
+		}
+	}
+	return cat.Breed{}, cat.ErrBreedNotFound
 }
 
-func (s *Service) GetRandomCatImage(ctx context.Context) (*cat.CatImage, error) {
-	image, err := s.repo.FetchRandomImage(ctx)
+// SearchBreeds は名前に query を含む猫種を最大 limit 件返す
+func (s *Service) SearchBreeds(ctx context.Context, query string, limit int) ([]cat.Breed, error) {
+	breeds, err := s.breeds.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return image, nil
+	return cat.SearchBreeds(breeds, query, limit), nil
 }
@@ -2,81 +2,231 @@ package collatz
 
 import (
 	"context"
-	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/collatz"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 )
 
 const (
-	// Discord の文字列制限は2000文字
-	maxMessageLength = 2000
+	// MaxSequenceDigits は計算過程を表示する開始値の最大桁数
+	// 値が長すぎると 1 ステップでもメッセージに収まらないため、それ以上は stats モードを使う
+	MaxSequenceDigits = 200
+	// MaxRangeWidth は range モードで一度に探索できる開始値の数
+	MaxRangeWidth = 1_000_000
 )
 
-type Service struct{}
+const (
+	// maxTextLength は計算過程のテキストの長さの上限（ページ送りで 20 ページ程度）
+	maxTextLength = 40_000
+	// maxSteps は 1 つの開始値について計算するステップ数の上限
+	maxSteps = 1_000_000
+	// computeTimeout は 1 回のコマンドで計算に使う時間の上限
+	computeTimeout = 10 * time.Second
+)
+
+// rangeLimits は range モードの計算量の上限
+var rangeLimits = collatz.RangeLimits{
+	MaxWidth: MaxRangeWidth,
+	MaxSteps: 200_000_000,
+}
+
+// scaleKeys はグラフの目盛りの表示名のカタログキー
+var scaleKeys = map[collatz.Scale]string{
+	collatz.LinearScale: "msg.collatz.scale.linear",
+	collatz.LogScale:    "msg.collatz.scale.log",
+}
+
+type Service struct {
+	renderer collatz.ChartRenderer
+}
+
+func NewCollatzService(renderer collatz.ChartRenderer) *Service {
+	return &Service{renderer: renderer}
+}
 
-func NewCollatzService() *Service {
-	return &Service{}
+// ChartResult はグラフ付きの計算結果
+type ChartResult struct {
+	// Summary は計算過程の代わりに表示する概要
+	Summary string
+	// Image はグラフの PNG 画像
+	Image []byte
+	// Sequence は添付ファイル用の全計算過程のテキスト
+	Sequence []byte
 }
 
-// Calculate はコラッツ予想の計算を実行し、結果を文字列のスライスとして返す
-// Discord の文字列制限に対応するため、必要に応じてメッセージを分割する
-func (s *Service) Calculate(ctx context.Context, start int64) ([]string, error) {
+// Calculate はコラッツ予想の計算を実行し、計算過程のテキストを返す
+// Discord の文字列制限に合わせた分割は呼び出し側（ページ送り）で行う
+func (s *Service) Calculate(ctx context.Context, locale i18n.Locale, start int64) (string, error) {
 	if start <= 0 {
-		return nil, fmt.Errorf("開始値は正の整数である必要があります")
+		return "", collatz.ErrInvalidStart
 	}
 
 	// コラッツ予想の計算
 	sequence := collatz.NewSequence(start)
-	sequence.Calculate()
+	if err := sequence.Calculate(); err != nil {
+		// int64 に収まらない値を経由する場合は任意精度で計算し直す
+		return s.CalculateBig(ctx, locale, big.NewInt(start))
+	}
 
 	// 結果を文字列化
-	messages := s.formatSequence(sequence)
+	values := make([]string, len(sequence.Steps))
+	for i, step := range sequence.Steps {
+		values[i] = strconv.FormatInt(step.Value, 10)
+	}
+	return s.formatSequence(locale, values), nil
+}
 
-	return messages, nil
+// CalculateBig は任意精度の開始値でコラッツ予想の計算を実行する
+// 開始値が int64 に収まる場合は Calculate と同じ結果になる
+func (s *Service) CalculateBig(ctx context.Context, locale i18n.Locale, start *big.Int) (string, error) {
+	if start == nil || start.Sign() <= 0 {
+		return "", collatz.ErrInvalidStart
+	}
+	if len(start.String()) > MaxSequenceDigits {
+		return "", collatz.ErrStartTooLarge
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, computeTimeout)
+	defer cancel()
+
+	sequence := collatz.NewBigSequence(start)
+	if err := sequence.Calculate(ctx, maxSteps); err != nil {
+		return "", err
+	}
+
+	values := make([]string, len(sequence.Steps))
+	for i, step := range sequence.Steps {
+		values[i] = step.String()
+	}
+	return s.formatSequence(locale, values), nil
 }
 
-// formatSequence は計算結果を Discord 用にフォーマットする
-// メッセージが長すぎる場合は自動的に分割する
-func (s *Service) formatSequence(sequence *collatz.Sequence) []string {
-	var messages []string
-	var currentMessage strings.Builder
+// Chart は計算過程をグラフ画像として描画し、概要と全計算過程のテキストを添えて返す
+func (s *Service) Chart(ctx context.Context, locale i18n.Locale, start *big.Int, scale collatz.Scale) (*ChartResult, error) {
+	if start == nil || start.Sign() <= 0 {
+		return nil, collatz.ErrInvalidStart
+	}
+	if len(start.String()) > MaxSequenceDigits {
+		return nil, collatz.ErrStartTooLarge
+	}
 
-	// ヘッダー
-	header := fmt.Sprintf("🔢 **コラッツ予想シミュレーション**\n開始値: %d\nステップ数: %d\n\n",
-		sequence.Steps[0].Value,
-		sequence.Length()-1)
+	ctx, cancel := context.WithTimeout(ctx, computeTimeout)
+	defer cancel()
 
-	currentMessage.WriteString(header)
-	currentMessage.WriteString("**計算過程:**\n")
+	sequence := collatz.NewBigSequence(start)
+	if err := sequence.Calculate(ctx, maxSteps); err != nil {
+		return nil, err
+	}
 
-	// 各ステップを追加
+	chart := collatz.NewChart(sequence.Steps, scale)
+	image, err := s.renderer.RenderPNG(chart)
+	if err != nil {
+		return nil, err
+	}
+
+	maxStep := 0
+	var text strings.Builder
 	for i, step := range sequence.Steps {
-		var line string
-		if i == 0 {
-			line = fmt.Sprintf("%d", step.Value)
-		} else {
-			line = fmt.Sprintf(" → %d", step.Value)
+		if step.Cmp(sequence.Steps[maxStep]) > 0 {
+			maxStep = i
 		}
+		text.WriteString(step.String())
+		text.WriteByte('\n')
+	}
 
-		// メッセージが制限を超える場合は分割
-		if currentMessage.Len()+len(line) > maxMessageLength {
-			messages = append(messages, currentMessage.String())
-			currentMessage.Reset()
-			currentMessage.WriteString("**（続き）**\n")
-			// 前の値を含めて続きを書く（連続性を保つため）
-			if i > 0 {
-				line = fmt.Sprintf("%d → %d", sequence.Steps[i-1].Value, step.Value)
-			}
-		}
+	summary := i18n.T(locale, "msg.collatz.chart_summary",
+		abbreviate(locale, start.String()),
+		sequence.Length()-1,
+		abbreviate(locale, sequence.Steps[maxStep].String()),
+		maxStep,
+		i18n.T(locale, scaleKeys[chart.Scale]))
+
+	return &ChartResult{
+		Summary:  summary,
+		Image:    image,
+		Sequence: []byte(text.String()),
+	}, nil
+}
+
+// Stats は計算過程を表示せずに、ステップ数や最大値などの統計をまとめたメッセージを返す
+func (s *Service) Stats(ctx context.Context, locale i18n.Locale, start *big.Int) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, computeTimeout)
+	defer cancel()
+
+	stats, err := collatz.Analyze(ctx, start, maxSteps)
+	if err != nil {
+		return "", err
+	}
+
+	return i18n.T(locale, "msg.collatz.stats",
+		abbreviate(locale, stats.Start.String()),
+		stats.TotalStoppingTime,
+		stats.StoppingTime,
+		abbreviate(locale, stats.MaxValue.String()),
+		stats.MaxStep,
+		stats.EvenSteps,
+		stats.OddSteps), nil
+}
+
+// LongestInRange は from 以上 to 以下で最も長い計算過程を持つ開始値を探し、結果のメッセージを返す
+// 計算量の上限や時間切れで探索を打ち切った場合は、そこまでの結果を返す
+func (s *Service) LongestInRange(ctx context.Context, locale i18n.Locale, from, to int64) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, computeTimeout)
+	defer cancel()
+
+	result, err := collatz.LongestInRange(ctx, from, to, rangeLimits)
+	if err != nil && (ctx.Err() == nil || result.Checked == 0) {
+		return "", err
+	}
 
-		currentMessage.WriteString(line)
+	message := i18n.T(locale, "msg.collatz.range",
+		result.From, result.To,
+		result.Best, result.BestSteps,
+		result.Checked, result.TotalSteps)
+	if !result.Complete {
+		message += i18n.T(locale, "msg.collatz.range_incomplete", result.From+result.Checked-1)
 	}
+	return message, nil
+}
+
+// formatSequence は計算結果を Discord 用にフォーマットする
+// 全体が maxTextLength を超える場合は、区切りの位置で切り詰めて省略した旨を添える
+func (s *Service) formatSequence(locale i18n.Locale, values []string) string {
+	var text strings.Builder
 
-	// 最後のメッセージを追加
-	if currentMessage.Len() > 0 {
-		messages = append(messages, currentMessage.String())
+	// ヘッダー
+	text.WriteString(i18n.T(locale, "msg.collatz.header",
+		abbreviate(locale, values[0]),
+		len(values)-1))
+	text.WriteString(i18n.T(locale, "msg.collatz.steps_heading"))
+
+	// 各ステップを追加
+	for i, value := range values {
+		line := value
+		if i > 0 {
+			line = " → " + value
+		}
+
+		if text.Len()+len(line) > maxTextLength {
+			// 残りは省略してグラフや stats モードを案内する
+			text.WriteString(i18n.T(locale, "msg.collatz.truncated"))
+			break
+		}
+		text.WriteString(line)
 	}
 
-	return messages
+	return text.String()
+}
+
+// abbreviate は長い数値を先頭と末尾だけ残して省略し、桁数を添える
+func abbreviate(locale i18n.Locale, digits string) string {
+	const keep = 20
+	if len(digits) <= keep*2+3 {
+		return digits
+	}
+	return i18n.T(locale, "msg.collatz.abbreviated", digits[:keep], digits[len(digits)-keep:], len(digits))
 }
@@ -2,12 +2,17 @@ package collatz
 
 import (
 	"context"
+	"errors"
+	"math/big"
 	"strings"
 	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/collatz"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 )
 
 func TestCalculate(t *testing.T) {
-	service := NewCollatzService()
+	service := NewCollatzService(stubRenderer{})
 
 	tests := []struct {
 		name         string
@@ -47,7 +52,7 @@ func TestCalculate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			messages, err := service.Calculate(context.Background(), tt.input)
+			text, err := service.Calculate(context.Background(), i18n.Japanese, tt.input)
 
 			if tt.expectError {
 				if err == nil {
@@ -61,68 +66,132 @@ func TestCalculate(t *testing.T) {
 				return
 			}
 
-			if len(messages) == 0 {
-				t.Errorf("expected at least one message but got none")
-				return
-			}
-
-			// Check that messages are within Discord's character limit
-			for i, msg := range messages {
-				if len(msg) > 2000 {
-					t.Errorf("message %d exceeds Discord's 2000 character limit: %d characters", i, len(msg))
-				}
+			// Check that the trajectory contains every step
+			if got := strings.Count(text, " → ") + 1; got != tt.expectSteps {
+				t.Errorf("expected %d steps in the trajectory, got %d", tt.expectSteps, got)
 			}
 		})
 	}
 }
 
 func TestFormatSequence(t *testing.T) {
-	service := NewCollatzService()
+	service := NewCollatzService(stubRenderer{})
+
+	// Test with a number that generates a long sequence (97 is known to have 118 steps)
+	text, err := service.Calculate(context.Background(), i18n.Japanese, 97)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(text, "ステップ数: 118") {
+		t.Errorf("header should contain the number of steps: %s", text)
+	}
+	if !strings.HasSuffix(text, " → 2 → 1") {
+		t.Errorf("trajectory should end with 1")
+	}
+	if !strings.Contains(text, "97 → 292 → 146") {
+		t.Errorf("trajectory should start with 97 → 292 → 146")
+	}
+}
 
-	// Test with a number that will generate a moderately long sequence
-	messages, err := service.Calculate(context.Background(), 27)
+func TestCalculateBig(t *testing.T) {
+	service := NewCollatzService(stubRenderer{})
+
+	// int64 を超える開始値でも最後まで計算できること
+	start, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	text, err := service.CalculateBig(context.Background(), i18n.Japanese, start)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if !strings.Contains(text, "123456789012345678901234567890 → 61728394506172839450617283945") {
+		t.Errorf("trajectory should start with the first halving step")
+	}
+	if !strings.HasSuffix(text, " → 1") {
+		t.Errorf("trajectory should end with the final value 1")
+	}
 
-	if len(messages) == 0 {
-		t.Fatal("expected at least one message but got none")
+	tooLarge := new(big.Int).Exp(big.NewInt(10), big.NewInt(MaxSequenceDigits), nil)
+	if _, err := service.CalculateBig(context.Background(), i18n.Japanese, tooLarge); !errors.Is(err, collatz.ErrStartTooLarge) {
+		t.Errorf("expected ErrStartTooLarge, got %v", err)
+	}
+}
+
+func TestFormatSequenceTruncated(t *testing.T) {
+	service := NewCollatzService(stubRenderer{})
+
+	values := make([]string, 5000)
+	for i := range values {
+		values[i] = strings.Repeat("9", 50)
+	}
+	text := service.formatSequence(i18n.Japanese, values)
+	suffix := i18n.T(i18n.Japanese, "msg.collatz.truncated")
+	if len(text) > maxTextLength+len(suffix) || !strings.HasSuffix(text, suffix) {
+		t.Errorf("text should be truncated within the limit: %d characters", len(text))
 	}
+}
+
+func TestStats(t *testing.T) {
+	service := NewCollatzService(stubRenderer{})
 
-	// All messages should be within Discord's limit
-	for i, msg := range messages {
-		if len(msg) > 2000 {
-			t.Errorf("message %d exceeds 2000 character limit: %d", i, len(msg))
+	message, err := service.Stats(context.Background(), i18n.English, big.NewInt(27))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"Steps to reach 1: 111", "Maximum: 9232 (at step 77)", "Even steps: 70 / Odd steps: 41"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message should contain %q: %s", want, message)
 		}
 	}
 }
 
-func TestMessageSplitting(t *testing.T) {
-	service := NewCollatzService()
+func TestLongestInRange(t *testing.T) {
+	service := NewCollatzService(stubRenderer{})
 
-	// Test with a number that generates a very long sequence (97 is known to have 118 steps)
-	messages, err := service.Calculate(context.Background(), 97)
+	message, err := service.LongestInRange(context.Background(), i18n.English, 1, 100)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if !strings.Contains(message, "Longest: 97 (118 steps)") {
+		t.Errorf("unexpected message: %s", message)
+	}
 
-	t.Logf("Number 97 generated %d message(s)", len(messages))
+	if _, err := service.LongestInRange(context.Background(), i18n.English, 1, MaxRangeWidth+1); !errors.Is(err, collatz.ErrRangeTooWide) {
+		t.Errorf("expected ErrRangeTooWide, got %v", err)
+	}
+}
 
-	// Verify each message is within limits
-	for i, msg := range messages {
-		if len(msg) > 2000 {
-			t.Errorf("message %d exceeds 2000 character limit: got %d characters", i, len(msg))
-		}
-		t.Logf("Message %d: %d characters", i+1, len(msg))
+// stubRenderer は描画せずにグラフのデータを記録する ChartRenderer
+type stubRenderer struct {
+	rendered *collatz.Chart
+}
+
+func (r stubRenderer) RenderPNG(chart collatz.Chart) ([]byte, error) {
+	if r.rendered != nil {
+		*r.rendered = chart
 	}
+	return []byte("png"), nil
+}
 
-	// If multiple messages, verify continuation messages are properly formatted
-	if len(messages) > 1 {
-		for i := 1; i < len(messages); i++ {
-			expectedStart := "**（続き）**"
-			if !strings.HasPrefix(messages[i], expectedStart) {
-				t.Errorf("continuation message %d should start with '%s'", i, expectedStart)
-			}
-		}
+func TestChart(t *testing.T) {
+	var rendered collatz.Chart
+	service := NewCollatzService(stubRenderer{rendered: &rendered})
+
+	result, err := service.Chart(context.Background(), i18n.English, big.NewInt(27), collatz.LogScale)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rendered.Scale != collatz.LogScale || len(rendered.Values) != 112 {
+		t.Errorf("rendered %s chart with %d values, want log chart with 112 values", rendered.Scale, len(rendered.Values))
+	}
+	if string(result.Image) != "png" {
+		t.Errorf("unexpected image: %q", result.Image)
+	}
+	if !strings.Contains(result.Summary, "Maximum: 9232 (at step 77)") {
+		t.Errorf("unexpected summary: %s", result.Summary)
+	}
+	lines := strings.Split(strings.TrimSpace(string(result.Sequence)), "\n")
+	if len(lines) != 112 || lines[0] != "27" || lines[len(lines)-1] != "1" {
+		t.Errorf("sequence text should list all 112 values from 27 to 1, got %d lines", len(lines))
 	}
 }
@@ -2,22 +2,67 @@ package dog
 
 import (
 	"context"
+	"time"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/dog"
+	"github.com/aktnb/discord-bot-go/internal/shared/cache"
 )
 
+// BreedCacheTTL は犬種の一覧を API から取得し直すまでの時間
+const BreedCacheTTL = 24 * time.Hour
+
 type Service struct {
-	repo dog.DogImageRepository
+	repo   dog.DogImageRepository
+	breeds *cache.Value[[]dog.Breed]
+}
+
+func NewDogService(repo dog.DogImageRepository, breeds dog.BreedRepository) *Service {
+	return &Service{
+		repo:   repo,
+		breeds: cache.NewValue(BreedCacheTTL, breeds.FetchBreeds),
+	}
+}
+
+// GetRandomDogImages はランダムな犬画像を count 枚返す。breedID を指定するとその犬種に限る
+func (s *Service) GetRandomDogImages(ctx context.Context, breedID string, count int) ([]*dog.DogImage, error) {
+	if count < 1 || count > dog.MaxImages {
+		return nil, dog.ErrInvalidCount
+	}
+	if breedID != "" {
+		if _, err := s.Breed(ctx, breedID); err != nil {
+			return nil, err
+		}
+	}
+
+	images, err := s.repo.FetchRandomImages(ctx, breedID, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, dog.ErrImageNotFound
+	}
+	return images, nil
 }
 
-func NewDogService(repo dog.DogImageRepository) *Service {
-	return &Service{repo: repo}
+// Breed は ID で犬種を返す
+func (s *Service) Breed(ctx context.Context, id string) (dog.Breed, error) {
+	breeds, err := s.breeds.Get(ctx)
+	if err != nil {
+		return dog.Breed{}, err
+	}
+	for _, breed := range breeds {
+		if breed.ID == id {
+			return breed, nil
+		}
+	}
+	return dog.Breed{}, dog.ErrBreedNotFound
 }
 
-func (s *Service) GetRandomDogImage(ctx context.Context) (*dog.DogImage, error) {
-	image, err := s.repo.FetchRandomImage(ctx)
+// SearchBreeds は名前に query を含む犬種を最大 limit 件返す
+func (s *Service) SearchBreeds(ctx context.Context, query string, limit int) ([]dog.Breed, error) {
+	breeds, err := s.breeds.Get(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return image, nil
+	return dog.SearchBreeds(breeds, query, limit), nil
 }
@@ -0,0 +1,128 @@
+package guildcommand
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/guildcommand"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+type Service struct {
+	repositories guildcommand.Repositories
+	txm          db.TxManager
+}
+
+func NewGuildCommandService(repositories guildcommand.Repositories, txm db.TxManager) *Service {
+	return &Service{
+		repositories: repositories,
+		txm:          txm,
+	}
+}
+
+// Enable は指定したギルドでコマンドを有効化する
+func (s *Service) Enable(ctx context.Context, guildID discordid.GuildID, commandName string) error {
+	return s.setEnabled(ctx, guildID, commandName, true)
+}
+
+// Disable は指定したギルドでコマンドを無効化する
+func (s *Service) Disable(ctx context.Context, guildID discordid.GuildID, commandName string) error {
+	return s.setEnabled(ctx, guildID, commandName, false)
+}
+
+func (s *Service) setEnabled(ctx context.Context, guildID discordid.GuildID, commandName string, enabled bool) error {
+	return s.txm.WithKeyLock(ctx, db.LockKey("guild_command:"+string(guildID)+":"+commandName), func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.GuildCommandSetting(tx)
+
+		setting, err := repo.Find(ctx, guildID, commandName)
+		if err != nil && !errors.Is(err, guildcommand.ErrSettingNotFound) {
+			return err
+		}
+
+		if setting == nil {
+			setting, err = guildcommand.NewSetting(guildID, commandName, enabled)
+			if err != nil {
+				return err
+			}
+		} else {
+			setting.ChangeEnabled(enabled)
+		}
+
+		return repo.Save(ctx, setting)
+	})
+}
+
+// ResolveGuildIDs はデフォルトの登録先に永続化された設定を適用し、コマンドを登録するギルドを返す
+func (s *Service) ResolveGuildIDs(ctx context.Context, commandName string, defaults []discordid.GuildID) ([]discordid.GuildID, error) {
+	var settings []*guildcommand.Setting
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		found, err := s.repositories.GuildCommandSetting(tx).FindByCommand(ctx, commandName)
+		if err != nil {
+			return err
+		}
+		settings = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return guildcommand.ResolveGuildIDs(defaults, settings), nil
+}
+
+// ConfiguredGuildIDs は設定が存在するギルドの一覧を返す
+// 全コマンドが無効化されたギルドの登録を空にするために使用する
+func (s *Service) ConfiguredGuildIDs(ctx context.Context) ([]discordid.GuildID, error) {
+	var guildIDs []discordid.GuildID
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		settings, err := s.repositories.GuildCommandSetting(tx).FindAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[discordid.GuildID]bool)
+		for _, setting := range settings {
+			if !seen[setting.GuildID()] {
+				seen[setting.GuildID()] = true
+				guildIDs = append(guildIDs, setting.GuildID())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return guildIDs, nil
+}
+
+// RegisteredGuildIDs はギルド固有コマンドを登録済みのギルドの一覧を返す
+// 登録するコマンドが無くなったギルドの登録を、再起動後も空にできるようにするために使用する
+func (s *Service) RegisteredGuildIDs(ctx context.Context) ([]discordid.GuildID, error) {
+	var guildIDs []discordid.GuildID
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		found, err := s.repositories.GuildCommandRegistration(tx).FindAll(ctx)
+		if err != nil {
+			return err
+		}
+		guildIDs = found
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return guildIDs, nil
+}
+
+// RecordRegistration はギルドにギルド固有コマンドを登録したかどうかを記録する
+func (s *Service) RecordRegistration(ctx context.Context, guildID discordid.GuildID, registered bool) error {
+	return s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.GuildCommandRegistration(tx)
+		if registered {
+			return repo.Save(ctx, guildID)
+		}
+		return repo.Delete(ctx, guildID)
+	})
+}
@@ -0,0 +1,32 @@
+package legend
+
+import (
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// CreateLegendCommand はギルドで定義する伝説コマンドの内容
+type CreateLegendCommand struct {
+	GuildID     discordid.GuildID
+	CreatedBy   discordid.UserID
+	Name        string
+	Description string
+	// Prefix はエピソードの見出しに使う名前（「〇〇伝説 その1」の〇〇）
+	Prefix string
+}
+
+// Reviewer はエピソードを審査するユーザー
+// ボットのオーナーはすべてのエピソードを、ギルドの管理者はそのギルドで定義された伝説のエピソードだけを審査できる
+type Reviewer struct {
+	UserID  discordid.UserID
+	GuildID discordid.GuildID
+	Owner   bool
+}
+
+// CanReview は伝説のエピソードを審査できるかどうかを返す
+func (r Reviewer) CanReview(ref legend.Ref) bool {
+	if r.Owner {
+		return true
+	}
+	return !ref.IsBuiltin() && ref.GuildID == r.GuildID
+}
@@ -0,0 +1,85 @@
+package legend
+
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// Definitions は全ギルドで定義された伝説コマンドを返す
+func (s *Service) Definitions(ctx context.Context) ([]*legend.Definition, error) {
+	var definitions []*legend.Definition
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		definitions, err = s.definitionRepositories.LegendDefinition(tx).FindAll(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+// Definition はギルドで定義された伝説コマンドを返す
+func (s *Service) Definition(ctx context.Context, guildID discordid.GuildID, name string) (*legend.Definition, error) {
+	if guildID == "" {
+		return nil, legend.ErrDefinitionNotFound
+	}
+
+	var definition *legend.Definition
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		definition, err = s.definitionRepositories.LegendDefinition(tx).FindByName(ctx, guildID, name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// CreateLegend はギルドに伝説コマンドを定義する
+// エピソードは空の状態で作られ、/legend submit の投稿を審査して追加していく
+func (s *Service) CreateLegend(ctx context.Context, cmd CreateLegendCommand) (*legend.Definition, error) {
+	definition, err := legend.NewDefinition(cmd.GuildID, cmd.Name, cmd.Description, cmd.Prefix, cmd.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txm.WithKeyLock(ctx, definitionLockKey(cmd.GuildID), func(ctx context.Context, tx db.Tx) error {
+		repo := s.definitionRepositories.LegendDefinition(tx)
+
+		definitions, err := repo.FindByGuild(ctx, cmd.GuildID)
+		if err != nil {
+			return err
+		}
+		if len(definitions) >= legend.MaxDefinitionsPerGuild {
+			return legend.ErrTooManyDefinitions
+		}
+		return repo.Create(ctx, definition)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// DeleteLegend はギルドで定義した伝説コマンドを、投稿されたエピソードとともに削除する
+func (s *Service) DeleteLegend(ctx context.Context, guildID discordid.GuildID, name string) error {
+	return s.txm.WithKeyLock(ctx, definitionLockKey(guildID), func(ctx context.Context, tx db.Tx) error {
+		if err := s.definitionRepositories.LegendDefinition(tx).Delete(ctx, guildID, name); err != nil {
+			return err
+		}
+		ref := legend.Ref{GuildID: guildID, Name: name}
+		if err := s.rotationRepositories.LegendRotation(tx).DeleteByLegend(ctx, ref); err != nil {
+			return err
+		}
+		return s.repositories.LegendEntry(tx).DeleteByLegend(ctx, ref)
+	})
+}
+
+func definitionLockKey(guildID discordid.GuildID) db.LockKey {
+	return db.LockKey("legend:definitions:" + string(guildID))
+}
@@ -0,0 +1,120 @@
+package legend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// SearchLimit は検索結果として返すエピソードの最大件数
+const SearchLimit = 5
+
+// NextEpisode はギルドで実行された伝説コマンドのエピソードを1つ返す
+// ギルドではシャッフルバッグで一巡するまで同じエピソードを出さず、DM では毎回ランダムに選ぶ
+func (s *Service) NextEpisode(ctx context.Context, guildID discordid.GuildID, legendName string) (legend.Episode, error) {
+	ref := legend.Resolve(guildID, legendName)
+	if guildID == "" {
+		episodes, err := s.approvedEpisodes(ctx, ref)
+		if err != nil {
+			return legend.Episode{}, err
+		}
+		return legend.Random(episodes)
+	}
+
+	var episode legend.Episode
+	err := s.txm.WithKeyLock(ctx, rotationLockKey(guildID, ref), func(ctx context.Context, tx db.Tx) error {
+		entries, err := s.repositories.LegendEntry(tx).FindApproved(ctx, ref)
+		if err != nil {
+			return err
+		}
+
+		repo := s.rotationRepositories.LegendRotation(tx)
+		rotation, err := repo.Find(ctx, guildID, ref)
+		if errors.Is(err, legend.ErrRotationNotFound) {
+			rotation, err = legend.NewRotation(guildID, ref)
+		}
+		if err != nil {
+			return err
+		}
+
+		episode, err = rotation.Next(toEpisodes(entries))
+		if err != nil {
+			return err
+		}
+		return repo.Save(ctx, rotation)
+	})
+	if err != nil {
+		return legend.Episode{}, err
+	}
+	return episode, nil
+}
+
+// Episode は通し番号を指定してエピソードを返す
+func (s *Service) Episode(ctx context.Context, guildID discordid.GuildID, legendName string, number int) (legend.Episode, error) {
+	episodes, err := s.approvedEpisodes(ctx, legend.Resolve(guildID, legendName))
+	if err != nil {
+		return legend.Episode{}, err
+	}
+	return legend.Find(episodes, number)
+}
+
+// SearchEpisodes はキーワードに一致するエピソードを一致度の高い順に最大 SearchLimit 件返す
+func (s *Service) SearchEpisodes(ctx context.Context, guildID discordid.GuildID, legendName, query string) ([]legend.Match, error) {
+	episodes, err := s.approvedEpisodes(ctx, legend.Resolve(guildID, legendName))
+	if err != nil {
+		return nil, err
+	}
+	return legend.Search(episodes, query, SearchLimit), nil
+}
+
+// EpisodeOfTheDay はギルドの今日のエピソードと、その日付を返す
+// おみくじと同じく日付で決まり、同じ日なら何度実行しても同じエピソードになる
+func (s *Service) EpisodeOfTheDay(ctx context.Context, guildID discordid.GuildID, legendName string) (legend.Episode, time.Time, error) {
+	ref := legend.Resolve(guildID, legendName)
+	episodes, err := s.approvedEpisodes(ctx, ref)
+	if err != nil {
+		return legend.Episode{}, time.Time{}, err
+	}
+
+	today := s.now().In(legend.DailyLocation)
+	episode, err := legend.OfTheDay(episodes, guildID, ref, today)
+	if err != nil {
+		return legend.Episode{}, time.Time{}, err
+	}
+	return episode, today, nil
+}
+
+// GenerateEpisode は伝説の文法からシードに応じたエピソードの本文を生成する
+// subject を指定すると主人公をその名前に差し替える
+func (s *Service) GenerateEpisode(legendName string, seed uint64, subject string) (string, error) {
+	return legend.Generate(legendName, seed, subject)
+}
+
+func (s *Service) approvedEpisodes(ctx context.Context, ref legend.Ref) ([]legend.Episode, error) {
+	var entries []*legend.Entry
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		entries, err = s.repositories.LegendEntry(tx).FindApproved(ctx, ref)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toEpisodes(entries), nil
+}
+
+func toEpisodes(entries []*legend.Entry) []legend.Episode {
+	episodes := make([]legend.Episode, len(entries))
+	for i, entry := range entries {
+		episodes[i] = entry.Episode()
+	}
+	return episodes
+}
+
+func rotationLockKey(guildID discordid.GuildID, ref legend.Ref) db.LockKey {
+	return db.LockKey("legend:rotation:" + string(guildID) + ":" + string(ref.GuildID) + ":" + ref.Name)
+}
@@ -0,0 +1,149 @@
+package legend
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// reviewLockKey は審査を直列化するロックのキー
+// 承認時の通し番号の採番が競合しないよう、審査は1件ずつ行う
+const reviewLockKey = db.LockKey("legend:review")
+
+type Service struct {
+	repositories           legend.Repositories
+	definitionRepositories legend.DefinitionRepositories
+	rotationRepositories   legend.RotationRepositories
+	txm                    db.TxManager
+	now                    func() time.Time
+}
+
+func NewLegendService(
+	repositories legend.Repositories,
+	definitionRepositories legend.DefinitionRepositories,
+	rotationRepositories legend.RotationRepositories,
+	txm db.TxManager,
+) *Service {
+	return &Service{
+		repositories:           repositories,
+		definitionRepositories: definitionRepositories,
+		rotationRepositories:   rotationRepositories,
+		txm:                    txm,
+		now:                    time.Now,
+	}
+}
+
+// Legends はギルドでエピソードを投稿できる伝説コマンドの名前を返す
+// 組み込みの伝説に続けて、そのギルドで定義された伝説を名前順に返す
+func (s *Service) Legends(ctx context.Context, guildID discordid.GuildID) ([]string, error) {
+	legends := slices.Clone(legend.Builtins)
+	if guildID == "" {
+		return legends, nil
+	}
+
+	var definitions []*legend.Definition
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		definitions, err = s.definitionRepositories.LegendDefinition(tx).FindByGuild(ctx, guildID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, definition := range definitions {
+		legends = append(legends, definition.Name())
+	}
+	return legends, nil
+}
+
+// Submit はエピソードを審査待ちとして投稿する
+func (s *Service) Submit(ctx context.Context, guildID discordid.GuildID, authorID discordid.UserID, legendName, text string) (*legend.Entry, error) {
+	legends, err := s.Legends(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(legends, legendName) {
+		return nil, legend.ErrUnknownLegend
+	}
+
+	entry, err := legend.NewSubmission(legend.Resolve(guildID, legendName), text, authorID, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		return s.repositories.LegendEntry(tx).Save(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// NextPending は審査者が審査できるエピソードのうち最も古いものと、その件数を返す
+// 審査待ちが無い場合は nil を返す
+func (s *Service) NextPending(ctx context.Context, reviewer Reviewer) (*legend.Entry, int, error) {
+	var pending []*legend.Entry
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		pending, err = s.repositories.LegendEntry(tx).FindPending(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pending = slices.DeleteFunc(pending, func(entry *legend.Entry) bool {
+		return !reviewer.CanReview(entry.Legend())
+	})
+	if len(pending) == 0 {
+		return nil, 0, nil
+	}
+	return pending[0], len(pending), nil
+}
+
+// Approve はエピソードを承認し、伝説の末尾の通し番号を割り当てる
+func (s *Service) Approve(ctx context.Context, id legend.EntryID, reviewer Reviewer) (*legend.Entry, error) {
+	return s.review(ctx, id, reviewer, func(repo legend.Repository, entry *legend.Entry) error {
+		number, err := repo.MaxNumber(ctx, entry.Legend())
+		if err != nil {
+			return err
+		}
+		return entry.Approve(reviewer.UserID, number+1)
+	})
+}
+
+// Reject はエピソードを却下する
+func (s *Service) Reject(ctx context.Context, id legend.EntryID, reviewer Reviewer) (*legend.Entry, error) {
+	return s.review(ctx, id, reviewer, func(repo legend.Repository, entry *legend.Entry) error {
+		return entry.Reject(reviewer.UserID)
+	})
+}
+
+func (s *Service) review(ctx context.Context, id legend.EntryID, reviewer Reviewer, decide func(repo legend.Repository, entry *legend.Entry) error) (*legend.Entry, error) {
+	var entry *legend.Entry
+	err := s.txm.WithKeyLock(ctx, reviewLockKey, func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.LegendEntry(tx)
+
+		var err error
+		entry, err = repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !reviewer.CanReview(entry.Legend()) {
+			return legend.ErrReviewForbidden
+		}
+		if err := decide(repo, entry); err != nil {
+			return err
+		}
+		return repo.Save(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,31 @@
+package mahjong
+
+import "github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+
+// ScoreCommand は点数計算する和了の内容
+type ScoreCommand struct {
+	// Hand は和了牌を最後に書いた 14 枚の手牌の表記
+	Hand    string
+	Tsumo   bool
+	Riichi  bool
+	Ippatsu bool
+	// SeatWind は自風、RoundWind は場風（東〜北）
+	SeatWind  mahjong.Kind
+	RoundWind mahjong.Kind
+	// Dora・Ura はドラ表示牌・裏ドラ表示牌の表記。なければ空
+	Dora string
+	Ura  string
+}
+
+// RecordHandCommand はモーダルで入力された局の結果。席は起家を 1 とした番号で書く
+type RecordHandCommand struct {
+	// Winner は和了した人の席。空なら流局
+	Winner string
+	// Loser は放銃した人の席。空ならツモ
+	Loser string
+	// Points はロンなら "7700"、子のツモなら "2000/3900"（子/親の払い）、親のツモなら "3900"（1人あたり）
+	Points string
+	// Riichi は立直した人の席、Tenpai は流局時に聴牌していた人の席を区切って並べたもの
+	Riichi string
+	Tenpai string
+}
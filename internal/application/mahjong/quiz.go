@@ -0,0 +1,113 @@
+package mahjong
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// RankingSize は何切る問題のランキングに表示する人数
+const RankingSize = 10
+
+// QuizStart は出題した問題と手牌の画像
+type QuizStart struct {
+	Round     *mahjong.QuizRound
+	ImageData []byte
+}
+
+// QuizService は何切る問題の出題・採点と成績の集計を行う
+type QuizService struct {
+	repositories mahjong.QuizRepositories
+	txm          db.TxManager
+	renderer     mahjong.HandRenderer
+	now          func() time.Time
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func NewQuizService(repositories mahjong.QuizRepositories, txm db.TxManager, renderer mahjong.HandRenderer) *QuizService {
+	return &QuizService{
+		repositories: repositories,
+		txm:          txm,
+		renderer:     renderer,
+		now:          time.Now,
+		rng:          rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// StartQuiz は userID に何切る問題を出題する
+func (s *QuizService) StartQuiz(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) (*QuizStart, error) {
+	s.mu.Lock()
+	hand, err := mahjong.DealQuizHand(s.rng)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	round, err := mahjong.NewQuizRound(guildID, userID, hand, s.now())
+	if err != nil {
+		return nil, err
+	}
+	imageData, err := s.renderer.RenderPNG(hand)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		return s.repositories.QuizRound(tx).Save(ctx, round)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &QuizStart{Round: round, ImageData: imageData}, nil
+}
+
+// AnswerQuiz は問題への userID の回答を採点して記録する
+// 同時に押されたボタンで二重に採点しないよう、問題ごとにロックして読み直す
+func (s *QuizService) AnswerQuiz(ctx context.Context, id mahjong.QuizRoundID, userID discordid.UserID, kind mahjong.Kind) (*mahjong.QuizRound, mahjong.QuizResult, error) {
+	var (
+		round  *mahjong.QuizRound
+		result mahjong.QuizResult
+	)
+	err := s.txm.WithKeyLock(ctx, quizLockKey(id), func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.QuizRound(tx)
+		var err error
+		round, err = repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		result, err = round.Answer(userID, kind, s.now())
+		if err != nil {
+			return err
+		}
+		return repo.Save(ctx, round)
+	})
+	if err != nil {
+		return nil, mahjong.QuizResult{}, err
+	}
+	return round, result, nil
+}
+
+// Ranking はギルドの何切る問題の成績を得点の高い順に RankingSize 人まで返す
+func (s *QuizService) Ranking(ctx context.Context, guildID discordid.GuildID) ([]mahjong.QuizStanding, error) {
+	var standings []mahjong.QuizStanding
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		standings, err = s.repositories.QuizRound(tx).Ranking(ctx, guildID, RankingSize)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
+
+func quizLockKey(id mahjong.QuizRoundID) db.LockKey {
+	return db.LockKey("mahjong:quiz:" + string(id))
+}
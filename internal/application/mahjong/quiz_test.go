@@ -0,0 +1,108 @@
+package mahjong
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// stubTxManager はトランザクションを使わずに fn を実行する
+type stubTxManager struct{}
+
+func (stubTxManager) WithTx(ctx context.Context, fn func(ctx context.Context, tx db.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+func (stubTxManager) WithKeyLock(ctx context.Context, key db.LockKey, fn func(ctx context.Context, tx db.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+// memoryQuizRepository は何切る問題をメモリに保存する
+type memoryQuizRepository map[mahjong.QuizRoundID]*mahjong.QuizRound
+
+func (r memoryQuizRepository) QuizRound(tx db.Tx) mahjong.QuizRepository {
+	return r
+}
+
+func (r memoryQuizRepository) FindByID(ctx context.Context, id mahjong.QuizRoundID) (*mahjong.QuizRound, error) {
+	round, ok := r[id]
+	if !ok {
+		return nil, mahjong.ErrQuizRoundNotFound
+	}
+	return round, nil
+}
+
+func (r memoryQuizRepository) Save(ctx context.Context, round *mahjong.QuizRound) error {
+	r[round.ID()] = round
+	return nil
+}
+
+func (r memoryQuizRepository) Ranking(ctx context.Context, guildID discordid.GuildID, limit int) ([]mahjong.QuizStanding, error) {
+	return nil, nil
+}
+
+type stubRenderer struct{}
+
+func (stubRenderer) RenderPNG(hand mahjong.Hand) ([]byte, error) {
+	return []byte(hand.String()), nil
+}
+
+func newTestQuizService(repo memoryQuizRepository) *QuizService {
+	s := NewQuizService(repo, stubTxManager{}, stubRenderer{})
+	s.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func TestQuizService_StartAndAnswer(t *testing.T) {
+	repo := memoryQuizRepository{}
+	s := newTestQuizService(repo)
+	ctx := context.Background()
+
+	start, err := s.StartQuiz(ctx, "guild", "player")
+	if err != nil {
+		t.Fatalf("StartQuiz: %v", err)
+	}
+	if _, ok := repo[start.Round.ID()]; !ok {
+		t.Fatal("quiz round is not saved")
+	}
+	if string(start.ImageData) != start.Round.Hand().String() {
+		t.Errorf("ImageData = %q, want the rendered hand", start.ImageData)
+	}
+
+	analysis, err := mahjong.Analyze(start.Round.Hand())
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	best := analysis.Discards[0].Kind
+
+	if _, _, err := s.AnswerQuiz(ctx, start.Round.ID(), "someone", best); !errors.Is(err, mahjong.ErrNotQuizPlayer) {
+		t.Errorf("answer by another user: err = %v, want ErrNotQuizPlayer", err)
+	}
+
+	round, result, err := s.AnswerQuiz(ctx, start.Round.ID(), "player", best)
+	if err != nil {
+		t.Fatalf("AnswerQuiz: %v", err)
+	}
+	if !result.Correct || result.Points != mahjong.QuizFullPoints {
+		t.Errorf("result = %+v, want a correct answer", result)
+	}
+	if !repo[round.ID()].Answered() {
+		t.Error("answer is not saved")
+	}
+
+	if _, _, err := s.AnswerQuiz(ctx, start.Round.ID(), "player", best); !errors.Is(err, mahjong.ErrAlreadyAnswered) {
+		t.Errorf("second answer: err = %v, want ErrAlreadyAnswered", err)
+	}
+}
+
+func TestQuizService_AnswerUnknownRound(t *testing.T) {
+	s := newTestQuizService(memoryQuizRepository{})
+	if _, _, err := s.AnswerQuiz(context.Background(), "missing", "player", mahjong.East); !errors.Is(err, mahjong.ErrQuizRoundNotFound) {
+		t.Errorf("err = %v, want ErrQuizRoundNotFound", err)
+	}
+}
@@ -2,6 +2,7 @@ package mahjong
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
 )
@@ -14,10 +15,60 @@ func NewMahjongService(repo mahjong.MahjongRepository) *Service {
 	return &Service{repo: repo}
 }
 
-func (s *Service) GetRandomStartingHand(ctx context.Context) (*mahjong.MahjongStartingHand, error) {
-	hand, err := s.repo.FetchRandomStartingHand(ctx)
+// SupportsDealOptions は配牌の枚数と赤ドラを指定できるかを返す
+func (s *Service) SupportsDealOptions() bool {
+	return s.repo.SupportsDealOptions()
+}
+
+// GetRandomStartingHand は options の条件で配牌を返す
+func (s *Service) GetRandomStartingHand(ctx context.Context, options mahjong.DealOptions) (*mahjong.MahjongStartingHand, error) {
+	hand, err := s.repo.FetchRandomStartingHand(ctx, options)
 	if err != nil {
 		return nil, err
 	}
 	return hand, nil
 }
+
+// Analyze は手牌の向聴数と有効牌、または打牌の候補を求める
+func (s *Service) Analyze(hand mahjong.Hand) (mahjong.Analysis, error) {
+	return mahjong.Analyze(hand)
+}
+
+// AnalyzeNotation は "123m456p789s11z" のような表記の手牌を分析する
+func (s *Service) AnalyzeNotation(notation string) (mahjong.Analysis, error) {
+	hand, err := mahjong.ParseHand(notation)
+	if err != nil {
+		return mahjong.Analysis{}, err
+	}
+	return mahjong.Analyze(hand)
+}
+
+// Score は表記で指定した和了の役・翻・符と支払いを求める
+func (s *Service) Score(cmd ScoreCommand) (mahjong.Score, error) {
+	hand, err := mahjong.ParseHand(cmd.Hand)
+	if err != nil {
+		return mahjong.Score{}, err
+	}
+	wc := mahjong.WinContext{
+		Tsumo:     cmd.Tsumo,
+		Riichi:    cmd.Riichi,
+		Ippatsu:   cmd.Ippatsu,
+		SeatWind:  cmd.SeatWind,
+		RoundWind: cmd.RoundWind,
+	}
+	if wc.DoraIndicators, err = parseIndicators(cmd.Dora); err != nil {
+		return mahjong.Score{}, err
+	}
+	if wc.UraIndicators, err = parseIndicators(cmd.Ura); err != nil {
+		return mahjong.Score{}, err
+	}
+	return mahjong.CalculateScore(hand, wc)
+}
+
+// parseIndicators はドラ表示牌の表記を読み込む。空なら表示牌はない
+func parseIndicators(notation string) ([]mahjong.Tile, error) {
+	if strings.TrimSpace(notation) == "" {
+		return nil, nil
+	}
+	return mahjong.ParseHand(notation)
+}
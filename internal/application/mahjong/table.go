@@ -0,0 +1,276 @@
+package mahjong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/discord"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// StartTableCommand は対局を始める要求
+type StartTableCommand struct {
+	GuildID   discordid.GuildID
+	ChannelID discordid.TextChannelID
+	UserID    discordid.UserID
+	// Players は起家から順に並べた参加者。空なら UserID がいるボイスチャンネルのメンバーを無作為な席順で参加させる
+	Players []discordid.UserID
+}
+
+// TableService は麻雀の対局の記録と成績の集計を行う
+type TableService struct {
+	repositories mahjong.TableRepositories
+	txm          db.TxManager
+	discord      discord.DiscordPort
+	now          func() time.Time
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func NewTableService(repositories mahjong.TableRepositories, txm db.TxManager, discordPort discord.DiscordPort) *TableService {
+	return &TableService{
+		repositories: repositories,
+		txm:          txm,
+		discord:      discordPort,
+		now:          time.Now,
+		rng:          rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// StartTable はチャンネルで対局を始める。チャンネルで進行中の対局があれば始めない
+func (s *TableService) StartTable(ctx context.Context, cmd StartTableCommand) (*mahjong.Table, error) {
+	players := cmd.Players
+	if len(players) == 0 {
+		var err error
+		players, err = s.voiceMembers(ctx, cmd.GuildID, cmd.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	table, err := mahjong.NewTable(cmd.GuildID, cmd.ChannelID, players, cmd.UserID, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txm.WithKeyLock(ctx, tableChannelLockKey(cmd.ChannelID), func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.Table(tx)
+		_, err := repo.FindPlayingByChannel(ctx, cmd.GuildID, cmd.ChannelID)
+		switch {
+		case err == nil:
+			return mahjong.ErrTableInProgress
+		case !errors.Is(err, mahjong.ErrTableNotFound):
+			return err
+		}
+		return repo.Save(ctx, table)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// voiceMembers は userID がいるボイスチャンネルのボット以外のメンバーを無作為に並べて返す
+func (s *TableService) voiceMembers(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) ([]discordid.UserID, error) {
+	voiceStates, err := s.discord.GetGuildVoiceStates(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	for _, members := range voiceStates {
+		if !slices.Contains(members, userID) {
+			continue
+		}
+		var players []discordid.UserID
+		for _, member := range members {
+			bot, err := s.discord.IsBot(ctx, guildID, member)
+			if err != nil {
+				return nil, err
+			}
+			if !bot {
+				players = append(players, member)
+			}
+		}
+		s.mu.Lock()
+		s.rng.Shuffle(len(players), func(i, j int) {
+			players[i], players[j] = players[j], players[i]
+		})
+		s.mu.Unlock()
+		return players, nil
+	}
+	return nil, mahjong.ErrNotInVoiceChannel
+}
+
+// PlayingTable はチャンネルで進行中の対局を返す
+func (s *TableService) PlayingTable(ctx context.Context, guildID discordid.GuildID, channelID discordid.TextChannelID) (*mahjong.Table, error) {
+	var table *mahjong.Table
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		table, err = s.repositories.Table(tx).FindPlayingByChannel(ctx, guildID, channelID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// RecordHand は userID が入力した局の結果を記録する
+func (s *TableService) RecordHand(ctx context.Context, id mahjong.TableID, userID discordid.UserID, cmd RecordHandCommand) (*mahjong.Table, error) {
+	hand, err := parseHandRecord(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, id, func(table *mahjong.Table) error {
+		return table.Record(userID, hand)
+	})
+}
+
+// UndoHand は最後に記録した局の結果を取り消す
+func (s *TableService) UndoHand(ctx context.Context, id mahjong.TableID, userID discordid.UserID) (*mahjong.Table, error) {
+	return s.update(ctx, id, func(table *mahjong.Table) error {
+		return table.Undo(userID)
+	})
+}
+
+// FinishTable は対局を終えて成績を確定する
+func (s *TableService) FinishTable(ctx context.Context, id mahjong.TableID, userID discordid.UserID) (*mahjong.Table, error) {
+	return s.update(ctx, id, func(table *mahjong.Table) error {
+		return table.Finish(userID, s.now())
+	})
+}
+
+// update は対局ごとにロックして読み直した対局を fn で変更して保存する
+// 同時に送信された記録が互いを上書きしないようにする
+func (s *TableService) update(ctx context.Context, id mahjong.TableID, fn func(table *mahjong.Table) error) (*mahjong.Table, error) {
+	var table *mahjong.Table
+	err := s.txm.WithKeyLock(ctx, tableLockKey(id), func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.Table(tx)
+		var err error
+		table, err = repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fn(table); err != nil {
+			return err
+		}
+		return repo.Save(ctx, table)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// Stats はギルドで終えた対局の成績を参加者ごとに集計する
+func (s *TableService) Stats(ctx context.Context, guildID discordid.GuildID) ([]mahjong.TableStats, error) {
+	var tables []*mahjong.Table
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		tables, err = s.repositories.Table(tx).FindFinishedByGuild(ctx, guildID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mahjong.AggregateTableStats(tables), nil
+}
+
+func tableLockKey(id mahjong.TableID) db.LockKey {
+	return db.LockKey("mahjong:table:" + string(id))
+}
+
+func tableChannelLockKey(channelID discordid.TextChannelID) db.LockKey {
+	return db.LockKey("mahjong:table:channel:" + string(channelID))
+}
+
+// parseHandRecord は入力された局の結果を席の番号が 0 始まりの HandRecord に変換する
+// 席の範囲や点の組み合わせは対局の状態と合わせて mahjong.Table が検証する
+func parseHandRecord(cmd RecordHandCommand) (mahjong.HandRecord, error) {
+	hand := mahjong.HandRecord{Winner: -1, Loser: -1}
+	var err error
+	if hand.Winner, err = parseSeat(cmd.Winner); err != nil {
+		return mahjong.HandRecord{}, err
+	}
+	if hand.Loser, err = parseSeat(cmd.Loser); err != nil {
+		return mahjong.HandRecord{}, err
+	}
+	if hand.Riichi, err = parseSeats(cmd.Riichi); err != nil {
+		return mahjong.HandRecord{}, err
+	}
+	if hand.Tenpai, err = parseSeats(cmd.Tenpai); err != nil {
+		return mahjong.HandRecord{}, err
+	}
+	if hand.Draw() {
+		return hand, nil
+	}
+
+	points := strings.NewReplacer(",", "", " ", "", "点", "").Replace(strings.TrimSpace(cmd.Points))
+	nonDealer, dealer, split := strings.Cut(points, "/")
+	if hand.Tsumo() {
+		if hand.TsumoNonDealer, err = parsePoints(nonDealer); err != nil {
+			return mahjong.HandRecord{}, err
+		}
+		if split {
+			if hand.TsumoDealer, err = parsePoints(dealer); err != nil {
+				return mahjong.HandRecord{}, err
+			}
+		}
+		return hand, nil
+	}
+	if split {
+		return mahjong.HandRecord{}, fmt.Errorf("%w: ron with split points %q", mahjong.ErrInvalidHandRecord, cmd.Points)
+	}
+	if hand.RonPoints, err = parsePoints(points); err != nil {
+		return mahjong.HandRecord{}, err
+	}
+	return hand, nil
+}
+
+// parseSeat は 1 始まりの席の番号を 0 始まりに変換する。空なら -1
+func parseSeat(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return -1, nil
+	}
+	seat, err := strconv.Atoi(value)
+	if err != nil || seat < 1 || seat > mahjong.MaxTablePlayers {
+		return 0, fmt.Errorf("%w: invalid seat %q", mahjong.ErrInvalidHandRecord, value)
+	}
+	return seat - 1, nil
+}
+
+// parseSeats は空白・カンマ・読点で区切った席の番号を変換する。"13" のように区切らずに並べてもよい
+func parseSeats(value string) ([]int, error) {
+	var seats []int
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '、' || unicode.IsSpace(r)
+	}) {
+		for _, r := range field {
+			seat, err := parseSeat(string(r))
+			if err != nil {
+				return nil, err
+			}
+			seats = append(seats, seat)
+		}
+	}
+	return seats, nil
+}
+
+func parsePoints(value string) (int, error) {
+	points, err := strconv.Atoi(value)
+	if err != nil || points <= 0 || points%100 != 0 {
+		return 0, fmt.Errorf("%w: invalid points %q", mahjong.ErrInvalidHandRecord, value)
+	}
+	return points, nil
+}
@@ -0,0 +1,221 @@
+package mahjong
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/discord"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// memoryTableRepository は対局をメモリに保存する
+type memoryTableRepository map[mahjong.TableID]*mahjong.Table
+
+func (r memoryTableRepository) Table(tx db.Tx) mahjong.TableRepository {
+	return r
+}
+
+func (r memoryTableRepository) FindByID(ctx context.Context, id mahjong.TableID) (*mahjong.Table, error) {
+	table, ok := r[id]
+	if !ok {
+		return nil, mahjong.ErrTableNotFound
+	}
+	return table, nil
+}
+
+func (r memoryTableRepository) FindPlayingByChannel(ctx context.Context, guildID discordid.GuildID, channelID discordid.TextChannelID) (*mahjong.Table, error) {
+	for _, table := range r {
+		if table.GuildID() == guildID && table.ChannelID() == channelID && table.Status() == mahjong.TableStatusPlaying {
+			return table, nil
+		}
+	}
+	return nil, mahjong.ErrTableNotFound
+}
+
+func (r memoryTableRepository) FindFinishedByGuild(ctx context.Context, guildID discordid.GuildID) ([]*mahjong.Table, error) {
+	var tables []*mahjong.Table
+	for _, table := range r {
+		if table.GuildID() == guildID && table.Status() == mahjong.TableStatusFinished {
+			tables = append(tables, table)
+		}
+	}
+	return tables, nil
+}
+
+func (r memoryTableRepository) Save(ctx context.Context, table *mahjong.Table) error {
+	r[table.ID()] = table
+	return nil
+}
+
+// stubVoiceStates はボイスチャンネルのメンバーだけを返す DiscordPort
+type stubVoiceStates struct {
+	discord.DiscordPort
+	members map[discordid.VoiceChannelID][]discordid.UserID
+	bots    []discordid.UserID
+}
+
+func (s stubVoiceStates) GetGuildVoiceStates(ctx context.Context, guildID discordid.GuildID) (map[discordid.VoiceChannelID][]discordid.UserID, error) {
+	return s.members, nil
+}
+
+func (s stubVoiceStates) IsBot(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) (bool, error) {
+	return slices.Contains(s.bots, userID), nil
+}
+
+func newTestTableService(repo memoryTableRepository) *TableService {
+	voice := stubVoiceStates{members: map[discordid.VoiceChannelID][]discordid.UserID{
+		"mahjong": {"a", "b", "bot", "c", "d"},
+		"lobby":   {"e"},
+	}, bots: []discordid.UserID{"bot"}}
+	s := NewTableService(repo, stubTxManager{}, voice)
+	s.now = func() time.Time { return time.Date(2026, 1, 1, 21, 0, 0, 0, time.UTC) }
+	return s
+}
+
+func TestTableService_StartTable(t *testing.T) {
+	repo := memoryTableRepository{}
+	s := newTestTableService(repo)
+	ctx := context.Background()
+
+	table, err := s.StartTable(ctx, StartTableCommand{GuildID: "guild", ChannelID: "channel", UserID: "b"})
+	if err != nil {
+		t.Fatalf("StartTable: %v", err)
+	}
+	players := slices.Clone(table.Players())
+	slices.Sort(players)
+	if !slices.Equal(players, []discordid.UserID{"a", "b", "c", "d"}) {
+		t.Errorf("Players = %v, want the voice channel members", table.Players())
+	}
+	if _, ok := repo[table.ID()]; !ok {
+		t.Fatal("table is not saved")
+	}
+
+	_, err = s.StartTable(ctx, StartTableCommand{GuildID: "guild", ChannelID: "channel", UserID: "a", Players: []discordid.UserID{"a", "b", "c"}})
+	if !errors.Is(err, mahjong.ErrTableInProgress) {
+		t.Errorf("StartTable in a busy channel = %v, want ErrTableInProgress", err)
+	}
+
+	other, err := s.StartTable(ctx, StartTableCommand{GuildID: "guild", ChannelID: "other", UserID: "e", Players: []discordid.UserID{"e", "f", "g"}})
+	if err != nil {
+		t.Fatalf("StartTable with players: %v", err)
+	}
+	if !slices.Equal(other.Players(), []discordid.UserID{"e", "f", "g"}) {
+		t.Errorf("Players = %v, want the given order", other.Players())
+	}
+}
+
+func TestTableService_StartTableVoiceErrors(t *testing.T) {
+	s := newTestTableService(memoryTableRepository{})
+	ctx := context.Background()
+
+	if _, err := s.StartTable(ctx, StartTableCommand{GuildID: "guild", ChannelID: "channel", UserID: "z"}); !errors.Is(err, mahjong.ErrNotInVoiceChannel) {
+		t.Errorf("StartTable outside voice = %v, want ErrNotInVoiceChannel", err)
+	}
+	if _, err := s.StartTable(ctx, StartTableCommand{GuildID: "guild", ChannelID: "channel", UserID: "e"}); !errors.Is(err, mahjong.ErrInvalidPlayerCount) {
+		t.Errorf("StartTable alone in voice = %v, want ErrInvalidPlayerCount", err)
+	}
+}
+
+func TestTableService_RecordAndFinish(t *testing.T) {
+	repo := memoryTableRepository{}
+	s := newTestTableService(repo)
+	ctx := context.Background()
+
+	table, err := s.StartTable(ctx, StartTableCommand{GuildID: "guild", ChannelID: "channel", UserID: "a", Players: []discordid.UserID{"a", "b", "c", "d"}})
+	if err != nil {
+		t.Fatalf("StartTable: %v", err)
+	}
+	for _, cmd := range []RecordHandCommand{
+		{Winner: "2", Loser: "3", Points: "3,900", Riichi: "1"},
+		{Tenpai: "2"},
+		{Winner: "4", Points: "1000/2000"},
+	} {
+		if _, err := s.RecordHand(ctx, table.ID(), "c", cmd); err != nil {
+			t.Fatalf("RecordHand(%+v): %v", cmd, err)
+		}
+	}
+	if _, err := s.RecordHand(ctx, table.ID(), "e", RecordHandCommand{}); !errors.Is(err, mahjong.ErrNotTablePlayer) {
+		t.Errorf("RecordHand by a non-player = %v, want ErrNotTablePlayer", err)
+	}
+
+	table, err = s.FinishTable(ctx, table.ID(), "d")
+	if err != nil {
+		t.Fatalf("FinishTable: %v", err)
+	}
+	if want := []int{21900, 30800, 19000, 28300}; !slices.Equal(table.Points(), want) {
+		t.Errorf("Points = %v, want %v", table.Points(), want)
+	}
+	if !table.FinishedAt().Equal(s.now()) {
+		t.Errorf("FinishedAt = %v, want %v", table.FinishedAt(), s.now())
+	}
+
+	stats, err := s.Stats(ctx, "guild")
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if len(stats) != 4 || stats[0].UserID != "b" || stats[0].Placements[0] != 1 {
+		t.Errorf("Stats = %+v, want b on top of 4 players", stats)
+	}
+}
+
+func TestParseHandRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  RecordHandCommand
+		want mahjong.HandRecord
+	}{
+		{
+			name: "ron",
+			cmd:  RecordHandCommand{Winner: "1", Loser: " 3 ", Points: "12000点", Riichi: "1、3"},
+			want: mahjong.HandRecord{Winner: 0, Loser: 2, RonPoints: 12000, Riichi: []int{0, 2}},
+		},
+		{
+			name: "non-dealer tsumo",
+			cmd:  RecordHandCommand{Winner: "2", Points: "700 / 1300"},
+			want: mahjong.HandRecord{Winner: 1, Loser: -1, TsumoNonDealer: 700, TsumoDealer: 1300},
+		},
+		{
+			name: "dealer tsumo",
+			cmd:  RecordHandCommand{Winner: "1", Points: "4000", Riichi: "12"},
+			want: mahjong.HandRecord{Winner: 0, Loser: -1, TsumoNonDealer: 4000, Riichi: []int{0, 1}},
+		},
+		{
+			name: "draw ignores points",
+			cmd:  RecordHandCommand{Points: "1000", Tenpai: "2 4"},
+			want: mahjong.HandRecord{Winner: -1, Loser: -1, Tenpai: []int{1, 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHandRecord(tt.cmd)
+			if err != nil {
+				t.Fatalf("parseHandRecord: %v", err)
+			}
+			if got.Winner != tt.want.Winner || got.Loser != tt.want.Loser || got.RonPoints != tt.want.RonPoints ||
+				got.TsumoNonDealer != tt.want.TsumoNonDealer || got.TsumoDealer != tt.want.TsumoDealer ||
+				!slices.Equal(got.Riichi, tt.want.Riichi) || !slices.Equal(got.Tenpai, tt.want.Tenpai) {
+				t.Errorf("parseHandRecord(%+v) = %+v, want %+v", tt.cmd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHandRecord_Invalid(t *testing.T) {
+	for _, cmd := range []RecordHandCommand{
+		{Winner: "5", Points: "1000"},
+		{Winner: "x", Points: "1000"},
+		{Winner: "1", Loser: "2", Points: "1000/2000"},
+		{Winner: "1", Loser: "2", Points: "1050"},
+		{Winner: "1", Loser: "2"},
+		{Riichi: "1,9"},
+	} {
+		if _, err := parseHandRecord(cmd); !errors.Is(err, mahjong.ErrInvalidHandRecord) {
+			t.Errorf("parseHandRecord(%+v) = %v, want ErrInvalidHandRecord", cmd, err)
+		}
+	}
+}
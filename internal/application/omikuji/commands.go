@@ -0,0 +1,14 @@
+package omikuji
+
+import "github.com/aktnb/discord-bot-go/internal/shared/discordid"
+
+// AddOverrideCommand は確率分布の差し替え設定の追加内容
+type AddOverrideCommand struct {
+	GuildID discordid.GuildID
+	Label   string
+	// StartDate と EndDate は "2006-01-02" 形式で、どちらもその日を含む
+	StartDate string
+	EndDate   string
+	// Distribution は "1/10/20/20/25/20/4" 形式のパーセント表記
+	Distribution string
+}
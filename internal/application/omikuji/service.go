@@ -5,33 +5,151 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
 )
 
-type Service struct{}
+const (
+	// 履歴カレンダーに表示する日数
+	HistoryDays = 30
+	// ランキングに表示する人数
+	RankingSize = 10
+)
+
+type Service struct {
+	repositories         omikuji.Repositories
+	settingsRepositories omikuji.SettingsRepositories
+	txm                  db.TxManager
+	now                  func() time.Time
+}
 
-func NewOmikujiService() *Service {
-	return &Service{}
+func NewOmikujiService(repositories omikuji.Repositories, settingsRepositories omikuji.SettingsRepositories, txm db.TxManager) *Service {
+	return &Service{
+		repositories:         repositories,
+		settingsRepositories: settingsRepositories,
+		txm:                  txm,
+		now:                  time.Now,
+	}
 }
 
 // DrawFortune はユーザーIDと日付に基づいて決定的におみくじを引く
-func (s *Service) DrawFortune(ctx context.Context, userID string) (*omikuji.Fortune, error) {
-	// 今日の日付を取得（JST）
-	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
-	today := time.Now().In(jst).Format("2006-01-02")
+// 日付の区切りと確率分布はギルドの設定に従い、その日最初に引いた結果を履歴として記録する
+// その日の記録が既にあれば、途中で確率分布が変わっていても記録した運勢レベルを返す
+func (s *Service) DrawFortune(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) (*omikuji.Fortune, error) {
+	// 設定を読めなくても、既定の設定でおみくじは引けるようにする
+	settings, overrides, err := s.GuildSettings(ctx, guildID)
+	if err != nil {
+		log.Printf("[WARN] Failed to load omikuji settings for guild %s, using defaults: %v", guildID, err)
+		settings, overrides = omikuji.DefaultGuildSettings(guildID), nil
+	}
+
+	// 今日の日付を取得（ギルドのタイムゾーン、既定は JST）
+	now := s.now().In(settings.Location())
+	today := now.Format("2006-01-02")
 
 	// ユーザーID + 日付でシード値を生成（決定性を保証）
-	seed := generateSeed(userID, today)
+	seed := generateSeed(string(userID), today)
 
 	// シードから運勢レベルを決定
-	level := determineFortuneLevel(seed)
+	level := determineFortuneLevel(seed, omikuji.ResolveDistribution(settings, overrides, now))
 
-	// Fortuneエンティティを生成
-	fortune := omikuji.NewFortune(level)
+	// 履歴の記録に失敗しても、おみくじの結果は返す
+	recorded, err := s.record(ctx, guildID, userID, now, level)
+	if err != nil {
+		log.Printf("[WARN] Failed to record omikuji draw for user %s: %v", userID, err)
+	} else {
+		level = recorded
+	}
 
-	return fortune, nil
+	// Fortuneエンティティを生成（項目別の運勢とラッキーアイテムも同じシードから導出）
+	return omikuji.NewFortune(level, seed, omikuji.DefaultContent()), nil
+}
+
+// record はその日最初のおみくじを記録し、その日に記録された運勢レベルを返す
+func (s *Service) record(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID, now time.Time, level omikuji.FortuneLevel) (omikuji.FortuneLevel, error) {
+	draw, err := omikuji.NewDraw(guildID, userID, now, level)
+	if err != nil {
+		return level, err
+	}
+
+	err = s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.OmikujiDraw(tx)
+		saved, err := repo.Save(ctx, draw)
+		if err != nil || saved {
+			return err
+		}
+		recorded, err := repo.Find(ctx, guildID, userID, now)
+		if err != nil {
+			return err
+		}
+		level = recorded.Level()
+		return nil
+	})
+	return level, err
+}
+
+// History は直近 HistoryDays 日分のおみくじの履歴を古い順に返す
+func (s *Service) History(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) ([]omikuji.HistoryDay, error) {
+	settings, _, err := s.GuildSettings(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	draws, err := s.findByUser(ctx, guildID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return omikuji.History(draws, s.now().In(settings.Location()), HistoryDays), nil
+}
+
+// Stats はユーザーのおみくじの分布と連続記録、比較に使うギルドの確率分布を返す
+func (s *Service) Stats(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) (omikuji.Stats, omikuji.Distribution, error) {
+	settings, _, err := s.GuildSettings(ctx, guildID)
+	if err != nil {
+		return omikuji.Stats{}, nil, err
+	}
+	draws, err := s.findByUser(ctx, guildID, userID)
+	if err != nil {
+		return omikuji.Stats{}, nil, err
+	}
+	return omikuji.CalculateStats(draws, s.now().In(settings.Location())), settings.Distribution(), nil
+}
+
+// TodayRanking はギルドで今日おみくじを引いたメンバーを運勢の良い順に最大 RankingSize 件返す
+func (s *Service) TodayRanking(ctx context.Context, guildID discordid.GuildID) ([]*omikuji.Draw, error) {
+	settings, _, err := s.GuildSettings(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var draws []*omikuji.Draw
+	err = s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		draws, err = s.repositories.OmikujiDraw(tx).FindByDate(ctx, guildID, s.now().In(settings.Location()))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	omikuji.SortByLuck(draws)
+	if len(draws) > RankingSize {
+		draws = draws[:RankingSize]
+	}
+	return draws, nil
+}
+
+func (s *Service) findByUser(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) ([]*omikuji.Draw, error) {
+	var draws []*omikuji.Draw
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		draws, err = s.repositories.OmikujiDraw(tx).FindByUser(ctx, guildID, userID)
+		return err
+	})
+	return draws, err
 }
 
 // generateSeed はユーザーIDと日付からシード値を生成
@@ -47,7 +165,7 @@ func generateSeed(userID string, date string) uint64 {
 }
 
 // determineFortuneLevel はシード値から運勢レベルを決定
-// 確率分布：
+// 0-999 に正規化した値を確率分布（千分率）に当てはめる。標準の分布では：
 // - 超大吉: 1%  (0-9)
 // - 大吉:  10%  (10-109)
 // - 中吉:  20%  (110-309)
@@ -55,24 +173,7 @@ func generateSeed(userID string, date string) uint64 {
 // - 吉:    25%  (510-759)
 // - 凶:    20%  (760-959)
 // - 大凶:  4%   (960-999)
-func determineFortuneLevel(seed uint64) omikuji.FortuneLevel {
+func determineFortuneLevel(seed uint64, distribution omikuji.Distribution) omikuji.FortuneLevel {
 	// 0-999の範囲に正規化
-	value := seed % 1000
-
-	switch {
-	case value < 10:
-		return omikuji.UltraGreatBlessing
-	case value < 110:
-		return omikuji.GreatBlessing
-	case value < 310:
-		return omikuji.MiddleBlessing
-	case value < 510:
-		return omikuji.SmallBlessing
-	case value < 760:
-		return omikuji.Blessing
-	case value < 960:
-		return omikuji.BadLuck
-	default:
-		return omikuji.GreatBadLuck
-	}
+	return distribution.Pick(int(seed % omikuji.TotalPermille))
 }
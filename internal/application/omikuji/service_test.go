@@ -0,0 +1,124 @@
+package omikuji
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// stubTxManager はトランザクションを使わずに fn を実行する
+type stubTxManager struct{}
+
+func (stubTxManager) WithTx(ctx context.Context, fn func(ctx context.Context, tx db.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+func (stubTxManager) WithKeyLock(ctx context.Context, key db.LockKey, fn func(ctx context.Context, tx db.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+// memoryDrawRepository はおみくじの記録をメモリに保存する
+type memoryDrawRepository struct {
+	draws []*omikuji.Draw
+}
+
+func (r *memoryDrawRepository) OmikujiDraw(tx db.Tx) omikuji.Repository {
+	return r
+}
+
+func (r *memoryDrawRepository) Save(ctx context.Context, draw *omikuji.Draw) (bool, error) {
+	if _, err := r.Find(ctx, draw.GuildID(), draw.UserID(), draw.Date()); err == nil {
+		return false, nil
+	}
+	r.draws = append(r.draws, draw)
+	return true, nil
+}
+
+func (r *memoryDrawRepository) Find(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID, date time.Time) (*omikuji.Draw, error) {
+	for _, draw := range r.draws {
+		if draw.GuildID() == guildID && draw.UserID() == userID && draw.Date().Equal(omikuji.DateOf(date)) {
+			return draw, nil
+		}
+	}
+	return nil, omikuji.ErrDrawNotFound
+}
+
+func (r *memoryDrawRepository) FindByUser(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) ([]*omikuji.Draw, error) {
+	return nil, nil
+}
+
+func (r *memoryDrawRepository) FindByDate(ctx context.Context, guildID discordid.GuildID, date time.Time) ([]*omikuji.Draw, error) {
+	return nil, nil
+}
+
+// stubSettingsRepository は決まった設定を返す
+type stubSettingsRepository struct {
+	omikuji.SettingsRepository
+	settings *omikuji.GuildSettings
+}
+
+func (r *stubSettingsRepository) OmikujiSettings(tx db.Tx) omikuji.SettingsRepository {
+	return r
+}
+
+func (r *stubSettingsRepository) FindSettings(ctx context.Context, guildID discordid.GuildID) (*omikuji.GuildSettings, error) {
+	return r.settings, nil
+}
+
+func (r *stubSettingsRepository) FindOverrides(ctx context.Context, guildID discordid.GuildID) ([]*omikuji.Override, error) {
+	return nil, nil
+}
+
+// onlyLevel は level だけが出る確率分布の設定を返す
+func onlyLevel(t *testing.T, guildID discordid.GuildID, level omikuji.FortuneLevel) *omikuji.GuildSettings {
+	t.Helper()
+	permilles := make([]int, len(omikuji.Levels))
+	for n, l := range omikuji.Levels {
+		if l == level {
+			permilles[n] = omikuji.TotalPermille
+		}
+	}
+	settings, err := omikuji.RebuildGuildSettings(guildID, omikuji.DefaultTimezone, permilles, time.Now(), time.Now())
+	if err != nil {
+		t.Fatalf("RebuildGuildSettings: %v", err)
+	}
+	return settings
+}
+
+func TestService_DrawFortuneReplaysRecordedDraw(t *testing.T) {
+	settingsRepo := &stubSettingsRepository{settings: onlyLevel(t, "guild", omikuji.GreatBadLuck)}
+	s := NewOmikujiService(&memoryDrawRepository{}, settingsRepo, stubTxManager{})
+	s.now = func() time.Time { return time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC) }
+
+	first, err := s.DrawFortune(context.Background(), "guild", "user")
+	if err != nil {
+		t.Fatalf("DrawFortune: %v", err)
+	}
+	if first.Level != omikuji.GreatBadLuck {
+		t.Fatalf("first draw = %s, want %s", first.Level, omikuji.GreatBadLuck)
+	}
+
+	// 同じ日に確率分布が変わっても、記録した結果を返す
+	settingsRepo.settings = onlyLevel(t, "guild", omikuji.UltraGreatBlessing)
+	again, err := s.DrawFortune(context.Background(), "guild", "user")
+	if err != nil {
+		t.Fatalf("DrawFortune: %v", err)
+	}
+	if again.Level != omikuji.GreatBadLuck {
+		t.Errorf("second draw = %s, want the recorded %s", again.Level, omikuji.GreatBadLuck)
+	}
+
+	// 翌日は新しい確率分布で引く
+	s.now = func() time.Time { return time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC) }
+	next, err := s.DrawFortune(context.Background(), "guild", "user")
+	if err != nil {
+		t.Fatalf("DrawFortune: %v", err)
+	}
+	if next.Level != omikuji.UltraGreatBlessing {
+		t.Errorf("next day draw = %s, want %s", next.Level, omikuji.UltraGreatBlessing)
+	}
+}
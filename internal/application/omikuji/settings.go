@@ -0,0 +1,159 @@
+package omikuji
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// dateLayout は差し替え設定の期間の入力形式
+const dateLayout = "2006-01-02"
+
+// GuildSettings はギルドの設定と差し替え設定を返す
+// 設定が無いギルドや DM では既定の設定を返す
+func (s *Service) GuildSettings(ctx context.Context, guildID discordid.GuildID) (*omikuji.GuildSettings, []*omikuji.Override, error) {
+	if guildID == "" {
+		return omikuji.DefaultGuildSettings(guildID), nil, nil
+	}
+
+	var (
+		settings  *omikuji.GuildSettings
+		overrides []*omikuji.Override
+	)
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		repo := s.settingsRepositories.OmikujiSettings(tx)
+
+		var err error
+		settings, err = findOrDefaultSettings(ctx, repo, guildID)
+		if err != nil {
+			return err
+		}
+		overrides, err = repo.FindOverrides(ctx, guildID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return settings, overrides, nil
+}
+
+// Location はギルドのタイムゾーンを返す
+// おみくじの設定で変更したタイムゾーンは、予約投稿などおみくじ以外の機能でもギルドのタイムゾーンとして使う
+func (s *Service) Location(ctx context.Context, guildID discordid.GuildID) (*time.Location, error) {
+	settings, _, err := s.GuildSettings(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	return settings.Location(), nil
+}
+
+// ChangeDistribution はギルドの確率分布を "1/10/20/20/25/20/4" 形式のパーセント表記で変更する
+func (s *Service) ChangeDistribution(ctx context.Context, guildID discordid.GuildID, table string) (*omikuji.GuildSettings, error) {
+	distribution, err := omikuji.ParseDistribution(table)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateSettings(ctx, guildID, func(settings *omikuji.GuildSettings) error {
+		return settings.ChangeDistribution(distribution)
+	})
+}
+
+// ChangeTimezone はギルドのおみくじの日付の区切りに使うタイムゾーンを変更する
+func (s *Service) ChangeTimezone(ctx context.Context, guildID discordid.GuildID, timezone string) (*omikuji.GuildSettings, error) {
+	return s.updateSettings(ctx, guildID, func(settings *omikuji.GuildSettings) error {
+		return settings.ChangeTimezone(timezone)
+	})
+}
+
+// AddOverride は期間を指定して確率分布の差し替え設定を追加する
+func (s *Service) AddOverride(ctx context.Context, cmd AddOverrideCommand) (*omikuji.Override, error) {
+	startDate, err := time.Parse(dateLayout, cmd.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: start date %q", omikuji.ErrInvalidDateRange, cmd.StartDate)
+	}
+	endDate, err := time.Parse(dateLayout, cmd.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: end date %q", omikuji.ErrInvalidDateRange, cmd.EndDate)
+	}
+	distribution, err := omikuji.ParseDistribution(cmd.Distribution)
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := omikuji.NewOverride(cmd.GuildID, cmd.Label, startDate, endDate, distribution)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txm.WithKeyLock(ctx, settingsLockKey(cmd.GuildID), func(ctx context.Context, tx db.Tx) error {
+		return s.settingsRepositories.OmikujiSettings(tx).SaveOverride(ctx, override)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return override, nil
+}
+
+// RemoveOverride は差し替え設定を削除する
+func (s *Service) RemoveOverride(ctx context.Context, guildID discordid.GuildID, id omikuji.OverrideID) error {
+	return s.txm.WithKeyLock(ctx, settingsLockKey(guildID), func(ctx context.Context, tx db.Tx) error {
+		return s.settingsRepositories.OmikujiSettings(tx).DeleteOverride(ctx, guildID, id)
+	})
+}
+
+// ResetSettings はギルドの設定と差し替え設定をすべて削除し、既定の動作に戻す
+func (s *Service) ResetSettings(ctx context.Context, guildID discordid.GuildID) error {
+	return s.txm.WithKeyLock(ctx, settingsLockKey(guildID), func(ctx context.Context, tx db.Tx) error {
+		repo := s.settingsRepositories.OmikujiSettings(tx)
+		if err := repo.DeleteOverrides(ctx, guildID); err != nil {
+			return err
+		}
+		return repo.DeleteSettings(ctx, guildID)
+	})
+}
+
+func (s *Service) updateSettings(ctx context.Context, guildID discordid.GuildID, update func(settings *omikuji.GuildSettings) error) (*omikuji.GuildSettings, error) {
+	if guildID == "" {
+		return nil, omikuji.ErrInvalidGuildID
+	}
+
+	var settings *omikuji.GuildSettings
+	err := s.txm.WithKeyLock(ctx, settingsLockKey(guildID), func(ctx context.Context, tx db.Tx) error {
+		repo := s.settingsRepositories.OmikujiSettings(tx)
+
+		var err error
+		settings, err = repo.FindSettings(ctx, guildID)
+		if errors.Is(err, omikuji.ErrSettingsNotFound) {
+			settings, err = omikuji.NewGuildSettings(guildID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := update(settings); err != nil {
+			return err
+		}
+		return repo.SaveSettings(ctx, settings)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func findOrDefaultSettings(ctx context.Context, repo omikuji.SettingsRepository, guildID discordid.GuildID) (*omikuji.GuildSettings, error) {
+	settings, err := repo.FindSettings(ctx, guildID)
+	if errors.Is(err, omikuji.ErrSettingsNotFound) {
+		return omikuji.DefaultGuildSettings(guildID), nil
+	}
+	return settings, err
+}
+
+func settingsLockKey(guildID discordid.GuildID) db.LockKey {
+	return db.LockKey("omikuji_settings:" + string(guildID))
+}
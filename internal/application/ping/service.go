@@ -2,14 +2,71 @@ package ping
 
 import (
 	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/ping"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
 )
 
-type Service struct{}
+// historySize は保持するハートビート遅延のサンプル数
+const historySize = 15
+
+type Service struct {
+	txm db.TxManager
+
+	mu      sync.Mutex
+	history *ping.History
+}
+
+func NewPingService(txm db.TxManager) *Service {
+	return &Service{
+		txm:     txm,
+		history: ping.NewHistory(historySize),
+	}
+}
+
+// MeasureDatabase は TxManager 経由で DB への往復時間を計測する
+func (s *Service) MeasureDatabase(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var one int
+		return tx.QueryRow(ctx, "SELECT 1").Scan(&one)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// RunSampler は interval ごとに probe でハートビート遅延を計測して履歴に記録する
+// ctx がキャンセルされるまでブロックするため、goroutine で呼び出す
+func (s *Service) RunSampler(ctx context.Context, interval time.Duration, probe func() time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-func NewPingService() *Service {
-	return &Service{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			latency := probe()
+			// 最初のハートビート応答を受け取るまでは 0 が返る
+			if latency <= 0 {
+				continue
+			}
+			s.mu.Lock()
+			s.history.Add(ping.Sample{At: now, Latency: latency})
+			s.mu.Unlock()
+			log.Printf("[DEBUG] Heartbeat latency sampled: %s", latency)
+		}
+	}
 }
 
-func (s *Service) Ping(ctx context.Context) (string, error) {
-	return "Pong!", nil
+// HeartbeatHistory は記録済みのハートビート遅延を古い順に返す
+func (s *Service) HeartbeatHistory() []ping.Sample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.history.Samples()
 }
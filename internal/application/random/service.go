@@ -0,0 +1,169 @@
+package random
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/random"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/discord"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// TeamsCommand はボイスチャンネルのメンバーをチームに分ける要求
+type TeamsCommand struct {
+	GuildID discordid.GuildID
+	// UserID はチーム分けを実行した人。この人がいるボイスチャンネルのメンバーを分ける
+	UserID discordid.UserID
+	Count  int
+	Seed   uint64
+	// Channels はチームの番号の順に並べた移動先のボイスチャンネル。空文字列のチームは移動しない
+	// 移動先を指定するには、実行した人が元のボイスチャンネルと移動先の両方でメンバーを移動する権限を持つ必要がある
+	Channels []discordid.VoiceChannelID
+}
+
+// TeamsResult はチーム分けの結果
+type TeamsResult struct {
+	// Source はチームに分けたメンバーがいたボイスチャンネル
+	Source discordid.VoiceChannelID
+	Teams  [][]discordid.UserID
+	// Channels はチームごとの移動先。移動しないチームは空文字列
+	Channels []discordid.VoiceChannelID
+	// Failed は移動できなかったメンバー
+	Failed []discordid.UserID
+}
+
+type Service struct {
+	discord discord.DiscordPort
+}
+
+func NewRandomService(discordPort discord.DiscordPort) *Service {
+	return &Service{discord: discordPort}
+}
+
+// Roll はダイスの式を seed で振る
+func (s *Service) Roll(expression string, seed uint64) (random.RollResult, error) {
+	expr, err := random.ParseDice(expression)
+	if err != nil {
+		return random.RollResult{}, err
+	}
+	rng, err := random.NewRand(seed)
+	if err != nil {
+		return random.RollResult{}, err
+	}
+	return expr.Roll(rng)
+}
+
+// Choose は区切られた選択肢から seed で count 個を選ぶ
+func (s *Service) Choose(text string, count int, seed uint64) ([]string, error) {
+	choices, err := random.ParseChoices(text)
+	if err != nil {
+		return nil, err
+	}
+	rng, err := random.NewRand(seed)
+	if err != nil {
+		return nil, err
+	}
+	return random.Choose(rng, choices, count)
+}
+
+// CheckMovePermission は実行した人がボイスチャンネルにいて、指定した移動先へメンバーを移動できるかを確かめる
+// 移動先を指定しなければ、ボイスチャンネルにいるかだけを確かめる
+func (s *Service) CheckMovePermission(ctx context.Context, cmd TeamsCommand) error {
+	source, _, err := s.voiceChannel(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	return s.checkMovePermission(ctx, cmd, source)
+}
+
+// Teams は実行した人がいるボイスチャンネルのボット以外のメンバーを seed でチームに分ける
+// 移動先を指定したチームのメンバーはそのボイスチャンネルに移動し、移動できなかったメンバーは Failed に入れる
+func (s *Service) Teams(ctx context.Context, cmd TeamsCommand) (*TeamsResult, error) {
+	rng, err := random.NewRand(cmd.Seed)
+	if err != nil {
+		return nil, err
+	}
+	source, users, err := s.voiceChannel(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMovePermission(ctx, cmd, source); err != nil {
+		return nil, err
+	}
+
+	var members []discordid.UserID
+	for _, user := range users {
+		bot, err := s.discord.IsBot(ctx, cmd.GuildID, user)
+		if err != nil {
+			return nil, err
+		}
+		if !bot {
+			members = append(members, user)
+		}
+	}
+
+	teams, err := random.SplitTeams(rng, members, cmd.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TeamsResult{Source: source, Teams: teams, Channels: make([]discordid.VoiceChannelID, len(teams))}
+	for n, team := range teams {
+		if n >= len(cmd.Channels) || cmd.Channels[n] == "" {
+			continue
+		}
+		result.Channels[n] = cmd.Channels[n]
+		if cmd.Channels[n] == source {
+			continue
+		}
+		for _, member := range team {
+			if err := s.discord.MoveMemberToVoiceChannel(ctx, cmd.GuildID, member, cmd.Channels[n]); err != nil {
+				log.Printf("Error moving %s to voice channel %s: %v", member, cmd.Channels[n], err)
+				result.Failed = append(result.Failed, member)
+			}
+		}
+	}
+	return result, nil
+}
+
+// voiceChannel は実行した人がいるボイスチャンネルとそのメンバーを返す
+func (s *Service) voiceChannel(ctx context.Context, cmd TeamsCommand) (discordid.VoiceChannelID, []discordid.UserID, error) {
+	voiceStates, err := s.discord.GetGuildVoiceStates(ctx, cmd.GuildID)
+	if err != nil {
+		return "", nil, err
+	}
+	for channelID, users := range voiceStates {
+		if slices.Contains(users, cmd.UserID) {
+			return channelID, users, nil
+		}
+	}
+	return "", nil, random.ErrNotInVoiceChannel
+}
+
+// checkMovePermission は移動先を指定した場合に、実行した人が元のボイスチャンネルと各移動先でメンバーを移動できるかを確かめる
+// ボットの権限で移動させるので、実行した人自身が移動できないメンバーを動かさないようにする
+func (s *Service) checkMovePermission(ctx context.Context, cmd TeamsCommand, source discordid.VoiceChannelID) error {
+	var channels []discordid.VoiceChannelID
+	for _, channelID := range cmd.Channels {
+		if channelID != "" && !slices.Contains(channels, channelID) {
+			channels = append(channels, channelID)
+		}
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+	if !slices.Contains(channels, source) {
+		channels = append(channels, source)
+	}
+	for _, channelID := range channels {
+		ok, err := s.discord.CanMoveMembers(ctx, cmd.UserID, channelID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return random.ErrMoveNotPermitted
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package random
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/random"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/discord"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// stubDiscord はボイスチャンネルのメンバーを返し、移動を記録する DiscordPort
+type stubDiscord struct {
+	discord.DiscordPort
+	members map[discordid.VoiceChannelID][]discordid.UserID
+	bots    []discordid.UserID
+	// movers はボイスチャンネルごとのメンバーを移動する権限を持つ人
+	movers map[discordid.VoiceChannelID][]discordid.UserID
+	// blocked は移動に失敗するメンバー
+	blocked discordid.UserID
+	moved   map[discordid.UserID]discordid.VoiceChannelID
+}
+
+func (s *stubDiscord) GetGuildVoiceStates(ctx context.Context, guildID discordid.GuildID) (map[discordid.VoiceChannelID][]discordid.UserID, error) {
+	return s.members, nil
+}
+
+func (s *stubDiscord) IsBot(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) (bool, error) {
+	return slices.Contains(s.bots, userID), nil
+}
+
+func (s *stubDiscord) CanMoveMembers(ctx context.Context, userID discordid.UserID, voiceChannelID discordid.VoiceChannelID) (bool, error) {
+	return slices.Contains(s.movers[voiceChannelID], userID), nil
+}
+
+func (s *stubDiscord) MoveMemberToVoiceChannel(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID, voiceChannelID discordid.VoiceChannelID) error {
+	if userID == s.blocked {
+		return errors.New("missing permission")
+	}
+	s.moved[userID] = voiceChannelID
+	return nil
+}
+
+func newStubDiscord() *stubDiscord {
+	return &stubDiscord{
+		members: map[discordid.VoiceChannelID][]discordid.UserID{
+			"lobby": {"a", "b", "bot", "c", "d", "e"},
+			"other": {"z"},
+		},
+		bots: []discordid.UserID{"bot"},
+		movers: map[discordid.VoiceChannelID][]discordid.UserID{
+			"lobby": {"b"},
+			"red":   {"b"},
+		},
+		moved: make(map[discordid.UserID]discordid.VoiceChannelID),
+	}
+}
+
+func TestService_Teams(t *testing.T) {
+	port := newStubDiscord()
+	port.blocked = "c"
+	s := NewRandomService(port)
+
+	result, err := s.Teams(context.Background(), TeamsCommand{
+		GuildID:  "guild",
+		UserID:   "b",
+		Count:    2,
+		Seed:     7,
+		Channels: []discordid.VoiceChannelID{"", "red"},
+	})
+	if err != nil {
+		t.Fatalf("Teams: %v", err)
+	}
+	if result.Source != "lobby" || len(result.Teams) != 2 || len(result.Teams[0]) != 3 || len(result.Teams[1]) != 2 {
+		t.Fatalf("Teams = %+v, want teams of 3 and 2 from lobby", result)
+	}
+	for _, team := range result.Teams {
+		if slices.Contains(team, "bot") {
+			t.Errorf("team %v contains the bot", team)
+		}
+	}
+	if !slices.Equal(result.Channels, []discordid.VoiceChannelID{"", "red"}) {
+		t.Errorf("Channels = %v, want [\"\" red]", result.Channels)
+	}
+
+	// 2番目のチームだけが移動し、移動できなかったメンバーは Failed に入る
+	var wantFailed []discordid.UserID
+	for _, member := range result.Teams[1] {
+		if member == "c" {
+			wantFailed = append(wantFailed, member)
+		} else if port.moved[member] != "red" {
+			t.Errorf("%s moved to %q, want red", member, port.moved[member])
+		}
+	}
+	for _, member := range result.Teams[0] {
+		if _, ok := port.moved[member]; ok {
+			t.Errorf("%s in the first team was moved", member)
+		}
+	}
+	if !slices.Equal(result.Failed, wantFailed) {
+		t.Errorf("Failed = %v, want %v", result.Failed, wantFailed)
+	}
+
+	again, err := NewRandomService(newStubDiscord()).Teams(context.Background(), TeamsCommand{GuildID: "guild", UserID: "e", Count: 2, Seed: 7})
+	if err != nil {
+		t.Fatalf("Teams: %v", err)
+	}
+	for n := range result.Teams {
+		if !slices.Equal(result.Teams[n], again.Teams[n]) {
+			t.Errorf("team %d = %v and %v for the same seed", n+1, result.Teams[n], again.Teams[n])
+		}
+	}
+}
+
+func TestService_TeamsNotInVoice(t *testing.T) {
+	s := NewRandomService(newStubDiscord())
+	_, err := s.Teams(context.Background(), TeamsCommand{GuildID: "guild", UserID: "nobody", Count: 2})
+	if !errors.Is(err, random.ErrNotInVoiceChannel) {
+		t.Errorf("Teams = %v, want ErrNotInVoiceChannel", err)
+	}
+}
+
+func TestService_TeamsMoveNotPermitted(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		movers map[discordid.VoiceChannelID][]discordid.UserID
+	}{
+		// テキストチャンネルで権限があっても、ボイスチャンネルの上書きで拒否されている
+		{name: "denied in the source channel", movers: map[discordid.VoiceChannelID][]discordid.UserID{"red": {"b"}}},
+		{name: "denied in the target channel", movers: map[discordid.VoiceChannelID][]discordid.UserID{"lobby": {"b"}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			port := newStubDiscord()
+			port.movers = tt.movers
+			s := NewRandomService(port)
+			cmd := TeamsCommand{GuildID: "guild", UserID: "b", Count: 2, Channels: []discordid.VoiceChannelID{"red", ""}}
+			if err := s.CheckMovePermission(context.Background(), cmd); !errors.Is(err, random.ErrMoveNotPermitted) {
+				t.Errorf("CheckMovePermission = %v, want ErrMoveNotPermitted", err)
+			}
+			if _, err := s.Teams(context.Background(), cmd); !errors.Is(err, random.ErrMoveNotPermitted) {
+				t.Errorf("Teams = %v, want ErrMoveNotPermitted", err)
+			}
+			if len(port.moved) != 0 {
+				t.Errorf("moved %v without the permission", port.moved)
+			}
+
+			// 移動先を指定しなければ権限が無くてもチームに分けられる
+			if _, err := s.Teams(context.Background(), TeamsCommand{GuildID: "guild", UserID: "b", Count: 2, Channels: make([]discordid.VoiceChannelID, 2)}); err != nil {
+				t.Errorf("Teams without channels: %v", err)
+			}
+		})
+	}
+}
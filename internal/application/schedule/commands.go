@@ -0,0 +1,18 @@
+package schedule
+
+import (
+	"github.com/aktnb/discord-bot-go/internal/domain/schedule"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// AddScheduleCommand は登録する予約投稿の内容
+type AddScheduleCommand struct {
+	GuildID   discordid.GuildID
+	ChannelID discordid.TextChannelID
+	CreatedBy discordid.UserID
+	// Legend は投稿する伝説コマンドの名前
+	Legend string
+	// Spec は「分 時 日 月 曜日」の cron 形式の式
+	Spec    string
+	CatchUp schedule.CatchUp
+}
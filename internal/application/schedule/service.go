@@ -0,0 +1,248 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/schedule"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// LegendProvider はギルドで投稿できる伝説コマンドの名前を返す
+type LegendProvider interface {
+	Legends(ctx context.Context, guildID discordid.GuildID) ([]string, error)
+}
+
+// LocationProvider はギルドのタイムゾーンを返す
+type LocationProvider interface {
+	Location(ctx context.Context, guildID discordid.GuildID) (*time.Location, error)
+}
+
+// Publisher は予約投稿の伝説エピソードを選んでチャンネルに投稿する
+type Publisher interface {
+	Publish(ctx context.Context, schedule *schedule.Schedule) error
+}
+
+type Service struct {
+	repositories schedule.Repositories
+	txm          db.TxManager
+	legends      LegendProvider
+	locations    LocationProvider
+	publisher    Publisher
+	now          func() time.Time
+}
+
+func NewScheduleService(
+	repositories schedule.Repositories,
+	txm db.TxManager,
+	legends LegendProvider,
+	locations LocationProvider,
+	publisher Publisher,
+) *Service {
+	return &Service{
+		repositories: repositories,
+		txm:          txm,
+		legends:      legends,
+		locations:    locations,
+		publisher:    publisher,
+		now:          time.Now,
+	}
+}
+
+// Schedules はギルドの予約投稿を登録の古い順に返す
+func (s *Service) Schedules(ctx context.Context, guildID discordid.GuildID) ([]*schedule.Schedule, error) {
+	var schedules []*schedule.Schedule
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		schedules, err = s.repositories.Schedule(tx).FindByGuild(ctx, guildID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// AddSchedule はギルドに予約投稿を登録する
+// 最初の実行時刻はギルドのタイムゾーンで計算する
+func (s *Service) AddSchedule(ctx context.Context, cmd AddScheduleCommand) (*schedule.Schedule, error) {
+	if cmd.GuildID == "" {
+		return nil, schedule.ErrInvalidGuildID
+	}
+	legends, err := s.legends.Legends(ctx, cmd.GuildID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(legends, cmd.Legend) {
+		return nil, schedule.ErrUnknownLegend
+	}
+	loc, err := s.locations.Location(ctx, cmd.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *schedule.Schedule
+	err = s.txm.WithKeyLock(ctx, guildLockKey(cmd.GuildID), func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.Schedule(tx)
+		schedules, err := repo.FindByGuild(ctx, cmd.GuildID)
+		if err != nil {
+			return err
+		}
+		if len(schedules) >= schedule.MaxSchedulesPerGuild {
+			return schedule.ErrTooManySchedules
+		}
+
+		created, err = schedule.NewSchedule(cmd.GuildID, cmd.ChannelID, cmd.Legend, cmd.Spec, cmd.CatchUp, cmd.CreatedBy, s.now().In(loc))
+		if err != nil {
+			return err
+		}
+		return repo.Save(ctx, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// RemoveSchedule はギルドの予約投稿を削除する
+func (s *Service) RemoveSchedule(ctx context.Context, guildID discordid.GuildID, id schedule.ID) error {
+	return s.txm.WithKeyLock(ctx, guildLockKey(guildID), func(ctx context.Context, tx db.Tx) error {
+		return s.repositories.Schedule(tx).Delete(ctx, guildID, id)
+	})
+}
+
+// Run は interval ごとに実行時刻を過ぎた予約投稿を処理する
+// 起動直後にも一度処理し、停止中に逃した実行を各予約投稿の CatchUp に従って扱う
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RunDue(ctx); err != nil {
+			log.Printf("Error running schedules: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue は現在時刻の時点で実行時刻を過ぎた予約投稿を処理し、投稿した件数を返す
+// 投稿に失敗した予約投稿も次の実行時刻に進め、同じ実行を繰り返し投稿しようとしない
+func (s *Service) RunDue(ctx context.Context) (int, error) {
+	now := s.now()
+
+	var due []*schedule.Schedule
+	err := s.txm.WithTx(ctx, func(ctx context.Context, tx db.Tx) error {
+		var err error
+		due, err = s.repositories.Schedule(tx).FindDue(ctx, now)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, sch := range due {
+		ok, err := s.run(ctx, sch.GuildID(), sch.ID(), now)
+		if err != nil {
+			log.Printf("Error running schedule: id=%s guild=%s err=%v", sch.ID(), sch.GuildID(), err)
+			continue
+		}
+		if ok {
+			posted++
+		}
+	}
+	return posted, nil
+}
+
+// run は予約投稿を1件処理し、投稿したかどうかを返す
+// 複数のインスタンスが同時に処理しても二重に投稿しないよう、予約投稿ごとにロックして読み直す
+// 次の実行時刻を保存してロックを解放してから投稿し、Discord への送信の間トランザクションを保持しない
+func (s *Service) run(ctx context.Context, guildID discordid.GuildID, id schedule.ID, now time.Time) (bool, error) {
+	loc, err := s.locations.Location(ctx, guildID)
+	if err != nil {
+		return false, err
+	}
+
+	var target *schedule.Schedule
+	err = s.txm.WithKeyLock(ctx, scheduleLockKey(id), func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.Schedule(tx)
+		sch, err := repo.FindByID(ctx, id)
+		if errors.Is(err, schedule.ErrScheduleNotFound) {
+			// 処理を始める前に削除された
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !sch.Due(now) {
+			// 他のインスタンスが処理済み
+			return nil
+		}
+
+		if sch.Advance(now.In(loc)) {
+			target = sch
+		} else {
+			log.Printf("[DEBUG] Skipped missed schedule run: id=%s guild=%s", sch.ID(), sch.GuildID())
+		}
+		return repo.Save(ctx, sch)
+	})
+	if err != nil || target == nil {
+		return false, err
+	}
+
+	if err := s.publisher.Publish(ctx, target); err != nil {
+		log.Printf("Error publishing schedule: id=%s guild=%s err=%v", target.ID(), target.GuildID(), err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// RescheduleGuild はギルドの予約投稿の次の実行時刻を現在のタイムゾーンで計算し直す
+// ギルドのタイムゾーンを変えたあとに呼び、古いタイムゾーンで計算した時刻に投稿しないようにする
+func (s *Service) RescheduleGuild(ctx context.Context, guildID discordid.GuildID) error {
+	loc, err := s.locations.Location(ctx, guildID)
+	if err != nil {
+		return err
+	}
+	schedules, err := s.Schedules(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	// 実行中の run と同じロックを取り、次の実行時刻を上書きし合わないようにする
+	for _, listed := range schedules {
+		err := s.txm.WithKeyLock(ctx, scheduleLockKey(listed.ID()), func(ctx context.Context, tx db.Tx) error {
+			repo := s.repositories.Schedule(tx)
+			sch, err := repo.FindByID(ctx, listed.ID())
+			if errors.Is(err, schedule.ErrScheduleNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			sch.Reschedule(s.now().In(loc))
+			return repo.Save(ctx, sch)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func guildLockKey(guildID discordid.GuildID) db.LockKey {
+	return db.LockKey("schedule:guild:" + string(guildID))
+}
+
+func scheduleLockKey(id schedule.ID) db.LockKey {
+	return db.LockKey("schedule:" + string(id))
+}
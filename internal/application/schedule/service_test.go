@@ -0,0 +1,269 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/schedule"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+// stubTxManager はトランザクションを使わずに fn を実行する
+type stubTxManager struct{}
+
+func (stubTxManager) WithTx(ctx context.Context, fn func(ctx context.Context, tx db.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+func (stubTxManager) WithKeyLock(ctx context.Context, key db.LockKey, fn func(ctx context.Context, tx db.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+// memoryRepository は予約投稿をメモリに保存する
+type memoryRepository map[schedule.ID]*schedule.Schedule
+
+func (r memoryRepository) Schedule(tx db.Tx) schedule.Repository {
+	return r
+}
+
+func (r memoryRepository) FindByID(ctx context.Context, id schedule.ID) (*schedule.Schedule, error) {
+	s, ok := r[id]
+	if !ok {
+		return nil, schedule.ErrScheduleNotFound
+	}
+	return s, nil
+}
+
+func (r memoryRepository) FindByGuild(ctx context.Context, guildID discordid.GuildID) ([]*schedule.Schedule, error) {
+	var schedules []*schedule.Schedule
+	for _, s := range r {
+		if s.GuildID() == guildID {
+			schedules = append(schedules, s)
+		}
+	}
+	return schedules, nil
+}
+
+func (r memoryRepository) FindDue(ctx context.Context, now time.Time) ([]*schedule.Schedule, error) {
+	var schedules []*schedule.Schedule
+	for _, s := range r {
+		if s.Due(now) {
+			schedules = append(schedules, s)
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].NextRunAt().Before(schedules[j].NextRunAt()) })
+	return schedules, nil
+}
+
+func (r memoryRepository) Save(ctx context.Context, s *schedule.Schedule) error {
+	r[s.ID()] = s
+	return nil
+}
+
+func (r memoryRepository) Delete(ctx context.Context, guildID discordid.GuildID, id schedule.ID) error {
+	if s, ok := r[id]; !ok || s.GuildID() != guildID {
+		return schedule.ErrScheduleNotFound
+	}
+	delete(r, id)
+	return nil
+}
+
+type stubLegends []string
+
+func (l stubLegends) Legends(ctx context.Context, guildID discordid.GuildID) ([]string, error) {
+	return l, nil
+}
+
+type stubLocations struct{}
+
+func (stubLocations) Location(ctx context.Context, guildID discordid.GuildID) (*time.Location, error) {
+	return jst, nil
+}
+
+// recordingPublisher は投稿した予約投稿の伝説を記録する
+type recordingPublisher struct {
+	published []string
+	err       error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, s *schedule.Schedule) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, s.Legend())
+	return nil
+}
+
+// clock はテストから進められる時計
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newTestService(now time.Time) (*Service, *recordingPublisher, *clock) {
+	publisher := &recordingPublisher{}
+	c := &clock{now: now}
+	service := NewScheduleService(memoryRepository{}, stubTxManager{}, stubLegends{"faker", "yamada"}, stubLocations{}, publisher)
+	service.now = c.Now
+	return service, publisher, c
+}
+
+func TestAddSchedule(t *testing.T) {
+	ctx := context.Background()
+	service, _, _ := newTestService(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC))
+
+	// ギルドのタイムゾーン（JST）の9時は、UTC の 8 時を過ぎているので翌日になる
+	created, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "yamada", Spec: "0 9 * * *", CatchUp: schedule.CatchUpSkip})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2026, 10, 20, 9, 0, 0, 0, jst); !created.NextRunAt().Equal(want) {
+		t.Errorf("NextRunAt() = %s, want %s", created.NextRunAt(), want)
+	}
+
+	if _, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "tanaka", Spec: "@daily", CatchUp: schedule.CatchUpSkip}); !errors.Is(err, schedule.ErrUnknownLegend) {
+		t.Errorf("expected ErrUnknownLegend, got %v", err)
+	}
+
+	for n := 1; n < schedule.MaxSchedulesPerGuild; n++ {
+		if _, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "faker", Spec: "@daily", CatchUp: schedule.CatchUpSkip}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "faker", Spec: "@daily", CatchUp: schedule.CatchUpSkip}); !errors.Is(err, schedule.ErrTooManySchedules) {
+		t.Errorf("expected ErrTooManySchedules, got %v", err)
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	ctx := context.Background()
+	service, publisher, c := newTestService(time.Date(2026, 10, 19, 8, 0, 0, 0, jst))
+
+	if _, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "yamada", Spec: "0 9 * * *", CatchUp: schedule.CatchUpSkip}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "faker", Spec: "0 9 * * *", CatchUp: schedule.CatchUpLatest}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	steps := []struct {
+		name string
+		now  time.Time
+		want []string // 投稿した伝説（名前順）
+	}{
+		{name: "before the first run", now: time.Date(2026, 10, 19, 8, 59, 0, 0, jst), want: nil},
+		{name: "on time", now: time.Date(2026, 10, 19, 9, 0, 30, 0, jst), want: []string{"faker", "yamada"}},
+		{name: "same run again", now: time.Date(2026, 10, 19, 9, 1, 30, 0, jst), want: nil},
+		// 翌日の 9 時をまたいで停止していた
+		{name: "after downtime", now: time.Date(2026, 10, 20, 13, 0, 0, 0, jst), want: []string{"faker"}},
+		{name: "next day on time", now: time.Date(2026, 10, 21, 9, 2, 0, 0, jst), want: []string{"faker", "yamada"}},
+	}
+	for _, step := range steps {
+		publisher.published = nil
+		c.now = step.now
+
+		posted, err := service.RunDue(ctx)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		sort.Strings(publisher.published)
+		if posted != len(step.want) || len(publisher.published) != len(step.want) {
+			t.Fatalf("%s: posted %d %v, want %v", step.name, posted, publisher.published, step.want)
+		}
+		for n := range step.want {
+			if publisher.published[n] != step.want[n] {
+				t.Errorf("%s: published %v, want %v", step.name, publisher.published, step.want)
+			}
+		}
+	}
+}
+
+func TestRunDueAdvancesOnPublishError(t *testing.T) {
+	ctx := context.Background()
+	service, publisher, c := newTestService(time.Date(2026, 10, 19, 8, 0, 0, 0, jst))
+	created, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "yamada", Spec: "0 9 * * *", CatchUp: schedule.CatchUpSkip})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	publisher.err = errors.New("missing access")
+	c.now = time.Date(2026, 10, 19, 9, 0, 0, 0, jst)
+	if posted, err := service.RunDue(ctx); err != nil || posted != 0 {
+		t.Fatalf("RunDue() = %d, %v", posted, err)
+	}
+
+	// 失敗した実行は繰り返さず、次の実行時刻に進む
+	schedules, _ := service.Schedules(ctx, "guild")
+	if want := time.Date(2026, 10, 20, 9, 0, 0, 0, jst); len(schedules) != 1 || schedules[0].ID() != created.ID() || !schedules[0].NextRunAt().Equal(want) {
+		t.Errorf("expected the schedule to advance to %s", want)
+	}
+}
+
+func TestRunDuePublishesAfterSaving(t *testing.T) {
+	ctx := context.Background()
+	service, publisher, c := newTestService(time.Date(2026, 10, 19, 8, 0, 0, 0, jst))
+	repo := memoryRepository{}
+	service.repositories = repo
+	created, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "yamada", Spec: "0 9 * * *", CatchUp: schedule.CatchUpSkip})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 投稿する時点で、次の実行時刻はすでに保存されている
+	want := time.Date(2026, 10, 20, 9, 0, 0, 0, jst)
+	service.publisher = publishFunc(func(ctx context.Context, s *schedule.Schedule) error {
+		if saved := repo[created.ID()]; !saved.NextRunAt().Equal(want) {
+			t.Errorf("NextRunAt() = %s while publishing, want %s", saved.NextRunAt(), want)
+		}
+		return publisher.Publish(ctx, s)
+	})
+	c.now = time.Date(2026, 10, 19, 9, 0, 0, 0, jst)
+	if posted, err := service.RunDue(ctx); err != nil || posted != 1 {
+		t.Fatalf("RunDue() = %d, %v", posted, err)
+	}
+}
+
+func TestRescheduleGuild(t *testing.T) {
+	ctx := context.Background()
+	service, _, c := newTestService(time.Date(2026, 10, 19, 8, 0, 0, 0, jst))
+	created, err := service.AddSchedule(ctx, AddScheduleCommand{GuildID: "guild", ChannelID: "channel", Legend: "yamada", Spec: "0 9 * * *", CatchUp: schedule.CatchUpSkip})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// JST の9時より前にギルドのタイムゾーンを UTC に変えると、次の実行は UTC の9時になる
+	service.locations = fixedLocation{time.UTC}
+	c.now = time.Date(2026, 10, 19, 8, 30, 0, 0, jst)
+	if err := service.RescheduleGuild(ctx, "guild"); err != nil {
+		t.Fatalf("RescheduleGuild: %v", err)
+	}
+	schedules, _ := service.Schedules(ctx, "guild")
+	if want := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC); len(schedules) != 1 || schedules[0].ID() != created.ID() || !schedules[0].NextRunAt().Equal(want) {
+		t.Errorf("expected the schedule to be rescheduled to %s", want)
+	}
+}
+
+// publishFunc は関数で投稿する Publisher
+type publishFunc func(ctx context.Context, s *schedule.Schedule) error
+
+func (f publishFunc) Publish(ctx context.Context, s *schedule.Schedule) error {
+	return f(ctx, s)
+}
+
+// fixedLocation は決まったタイムゾーンを返す LocationProvider
+type fixedLocation struct {
+	loc *time.Location
+}
+
+func (l fixedLocation) Location(ctx context.Context, guildID discordid.GuildID) (*time.Location, error) {
+	return l.loc, nil
+}
@@ -1,15 +1,100 @@
 package version
 
-import "context"
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/version"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/discord"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+)
+
+const (
+	// 複数のインスタンスが同時に起動しても告知が重複しないようにするロックキー
+	announceLockKey db.LockKey = "version_announcement"
+	// Discord のメッセージ本文の文字数上限
+	maxMessageLength = 2000
+)
 
 type Service struct {
-	version string
+	buildInfo    version.BuildInfo
+	changelog    string
+	repositories version.Repositories
+	txm          db.TxManager
+	discord      discord.DiscordPort
+}
+
+func NewVersionService(
+	buildInfo version.BuildInfo,
+	changelog string,
+	repositories version.Repositories,
+	txm db.TxManager,
+	discordPort discord.DiscordPort,
+) *Service {
+	return &Service{
+		buildInfo:    buildInfo,
+		changelog:    changelog,
+		repositories: repositories,
+		txm:          txm,
+		discord:      discordPort,
+	}
 }
 
-func NewVersionService(version string) *Service {
-	return &Service{version: version}
+// BuildInfo は実行中のバイナリのビルド情報を返す
+func (s *Service) BuildInfo() version.BuildInfo {
+	return s.buildInfo
 }
 
-func (s *Service) Version(ctx context.Context) (string, error) {
-	return s.version, nil
+// ReleaseNotes は実行中のバージョンの変更履歴を返す
+func (s *Service) ReleaseNotes() (string, bool) {
+	return version.ReleaseNotes(s.changelog, s.buildInfo.Version)
+}
+
+// AnnounceRelease は前回告知したバージョンから変わっていれば、指定したチャンネルに告知する
+// 告知した場合は true を返す
+func (s *Service) AnnounceRelease(ctx context.Context, channelID discordid.TextChannelID) (bool, error) {
+	current := s.buildInfo.Version
+	announced := false
+
+	err := s.txm.WithKeyLock(ctx, announceLockKey, func(ctx context.Context, tx db.Tx) error {
+		repo := s.repositories.AnnouncedVersion(tx)
+
+		previous, err := repo.FindAnnouncedVersion(ctx)
+		if err != nil && !errors.Is(err, version.ErrAnnouncedVersionNotFound) {
+			return err
+		}
+		if !version.ShouldAnnounce(current, previous) {
+			return nil
+		}
+
+		if err := s.discord.SendMessage(ctx, channelID, s.announcement()); err != nil {
+			return err
+		}
+		announced = true
+		log.Printf("[INFO] Announced version %s (previous: %q) to channel %s", current, previous, channelID)
+
+		return repo.SaveAnnouncedVersion(ctx, current)
+	})
+	if err != nil {
+		return announced, err
+	}
+	return announced, nil
+}
+
+// announcement は告知メッセージを組み立てる
+// 告知先のロケールは分からないため既定のロケールを使う
+func (s *Service) announcement() string {
+	message := i18n.T(i18n.Default, "msg.version.announcement", s.buildInfo.Version)
+	if notes, ok := s.ReleaseNotes(); ok {
+		message += "\n\n" + notes
+	}
+
+	runes := []rune(message)
+	if len(runes) > maxMessageLength {
+		return string(runes[:maxMessageLength-1]) + "…"
+	}
+	return message
 }
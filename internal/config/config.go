@@ -3,13 +3,37 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
 
+	"github.com/aktnb/discord-bot-go/internal/shared/logging"
 	"github.com/joho/godotenv"
 )
 
+// 配牌を生成するバックエンド（MAHJONG_BACKEND）
+const (
+	// MahjongBackendLocal は山を混ぜて配牌し、埋め込んだ牌の画像で描画する
+	MahjongBackendLocal = "local"
+	// MahjongBackendAPI は外部の配牌画像 API を使う
+	MahjongBackendAPI = "api"
+)
+
 type Config struct {
 	DiscordToken string
 	DatabaseURL  string
+	// OwnerIDs はボットの管理コマンドを実行できるユーザー ID の一覧
+	OwnerIDs []string
+	// LogLevel は起動時のログレベル。実行中は /admin loglevel set で変更できる
+	LogLevel logging.Level
+	// VersionAnnounceChannelID は起動時に新しいバージョンを告知するチャンネル ID。空なら告知しない
+	VersionAnnounceChannelID string
+	// CatAPIKey は The Cat API の API キー。空ならキーなしで利用する
+	CatAPIKey string
+	// DogAPIKey は Dog API に送る API キー。空ならキーなしで利用する
+	DogAPIKey string
+	// HTTPUserAgent は外部 API へのリクエストに付ける User-Agent。空ならボットのバージョンから決める
+	HTTPUserAgent string
+	// MahjongBackend は /mahjong の配牌を生成するバックエンド（MahjongBackendLocal か MahjongBackendAPI）
+	MahjongBackend string
 }
 
 // Load reads configuration from environment variables or a .env file
@@ -30,8 +54,46 @@ func Load() Config {
 		log.Fatal("DATABASE_URL environment variable is not set")
 	}
 
+	ownerIDs := splitList(os.Getenv("BOT_OWNER_IDS"))
+	if len(ownerIDs) == 0 {
+		log.Println("BOT_OWNER_IDS is not set, owner-only commands are disabled")
+	}
+
+	logLevel, err := logging.ParseLevel(os.Getenv("LOG_LEVEL"))
+	if err != nil {
+		log.Printf("Invalid LOG_LEVEL, falling back to %s: %v", logLevel, err)
+	}
+
+	mahjongBackend := strings.ToLower(strings.TrimSpace(os.Getenv("MAHJONG_BACKEND")))
+	switch mahjongBackend {
+	case MahjongBackendLocal, MahjongBackendAPI:
+	case "":
+		mahjongBackend = MahjongBackendLocal
+	default:
+		log.Printf("Invalid MAHJONG_BACKEND %q, falling back to %s", mahjongBackend, MahjongBackendLocal)
+		mahjongBackend = MahjongBackendLocal
+	}
+
 	return Config{
-		DiscordToken: token,
-		DatabaseURL:  dbURL,
+		DiscordToken:             token,
+		DatabaseURL:              dbURL,
+		OwnerIDs:                 ownerIDs,
+		LogLevel:                 logLevel,
+		VersionAnnounceChannelID: strings.TrimSpace(os.Getenv("VERSION_ANNOUNCE_CHANNEL_ID")),
+		CatAPIKey:                strings.TrimSpace(os.Getenv("CAT_API_KEY")),
+		DogAPIKey:                strings.TrimSpace(os.Getenv("DOG_API_KEY")),
+		HTTPUserAgent:            strings.TrimSpace(os.Getenv("HTTP_USER_AGENT")),
+		MahjongBackend:           mahjongBackend,
+	}
+}
+
+// splitList はカンマ区切りの環境変数を空要素を除いて分割する
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
@@ -0,0 +1,9 @@
+package audit
+
+import "errors"
+
+var (
+	ErrInvalidUserID  = errors.New("invalid User ID")
+	ErrInvalidAction  = errors.New("invalid action")
+	ErrInvalidOutcome = errors.New("invalid outcome")
+)
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/google/uuid"
+)
+
+type EntryID string
+
+// Outcome は管理操作の結果
+type Outcome string
+
+const (
+	// OutcomeSuccess は操作が成功したことを表す
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailed は操作を実行したが失敗したことを表す
+	OutcomeFailed Outcome = "failed"
+	// OutcomeDenied はオーナー以外が実行しようとして拒否されたことを表す
+	OutcomeDenied Outcome = "denied"
+)
+
+// Entry は管理コマンドの実行記録
+type Entry struct {
+	id        EntryID
+	userID    discordid.UserID
+	guildID   discordid.GuildID
+	action    string
+	arguments string
+	outcome   Outcome
+	detail    string
+	createdAt time.Time
+}
+
+func (e *Entry) ID() EntryID {
+	return e.id
+}
+
+func (e *Entry) UserID() discordid.UserID {
+	return e.userID
+}
+
+// GuildID は実行されたギルドを返す。DM から実行された場合は空
+func (e *Entry) GuildID() discordid.GuildID {
+	return e.guildID
+}
+
+// Action は "commands enable" のような実行されたサブコマンドのパス
+func (e *Entry) Action() string {
+	return e.action
+}
+
+// Arguments は "command=yamada guild=123" のような引数の文字列表現
+func (e *Entry) Arguments() string {
+	return e.arguments
+}
+
+func (e *Entry) Outcome() Outcome {
+	return e.outcome
+}
+
+// Detail は失敗理由などの補足情報
+func (e *Entry) Detail() string {
+	return e.detail
+}
+
+func (e *Entry) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+func NewEntry(
+	userID discordid.UserID,
+	guildID discordid.GuildID,
+	action string,
+	arguments string,
+	outcome Outcome,
+	detail string,
+) (*Entry, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+	if action == "" {
+		return nil, ErrInvalidAction
+	}
+	switch outcome {
+	case OutcomeSuccess, OutcomeFailed, OutcomeDenied:
+	default:
+		return nil, ErrInvalidOutcome
+	}
+
+	return &Entry{
+		id:        EntryID(uuid.New().String()),
+		userID:    userID,
+		guildID:   guildID,
+		action:    action,
+		arguments: arguments,
+		outcome:   outcome,
+		detail:    detail,
+		createdAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+)
+
+type Repository interface {
+	Save(ctx context.Context, entry *Entry) error
+}
+
+type Repositories interface {
+	AuditEntry(tx db.Tx) Repository
+}
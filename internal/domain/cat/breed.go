@@ -0,0 +1,33 @@
+package cat
+
+import "strings"
+
+// Breed は猫種
+type Breed struct {
+	// ID は API で猫種を指定するときの識別子
+	ID   string
+	Name string
+}
+
+// SearchBreeds は名前に query を含む猫種を最大 limit 件返す
+// 大文字と小文字は区別せず、名前が query で始まる猫種を先に返す
+func SearchBreeds(breeds []Breed, query string, limit int) []Breed {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var prefixed, contained []Breed
+	for _, breed := range breeds {
+		name := strings.ToLower(breed.Name)
+		switch {
+		case strings.HasPrefix(name, query):
+			prefixed = append(prefixed, breed)
+		case strings.Contains(name, query):
+			contained = append(contained, breed)
+		}
+	}
+
+	matches := append(prefixed, contained...)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
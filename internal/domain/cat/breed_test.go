@@ -0,0 +1,40 @@
+package cat
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSearchBreeds(t *testing.T) {
+	breeds := []Breed{
+		{ID: "abys", Name: "Abyssinian"},
+		{ID: "bsho", Name: "British Shorthair"},
+		{ID: "esho", Name: "Exotic Shorthair"},
+		{ID: "sphy", Name: "Sphynx"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "empty query", query: "", limit: 25, want: []string{"abys", "bsho", "esho", "sphy"}},
+		{name: "case insensitive", query: "ABYS", limit: 25, want: []string{"abys"}},
+		{name: "prefix first", query: "s", limit: 25, want: []string{"sphy", "abys", "bsho", "esho"}},
+		{name: "contains", query: "shorthair", limit: 25, want: []string{"bsho", "esho"}},
+		{name: "limit", query: "", limit: 2, want: []string{"abys", "bsho"}},
+		{name: "no match", query: "tiger", limit: 25, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, breed := range SearchBreeds(breeds, tt.query, tt.limit) {
+				got = append(got, breed.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SearchBreeds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,4 +6,6 @@ var (
 	ErrImageNotFound   = errors.New("cat image not found")
 	ErrAPIUnavailable  = errors.New("cat API is unavailable")
 	ErrInvalidResponse = errors.New("invalid API response")
+	ErrBreedNotFound   = errors.New("cat breed not found")
+	ErrInvalidCount    = errors.New("invalid number of cat images")
 )
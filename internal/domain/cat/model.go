@@ -1,5 +1,8 @@
 package cat
 
+// MaxImages は1回に表示できる猫画像の最大枚数（Discord のギャラリー表示の上限）
+const MaxImages = 4
+
 // CatImage は猫画像のドメインエンティティ
 type CatImage struct {
 	ID     string
@@ -4,5 +4,11 @@ import "context"
 
 // CatImageRepository は猫画像取得のポートインターフェース
 type CatImageRepository interface {
-	FetchRandomImage(ctx context.Context) (*CatImage, error)
+	// FetchRandomImages はランダムな猫画像を最大 count 枚返す。breedID が空なら猫種を問わない
+	FetchRandomImages(ctx context.Context, breedID string, count int) ([]*CatImage, error)
+}
+
+// BreedRepository は猫種の一覧取得のポートインターフェース
+type BreedRepository interface {
+	FetchBreeds(ctx context.Context) ([]Breed, error)
 }
@@ -0,0 +1,83 @@
+package collatz
+
+import (
+	"context"
+	"math/big"
+	"strings"
+)
+
+const (
+	// MaxStartDigits は受け付ける開始値の最大桁数
+	MaxStartDigits = 1000
+	// checkInterval はコンテキストのキャンセルを確認する間隔（ステップ数）
+	checkInterval = 1024
+)
+
+var (
+	bigOne   = big.NewInt(1)
+	bigThree = big.NewInt(3)
+)
+
+// ParseStart は10進数の文字列を開始値として読み込む
+// int64 を超える値も受け付けるが、MaxStartDigits 桁を超える値は ErrStartTooLarge を返す
+func ParseStart(s string) (*big.Int, error) {
+	s = strings.TrimSpace(strings.ReplaceAll(s, ",", ""))
+	if len(strings.TrimLeft(s, "+0")) > MaxStartDigits {
+		return nil, ErrStartTooLarge
+	}
+	start, ok := new(big.Int).SetString(s, 10)
+	if !ok || start.Sign() <= 0 {
+		return nil, ErrInvalidStart
+	}
+	return start, nil
+}
+
+// BigSequence は任意精度の整数によるコラッツ予想の計算過程
+type BigSequence struct {
+	Steps []*big.Int
+}
+
+// NewBigSequence は新しい BigSequence を生成する
+func NewBigSequence(start *big.Int) *BigSequence {
+	return &BigSequence{
+		Steps: []*big.Int{new(big.Int).Set(start)},
+	}
+}
+
+// Calculate は 1 に到達するまで計算する
+// maxSteps ステップを超えた場合は ErrBudgetExceeded を、キャンセルされた場合はコンテキストのエラーを返す
+func (s *BigSequence) Calculate(ctx context.Context, maxSteps int) error {
+	current := new(big.Int).Set(s.Steps[0])
+	if current.Sign() <= 0 {
+		return ErrInvalidStart
+	}
+
+	for current.Cmp(bigOne) != 0 {
+		if len(s.Steps) > maxSteps {
+			return ErrBudgetExceeded
+		}
+		if len(s.Steps)%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		next(current)
+		s.Steps = append(s.Steps, new(big.Int).Set(current))
+	}
+	return nil
+}
+
+// Length は計算ステップの長さを返す
+func (s *BigSequence) Length() int {
+	return len(s.Steps)
+}
+
+// next は n を次の値に置き換える
+func next(n *big.Int) {
+	if n.Bit(0) == 0 {
+		n.Rsh(n, 1)
+	} else {
+		n.Mul(n, bigThree).Add(n, bigOne)
+	}
+}
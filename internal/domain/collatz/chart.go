@@ -0,0 +1,65 @@
+package collatz
+
+import (
+	"math"
+	"math/big"
+)
+
+// Scale はグラフの縦軸の目盛り
+type Scale string
+
+const (
+	LinearScale Scale = "linear"
+	LogScale    Scale = "log"
+)
+
+// ParseScale は文字列を Scale に変換する。空文字列や不明な値は LinearScale として扱う
+func ParseScale(s string) Scale {
+	if Scale(s) == LogScale {
+		return LogScale
+	}
+	return LinearScale
+}
+
+// Chart は計算過程を描画するためのグラフのデータ
+type Chart struct {
+	// Values は各ステップの縦軸の値。LogScale の場合は常用対数（log10）
+	Values []float64
+	Scale  Scale
+}
+
+// ChartRenderer は計算過程のグラフ画像を描画するポートインターフェース
+type ChartRenderer interface {
+	// RenderPNG はグラフを PNG 画像として描画する
+	RenderPNG(chart Chart) ([]byte, error)
+}
+
+// NewChart は計算過程からグラフのデータを生成する
+// 線形スケールで float64 に収まらない値がある場合は、対数スケールに切り替える
+func NewChart(steps []*big.Int, scale Scale) Chart {
+	if scale == LinearScale {
+		values := make([]float64, len(steps))
+		for i, step := range steps {
+			f, _ := new(big.Float).SetInt(step).Float64()
+			if math.IsInf(f, 0) {
+				return NewChart(steps, LogScale)
+			}
+			values[i] = f
+		}
+		return Chart{Values: values, Scale: LinearScale}
+	}
+
+	values := make([]float64, len(steps))
+	for i, step := range steps {
+		values[i] = log10(step)
+	}
+	return Chart{Values: values, Scale: LogScale}
+}
+
+// log10 は float64 に収まらない値も含めて常用対数を求める
+func log10(n *big.Int) float64 {
+	mant := new(big.Float)
+	exp := new(big.Float).SetInt(n).MantExp(mant)
+	m, _ := mant.Float64()
+	return math.Log10(m) + float64(exp)*math.Log10(2)
+}
@@ -0,0 +1,50 @@
+package collatz
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNewChart(t *testing.T) {
+	steps := []*big.Int{big.NewInt(1), big.NewInt(10), big.NewInt(1000)}
+
+	linear := NewChart(steps, LinearScale)
+	if linear.Scale != LinearScale {
+		t.Fatalf("Scale = %s, want %s", linear.Scale, LinearScale)
+	}
+	for i, want := range []float64{1, 10, 1000} {
+		if linear.Values[i] != want {
+			t.Errorf("Values[%d] = %v, want %v", i, linear.Values[i], want)
+		}
+	}
+
+	log := NewChart(steps, LogScale)
+	for i, want := range []float64{0, 1, 3} {
+		if math.Abs(log.Values[i]-want) > 1e-9 {
+			t.Errorf("log Values[%d] = %v, want %v", i, log.Values[i], want)
+		}
+	}
+}
+
+func TestNewChartFallsBackToLogScale(t *testing.T) {
+	// float64 に収まらない値があれば対数スケールに切り替える
+	huge := new(big.Int).Exp(big.NewInt(10), big.NewInt(400), nil)
+	chart := NewChart([]*big.Int{huge, big.NewInt(1)}, LinearScale)
+
+	if chart.Scale != LogScale {
+		t.Fatalf("Scale = %s, want %s", chart.Scale, LogScale)
+	}
+	if math.Abs(chart.Values[0]-400) > 1e-9 {
+		t.Errorf("Values[0] = %v, want 400", chart.Values[0])
+	}
+}
+
+func TestParseScale(t *testing.T) {
+	tests := map[string]Scale{"log": LogScale, "linear": LinearScale, "": LinearScale, "unknown": LinearScale}
+	for input, want := range tests {
+		if got := ParseScale(input); got != want {
+			t.Errorf("ParseScale(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package collatz
+
+import "errors"
+
+var (
+	ErrInvalidStart   = errors.New("start value must be a positive integer")
+	ErrStartTooLarge  = errors.New("start value is too large")
+	ErrOverflow       = errors.New("value overflows int64")
+	ErrBudgetExceeded = errors.New("computation budget exceeded")
+	ErrInvalidRange   = errors.New("invalid range")
+	ErrRangeTooWide   = errors.New("range is too wide")
+)
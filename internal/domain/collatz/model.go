@@ -1,5 +1,10 @@
 package collatz
 
+import "math"
+
+// maxOddInt64 は 3n+1 が int64 に収まる奇数の上限
+const maxOddInt64 = (math.MaxInt64 - 1) / 3
+
 // Step はコラッツ予想の1ステップを表す
 type Step struct {
 	Value int64
@@ -22,22 +27,28 @@ func NewSequence(start int64) *Sequence {
 // 奇数の場合: 3n + 1
 // 1に到達するまで繰り返す
 // 開始値が1以下の場合は何もしない（不正な状態を防ぐ）
-func (s *Sequence) Calculate() {
+// 途中の値が int64 に収まらない場合は、そこまでの計算過程を残して ErrOverflow を返す
+// （その場合は BigSequence で計算し直す）
+func (s *Sequence) Calculate() error {
 	current := s.Steps[0].Value
 
 	// 不正な値の場合は計算しない
 	if current <= 0 {
-		return
+		return nil
 	}
 
 	for current != 1 {
 		if current%2 == 0 {
 			current = current / 2
 		} else {
+			if current > maxOddInt64 {
+				return ErrOverflow
+			}
 			current = current*3 + 1
 		}
 		s.Steps = append(s.Steps, Step{Value: current})
 	}
+	return nil
 }
 
 // Length は計算ステップの長さを返す
@@ -85,3 +85,16 @@ func TestCollatzRules(t *testing.T) {
 		t.Errorf("expected second step to be 10 (3*3+1), got %d", sequence.Steps[1].Value)
 	}
 }
+
+func TestCollatzSequenceOverflow(t *testing.T) {
+	// 3n+1 が int64 に収まらない場合は ErrOverflow を返す
+	sequence := NewSequence(maxOddInt64 + 1)
+	if err := sequence.Calculate(); err != ErrOverflow {
+		t.Fatalf("Calculate() error = %v, want %v", err, ErrOverflow)
+	}
+
+	sequence = NewSequence(27)
+	if err := sequence.Calculate(); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package collatz
+
+import (
+	"context"
+	"math/big"
+)
+
+// RangeLimits は範囲探索の計算量の上限
+type RangeLimits struct {
+	// MaxWidth は一度に探索できる範囲の幅
+	MaxWidth int64
+	// MaxSteps はすべての開始値を合わせた計算ステップ数の上限
+	MaxSteps int64
+}
+
+// RangeResult は範囲内で最も長い計算過程の探索結果
+type RangeResult struct {
+	From int64
+	To   int64
+	// Best は 1 に到達するまでのステップ数が最も多い開始値（同じなら小さい方）
+	Best      int64
+	BestSteps int
+	// Checked は探索を終えた開始値の数、TotalSteps は計算したステップ数の合計
+	Checked    int64
+	TotalSteps int64
+	// Complete は範囲全体を探索し終えたかどうか。計算量の上限に達した場合は false
+	Complete bool
+}
+
+// LongestInRange は from 以上 to 以下の開始値から、1 に到達するまでのステップ数が最も多いものを探す
+// 計算量の上限に達した場合は、そこまでの結果を Complete = false で返す
+// キャンセルされた場合は、そこまでの結果とコンテキストのエラーを返す
+func LongestInRange(ctx context.Context, from, to int64, limits RangeLimits) (RangeResult, error) {
+	if from <= 0 || to < from {
+		return RangeResult{}, ErrInvalidRange
+	}
+	if to-from >= limits.MaxWidth {
+		return RangeResult{}, ErrRangeTooWide
+	}
+
+	result := RangeResult{From: from, To: to, Best: from, BestSteps: -1}
+	for n := from; n <= to; n++ {
+		if result.Checked%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+		}
+
+		remaining := limits.MaxSteps - result.TotalSteps
+		steps, ok := totalStoppingTime(uint64(n), remaining)
+		if !ok {
+			return result, nil
+		}
+
+		result.Checked++
+		result.TotalSteps += int64(steps)
+		if steps > result.BestSteps {
+			result.Best, result.BestSteps = n, steps
+		}
+	}
+
+	result.Complete = true
+	return result, nil
+}
+
+// totalStoppingTime は 1 に到達するまでのステップ数を返す
+// budget ステップ以内に到達しない場合は false を返す
+func totalStoppingTime(n uint64, budget int64) (int, bool) {
+	steps := 0
+	for n != 1 {
+		if int64(steps) >= budget {
+			return steps, false
+		}
+		if n%2 == 1 && n > maxOddUint64 {
+			return bigTotalStoppingTime(new(big.Int).SetUint64(n), steps, budget)
+		}
+		if n%2 == 0 {
+			n /= 2
+		} else {
+			n = n*3 + 1
+		}
+		steps++
+	}
+	return steps, true
+}
+
+func bigTotalStoppingTime(n *big.Int, steps int, budget int64) (int, bool) {
+	for n.Cmp(bigOne) != 0 {
+		if int64(steps) >= budget {
+			return steps, false
+		}
+		next(n)
+		steps++
+	}
+	return steps, true
+}
@@ -0,0 +1,90 @@
+package collatz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var testLimits = RangeLimits{MaxWidth: 1_000_000, MaxSteps: 100_000_000}
+
+func TestLongestInRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		from, to  int64
+		best      int64
+		bestSteps int
+	}{
+		{name: "single", from: 27, to: 27, best: 27, bestSteps: 111},
+		{name: "1..10", from: 1, to: 10, best: 9, bestSteps: 19},
+		{name: "1..100", from: 1, to: 100, best: 97, bestSteps: 118},
+		{name: "1..10000", from: 1, to: 10000, best: 6171, bestSteps: 261},
+		{name: "large", from: 9007199254740000, to: 9007199254740991, bestSteps: 0}, // 2^53 付近でも完走すること,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LongestInRange(context.Background(), tt.from, tt.to, testLimits)
+			if err != nil {
+				t.Fatalf("LongestInRange() error = %v", err)
+			}
+			if !result.Complete {
+				t.Fatal("LongestInRange() did not complete")
+			}
+			if result.Checked != tt.to-tt.from+1 {
+				t.Errorf("Checked = %d, want %d", result.Checked, tt.to-tt.from+1)
+			}
+			if tt.bestSteps == 0 {
+				return
+			}
+			if result.Best != tt.best || result.BestSteps != tt.bestSteps {
+				t.Errorf("Best = %d (%d steps), want %d (%d steps)", result.Best, result.BestSteps, tt.best, tt.bestSteps)
+			}
+		})
+	}
+}
+
+func TestLongestInRangeLimits(t *testing.T) {
+	t.Run("invalid range", func(t *testing.T) {
+		for _, r := range [][2]int64{{0, 10}, {-5, 10}, {10, 9}} {
+			if _, err := LongestInRange(context.Background(), r[0], r[1], testLimits); !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("LongestInRange(%d, %d) error = %v, want %v", r[0], r[1], err, ErrInvalidRange)
+			}
+		}
+	})
+
+	t.Run("too wide", func(t *testing.T) {
+		limits := RangeLimits{MaxWidth: 100, MaxSteps: testLimits.MaxSteps}
+		if _, err := LongestInRange(context.Background(), 1, 100, limits); err != nil {
+			t.Errorf("LongestInRange(1, 100) error = %v", err)
+		}
+		if _, err := LongestInRange(context.Background(), 1, 101, limits); !errors.Is(err, ErrRangeTooWide) {
+			t.Errorf("LongestInRange(1, 101) error = %v, want %v", err, ErrRangeTooWide)
+		}
+	})
+
+	t.Run("step budget", func(t *testing.T) {
+		limits := RangeLimits{MaxWidth: testLimits.MaxWidth, MaxSteps: 100}
+		result, err := LongestInRange(context.Background(), 1, 100, limits)
+		if err != nil {
+			t.Fatalf("LongestInRange() error = %v", err)
+		}
+		if result.Complete {
+			t.Error("LongestInRange() should not complete within the budget")
+		}
+		if result.TotalSteps > limits.MaxSteps {
+			t.Errorf("TotalSteps = %d, exceeds budget %d", result.TotalSteps, limits.MaxSteps)
+		}
+		if result.Checked == 0 || result.Checked >= 100 {
+			t.Errorf("Checked = %d, want partial progress", result.Checked)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := LongestInRange(ctx, 1, 10000, testLimits); !errors.Is(err, context.Canceled) {
+			t.Errorf("LongestInRange() error = %v, want %v", err, context.Canceled)
+		}
+	})
+}
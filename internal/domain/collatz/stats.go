@@ -0,0 +1,115 @@
+package collatz
+
+import (
+	"context"
+	"math"
+	"math/big"
+)
+
+// maxOddUint64 は 3n+1 が uint64 に収まる奇数の上限
+const maxOddUint64 = (math.MaxUint64 - 1) / 3
+
+// Stats は計算過程を保持せずに集計したコラッツ予想の統計
+type Stats struct {
+	Start *big.Int
+	// TotalStoppingTime は 1 に到達するまでのステップ数
+	TotalStoppingTime int
+	// StoppingTime は初めて開始値を下回るまでのステップ数（開始値が 1 の場合は 0）
+	StoppingTime int
+	// MaxValue は計算過程の最大値、MaxStep はそれに到達したステップ
+	MaxValue *big.Int
+	MaxStep  int
+	// EvenSteps は n/2、OddSteps は 3n+1 を適用した回数
+	EvenSteps int
+	OddSteps  int
+}
+
+// Analyze は開始値から 1 に到達するまでの統計を求める
+// 値が uint64 に収まる間は高速に計算し、超えた時点で任意精度の計算に切り替える
+// maxSteps ステップを超えた場合は ErrBudgetExceeded を、キャンセルされた場合はコンテキストのエラーを返す
+func Analyze(ctx context.Context, start *big.Int, maxSteps int) (Stats, error) {
+	if start == nil || start.Sign() <= 0 {
+		return Stats{}, ErrInvalidStart
+	}
+
+	stats := Stats{
+		Start:    new(big.Int).Set(start),
+		MaxValue: new(big.Int).Set(start),
+	}
+	stopped := start.Cmp(bigOne) == 0
+
+	if start.IsUint64() {
+		startValue := start.Uint64()
+		current, maxValue := startValue, startValue
+		for current != 1 {
+			if err := stats.checkBudget(ctx, maxSteps); err != nil {
+				return stats, err
+			}
+			if current%2 == 1 && current > maxOddUint64 {
+				// 以降は任意精度で続ける
+				stats.MaxValue.SetUint64(maxValue)
+				return stats, stats.analyzeBig(ctx, new(big.Int).SetUint64(current), stopped, maxSteps)
+			}
+
+			current = stats.stepUint64(current)
+			if current > maxValue {
+				maxValue = current
+				stats.MaxStep = stats.TotalStoppingTime
+			}
+			if !stopped && current < startValue {
+				stopped = true
+				stats.StoppingTime = stats.TotalStoppingTime
+			}
+		}
+		stats.MaxValue.SetUint64(maxValue)
+		return stats, nil
+	}
+
+	return stats, stats.analyzeBig(ctx, new(big.Int).Set(start), stopped, maxSteps)
+}
+
+func (s *Stats) analyzeBig(ctx context.Context, current *big.Int, stopped bool, maxSteps int) error {
+	for current.Cmp(bigOne) != 0 {
+		if err := s.checkBudget(ctx, maxSteps); err != nil {
+			return err
+		}
+
+		if current.Bit(0) == 0 {
+			s.EvenSteps++
+		} else {
+			s.OddSteps++
+		}
+		next(current)
+		s.TotalStoppingTime++
+
+		if current.Cmp(s.MaxValue) > 0 {
+			s.MaxValue.Set(current)
+			s.MaxStep = s.TotalStoppingTime
+		}
+		if !stopped && current.Cmp(s.Start) < 0 {
+			stopped = true
+			s.StoppingTime = s.TotalStoppingTime
+		}
+	}
+	return nil
+}
+
+func (s *Stats) stepUint64(current uint64) uint64 {
+	s.TotalStoppingTime++
+	if current%2 == 0 {
+		s.EvenSteps++
+		return current / 2
+	}
+	s.OddSteps++
+	return current*3 + 1
+}
+
+func (s *Stats) checkBudget(ctx context.Context, maxSteps int) error {
+	if s.TotalStoppingTime >= maxSteps {
+		return ErrBudgetExceeded
+	}
+	if s.TotalStoppingTime%checkInterval == 0 {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package collatz
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name              string
+		start             string
+		totalStoppingTime int
+		stoppingTime      int
+		maxValue          string
+		maxStep           int
+		evenSteps         int
+		oddSteps          int
+	}{
+		{name: "1", start: "1", totalStoppingTime: 0, stoppingTime: 0, maxValue: "1", maxStep: 0},
+		{name: "2", start: "2", totalStoppingTime: 1, stoppingTime: 1, maxValue: "2", maxStep: 0, evenSteps: 1},
+		{name: "3", start: "3", totalStoppingTime: 7, stoppingTime: 6, maxValue: "16", maxStep: 3, evenSteps: 5, oddSteps: 2},
+		{name: "27", start: "27", totalStoppingTime: 111, stoppingTime: 96, maxValue: "9232", maxStep: 77, evenSteps: 70, oddSteps: 41},
+		{name: "2^70", start: "1180591620717411303424", totalStoppingTime: 70, stoppingTime: 1, maxValue: "1180591620717411303424", maxStep: 0, evenSteps: 70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, err := ParseStart(tt.start)
+			if err != nil {
+				t.Fatalf("ParseStart() error = %v", err)
+			}
+
+			stats, err := Analyze(context.Background(), start, 1_000_000)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			if stats.TotalStoppingTime != tt.totalStoppingTime {
+				t.Errorf("TotalStoppingTime = %d, want %d", stats.TotalStoppingTime, tt.totalStoppingTime)
+			}
+			if stats.StoppingTime != tt.stoppingTime {
+				t.Errorf("StoppingTime = %d, want %d", stats.StoppingTime, tt.stoppingTime)
+			}
+			if stats.MaxValue.String() != tt.maxValue {
+				t.Errorf("MaxValue = %s, want %s", stats.MaxValue, tt.maxValue)
+			}
+			if stats.MaxStep != tt.maxStep {
+				t.Errorf("MaxStep = %d, want %d", stats.MaxStep, tt.maxStep)
+			}
+			if stats.EvenSteps != tt.evenSteps || stats.OddSteps != tt.oddSteps {
+				t.Errorf("EvenSteps/OddSteps = %d/%d, want %d/%d", stats.EvenSteps, stats.OddSteps, tt.evenSteps, tt.oddSteps)
+			}
+		})
+	}
+}
+
+func TestAnalyzeMatchesBigSequence(t *testing.T) {
+	// uint64 を超える値を経由する開始値でも、任意精度の計算過程と一致すること
+	start, _ := ParseStart("18446744073709551615")
+
+	sequence := NewBigSequence(start)
+	if err := sequence.Calculate(context.Background(), 1_000_000); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	stats, err := Analyze(context.Background(), start, 1_000_000)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if stats.TotalStoppingTime != sequence.Length()-1 {
+		t.Errorf("TotalStoppingTime = %d, want %d", stats.TotalStoppingTime, sequence.Length()-1)
+	}
+	maxValue, maxStep := new(big.Int), 0
+	for i, v := range sequence.Steps {
+		if v.Cmp(maxValue) > 0 {
+			maxValue, maxStep = v, i
+		}
+	}
+	if stats.MaxValue.Cmp(maxValue) != 0 || stats.MaxStep != maxStep {
+		t.Errorf("Max = %s at %d, want %s at %d", stats.MaxValue, stats.MaxStep, maxValue, maxStep)
+	}
+	if stats.EvenSteps+stats.OddSteps != stats.TotalStoppingTime {
+		t.Errorf("EvenSteps + OddSteps = %d, want %d", stats.EvenSteps+stats.OddSteps, stats.TotalStoppingTime)
+	}
+}
+
+func TestAnalyzeLimits(t *testing.T) {
+	start := big.NewInt(27)
+
+	if _, err := Analyze(context.Background(), start, 50); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Analyze() with small budget error = %v, want %v", err, ErrBudgetExceeded)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Analyze(ctx, start, 1_000_000); !errors.Is(err, context.Canceled) {
+		t.Errorf("Analyze() with canceled context error = %v, want %v", err, context.Canceled)
+	}
+
+	if _, err := Analyze(context.Background(), big.NewInt(0), 1_000_000); !errors.Is(err, ErrInvalidStart) {
+		t.Errorf("Analyze(0) error = %v, want %v", err, ErrInvalidStart)
+	}
+}
+
+func TestParseStart(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "27", want: "27"},
+		{input: " 1,000 ", want: "1000"},
+		{input: "99999999999999999999999999", want: "99999999999999999999999999"},
+		{input: "0", wantErr: ErrInvalidStart},
+		{input: "-5", wantErr: ErrInvalidStart},
+		{input: "abc", wantErr: ErrInvalidStart},
+		{input: "", wantErr: ErrInvalidStart},
+		{input: "1" + strings.Repeat("0", MaxStartDigits), wantErr: ErrStartTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseStart(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseStart() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ParseStart() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package dog
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSearchBreeds(t *testing.T) {
+	breeds := []Breed{
+		{ID: "hound/afghan", Name: "Afghan Hound"},
+		{ID: "hound/basset", Name: "Basset Hound"},
+		{ID: "shiba", Name: "Shiba"},
+	}
+
+	var got []string
+	for _, breed := range SearchBreeds(breeds, "hound", 25) {
+		got = append(got, breed.ID)
+	}
+	if want := []string{"hound/afghan", "hound/basset"}; !slices.Equal(got, want) {
+		t.Errorf("SearchBreeds() = %v, want %v", got, want)
+	}
+
+	got = nil
+	for _, breed := range SearchBreeds(breeds, "sh", 25) {
+		got = append(got, breed.ID)
+	}
+	if want := []string{"shiba"}; !slices.Equal(got, want) {
+		t.Errorf("SearchBreeds() = %v, want %v", got, want)
+	}
+}
@@ -6,4 +6,6 @@ var (
 	ErrImageNotFound   = errors.New("dog image not found")
 	ErrAPIUnavailable  = errors.New("dog API is unavailable")
 	ErrInvalidResponse = errors.New("invalid API response")
+	ErrBreedNotFound   = errors.New("dog breed not found")
+	ErrInvalidCount    = errors.New("invalid number of dog images")
 )
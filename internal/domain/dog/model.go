@@ -1,5 +1,8 @@
 package dog
 
+// MaxImages は1回に表示できる犬画像の最大枚数（Discord のギャラリー表示の上限）
+const MaxImages = 4
+
 // DogImage は犬画像のドメインエンティティ
 type DogImage struct {
 	URL string
@@ -4,5 +4,11 @@ import "context"
 
 // DogImageRepository は犬画像取得のポートインターフェース
 type DogImageRepository interface {
-	FetchRandomImage(ctx context.Context) (*DogImage, error)
+	// FetchRandomImages はランダムな犬画像を最大 count 枚返す。breedID が空なら犬種を問わない
+	FetchRandomImages(ctx context.Context, breedID string, count int) ([]*DogImage, error)
+}
+
+// BreedRepository は犬種の一覧取得のポートインターフェース
+type BreedRepository interface {
+	FetchBreeds(ctx context.Context) ([]Breed, error)
 }
@@ -0,0 +1,145 @@
+// Package grammar は記号の置き換え規則からなる文法で、シードから決定的に文章を生成する
+package grammar
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxDepth は記号を展開する入れ子の深さの上限
+// 自分自身を含む規則で展開が終わらなくならないようにする
+const MaxDepth = 16
+
+// seedStream は乱数生成器の2つ目のシード
+// 同じシードでも他の用途の乱数と同じ列にならないよう固定の値を混ぜる
+const seedStream = 0x9e3779b97f4a7c15
+
+var (
+	ErrInvalidGrammar = errors.New("invalid grammar")
+	ErrTooDeep        = errors.New("grammar expansion is too deep")
+)
+
+// symbolPattern は規則の候補の中の記号の参照（{subject} など）
+var symbolPattern = regexp.MustCompile(`\{([a-z][a-z0-9_]*)\}`)
+
+// Grammar は開始記号と、記号ごとの置き換え候補からなる文法
+// 候補の中の {記号名} は、その記号の候補のいずれかで再帰的に置き換える
+type Grammar struct {
+	start string
+	rules map[string][]string
+}
+
+// grammarFile は文法のデータファイルの形式
+type grammarFile struct {
+	Start string              `json:"start"`
+	Rules map[string][]string `json:"rules"`
+}
+
+// Parse は JSON 形式の文法を読み込む
+func Parse(data []byte) (*Grammar, error) {
+	var file grammarFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGrammar, err)
+	}
+	return New(file.Start, file.Rules)
+}
+
+// New は文法を生成する
+// すべての記号に候補が1つ以上あり、参照している記号がすべて定義されている必要がある
+func New(start string, rules map[string][]string) (*Grammar, error) {
+	if _, ok := rules[start]; !ok {
+		return nil, fmt.Errorf("%w: start symbol %q is not defined", ErrInvalidGrammar, start)
+	}
+	for _, symbol := range sortedSymbols(rules) {
+		alternatives := rules[symbol]
+		if len(alternatives) == 0 {
+			return nil, fmt.Errorf("%w: %s has no alternatives", ErrInvalidGrammar, symbol)
+		}
+		for n, alternative := range alternatives {
+			if strings.TrimSpace(alternative) == "" {
+				return nil, fmt.Errorf("%w: %s[%d] is empty", ErrInvalidGrammar, symbol, n)
+			}
+			for _, match := range symbolPattern.FindAllStringSubmatch(alternative, -1) {
+				if _, ok := rules[match[1]]; !ok {
+					return nil, fmt.Errorf("%w: %s[%d] refers to undefined symbol %q", ErrInvalidGrammar, symbol, n, match[1])
+				}
+			}
+		}
+	}
+	return &Grammar{start: start, rules: rules}, nil
+}
+
+// Has は記号が定義されているかを返す
+func (g *Grammar) Has(symbol string) bool {
+	_, ok := g.rules[symbol]
+	return ok
+}
+
+// Generate は開始記号を展開した文章を返す。同じシードと bindings なら常に同じ文章になる
+// bindings に指定した記号は、候補から選んだ結果の代わりに指定した文字列に置き換える
+func (g *Grammar) Generate(seed uint64, bindings map[string]string) (string, error) {
+	e := expander{
+		grammar:  g,
+		rng:      rand.New(rand.NewPCG(seed, seedStream)),
+		bindings: bindings,
+	}
+	return e.expand(g.start, 0)
+}
+
+type expander struct {
+	grammar  *Grammar
+	rng      *rand.Rand
+	bindings map[string]string
+}
+
+func (e *expander) expand(symbol string, depth int) (string, error) {
+	text, err := e.choose(symbol, depth)
+	if err != nil {
+		return "", err
+	}
+	// 指定された記号も候補から選んだうえで置き換え、以降の選択が bindings によって変わらないようにする
+	if value, ok := e.bindings[symbol]; ok {
+		return value, nil
+	}
+	return text, nil
+}
+
+func (e *expander) choose(symbol string, depth int) (string, error) {
+	if depth >= MaxDepth {
+		return "", ErrTooDeep
+	}
+
+	alternatives := e.grammar.rules[symbol]
+	template := alternatives[e.rng.IntN(len(alternatives))]
+
+	var (
+		b    strings.Builder
+		last int
+	)
+	for _, loc := range symbolPattern.FindAllStringSubmatchIndex(template, -1) {
+		b.WriteString(template[last:loc[0]])
+		text, err := e.expand(template[loc[2]:loc[3]], depth+1)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(text)
+		last = loc[1]
+	}
+	b.WriteString(template[last:])
+	return b.String(), nil
+}
+
+// sortedSymbols はエラーメッセージが毎回同じになるよう記号を名前順に返す
+func sortedSymbols(rules map[string][]string) []string {
+	symbols := make([]string, 0, len(rules))
+	for symbol := range rules {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
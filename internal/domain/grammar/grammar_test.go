@@ -0,0 +1,90 @@
+package grammar
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid", data: `{"start":"s","rules":{"s":["{a}と{b}"],"a":["x"],"b":["y","{a}"]}}`},
+		{name: "broken json", data: `{"start":`, wantErr: true},
+		{name: "undefined start", data: `{"start":"s","rules":{"a":["x"]}}`, wantErr: true},
+		{name: "undefined symbol", data: `{"start":"s","rules":{"s":["{a}"]}}`, wantErr: true},
+		{name: "no alternatives", data: `{"start":"s","rules":{"s":[]}}`, wantErr: true},
+		{name: "empty alternative", data: `{"start":"s","rules":{"s":[" "]}}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidGrammar) {
+				t.Errorf("expected ErrInvalidGrammar, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g, err := New("headline", map[string][]string{
+		"headline": {"{subject}、{action}「{quote}」"},
+		"subject":  {"山田"},
+		"action":   {"空を飛ぶ", "月に住む", "{thing}を発見"},
+		"thing":    {"新元素", "新しい季節"},
+		"quote":    {"企業秘密", "思ったより寒かった"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, err := g.Generate(42, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(first, "山田、") {
+		t.Errorf("expected default subject, got %q", first)
+	}
+
+	// 同じシードなら同じ文章になる
+	for range 10 {
+		if again, _ := g.Generate(42, nil); again != first {
+			t.Fatalf("Generate() is not deterministic: %q != %q", again, first)
+		}
+	}
+
+	// 主語を差し替えても、残りの部分はシードで決まる
+	replaced, err := g.Generate(42, map[string]string{"subject": "佐藤"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replaced != "佐藤"+strings.TrimPrefix(first, "山田") {
+		t.Errorf("expected %q with another subject, got %q", first, replaced)
+	}
+
+	// シードを変えれば別の文章も生成される
+	seen := map[string]bool{}
+	for seed := range uint64(50) {
+		text, _ := g.Generate(seed, nil)
+		seen[text] = true
+	}
+	if len(seen) < 5 {
+		t.Errorf("expected various headlines, got %d kinds", len(seen))
+	}
+}
+
+func TestGenerateTooDeep(t *testing.T) {
+	g, err := New("s", map[string][]string{"s": {"ま{s}"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := g.Generate(1, nil); !errors.Is(err, ErrTooDeep) {
+		t.Errorf("expected ErrTooDeep, got %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+package guildcommand
+
+import "errors"
+
+var (
+	ErrSettingNotFound    = errors.New("guild command setting not found")
+	ErrInvalidGuildID     = errors.New("invalid Guild ID")
+	ErrInvalidCommandName = errors.New("invalid command name")
+)
@@ -0,0 +1,108 @@
+package guildcommand
+
+import (
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// Setting はギルド固有コマンドをギルド単位で有効化・無効化する設定
+type Setting struct {
+	guildID     discordid.GuildID
+	commandName string
+	enabled     bool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (s *Setting) GuildID() discordid.GuildID {
+	return s.guildID
+}
+
+func (s *Setting) CommandName() string {
+	return s.commandName
+}
+
+func (s *Setting) Enabled() bool {
+	return s.enabled
+}
+
+func (s *Setting) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Setting) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// ChangeEnabled は有効・無効を切り替える
+func (s *Setting) ChangeEnabled(enabled bool) {
+	s.enabled = enabled
+	s.updatedAt = time.Now()
+}
+
+func NewSetting(guildID discordid.GuildID, commandName string, enabled bool) (*Setting, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if commandName == "" {
+		return nil, ErrInvalidCommandName
+	}
+	now := time.Now()
+	return &Setting{
+		guildID:     guildID,
+		commandName: commandName,
+		enabled:     enabled,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+func RebuildSetting(
+	guildID discordid.GuildID,
+	commandName string,
+	enabled bool,
+	createdAt, updatedAt time.Time,
+) (*Setting, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if commandName == "" {
+		return nil, ErrInvalidCommandName
+	}
+	return &Setting{
+		guildID:     guildID,
+		commandName: commandName,
+		enabled:     enabled,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}, nil
+}
+
+// ResolveGuildIDs はデフォルトの登録先ギルドに設定を適用し、
+// コマンドを登録すべきギルド ID の一覧を返す
+// 有効化設定はギルドを追加し、無効化設定はデフォルトのギルドであっても除外する
+func ResolveGuildIDs(defaults []discordid.GuildID, settings []*Setting) []discordid.GuildID {
+	enabled := make(map[discordid.GuildID]bool)
+	var order []discordid.GuildID
+	for _, guildID := range defaults {
+		if _, ok := enabled[guildID]; !ok {
+			order = append(order, guildID)
+		}
+		enabled[guildID] = true
+	}
+	for _, setting := range settings {
+		if _, ok := enabled[setting.guildID]; !ok {
+			order = append(order, setting.guildID)
+		}
+		enabled[setting.guildID] = setting.enabled
+	}
+
+	var result []discordid.GuildID
+	for _, guildID := range order {
+		if enabled[guildID] {
+			result = append(result, guildID)
+		}
+	}
+	return result
+}
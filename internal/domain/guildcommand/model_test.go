@@ -0,0 +1,66 @@
+package guildcommand
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+func TestResolveGuildIDs(t *testing.T) {
+	setting := func(guildID string, enabled bool) *Setting {
+		s, err := NewSetting(discordid.GuildID(guildID), "yamada", enabled)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		defaults []discordid.GuildID
+		settings []*Setting
+		expected []discordid.GuildID
+	}{
+		{
+			name:     "defaults only",
+			defaults: []discordid.GuildID{"a", "b"},
+			expected: []discordid.GuildID{"a", "b"},
+		},
+		{
+			name:     "enable adds guild",
+			defaults: []discordid.GuildID{"a"},
+			settings: []*Setting{setting("b", true)},
+			expected: []discordid.GuildID{"a", "b"},
+		},
+		{
+			name:     "disable removes default guild",
+			defaults: []discordid.GuildID{"a", "b"},
+			settings: []*Setting{setting("a", false)},
+			expected: []discordid.GuildID{"b"},
+		},
+		{
+			name:     "no defaults and disabled setting",
+			settings: []*Setting{setting("a", false), setting("b", true)},
+			expected: []discordid.GuildID{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveGuildIDs(tt.defaults, tt.settings)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewSettingValidation(t *testing.T) {
+	if _, err := NewSetting("", "yamada", true); err != ErrInvalidGuildID {
+		t.Errorf("expected ErrInvalidGuildID, got %v", err)
+	}
+	if _, err := NewSetting("123", "", true); err != ErrInvalidCommandName {
+		t.Errorf("expected ErrInvalidCommandName, got %v", err)
+	}
+}
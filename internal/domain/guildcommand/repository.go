@@ -0,0 +1,27 @@
+package guildcommand
+
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+type Repository interface {
+	Find(ctx context.Context, guildID discordid.GuildID, commandName string) (*Setting, error)
+	FindByCommand(ctx context.Context, commandName string) ([]*Setting, error)
+	FindAll(ctx context.Context) ([]*Setting, error)
+	Save(ctx context.Context, setting *Setting) error
+}
+
+// RegistrationRepository はギルド固有コマンドを登録済みのギルドを記録する
+type RegistrationRepository interface {
+	FindAll(ctx context.Context) ([]discordid.GuildID, error)
+	Save(ctx context.Context, guildID discordid.GuildID) error
+	Delete(ctx context.Context, guildID discordid.GuildID) error
+}
+
+type Repositories interface {
+	GuildCommandSetting(tx db.Tx) Repository
+	GuildCommandRegistration(tx db.Tx) RegistrationRepository
+}
@@ -1,30 +0,0 @@
-package ichiro
-
-import "github.com/aktnb/discord-bot-go/internal/domain/legend"
-
-// episodes は全盛期のイチロー伝説エピソード一覧
-var episodes = []string{
-	"３打数５安打は当たり前、３打数８安打も",
-	"先頭打者満塁ホームランを頻発",
-	"イチローにとってのホームランは内野安打の打ちそこない",
-	"先頭打者サイクルヒットも日常茶飯",
-	"９回裏100点差、チームメイト全員負傷の状況から１人で逆転",
-	"バントでホームランが特技",
-	"打席に立つだけで相手投手が泣いて謝った、心臓発作を起こす投手も",
-	"ピッチャーを一睨みしただけでボールが二遊間に飛んでいく",
-	"試合の無い移動日でも2安打",
-	"湾岸戦争が始まったきっかけはイチローの場外ホームラン",
-	"WBC決勝で自らのヒットにより２ちゃんの鯖を落とした",
-	"スイングでハリケーンが起きたことは有名",
-	"ワンバウンドも余裕でヒット",
-	"打球が投手と外野手を同時に場外に吹き飛ばした",
-	"レーザービームで外野手の手袋を焦がした",
-	"全盛期のイチローは守備でもホームランを打てた",
-	"全盛期のイチローは走塁中にホームランを打てた",
-	"かつてイチローの打球を受け止めたグラブは今でも煙を上げている",
-}
-
-// RandomEpisode はエピソードをランダムに1つ返す
-func RandomEpisode() legend.Episode {
-	return legend.Random(episodes)
-}
@@ -0,0 +1,37 @@
+package legend
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// DailyTimezone は今日のエピソードの日付の区切りに使うタイムゾーン
+const DailyTimezone = "Asia/Tokyo"
+
+// DailyLocation は DailyTimezone のロケーション
+// tzdata が無い環境でも動くよう、読み込めない場合は固定の UTC+9 を使う
+var DailyLocation = loadDailyLocation()
+
+func loadDailyLocation() *time.Location {
+	if loc, err := time.LoadLocation(DailyTimezone); err == nil {
+		return loc
+	}
+	return time.FixedZone(DailyTimezone, 9*60*60)
+}
+
+// OfTheDay はギルド・伝説・日付から決まる今日のエピソードを返す
+// 同じ日なら何度実行しても同じエピソードになる（エピソードが追加されると変わることがある）
+func OfTheDay(episodes []Episode, guildID discordid.GuildID, legend Ref, date time.Time) (Episode, error) {
+	if len(episodes) == 0 {
+		return Episode{}, ErrNoEpisodes
+	}
+
+	input := fmt.Sprintf("%s:%s:%s:%s", guildID, legend.GuildID, legend.Name, date.In(DailyLocation).Format("2006-01-02"))
+	hash := sha256.Sum256([]byte(input))
+	seed := binary.BigEndian.Uint64(hash[:8])
+	return episodes[seed%uint64(len(episodes))], nil
+}
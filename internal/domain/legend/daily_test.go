@@ -0,0 +1,49 @@
+package legend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOfTheDay(t *testing.T) {
+	episodes := make([]Episode, 30)
+	for i := range episodes {
+		episodes[i] = Episode{Number: i + 1}
+	}
+	ref := Ref{Name: "faker"}
+
+	// JST の同じ日付なら同じエピソード
+	morning := time.Date(2026, 1, 1, 0, 30, 0, 0, DailyLocation)
+	night := time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC)
+	a, err := OfTheDay(episodes, "guild", ref, morning)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b, _ := OfTheDay(episodes, "guild", ref, night); a != b {
+		t.Errorf("expected the same episode within a day, got %v and %v", a, b)
+	}
+
+	// 日付が変われば別のエピソードになりうる
+	distinct := map[int]bool{}
+	for day := range 30 {
+		episode, _ := OfTheDay(episodes, "guild", ref, morning.AddDate(0, 0, day))
+		distinct[episode.Number] = true
+	}
+	if len(distinct) < 5 {
+		t.Errorf("expected the episode to vary across days, got %d distinct episodes", len(distinct))
+	}
+
+	if _, err := OfTheDay(nil, "guild", ref, morning); err != ErrNoEpisodes {
+		t.Errorf("expected ErrNoEpisodes, got %v", err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	episodes := []Episode{{Number: 1, Text: "a"}, {Number: 3, Text: "c"}}
+	if episode, err := Find(episodes, 3); err != nil || episode.Text != "c" {
+		t.Errorf("Find(3) = %v, %v", episode, err)
+	}
+	if _, err := Find(episodes, 2); err != ErrEpisodeNotFound {
+		t.Errorf("expected ErrEpisodeNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package legend
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+const (
+	// MaxNameLength はコマンド名の最大文字数（Discord の仕様）
+	MaxNameLength = 32
+	// MaxDescriptionLength はコマンドの説明の最大文字数（Discord の仕様）
+	MaxDescriptionLength = 100
+	// MaxPrefixLength はエピソードの見出し（「〇〇伝説」の〇〇）の最大文字数
+	MaxPrefixLength = 50
+	// MaxDefinitionsPerGuild は1つのギルドで定義できる伝説コマンドの数
+	MaxDefinitionsPerGuild = 10
+)
+
+// namePattern は Discord のコマンド名として使える文字列
+// 大文字を含む名前は Discord に拒否されるため、別途小文字であることも確認する
+var namePattern = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// Definition はギルドで定義された伝説コマンド
+// エピソードは組み込みの伝説と同じく投稿と審査で追加する
+type Definition struct {
+	guildID     discordid.GuildID
+	name        string
+	description string
+	prefix      string
+	createdBy   discordid.UserID
+	createdAt   time.Time
+}
+
+func (d *Definition) GuildID() discordid.GuildID {
+	return d.guildID
+}
+
+// Name はコマンド名
+func (d *Definition) Name() string {
+	return d.name
+}
+
+// Description はコマンドの説明
+func (d *Definition) Description() string {
+	return d.description
+}
+
+// Prefix はエピソードの見出しに使う名前（「〇〇伝説 その1」の〇〇）
+func (d *Definition) Prefix() string {
+	return d.prefix
+}
+
+func (d *Definition) CreatedBy() discordid.UserID {
+	return d.createdBy
+}
+
+func (d *Definition) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// Ref はこの伝説を指す Ref を返す
+func (d *Definition) Ref() Ref {
+	return Ref{GuildID: d.guildID, Name: d.name}
+}
+
+func NewDefinition(guildID discordid.GuildID, name, description, prefix string, createdBy discordid.UserID) (*Definition, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	name = strings.TrimPrefix(strings.TrimSpace(name), "/")
+	if !namePattern.MatchString(name) || name != strings.ToLower(name) {
+		return nil, ErrInvalidLegend
+	}
+	if slices.Contains(Builtins, name) {
+		return nil, ErrLegendExists
+	}
+	description = strings.TrimSpace(description)
+	if description == "" || utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return nil, ErrInvalidDescription
+	}
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" || utf8.RuneCountInString(prefix) > MaxPrefixLength {
+		return nil, ErrInvalidPrefix
+	}
+	return &Definition{
+		guildID:     guildID,
+		name:        name,
+		description: description,
+		prefix:      prefix,
+		createdBy:   createdBy,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+func RebuildDefinition(
+	guildID discordid.GuildID,
+	name, description, prefix string,
+	createdBy discordid.UserID,
+	createdAt time.Time,
+) (*Definition, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if name == "" {
+		return nil, ErrInvalidLegend
+	}
+	return &Definition{
+		guildID:     guildID,
+		name:        name,
+		description: description,
+		prefix:      prefix,
+		createdBy:   createdBy,
+		createdAt:   createdAt,
+	}, nil
+}
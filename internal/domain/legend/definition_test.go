@@ -0,0 +1,47 @@
+package legend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+func TestNewDefinition(t *testing.T) {
+	definition, err := NewDefinition("guild", " /tanaka ", "田中の伝説を紹介します", " 田中 ", "user")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if definition.Name() != "tanaka" || definition.Prefix() != "田中" {
+		t.Errorf("expected trimmed name and prefix, got %q and %q", definition.Name(), definition.Prefix())
+	}
+	if ref := definition.Ref(); ref != (Ref{GuildID: "guild", Name: "tanaka"}) {
+		t.Errorf("unexpected ref: %+v", ref)
+	}
+
+	tests := []struct {
+		name        string
+		guildID     discordid.GuildID
+		command     string
+		description string
+		prefix      string
+		want        error
+	}{
+		{name: "no guild", guildID: "", command: "tanaka", description: "desc", prefix: "田中", want: ErrInvalidGuildID},
+		{name: "japanese name", guildID: "guild", command: "田中", description: "desc", prefix: "田中", want: nil},
+		{name: "uppercase name", guildID: "guild", command: "Tanaka", description: "desc", prefix: "田中", want: ErrInvalidLegend},
+		{name: "space in name", guildID: "guild", command: "tanaka san", description: "desc", prefix: "田中", want: ErrInvalidLegend},
+		{name: "name too long", guildID: "guild", command: strings.Repeat("a", MaxNameLength+1), description: "desc", prefix: "田中", want: ErrInvalidLegend},
+		{name: "builtin name", guildID: "guild", command: "faker", description: "desc", prefix: "田中", want: ErrLegendExists},
+		{name: "empty description", guildID: "guild", command: "tanaka", description: " ", prefix: "田中", want: ErrInvalidDescription},
+		{name: "description too long", guildID: "guild", command: "tanaka", description: strings.Repeat("あ", MaxDescriptionLength+1), prefix: "田中", want: ErrInvalidDescription},
+		{name: "empty prefix", guildID: "guild", command: "tanaka", description: "desc", prefix: "", want: ErrInvalidPrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDefinition(tt.guildID, tt.command, tt.description, tt.prefix, "user"); err != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package legend
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/google/uuid"
+)
+
+// MaxTextLength は投稿できるエピソードの最大文字数
+const MaxTextLength = 500
+
+type EntryID string
+
+// Status はエピソードの審査状況
+type Status string
+
+const (
+	// StatusPending は審査待ち
+	StatusPending Status = "pending"
+	// StatusApproved は承認済みで、伝説コマンドに表示される
+	StatusApproved Status = "approved"
+	// StatusRejected は却下済み
+	StatusRejected Status = "rejected"
+)
+
+// Entry はユーザーが投稿した、または初期データとして登録された伝説エピソード
+type Entry struct {
+	id         EntryID
+	legend     Ref
+	number     int
+	text       string
+	status     Status
+	authorID   discordid.UserID
+	guildID    discordid.GuildID
+	reviewerID discordid.UserID
+	createdAt  time.Time
+	reviewedAt time.Time
+}
+
+func (e *Entry) ID() EntryID {
+	return e.id
+}
+
+// Legend はエピソードが属する伝説
+func (e *Entry) Legend() Ref {
+	return e.legend
+}
+
+// Number は伝説ごとの通し番号。承認されるまでは 0
+func (e *Entry) Number() int {
+	return e.number
+}
+
+func (e *Entry) Text() string {
+	return e.text
+}
+
+func (e *Entry) Status() Status {
+	return e.status
+}
+
+// AuthorID は投稿者。初期データでは空
+func (e *Entry) AuthorID() discordid.UserID {
+	return e.authorID
+}
+
+// GuildID は投稿されたギルド。DM や初期データでは空
+func (e *Entry) GuildID() discordid.GuildID {
+	return e.guildID
+}
+
+func (e *Entry) ReviewerID() discordid.UserID {
+	return e.reviewerID
+}
+
+func (e *Entry) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// ReviewedAt は審査された日時。審査待ちの間はゼロ値
+func (e *Entry) ReviewedAt() time.Time {
+	return e.reviewedAt
+}
+
+// Episode は表示用のエピソードを返す
+func (e *Entry) Episode() Episode {
+	return Episode{Number: e.number, Text: e.text}
+}
+
+// Approve はエピソードを承認し、通し番号を割り当てる
+func (e *Entry) Approve(reviewerID discordid.UserID, number int) error {
+	if e.status != StatusPending {
+		return ErrAlreadyReviewed
+	}
+	e.status = StatusApproved
+	e.number = number
+	e.reviewerID = reviewerID
+	e.reviewedAt = time.Now()
+	return nil
+}
+
+// Reject はエピソードを却下する
+func (e *Entry) Reject(reviewerID discordid.UserID) error {
+	if e.status != StatusPending {
+		return ErrAlreadyReviewed
+	}
+	e.status = StatusRejected
+	e.reviewerID = reviewerID
+	e.reviewedAt = time.Now()
+	return nil
+}
+
+// NewSubmission はユーザーの投稿を審査待ちのエピソードとして生成する
+func NewSubmission(legend Ref, text string, authorID discordid.UserID, guildID discordid.GuildID) (*Entry, error) {
+	if legend.Name == "" {
+		return nil, ErrInvalidLegend
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+	if utf8.RuneCountInString(text) > MaxTextLength {
+		return nil, ErrTextTooLong
+	}
+	return &Entry{
+		id:        EntryID(uuid.New().String()),
+		legend:    legend,
+		text:      text,
+		status:    StatusPending,
+		authorID:  authorID,
+		guildID:   guildID,
+		createdAt: time.Now(),
+	}, nil
+}
+
+func RebuildEntry(
+	id EntryID,
+	legend Ref,
+	number int,
+	text string,
+	status Status,
+	authorID discordid.UserID,
+	guildID discordid.GuildID,
+	reviewerID discordid.UserID,
+	createdAt, reviewedAt time.Time,
+) (*Entry, error) {
+	if legend.Name == "" {
+		return nil, ErrInvalidLegend
+	}
+	return &Entry{
+		id:         id,
+		legend:     legend,
+		number:     number,
+		text:       text,
+		status:     status,
+		authorID:   authorID,
+		guildID:    guildID,
+		reviewerID: reviewerID,
+		createdAt:  createdAt,
+		reviewedAt: reviewedAt,
+	}, nil
+}
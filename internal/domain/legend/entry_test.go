@@ -0,0 +1,58 @@
+package legend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSubmission(t *testing.T) {
+	entry, err := NewSubmission(Ref{Name: "faker"}, "  バロンが懐いた  ", "user", "guild")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Text() != "バロンが懐いた" {
+		t.Errorf("expected trimmed text, got %q", entry.Text())
+	}
+	if entry.Status() != StatusPending || entry.Number() != 0 {
+		t.Errorf("expected pending entry without number, got %s #%d", entry.Status(), entry.Number())
+	}
+
+	tests := []struct {
+		name   string
+		legend Ref
+		text   string
+		want   error
+	}{
+		{name: "empty legend", legend: Ref{}, text: "text", want: ErrInvalidLegend},
+		{name: "empty text", legend: Ref{Name: "faker"}, text: "   ", want: ErrEmptyText},
+		{name: "too long", legend: Ref{Name: "faker"}, text: strings.Repeat("あ", MaxTextLength+1), want: ErrTextTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSubmission(tt.legend, tt.text, "user", "guild"); err != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestEntryReview(t *testing.T) {
+	entry, _ := NewSubmission(Ref{Name: "faker"}, "text", "user", "guild")
+	if err := entry.Approve("reviewer", 25); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Status() != StatusApproved || entry.Number() != 25 || entry.ReviewerID() != "reviewer" {
+		t.Errorf("unexpected approved entry: %s #%d by %s", entry.Status(), entry.Number(), entry.ReviewerID())
+	}
+	if err := entry.Reject("reviewer"); err != ErrAlreadyReviewed {
+		t.Errorf("expected ErrAlreadyReviewed, got %v", err)
+	}
+
+	entry, _ = NewSubmission(Ref{Name: "faker"}, "text", "user", "guild")
+	if err := entry.Reject("reviewer"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := entry.Approve("reviewer", 1); err != ErrAlreadyReviewed {
+		t.Errorf("expected ErrAlreadyReviewed, got %v", err)
+	}
+}
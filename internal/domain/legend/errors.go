@@ -0,0 +1,28 @@
+package legend
+
+import "errors"
+
+var (
+	ErrNoEpisodes      = errors.New("no approved episodes")
+	ErrEpisodeNotFound = errors.New("legend episode not found")
+	ErrEntryNotFound   = errors.New("legend entry not found")
+	ErrUnknownLegend   = errors.New("unknown legend")
+	ErrInvalidLegend   = errors.New("invalid legend name")
+	ErrEmptyText       = errors.New("episode text is empty")
+	ErrTextTooLong     = errors.New("episode text is too long")
+	ErrAlreadyReviewed = errors.New("legend entry is already reviewed")
+	ErrReviewForbidden = errors.New("not allowed to review this legend entry")
+
+	ErrInvalidGuildID     = errors.New("invalid Guild ID")
+	ErrInvalidDescription = errors.New("invalid legend description")
+	ErrInvalidPrefix      = errors.New("invalid legend prefix")
+	ErrLegendExists       = errors.New("legend already exists")
+	ErrDefinitionNotFound = errors.New("legend definition not found")
+	ErrTooManyDefinitions = errors.New("too many legend definitions")
+
+	ErrRotationNotFound = errors.New("legend rotation not found")
+
+	ErrNoGrammar      = errors.New("legend has no grammar")
+	ErrInvalidSeed    = errors.New("invalid seed")
+	ErrInvalidSubject = errors.New("invalid subject")
+)
@@ -0,0 +1,81 @@
+package legend
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/grammar"
+)
+
+const (
+	// SubjectSymbol は文法の中で主人公を表す記号
+	// 伝説の文法はこの記号を定義し、主人公を差し替えられるようにする
+	SubjectSymbol = "subject"
+	// MaxSeed は生成に使うシードの最大値
+	// 再現するときに入力しやすいよう 9 桁までにする
+	MaxSeed = 999_999_999
+	// MaxSubjectLength は差し替える主人公の名前の最大文字数
+	MaxSubjectLength = 32
+)
+
+// grammarFS は伝説ごとの文法（data/grammars/<伝説の名前>.json）
+//
+//go:embed data/grammars/*.json
+var grammarFS embed.FS
+
+var grammars = mustLoadGrammars()
+
+func mustLoadGrammars() map[string]*grammar.Grammar {
+	entries, err := grammarFS.ReadDir("data/grammars")
+	if err != nil {
+		panic(fmt.Sprintf("legend: failed to read grammars: %v", err))
+	}
+
+	loaded := make(map[string]*grammar.Grammar, len(entries))
+	for _, entry := range entries {
+		data, err := grammarFS.ReadFile(path.Join("data/grammars", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("legend: failed to read grammar %s: %v", entry.Name(), err))
+		}
+		g, err := grammar.Parse(data)
+		if err != nil {
+			panic(fmt.Sprintf("legend: grammar %s: %v", entry.Name(), err))
+		}
+		if !g.Has(SubjectSymbol) {
+			panic(fmt.Sprintf("legend: grammar %s does not define %q", entry.Name(), SubjectSymbol))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = g
+	}
+	return loaded
+}
+
+// CanGenerate は伝説に文法が用意されていて、エピソードを生成できるかを返す
+func CanGenerate(name string) bool {
+	_, ok := grammars[name]
+	return ok
+}
+
+// Generate は伝説の文法からシードに応じたエピソードの本文を生成する
+// 同じシードと主人公なら常に同じ本文になり、主人公を空にすると文法の既定の主人公を使う
+func Generate(name string, seed uint64, subject string) (string, error) {
+	g, ok := grammars[name]
+	if !ok {
+		return "", ErrNoGrammar
+	}
+	if seed > MaxSeed {
+		return "", ErrInvalidSeed
+	}
+
+	var bindings map[string]string
+	subject = strings.Join(strings.Fields(subject), " ")
+	if subject != "" {
+		if utf8.RuneCountInString(subject) > MaxSubjectLength {
+			return "", ErrInvalidSubject
+		}
+		bindings = map[string]string{SubjectSymbol: subject}
+	}
+	return g.Generate(seed, bindings)
+}
@@ -0,0 +1,53 @@
+package legend
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	if !CanGenerate("yamada") {
+		t.Fatal("expected yamada to have a grammar")
+	}
+	if CanGenerate("faker") {
+		t.Error("expected faker to have no grammar")
+	}
+
+	first, err := Generate("yamada", 12345, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(first, "山田、") {
+		t.Errorf("expected a headline about 山田, got %q", first)
+	}
+	if again, _ := Generate("yamada", 12345, ""); again != first {
+		t.Errorf("expected the same headline for the same seed, got %q and %q", first, again)
+	}
+
+	other, err := Generate("yamada", 12345, "  佐藤 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(other, "佐藤、") || strings.Contains(other, "山田") {
+		t.Errorf("expected 佐藤 to replace 山田, got %q", other)
+	}
+
+	tests := []struct {
+		name    string
+		legend  string
+		seed    uint64
+		subject string
+		want    error
+	}{
+		{name: "no grammar", legend: "faker", seed: 1, want: ErrNoGrammar},
+		{name: "seed too large", legend: "yamada", seed: MaxSeed + 1, want: ErrInvalidSeed},
+		{name: "subject too long", legend: "yamada", seed: 1, subject: strings.Repeat("あ", MaxSubjectLength+1), want: ErrInvalidSubject},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(tt.legend, tt.seed, tt.subject); err != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,35 @@
 package legend
 
-import "math/rand/v2"
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// Builtins は初期データとしてエピソードが登録されている伝説コマンドの名前
+var Builtins = []string{"faker", "ichiro", "jeff-dean", "yamada"}
+
+// Ref は伝説コマンドを指す
+// 組み込みの伝説は全ギルド共通で GuildID が空、ギルドで定義された伝説は定義したギルドを持つ
+type Ref struct {
+	GuildID discordid.GuildID
+	Name    string
+}
+
+// IsBuiltin は組み込みの伝説かどうかを返す
+func (r Ref) IsBuiltin() bool {
+	return r.GuildID == ""
+}
+
+// Resolve はギルドで実行された伝説コマンドの名前が指す伝説を返す
+// 組み込みの伝説の名前はギルドで定義できないため、名前だけで区別できる
+func Resolve(guildID discordid.GuildID, name string) Ref {
+	if slices.Contains(Builtins, name) {
+		return Ref{Name: name}
+	}
+	return Ref{GuildID: guildID, Name: name}
+}
 
 // Episode は伝説エピソード
 type Episode struct {
@@ -9,7 +38,19 @@ type Episode struct {
 }
 
 // Random はエピソード一覧からランダムに1つ返す
-func Random(episodes []string) Episode {
-	i := rand.IntN(len(episodes))
-	return Episode{Number: i + 1, Text: episodes[i]}
+func Random(episodes []Episode) (Episode, error) {
+	if len(episodes) == 0 {
+		return Episode{}, ErrNoEpisodes
+	}
+	return episodes[rand.IntN(len(episodes))], nil
+}
+
+// Find は通し番号のエピソードを返す
+func Find(episodes []Episode, number int) (Episode, error) {
+	for _, episode := range episodes {
+		if episode.Number == number {
+			return episode, nil
+		}
+	}
+	return Episode{}, ErrEpisodeNotFound
 }
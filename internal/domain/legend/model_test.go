@@ -3,16 +3,32 @@ package legend
 import "testing"
 
 func TestRandom(t *testing.T) {
-	episodes := []string{"aaa", "bbb", "ccc"}
-	ep := Random(episodes)
+	episodes := []Episode{{Number: 1, Text: "aaa"}, {Number: 2, Text: "bbb"}, {Number: 5, Text: "ccc"}}
+	ep, err := Random(episodes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, episode := range episodes {
+		if episode == ep {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected one of %v, got %v", episodes, ep)
+	}
 
-	if ep.Number < 1 || ep.Number > len(episodes) {
-		t.Errorf("expected number between 1 and %d, got %d", len(episodes), ep.Number)
+	if _, err := Random(nil); err != ErrNoEpisodes {
+		t.Errorf("expected ErrNoEpisodes, got %v", err)
 	}
-	if ep.Text == "" {
-		t.Error("expected non-empty text")
+}
+
+func TestResolve(t *testing.T) {
+	if ref := Resolve("guild", "faker"); !ref.IsBuiltin() || ref.Name != "faker" {
+		t.Errorf("builtin legend should not be scoped to a guild: %+v", ref)
 	}
-	if episodes[ep.Number-1] != ep.Text {
-		t.Errorf("expected %q at index %d, got %q", episodes[ep.Number-1], ep.Number-1, ep.Text)
+	if ref := Resolve("guild", "tanaka"); ref.IsBuiltin() || ref.GuildID != "guild" {
+		t.Errorf("custom legend should be scoped to the guild: %+v", ref)
 	}
 }
@@ -0,0 +1,54 @@
+package legend
+
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+type Repository interface {
+	FindByID(ctx context.Context, id EntryID) (*Entry, error)
+	// FindApproved は伝説の承認済みエピソードを通し番号の順に返す
+	FindApproved(ctx context.Context, legend Ref) ([]*Entry, error)
+	// FindPending は審査待ちのエピソードを投稿の古い順に返す
+	FindPending(ctx context.Context) ([]*Entry, error)
+	// MaxNumber は伝説の承認済みエピソードの通し番号の最大値を返す。無ければ 0
+	MaxNumber(ctx context.Context, legend Ref) (int, error)
+	Save(ctx context.Context, entry *Entry) error
+	// DeleteByLegend は伝説のエピソードを審査状況によらずすべて削除する
+	DeleteByLegend(ctx context.Context, legend Ref) error
+}
+
+type Repositories interface {
+	LegendEntry(tx db.Tx) Repository
+}
+
+// DefinitionRepository はギルドで定義された伝説コマンドを保存する
+type DefinitionRepository interface {
+	// FindAll は全ギルドの定義をギルド・名前の順に返す
+	FindAll(ctx context.Context) ([]*Definition, error)
+	// FindByGuild はギルドの定義を名前の順に返す
+	FindByGuild(ctx context.Context, guildID discordid.GuildID) ([]*Definition, error)
+	FindByName(ctx context.Context, guildID discordid.GuildID, name string) (*Definition, error)
+	// Create は定義を追加する。同じギルドに同じ名前の定義があれば ErrLegendExists を返す
+	Create(ctx context.Context, definition *Definition) error
+	Delete(ctx context.Context, guildID discordid.GuildID, name string) error
+}
+
+type DefinitionRepositories interface {
+	LegendDefinition(tx db.Tx) DefinitionRepository
+}
+
+// RotationRepository はギルドごとの伝説エピソードの出題状況を保存する
+type RotationRepository interface {
+	// Find は出題状況を返す。まだ無ければ ErrRotationNotFound を返す
+	Find(ctx context.Context, guildID discordid.GuildID, legend Ref) (*Rotation, error)
+	Save(ctx context.Context, rotation *Rotation) error
+	// DeleteByLegend は伝説の全ギルドの出題状況を削除する
+	DeleteByLegend(ctx context.Context, legend Ref) error
+}
+
+type RotationRepositories interface {
+	LegendRotation(tx db.Tx) RotationRepository
+}
@@ -0,0 +1,106 @@
+package legend
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// Rotation はギルドごとの伝説エピソードの出題状況（シャッフルバッグ）
+// 一巡するまでは同じエピソードを出さず、すべて出し終えたら最初からやり直す
+type Rotation struct {
+	guildID   discordid.GuildID
+	legend    Ref
+	seen      []int
+	updatedAt time.Time
+}
+
+func (r *Rotation) GuildID() discordid.GuildID {
+	return r.guildID
+}
+
+func (r *Rotation) Legend() Ref {
+	return r.legend
+}
+
+// Seen は今の周回で既に出したエピソードの通し番号を出した順に返す
+func (r *Rotation) Seen() []int {
+	return slices.Clone(r.seen)
+}
+
+func (r *Rotation) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Next はまだ出していないエピソードからランダムに1つ選び、出したものとして記録する
+// 削除されたエピソードは除外し、後から承認されたエピソードはその周回から候補に加わる
+// 一巡した直後は、直前に出したエピソードが続けて選ばれないようにする
+func (r *Rotation) Next(episodes []Episode) (Episode, error) {
+	if len(episodes) == 0 {
+		return Episode{}, ErrNoEpisodes
+	}
+
+	numbers := make([]int, len(episodes))
+	for i, episode := range episodes {
+		numbers[i] = episode.Number
+	}
+	r.seen = slices.DeleteFunc(r.seen, func(number int) bool {
+		return !slices.Contains(numbers, number)
+	})
+
+	candidates := unseen(episodes, r.seen)
+	if len(candidates) == 0 {
+		last := r.seen[len(r.seen)-1]
+		r.seen = nil
+		candidates = unseen(episodes, []int{last})
+		if len(candidates) == 0 {
+			candidates = episodes
+		}
+	}
+
+	episode := candidates[rand.IntN(len(candidates))]
+	r.seen = append(r.seen, episode.Number)
+	r.updatedAt = time.Now()
+	return episode, nil
+}
+
+func unseen(episodes []Episode, seen []int) []Episode {
+	var candidates []Episode
+	for _, episode := range episodes {
+		if !slices.Contains(seen, episode.Number) {
+			candidates = append(candidates, episode)
+		}
+	}
+	return candidates
+}
+
+func NewRotation(guildID discordid.GuildID, legend Ref) (*Rotation, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if legend.Name == "" {
+		return nil, ErrInvalidLegend
+	}
+	return &Rotation{
+		guildID:   guildID,
+		legend:    legend,
+		updatedAt: time.Now(),
+	}, nil
+}
+
+func RebuildRotation(guildID discordid.GuildID, legend Ref, seen []int, updatedAt time.Time) (*Rotation, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if legend.Name == "" {
+		return nil, ErrInvalidLegend
+	}
+	return &Rotation{
+		guildID:   guildID,
+		legend:    legend,
+		seen:      seen,
+		updatedAt: updatedAt,
+	}, nil
+}
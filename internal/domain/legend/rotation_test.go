@@ -0,0 +1,62 @@
+package legend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRotationNext(t *testing.T) {
+	episodes := []Episode{{Number: 1, Text: "a"}, {Number: 2, Text: "b"}, {Number: 3, Text: "c"}, {Number: 4, Text: "d"}}
+	rotation, err := NewRotation("guild", Ref{Name: "faker"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var previous int
+	for round := 0; round < 50; round++ {
+		shown := make(map[int]bool)
+		for range episodes {
+			episode, err := rotation.Next(episodes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if shown[episode.Number] {
+				t.Fatalf("round %d: episode %d repeated before every episode was shown", round, episode.Number)
+			}
+			if episode.Number == previous {
+				t.Fatalf("round %d: episode %d shown twice in a row", round, episode.Number)
+			}
+			shown[episode.Number] = true
+			previous = episode.Number
+		}
+	}
+}
+
+func TestRotationNextWithChangedEpisodes(t *testing.T) {
+	rotation, _ := RebuildRotation("guild", Ref{Name: "faker"}, []int{1, 2, 9}, time.Time{})
+
+	// 削除された 9 は忘れ、後から承認された 5 は今の周回の候補になる
+	episodes := []Episode{{Number: 1}, {Number: 2}, {Number: 3}, {Number: 5}}
+	got := map[int]bool{}
+	for range 2 {
+		episode, err := rotation.Next(episodes)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got[episode.Number] = true
+	}
+	if !got[3] || !got[5] {
+		t.Errorf("expected the unseen episodes 3 and 5, got %v", got)
+	}
+
+	single := []Episode{{Number: 1}}
+	for range 3 {
+		if episode, err := rotation.Next(single); err != nil || episode.Number != 1 {
+			t.Errorf("a single episode should always be shown, got %v, %v", episode, err)
+		}
+	}
+
+	if _, err := rotation.Next(nil); err != ErrNoEpisodes {
+		t.Errorf("expected ErrNoEpisodes, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package legend
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// minSearchScore は検索結果に含める一致度の下限
+// キーワードの2文字組のうち、半分以上がエピソードに含まれていれば一致とみなす
+const minSearchScore = 0.5
+
+// Match は検索でキーワードに一致したエピソード
+type Match struct {
+	Episode Episode
+	// Score は一致度。キーワードをそのまま含む場合は 1、部分的に含む場合はそれ未満
+	Score float64
+}
+
+// Search はキーワードに一致するエピソードを一致度の高い順に最大 limit 件返す
+// 全角・半角、大文字・小文字、ひらがな・カタカナの違いと空白は無視し、
+// キーワードをそのまま含まなくても2文字組が多く一致すれば表記揺れや誤字として拾う
+func Search(episodes []Episode, query string, limit int) []Match {
+	query = normalize(query)
+	if query == "" {
+		return nil
+	}
+
+	var matches []Match
+	for _, episode := range episodes {
+		if score := matchScore(normalize(episode.Text), query); score >= minSearchScore {
+			matches = append(matches, Match{Episode: episode, Score: score})
+		}
+	}
+
+	// 一致度が同じなら通し番号の順に並べる
+	slices.SortStableFunc(matches, func(a, b Match) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return a.Episode.Number - b.Episode.Number
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// matchScore は正規化済みのテキストがキーワードにどれだけ一致するかを 0〜1 で返す
+func matchScore(text, query string) float64 {
+	if strings.Contains(text, query) {
+		return 1
+	}
+
+	grams := bigrams(query)
+	if len(grams) == 0 {
+		return 0
+	}
+	found := 0
+	for _, gram := range grams {
+		if strings.Contains(text, gram) {
+			found++
+		}
+	}
+	// 完全一致より必ず低くなるよう、わずかに割り引く
+	return float64(found) / float64(len(grams)) * 0.99
+}
+
+// bigrams は文字列を重複なしの2文字組に分割する
+func bigrams(s string) []string {
+	runes := []rune(s)
+	var grams []string
+	for i := 0; i+1 < len(runes); i++ {
+		gram := string(runes[i : i+2])
+		if !slices.Contains(grams, gram) {
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// normalize は検索のために表記を揃える
+// NFKC で全角英数字と半角カナを揃え、小文字化し、カタカナをひらがなに寄せ、空白を除く
+func normalize(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return -1
+		case r >= 'ァ' && r <= 'ヶ':
+			return r - 'ァ' + 'ぁ'
+		default:
+			return r
+		}
+	}, s)
+}
@@ -0,0 +1,46 @@
+package legend
+
+import "testing"
+
+func TestSearch(t *testing.T) {
+	episodes := []Episode{
+		{Number: 1, Text: "1vs1でペンタキルは当たり前"},
+		{Number: 2, Text: "バロンが懐いて付いてきた"},
+		{Number: 3, Text: "Ban Pick画面でファーストブラッド"},
+		{Number: 4, Text: "ばろんを素手で倒した"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{name: "exact substring", query: "ペンタキル", want: []int{1}},
+		{name: "case and width insensitive", query: "ＢＡＮ　ｐｉｃｋ", want: []int{3}},
+		{name: "hiragana matches katakana", query: "ばろん", want: []int{2, 4}},
+		{name: "typo still matches", query: "ファーストブラット", want: []int{3}},
+		{name: "no match", query: "ホームラン", want: nil},
+		{name: "empty query", query: "  ", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := Search(episodes, tt.query, 10)
+			var got []int
+			for _, match := range matches {
+				got = append(got, match.Episode.Number)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+
+	if matches := Search(episodes, "ばろん", 1); len(matches) != 1 {
+		t.Errorf("expected results to be limited to 1, got %d", len(matches))
+	}
+}
@@ -0,0 +1,93 @@
+package mahjong
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Acceptance は有効牌（引くと向聴数が進む牌）の種類と残り枚数
+type Acceptance struct {
+	Kind Kind
+	// Count は手牌に見えていない残りの枚数
+	Count int
+}
+
+// Discard は打牌の候補と、打牌した後の向聴数と有効牌
+type Discard struct {
+	Kind    Kind
+	Shanten int
+	Ukeire  []Acceptance
+	// UkeireCount は有効牌の残り枚数の合計
+	UkeireCount int
+}
+
+// Analysis は手牌の分析結果
+type Analysis struct {
+	Hand    Hand
+	Shanten Shanten
+	// Ukeire は 3n+1 枚の手牌の有効牌。3n+2 枚の手牌では nil
+	Ukeire      []Acceptance
+	UkeireCount int
+	// Discards は 3n+2 枚の手牌の打牌の候補で、向聴数が小さく有効牌が多い順に並ぶ。3n+1 枚の手牌では nil
+	Discards []Discard
+}
+
+// Analyze は手牌の向聴数と、3n+1 枚なら有効牌を、3n+2 枚なら打牌の候補を求める
+// 有効牌の残り枚数は手牌に見えている牌だけを除いて数える
+func Analyze(hand Hand) (Analysis, error) {
+	if len(hand) == 0 || len(hand) > DealerHandSize || len(hand)%3 == 0 {
+		return Analysis{}, fmt.Errorf("%w: %d tiles", ErrInvalidHandSize, len(hand))
+	}
+
+	counts := hand.Counts()
+	analysis := Analysis{Hand: hand, Shanten: CalculateShanten(counts)}
+	if len(hand)%3 == 1 {
+		analysis.Ukeire, analysis.UkeireCount = ukeire(counts, analysis.Shanten.Min())
+		return analysis, nil
+	}
+
+	for kind := Kind(0); kind < NumKinds; kind++ {
+		if counts[kind] == 0 {
+			continue
+		}
+		counts[kind]--
+		shanten := CalculateShanten(counts).Min()
+		acceptances, total := ukeire(counts, shanten)
+		counts[kind]++
+		analysis.Discards = append(analysis.Discards, Discard{
+			Kind:        kind,
+			Shanten:     shanten,
+			Ukeire:      acceptances,
+			UkeireCount: total,
+		})
+	}
+	sort.SliceStable(analysis.Discards, func(i, j int) bool {
+		a, b := analysis.Discards[i], analysis.Discards[j]
+		if a.Shanten != b.Shanten {
+			return a.Shanten < b.Shanten
+		}
+		return a.UkeireCount > b.UkeireCount
+	})
+	return analysis, nil
+}
+
+// ukeire は 3n+1 枚の手牌で、引くと向聴数が shanten より小さくなる牌を返す
+func ukeire(counts [NumKinds]int, shanten int) ([]Acceptance, int) {
+	var (
+		acceptances []Acceptance
+		total       int
+	)
+	for kind := Kind(0); kind < NumKinds; kind++ {
+		if counts[kind] >= 4 {
+			continue
+		}
+		counts[kind]++
+		if CalculateShanten(counts).Min() < shanten {
+			remaining := 4 - (counts[kind] - 1)
+			acceptances = append(acceptances, Acceptance{Kind: kind, Count: remaining})
+			total += remaining
+		}
+		counts[kind]--
+	}
+	return acceptances, total
+}
@@ -0,0 +1,116 @@
+package mahjong
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func mustParse(t *testing.T, notation string) Hand {
+	t.Helper()
+	hand, err := ParseHand(notation)
+	if err != nil {
+		t.Fatalf("ParseHand(%q): %v", notation, err)
+	}
+	return hand
+}
+
+func TestCalculateShanten(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		want     Shanten
+	}{
+		{name: "complete", notation: "123m234m456p789s11z", want: Shanten{Standard: -1, SevenPairs: 3, ThirteenOrphans: 9}},
+		{name: "tenpai", notation: "123m456p789s1122z", want: Shanten{Standard: 0, SevenPairs: 4, ThirteenOrphans: 8}},
+		{name: "iishanten", notation: "123m456p78s114z99m", want: Shanten{Standard: 1, SevenPairs: 4, ThirteenOrphans: 8}},
+		{name: "seven pairs tenpai", notation: "1122m3344p5566s7z", want: Shanten{Standard: 3, SevenPairs: 0, ThirteenOrphans: 10}},
+		{name: "seven pairs with a quad", notation: "1111m2233p4455s6z", want: Shanten{Standard: 2, SevenPairs: 2, ThirteenOrphans: 10}},
+		{name: "thirteen orphans 13-sided", notation: "19m19p19s1234567z", want: Shanten{Standard: 8, SevenPairs: 6, ThirteenOrphans: 0}},
+		{name: "thirteen orphans complete", notation: "19m19p19s12345677z", want: Shanten{Standard: 7, SevenPairs: 5, ThirteenOrphans: -1}},
+		{name: "scattered", notation: "147m258p369s1234z", want: Shanten{Standard: 8, SevenPairs: 6, ThirteenOrphans: 7}},
+		{name: "after calls", notation: "23m55p", want: Shanten{Standard: 0, SevenPairs: NotApplicable, ThirteenOrphans: NotApplicable}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateShanten(mustParse(t, tt.notation).Counts())
+			if got != tt.want {
+				t.Errorf("CalculateShanten(%s) = %+v, want %+v", tt.notation, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeUkeire(t *testing.T) {
+	tests := []struct {
+		name      string
+		notation  string
+		wantKinds string
+		wantCount int
+	}{
+		{name: "shanpon", notation: "123m456p789s1122z", wantKinds: "12z", wantCount: 4},
+		{name: "ryanmen", notation: "123456m456p78s11z", wantKinds: "69s", wantCount: 8},
+		{name: "nine gates", notation: "1112345678999m", wantKinds: "123456789m", wantCount: 23},
+		{name: "thirteen orphans", notation: "19m19p19s1234567z", wantKinds: "19m19p19s1234567z", wantCount: 39},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis, err := Analyze(mustParse(t, tt.notation))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var kinds Hand
+			for _, acceptance := range analysis.Ukeire {
+				kinds = append(kinds, Tile{Kind: acceptance.Kind})
+			}
+			if kinds.String() != tt.wantKinds || analysis.UkeireCount != tt.wantCount {
+				t.Errorf("ukeire = %s (%d), want %s (%d)", kinds, analysis.UkeireCount, tt.wantKinds, tt.wantCount)
+			}
+			if analysis.Discards != nil {
+				t.Error("expected no discards for a 13-tile hand")
+			}
+		})
+	}
+}
+
+func TestAnalyzeDiscards(t *testing.T) {
+	analysis, err := Analyze(mustParse(t, "123m456p789s1122z5m"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	best := analysis.Discards[0]
+	if best.Kind.String() != "5m" || best.Shanten != 0 || best.UkeireCount != 4 {
+		t.Errorf("unexpected best discard: %+v", best)
+	}
+	// 雀頭候補を崩す打牌は向聴数が進まない
+	for _, discard := range analysis.Discards[1:] {
+		if discard.Shanten == 0 && discard.UkeireCount > best.UkeireCount {
+			t.Errorf("discard %s is better than the best one", discard.Kind)
+		}
+	}
+	if analysis.Ukeire != nil {
+		t.Error("expected no ukeire for a 14-tile hand")
+	}
+}
+
+func TestAnalyzeInvalidSize(t *testing.T) {
+	for _, notation := range []string{"123m", "123456m456p789s111z"} {
+		if _, err := Analyze(mustParse(t, notation)); !errors.Is(err, ErrInvalidHandSize) {
+			t.Errorf("Analyze(%s): expected ErrInvalidHandSize, got %v", notation, err)
+		}
+	}
+}
+
+func BenchmarkAnalyze(b *testing.B) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	hands := make([]Hand, 64)
+	for n := range hands {
+		hands[n], _ = Deal(rng, DealOptions{Tiles: DealerHandSize})
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := Analyze(hands[n%len(hands)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,7 +3,30 @@ package mahjong
 import "errors"
 
 var (
-	ErrImageNotFound   = errors.New("mahjong image not found")
-	ErrAPIUnavailable  = errors.New("mahjong API is unavailable")
-	ErrInvalidResponse = errors.New("invalid API response")
+	ErrImageNotFound     = errors.New("mahjong image not found")
+	ErrAPIUnavailable    = errors.New("mahjong API is unavailable")
+	ErrInvalidResponse   = errors.New("invalid API response")
+	ErrInvalidTile       = errors.New("invalid mahjong tile")
+	ErrInvalidTileCount  = errors.New("invalid number of tiles in a starting hand")
+	ErrInvalidNotation   = errors.New("invalid hand notation")
+	ErrInvalidHandSize   = errors.New("invalid number of tiles in a hand")
+	ErrInvalidGuildID    = errors.New("invalid guild id")
+	ErrNotInHand         = errors.New("tile is not in the hand")
+	ErrNotQuizPlayer     = errors.New("only the quiz player can answer")
+	ErrAlreadyAnswered   = errors.New("quiz round is already answered")
+	ErrQuizRoundNotFound = errors.New("quiz round not found")
+	ErrNotWinningHand    = errors.New("hand is not a winning hand")
+	ErrNoYaku            = errors.New("winning hand has no yaku")
+	ErrInvalidWinContext = errors.New("invalid winning context")
+
+	ErrInvalidPlayerCount = errors.New("a table needs 3 or 4 distinct players")
+	ErrInvalidTableRule   = errors.New("invalid table rule")
+	ErrInvalidTableStatus = errors.New("invalid table status")
+	ErrInvalidHandRecord  = errors.New("invalid hand record")
+	ErrNotTablePlayer     = errors.New("only players of the table can record it")
+	ErrTableFinished      = errors.New("table is already finished")
+	ErrNoHandToUndo       = errors.New("no hand to undo")
+	ErrTableInProgress    = errors.New("a table is already in progress in this channel")
+	ErrTableNotFound      = errors.New("table not found")
+	ErrNotInVoiceChannel  = errors.New("user is not in a voice channel")
 )
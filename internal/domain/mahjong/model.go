@@ -6,4 +6,12 @@ type MahjongStartingHand struct {
 	ImageData []byte
 	// ContentType は画像のMIMEタイプ（通常は"image/png"）
 	ContentType string
+	// Tiles は配牌の牌。外部 API から取得した場合など、牌が分からなければ nil
+	Tiles Hand
+}
+
+// HandRenderer は手牌の画像を描画するポートインターフェース
+type HandRenderer interface {
+	// RenderPNG は手牌を PNG 画像として描画する
+	RenderPNG(hand Hand) ([]byte, error)
 }
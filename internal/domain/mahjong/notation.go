@@ -0,0 +1,87 @@
+package mahjong
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseHand は "123m406p789s11z" のような表記の手牌を読み込む
+// 数字の後に種類を表す文字（m・p・s・z）を付け、0 は赤ドラの 5 を表す。空白は無視する
+// 同じ種類の牌は 4 枚まで、赤ドラは各色 1 枚までとする
+func ParseHand(notation string) (Hand, error) {
+	var (
+		hand    Hand
+		pending []int
+	)
+	for _, r := range strings.Join(strings.Fields(notation), "") {
+		switch {
+		case r >= '0' && r <= '9':
+			pending = append(pending, int(r-'0'))
+		case strings.ContainsRune(suitLetters, r):
+			if len(pending) == 0 {
+				return nil, fmt.Errorf("%w: %c has no numbers", ErrInvalidNotation, r)
+			}
+			suit := Suit(strings.IndexRune(suitLetters, r))
+			for _, number := range pending {
+				tile, err := newTile(suit, number)
+				if err != nil {
+					return nil, err
+				}
+				hand = append(hand, tile)
+			}
+			pending = nil
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidNotation, r)
+		}
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("%w: numbers without a suit", ErrInvalidNotation)
+	}
+	if len(hand) == 0 {
+		return nil, fmt.Errorf("%w: no tiles", ErrInvalidNotation)
+	}
+
+	counts := hand.Counts()
+	var reds [3]int
+	for _, tile := range hand {
+		if tile.Red {
+			reds[tile.Kind.Suit()]++
+		}
+	}
+	for kind, count := range counts {
+		if count > 4 {
+			return nil, fmt.Errorf("%w: more than 4 of %s", ErrInvalidNotation, Kind(kind))
+		}
+	}
+	for suit, count := range reds {
+		if count > 1 {
+			return nil, fmt.Errorf("%w: more than one red 5%c", ErrInvalidNotation, Suit(suit).Letter())
+		}
+	}
+	return hand, nil
+}
+
+// newTile は表記の数字から牌を返す。0 は赤ドラの 5 を表す
+func newTile(suit Suit, number int) (Tile, error) {
+	red := number == 0
+	if red {
+		if suit == SuitHonor {
+			return Tile{}, fmt.Errorf("%w: 0z", ErrInvalidTile)
+		}
+		number = 5
+	}
+	kind, err := NewKind(suit, number)
+	if err != nil {
+		return Tile{}, err
+	}
+	return Tile{Kind: kind, Red: red}, nil
+}
+
+// Counts は牌の種類ごとの枚数を返す
+func (h Hand) Counts() [NumKinds]int {
+	var counts [NumKinds]int
+	for _, tile := range h {
+		counts[tile.Kind]++
+	}
+	return counts
+}
@@ -0,0 +1,43 @@
+package mahjong
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseHand(t *testing.T) {
+	tests := []struct {
+		notation string
+		want     string
+		wantErr  bool
+	}{
+		{notation: "123m456p789s11z", want: "123m456p789s11z"},
+		{notation: "123m 406p", want: "123m406p"},
+		{notation: "55m05s", want: "55m05s"},
+		{notation: "123", wantErr: true},
+		{notation: "m", wantErr: true},
+		{notation: "8z", wantErr: true},
+		{notation: "0z", wantErr: true},
+		{notation: "11111m", wantErr: true},
+		{notation: "00m", wantErr: true},
+		{notation: "123x", wantErr: true},
+		{notation: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			hand, err := ParseHand(tt.notation)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidNotation) && !errors.Is(err, ErrInvalidTile) {
+					t.Errorf("expected a notation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if hand.String() != tt.want {
+				t.Errorf("ParseHand(%q) = %s, want %s", tt.notation, hand, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package mahjong
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/google/uuid"
+)
+
+const (
+	// QuizFullPoints は最善の打牌（向聴数も有効牌の枚数も最善と同じ）に与える得点
+	QuizFullPoints = 10
+	// QuizPartialPoints は向聴数は最善と同じだが有効牌が少ない打牌に与える得点の上限
+	// 有効牌の枚数の最善に対する割合を掛けて切り捨てる
+	QuizPartialPoints = 6
+	// QuizMaxShanten は出題する手牌の向聴数の上限
+	// 向聴数が大きいと候補の差が小さく、問題として面白くないため配り直す
+	QuizMaxShanten = 2
+)
+
+// quizDealAttempts は QuizMaxShanten 以内の手牌を探して配り直す回数の上限
+const quizDealAttempts = 20
+
+type QuizRoundID string
+
+// QuizRound は何切る問題の1問。出題したユーザーだけが1回だけ回答できる
+type QuizRound struct {
+	id         QuizRoundID
+	guildID    discordid.GuildID
+	userID     discordid.UserID
+	hand       Hand
+	createdAt  time.Time
+	discard    Kind
+	points     int
+	correct    bool
+	answeredAt time.Time
+}
+
+func (r *QuizRound) ID() QuizRoundID {
+	return r.id
+}
+
+func (r *QuizRound) GuildID() discordid.GuildID {
+	return r.guildID
+}
+
+// UserID は出題したユーザー。このユーザーだけが回答できる
+func (r *QuizRound) UserID() discordid.UserID {
+	return r.userID
+}
+
+// Hand は出題した 14 枚の手牌
+func (r *QuizRound) Hand() Hand {
+	return r.hand
+}
+
+func (r *QuizRound) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Discard は回答した打牌。未回答なら意味を持たない
+func (r *QuizRound) Discard() Kind {
+	return r.discard
+}
+
+// Points は回答で得た得点。未回答なら 0
+func (r *QuizRound) Points() int {
+	return r.points
+}
+
+// Correct は最善の打牌を選んだかどうか
+func (r *QuizRound) Correct() bool {
+	return r.correct
+}
+
+// AnsweredAt は回答した時刻。未回答ならゼロ値
+func (r *QuizRound) AnsweredAt() time.Time {
+	return r.answeredAt
+}
+
+func (r *QuizRound) Answered() bool {
+	return !r.answeredAt.IsZero()
+}
+
+// QuizResult は回答の採点結果
+type QuizResult struct {
+	// Chosen は回答した打牌と、打牌した後の向聴数と有効牌
+	Chosen Discard
+	// Best は最善の打牌。向聴数と有効牌の枚数が同じ候補が複数あればすべて含む
+	Best    []Discard
+	Points  int
+	Correct bool
+}
+
+// Answer は userID の回答を採点し、結果を記録する
+func (r *QuizRound) Answer(userID discordid.UserID, kind Kind, now time.Time) (QuizResult, error) {
+	if userID != r.userID {
+		return QuizResult{}, ErrNotQuizPlayer
+	}
+	if r.Answered() {
+		return QuizResult{}, ErrAlreadyAnswered
+	}
+
+	analysis, err := Analyze(r.hand)
+	if err != nil {
+		return QuizResult{}, err
+	}
+	result, err := ScoreDiscard(analysis, kind)
+	if err != nil {
+		return QuizResult{}, err
+	}
+
+	r.discard = kind
+	r.points = result.Points
+	r.correct = result.Correct
+	r.answeredAt = now
+	return result, nil
+}
+
+// ScoreDiscard は 3n+2 枚の手牌の分析結果に対して kind を打牌したときの得点を求める
+// 最善と同じ向聴数と有効牌の枚数なら QuizFullPoints、向聴数だけが同じなら有効牌の枚数の割合に応じて
+// QuizPartialPoints まで、向聴数が戻るなら 0 点とする
+func ScoreDiscard(analysis Analysis, kind Kind) (QuizResult, error) {
+	if len(analysis.Discards) == 0 {
+		return QuizResult{}, fmt.Errorf("%w: %d tiles", ErrInvalidHandSize, len(analysis.Hand))
+	}
+
+	best := analysis.Discards[0]
+	result := QuizResult{}
+	found := false
+	for _, discard := range analysis.Discards {
+		if discard.Shanten == best.Shanten && discard.UkeireCount == best.UkeireCount {
+			result.Best = append(result.Best, discard)
+		}
+		if discard.Kind == kind {
+			result.Chosen = discard
+			found = true
+		}
+	}
+	if !found {
+		return QuizResult{}, ErrNotInHand
+	}
+
+	switch {
+	case result.Chosen.Shanten != best.Shanten:
+		result.Points = 0
+	case result.Chosen.UkeireCount == best.UkeireCount:
+		result.Points = QuizFullPoints
+		result.Correct = true
+	default:
+		result.Points = QuizPartialPoints * result.Chosen.UkeireCount / best.UkeireCount
+	}
+	return result, nil
+}
+
+// DealQuizHand は何切る問題の 14 枚の手牌を配る
+// 打牌後の向聴数が QuizMaxShanten を超える手牌は配り直すが、quizDealAttempts 回で見つからなければ最後の手牌を返す
+func DealQuizHand(rng *rand.Rand) (Hand, error) {
+	options := DealOptions{Tiles: DealerHandSize, RedFives: true}
+	var hand Hand
+	for range quizDealAttempts {
+		var err error
+		hand, err = Deal(rng, options)
+		if err != nil {
+			return nil, err
+		}
+		counts := hand.Counts()
+		// 14 枚の向聴数は最善の打牌をした後の向聴数と等しい
+		if CalculateShanten(counts).Min() <= QuizMaxShanten {
+			break
+		}
+	}
+	return hand, nil
+}
+
+func NewQuizRound(guildID discordid.GuildID, userID discordid.UserID, hand Hand, now time.Time) (*QuizRound, error) {
+	return RebuildQuizRound(QuizRoundID(uuid.New().String()), guildID, userID, hand, now, 0, 0, false, time.Time{})
+}
+
+func RebuildQuizRound(id QuizRoundID, guildID discordid.GuildID, userID discordid.UserID, hand Hand, createdAt time.Time, discard Kind, points int, correct bool, answeredAt time.Time) (*QuizRound, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if len(hand) != DealerHandSize {
+		return nil, fmt.Errorf("%w: %d tiles", ErrInvalidHandSize, len(hand))
+	}
+	if discard < 0 || discard >= NumKinds {
+		return nil, ErrInvalidTile
+	}
+	return &QuizRound{
+		id:         id,
+		guildID:    guildID,
+		userID:     userID,
+		hand:       hand,
+		createdAt:  createdAt,
+		discard:    discard,
+		points:     points,
+		correct:    correct,
+		answeredAt: answeredAt,
+	}, nil
+}
+
+// QuizStanding はギルドでのユーザーの何切る問題の成績
+type QuizStanding struct {
+	UserID discordid.UserID
+	Points int
+	// Answered は回答した問題の数、Correct はそのうち最善の打牌を選んだ数
+	Answered int
+	Correct  int
+}
+
+// Accuracy は回答した問題のうち最善の打牌を選んだ割合
+func (s QuizStanding) Accuracy() float64 {
+	if s.Answered == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Answered)
+}
@@ -0,0 +1,110 @@
+package mahjong
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+func mustKind(t *testing.T, notation string) Kind {
+	t.Helper()
+	return mustParse(t, notation)[0].Kind
+}
+
+func TestScoreDiscard(t *testing.T) {
+	tests := []struct {
+		name        string
+		notation    string
+		discard     string
+		wantPoints  int
+		wantCorrect bool
+		wantBest    int
+	}{
+		{name: "only tenpai discard", notation: "123m456p789s1122z3z", discard: "3z", wantPoints: QuizFullPoints, wantCorrect: true, wantBest: 1},
+		{name: "backward in shanten", notation: "123m456p789s1122z3z", discard: "1m", wantPoints: 0, wantBest: 1},
+		{name: "one of several best", notation: "234567m456p78s11z9s", discard: "7m", wantPoints: QuizFullPoints, wantCorrect: true, wantBest: 2},
+		// 有効牌 8 枚 / 最善 11 枚
+		{name: "fewer ukeire", notation: "234567m456p78s11z9s", discard: "4m", wantPoints: 4, wantBest: 2},
+		// 有効牌 3 枚 / 最善 11 枚
+		{name: "much fewer ukeire", notation: "234567m456p78s11z9s", discard: "1z", wantPoints: 1, wantBest: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis, err := Analyze(mustParse(t, tt.notation))
+			if err != nil {
+				t.Fatalf("Analyze: %v", err)
+			}
+			got, err := ScoreDiscard(analysis, mustKind(t, tt.discard))
+			if err != nil {
+				t.Fatalf("ScoreDiscard: %v", err)
+			}
+			if got.Points != tt.wantPoints || got.Correct != tt.wantCorrect || len(got.Best) != tt.wantBest {
+				t.Errorf("ScoreDiscard(%s, %s) = points %d correct %v best %d, want %d %v %d",
+					tt.notation, tt.discard, got.Points, got.Correct, len(got.Best), tt.wantPoints, tt.wantCorrect, tt.wantBest)
+			}
+			if got.Chosen.Kind != mustKind(t, tt.discard) {
+				t.Errorf("Chosen = %s, want %s", got.Chosen.Kind, tt.discard)
+			}
+		})
+	}
+}
+
+func TestScoreDiscard_NotInHand(t *testing.T) {
+	analysis, err := Analyze(mustParse(t, "123m456p789s1122z3z"))
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if _, err := ScoreDiscard(analysis, mustKind(t, "5z")); !errors.Is(err, ErrNotInHand) {
+		t.Errorf("err = %v, want ErrNotInHand", err)
+	}
+}
+
+func TestQuizRound_Answer(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	player := discordid.UserID("player")
+	round, err := NewQuizRound("guild", player, mustParse(t, "123m456p789s1122z3z"), now)
+	if err != nil {
+		t.Fatalf("NewQuizRound: %v", err)
+	}
+
+	if _, err := round.Answer("someone", mustKind(t, "3z"), now); !errors.Is(err, ErrNotQuizPlayer) {
+		t.Errorf("answer by another user: err = %v, want ErrNotQuizPlayer", err)
+	}
+	if round.Answered() {
+		t.Fatal("round is answered by another user")
+	}
+
+	result, err := round.Answer(player, mustKind(t, "3z"), now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Answer: %v", err)
+	}
+	if !result.Correct || round.Points() != QuizFullPoints || !round.Correct() || !round.AnsweredAt().Equal(now.Add(time.Minute)) {
+		t.Errorf("round after answer: points %d correct %v answeredAt %v", round.Points(), round.Correct(), round.AnsweredAt())
+	}
+
+	if _, err := round.Answer(player, mustKind(t, "1m"), now); !errors.Is(err, ErrAlreadyAnswered) {
+		t.Errorf("second answer: err = %v, want ErrAlreadyAnswered", err)
+	}
+}
+
+func TestNewQuizRound_InvalidHand(t *testing.T) {
+	if _, err := NewQuizRound("guild", "player", mustParse(t, "123m456p789s1122z"), time.Now()); !errors.Is(err, ErrInvalidHandSize) {
+		t.Errorf("13 tiles: err = %v, want ErrInvalidHandSize", err)
+	}
+}
+
+func TestDealQuizHand(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		hand, err := DealQuizHand(rng)
+		if err != nil {
+			t.Fatalf("DealQuizHand: %v", err)
+		}
+		if len(hand) != DealerHandSize {
+			t.Fatalf("len(hand) = %d, want %d", len(hand), DealerHandSize)
+		}
+	}
+}
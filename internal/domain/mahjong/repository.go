@@ -1,8 +1,43 @@
 package mahjong
 
-import "context"
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
 
 // MahjongRepository はランダムな麻雀配牌取得のポートインターフェース
 type MahjongRepository interface {
-	FetchRandomStartingHand(ctx context.Context) (*MahjongStartingHand, error)
+	// FetchRandomStartingHand は配牌を返す。options に対応しない実装は options を無視してよい
+	FetchRandomStartingHand(ctx context.Context, options DealOptions) (*MahjongStartingHand, error)
+	// SupportsDealOptions は FetchRandomStartingHand が options の条件に従うかを返す
+	SupportsDealOptions() bool
+}
+
+// QuizRepository は何切る問題の永続化のポートインターフェース
+type QuizRepository interface {
+	FindByID(ctx context.Context, id QuizRoundID) (*QuizRound, error)
+	Save(ctx context.Context, round *QuizRound) error
+	// Ranking はギルドで回答した問題の成績を得点の高い順に最大 limit 件返す
+	Ranking(ctx context.Context, guildID discordid.GuildID, limit int) ([]QuizStanding, error)
+}
+
+type QuizRepositories interface {
+	QuizRound(tx db.Tx) QuizRepository
+}
+
+// TableRepository は対局の永続化のポートインターフェース
+type TableRepository interface {
+	FindByID(ctx context.Context, id TableID) (*Table, error)
+	// FindPlayingByChannel はチャンネルで進行中の対局を返す
+	FindPlayingByChannel(ctx context.Context, guildID discordid.GuildID, channelID discordid.TextChannelID) (*Table, error)
+	// FindFinishedByGuild はギルドで終えた対局をすべて返す
+	FindFinishedByGuild(ctx context.Context, guildID discordid.GuildID) ([]*Table, error)
+	// Save は対局と記録した局の結果を保存する
+	Save(ctx context.Context, table *Table) error
+}
+
+type TableRepositories interface {
+	Table(tx db.Tx) TableRepository
 }
@@ -0,0 +1,298 @@
+package mahjong
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxDoraIndicators はドラ表示牌・裏ドラ表示牌のそれぞれの最大枚数（カンドラを含む）
+const MaxDoraIndicators = 5
+
+// WinContext は和了の状況
+// 手牌はすべて門前として扱い、鳴きと槓子には対応しない
+type WinContext struct {
+	// Tsumo はツモ和了かどうか。false ならロン和了
+	Tsumo   bool
+	Riichi  bool
+	Ippatsu bool
+	// SeatWind は自風、RoundWind は場風（東〜北）。自風が東なら親
+	SeatWind  Kind
+	RoundWind Kind
+	// DoraIndicators はドラ表示牌、UraIndicators は裏ドラ表示牌（立直した場合だけ数える）
+	DoraIndicators []Tile
+	UraIndicators  []Tile
+}
+
+// Dealer は親の和了かどうかを返す
+func (wc WinContext) Dealer() bool {
+	return wc.SeatWind == East
+}
+
+// Limit は満貫以上の点数の区分
+type Limit int
+
+const (
+	LimitNone Limit = iota
+	LimitMangan
+	LimitHaneman
+	LimitBaiman
+	LimitSanbaiman
+	LimitYakuman
+)
+
+// Payment は和了した人が受け取る点数の支払い方
+type Payment struct {
+	// Ron は放銃した人が払う点
+	Ron int
+	// TsumoDealer はツモ和了で親が払う点。親の和了では 0
+	TsumoDealer int
+	// TsumoNonDealer はツモ和了で子が1人あたり払う点
+	TsumoNonDealer int
+}
+
+// TsumoTotal はツモ和了で受け取る点の合計を返す
+func (p Payment) TsumoTotal() int {
+	if p.TsumoDealer == 0 {
+		return 3 * p.TsumoNonDealer
+	}
+	return p.TsumoDealer + 2*p.TsumoNonDealer
+}
+
+// Score は和了の点数計算の結果
+type Score struct {
+	Yaku []Yaku
+	// Han はドラを含む翻数。役満では 0
+	Han int
+	Fu  int
+	// Dora・RedDora・UraDora はドラ・赤ドラ・裏ドラの枚数
+	Dora    int
+	RedDora int
+	UraDora int
+	// Yakuman は役満の数。数え役満は含まない
+	Yakuman int
+	Limit   Limit
+	// BasicPoints は基本点（符 × 2^(翻 + 2)、満貫以上は区分ごとの点）
+	BasicPoints int
+	// DealerPayment は親が和了した場合、NonDealerPayment は子が和了した場合の支払い
+	DealerPayment    Payment
+	NonDealerPayment Payment
+	Dealer           bool
+	Tsumo            bool
+}
+
+// Payment は和了した人の支払い方を返す
+func (s Score) Payment() Payment {
+	if s.Dealer {
+		return s.DealerPayment
+	}
+	return s.NonDealerPayment
+}
+
+// Points は和了した人が受け取る点の合計を返す
+func (s Score) Points() int {
+	if s.Tsumo {
+		return s.Payment().TsumoTotal()
+	}
+	return s.Payment().Ron
+}
+
+// CalculateScore は 14 枚の門前の和了形の役・翻・符と支払いを求める
+// 手牌の最後の牌を和了牌とし、複数の面子の分け方がある場合は最も高い点数になるものを選ぶ
+func CalculateScore(hand Hand, wc WinContext) (Score, error) {
+	if len(hand) != DealerHandSize {
+		return Score{}, fmt.Errorf("%w: %d tiles", ErrInvalidHandSize, len(hand))
+	}
+	if err := validateWinContext(hand, wc); err != nil {
+		return Score{}, err
+	}
+
+	counts := hand.Counts()
+	shanten := CalculateShanten(counts)
+	if shanten.Min() != -1 {
+		return Score{}, ErrNotWinningHand
+	}
+	winning := hand[len(hand)-1].Kind
+
+	var candidates []Score
+	base := handYakuman(counts)
+	if shanten.ThirteenOrphans == -1 {
+		candidates = append(candidates, newScore(append(slices.Clone(base), yakuman(YakuKokushi)), 0, wc))
+	}
+	if shanten.SevenPairs == -1 {
+		yakus := base
+		if len(yakus) == 0 {
+			yakus = append(handYaku(counts, wc), yaku(YakuChiitoitsu, 2))
+		}
+		candidates = append(candidates, newScore(yakus, 25, wc))
+	}
+	for _, f := range standardForms(counts, winning, wc.Tsumo) {
+		yakus := append(slices.Clone(base), formYakuman(f)...)
+		fu := 0
+		if len(yakus) == 0 {
+			yakus = append(handYaku(counts, wc), formYaku(f, wc)...)
+			pinfu := slices.ContainsFunc(yakus, func(y Yaku) bool { return y.ID == YakuPinfu })
+			fu = formFu(f, wc, pinfu)
+		}
+		candidates = append(candidates, newScore(yakus, fu, wc))
+	}
+
+	var (
+		best  Score
+		found bool
+	)
+	for _, score := range candidates {
+		if len(score.Yaku) == 0 {
+			continue
+		}
+		if score.Yakuman == 0 {
+			score.addDora(hand, wc)
+			score.settle()
+		}
+		if !found || betterScore(score, best) {
+			best, found = score, true
+		}
+	}
+	if !found {
+		return Score{}, ErrNoYaku
+	}
+	return best, nil
+}
+
+// newScore は役と符から点数を求める。役満なら支払いまで決める
+func newScore(yakus []Yaku, fu int, wc WinContext) Score {
+	score := Score{Yaku: yakus, Fu: fu, Dealer: wc.Dealer(), Tsumo: wc.Tsumo}
+	for _, y := range yakus {
+		if y.Yakuman {
+			score.Yakuman++
+		}
+		score.Han += y.Han
+	}
+	if score.Yakuman > 0 {
+		score.Han, score.Fu = 0, 0
+		score.settle()
+	}
+	return score
+}
+
+// addDora はドラ・赤ドラ・裏ドラを数えて翻数に加える
+func (s *Score) addDora(hand Hand, wc WinContext) {
+	counts := hand.Counts()
+	for _, indicator := range wc.DoraIndicators {
+		s.Dora += counts[DoraFromIndicator(indicator.Kind)]
+	}
+	if wc.Riichi {
+		for _, indicator := range wc.UraIndicators {
+			s.UraDora += counts[DoraFromIndicator(indicator.Kind)]
+		}
+	}
+	for _, tile := range hand {
+		if tile.Red {
+			s.RedDora++
+		}
+	}
+	s.Han += s.Dora + s.RedDora + s.UraDora
+}
+
+// settle は翻数と符から基本点と支払いを決める
+func (s *Score) settle() {
+	switch {
+	case s.Yakuman > 0:
+		s.Limit = LimitYakuman
+		s.BasicPoints = 8000 * s.Yakuman
+	case s.Han >= 13:
+		// 数え役満
+		s.Limit = LimitYakuman
+		s.BasicPoints = 8000
+	case s.Han >= 11:
+		s.Limit = LimitSanbaiman
+		s.BasicPoints = 6000
+	case s.Han >= 8:
+		s.Limit = LimitBaiman
+		s.BasicPoints = 4000
+	case s.Han >= 6:
+		s.Limit = LimitHaneman
+		s.BasicPoints = 3000
+	default:
+		s.BasicPoints = s.Fu << (s.Han + 2)
+		if s.Han >= 5 || s.BasicPoints > 2000 {
+			s.Limit = LimitMangan
+			s.BasicPoints = 2000
+		}
+	}
+
+	s.DealerPayment = Payment{
+		Ron:            roundUp100(6 * s.BasicPoints),
+		TsumoNonDealer: roundUp100(2 * s.BasicPoints),
+	}
+	s.NonDealerPayment = Payment{
+		Ron:            roundUp100(4 * s.BasicPoints),
+		TsumoDealer:    roundUp100(2 * s.BasicPoints),
+		TsumoNonDealer: roundUp100(s.BasicPoints),
+	}
+}
+
+// betterScore は a が b より高い点数かを返す。同じ点数なら翻数、符の順に比べる
+func betterScore(a, b Score) bool {
+	if a.Points() != b.Points() {
+		return a.Points() > b.Points()
+	}
+	if a.Han != b.Han {
+		return a.Han > b.Han
+	}
+	return a.Fu > b.Fu
+}
+
+func roundUp100(points int) int {
+	return (points + 99) / 100 * 100
+}
+
+// DoraFromIndicator はドラ表示牌の次の牌（ドラ）を返す
+// 数牌は 9 の次が 1、風牌は東南西北の順、三元牌は白發中の順に巡る
+func DoraFromIndicator(indicator Kind) Kind {
+	switch {
+	case indicator >= Haku:
+		return Haku + (indicator-Haku+1)%3
+	case indicator.IsHonor():
+		return East + (indicator-East+1)%4
+	default:
+		base := Kind(int(indicator.Suit()) * 9)
+		return base + (indicator-base+1)%9
+	}
+}
+
+// validateWinContext は和了の状況が手牌と矛盾しないかを確かめる
+func validateWinContext(hand Hand, wc WinContext) error {
+	if wc.SeatWind < East || wc.SeatWind > North || wc.RoundWind < East || wc.RoundWind > North {
+		return fmt.Errorf("%w: winds must be East to North", ErrInvalidWinContext)
+	}
+	if wc.Ippatsu && !wc.Riichi {
+		return fmt.Errorf("%w: ippatsu without riichi", ErrInvalidWinContext)
+	}
+	if len(wc.UraIndicators) > 0 && !wc.Riichi {
+		return fmt.Errorf("%w: ura dora without riichi", ErrInvalidWinContext)
+	}
+	if len(wc.DoraIndicators) > MaxDoraIndicators || len(wc.UraIndicators) > MaxDoraIndicators {
+		return fmt.Errorf("%w: more than %d indicators", ErrInvalidWinContext, MaxDoraIndicators)
+	}
+
+	// 表示牌も含めて同じ牌は 4 枚、赤ドラは各色 1 枚まで
+	visible := slices.Concat(hand, wc.DoraIndicators, wc.UraIndicators)
+	counts := Hand(visible).Counts()
+	var reds [3]int
+	for _, tile := range visible {
+		if tile.Red {
+			reds[tile.Kind.Suit()]++
+		}
+	}
+	for kind, count := range counts {
+		if count > 4 {
+			return fmt.Errorf("%w: more than 4 of %s", ErrInvalidWinContext, Kind(kind))
+		}
+	}
+	for suit, count := range reds {
+		if count > 1 {
+			return fmt.Errorf("%w: more than one red 5%c", ErrInvalidWinContext, Suit(suit).Letter())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package mahjong
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCalculateScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		context  WinContext
+		// dora・ura は表示牌の表記
+		dora, ura  string
+		wantYaku   []YakuID
+		wantHan    int
+		wantFu     int
+		wantLimit  Limit
+		wantPoints int
+		wantPay    Payment
+	}{
+		{
+			name:       "riichi pinfu tsumo",
+			notation:   "234m56799p34567s8s",
+			context:    WinContext{Tsumo: true, Riichi: true, SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuRiichi, YakuMenzenTsumo, YakuPinfu},
+			wantHan:    3,
+			wantFu:     20,
+			wantPoints: 2700,
+			wantPay:    Payment{Ron: 2600, TsumoDealer: 1300, TsumoNonDealer: 700},
+		},
+		{
+			name:       "pinfu tanyao with dora",
+			notation:   "234m567p234s88s67s5s",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			dora:       "7s",
+			wantYaku:   []YakuID{YakuTanyao, YakuPinfu},
+			wantHan:    4,
+			wantFu:     30,
+			wantPoints: 7700,
+			wantPay:    Payment{Ron: 7700, TsumoDealer: 3900, TsumoNonDealer: 2000},
+		},
+		{
+			name:       "dealer 4 han 30 fu is not rounded up to mangan",
+			notation:   "234m567p234s88s67s5s",
+			context:    WinContext{SeatWind: East, RoundWind: East},
+			dora:       "7s",
+			wantYaku:   []YakuID{YakuTanyao, YakuPinfu},
+			wantHan:    4,
+			wantFu:     30,
+			wantPoints: 11600,
+			wantPay:    Payment{Ron: 11600, TsumoNonDealer: 3900},
+		},
+		{
+			name:       "mangan",
+			notation:   "234m567p234s88s67s5s",
+			context:    WinContext{Riichi: true, SeatWind: South, RoundWind: East},
+			dora:       "7s",
+			wantYaku:   []YakuID{YakuRiichi, YakuTanyao, YakuPinfu},
+			wantHan:    5,
+			wantFu:     30,
+			wantLimit:  LimitMangan,
+			wantPoints: 8000,
+			wantPay:    Payment{Ron: 8000, TsumoDealer: 4000, TsumoNonDealer: 2000},
+		},
+		{
+			name:       "red five and ura dora",
+			notation:   "234m067p99p34567s8s",
+			context:    WinContext{Riichi: true, SeatWind: South, RoundWind: East},
+			ura:        "1m",
+			wantYaku:   []YakuID{YakuRiichi, YakuPinfu},
+			wantHan:    4,
+			wantFu:     30,
+			wantPoints: 7700,
+		},
+		{
+			name:       "seven pairs",
+			notation:   "1133m5577p99s112z2z",
+			context:    WinContext{Tsumo: true, Riichi: true, SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuRiichi, YakuMenzenTsumo, YakuChiitoitsu},
+			wantHan:    4,
+			wantFu:     25,
+			wantPoints: 6400,
+			wantPay:    Payment{Ron: 6400, TsumoDealer: 3200, TsumoNonDealer: 1600},
+		},
+		{
+			name:       "closed wait and concealed dragon triplet",
+			notation:   "13m99m456p789s666z2m",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuHatsu},
+			wantHan:    1,
+			wantFu:     40,
+			wantPoints: 1300,
+		},
+		{
+			name:       "triplet completed by ron is open",
+			notation:   "111m999p55s234s77z7z",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuChun},
+			wantHan:    1,
+			wantFu:     50,
+			wantPoints: 1600,
+		},
+		{
+			name:       "three concealed triplets by tsumo",
+			notation:   "111m999p55s234s77z7z",
+			context:    WinContext{Tsumo: true, SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuMenzenTsumo, YakuChun, YakuSanankou},
+			wantHan:    4,
+			wantFu:     50,
+			wantLimit:  LimitMangan,
+			wantPoints: 8000,
+			wantPay:    Payment{Ron: 8000, TsumoDealer: 4000, TsumoNonDealer: 2000},
+		},
+		{
+			name:       "double east",
+			notation:   "11z234m567p789s55p1z",
+			context:    WinContext{SeatWind: East, RoundWind: East},
+			wantYaku:   []YakuID{YakuSeatWind, YakuRoundWind},
+			wantHan:    2,
+			wantFu:     40,
+			wantPoints: 3900,
+		},
+		{
+			name:       "pure straight",
+			notation:   "12345678m234p55s9m",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuPinfu, YakuIttsu},
+			wantHan:    3,
+			wantFu:     30,
+			wantPoints: 3900,
+		},
+		{
+			name:       "pure double sequence",
+			notation:   "223344m567p66s34s5s",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuTanyao, YakuPinfu, YakuIipeikou},
+			wantHan:    3,
+			wantFu:     30,
+			wantPoints: 3900,
+		},
+		{
+			name:       "twice pure double sequence beats seven pairs",
+			notation:   "223344m667788p55s",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuTanyao, YakuRyanpeikou},
+			wantHan:    4,
+			wantFu:     40,
+			wantLimit:  LimitMangan,
+			wantPoints: 8000,
+		},
+		{
+			name:       "triple triplets and all triplets by ron",
+			notation:   "11m222p333s444z55z1m",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuSanankou, YakuToitoi},
+			wantHan:    4,
+			wantFu:     60,
+			wantLimit:  LimitMangan,
+			wantPoints: 8000,
+		},
+		{
+			name:       "half flush straight and haku",
+			notation:   "123456789m11z55z5z",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuHonitsu, YakuHaku, YakuIttsu},
+			wantHan:    6,
+			wantFu:     40,
+			wantLimit:  LimitHaneman,
+			wantPoints: 12000,
+		},
+		{
+			name:       "full flush",
+			notation:   "111m234m567m99m88m8m",
+			context:    WinContext{Tsumo: true, Riichi: true, SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuRiichi, YakuMenzenTsumo, YakuChinitsu},
+			wantHan:    8,
+			wantFu:     40,
+			wantLimit:  LimitBaiman,
+			wantPoints: 16000,
+			wantPay:    Payment{Ron: 16000, TsumoDealer: 8000, TsumoNonDealer: 4000},
+		},
+		{
+			name:       "counted yakuman",
+			notation:   "111m234m567m99m88m8m",
+			context:    WinContext{Tsumo: true, Riichi: true, Ippatsu: true, SeatWind: South, RoundWind: East},
+			dora:       "7m1m",
+			wantYaku:   []YakuID{YakuRiichi, YakuIppatsu, YakuMenzenTsumo, YakuChinitsu},
+			wantHan:    13,
+			wantFu:     40,
+			wantLimit:  LimitYakuman,
+			wantPoints: 32000,
+		},
+		{
+			name:       "thirteen orphans",
+			notation:   "19m19p19s1234567z1m",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuKokushi},
+			wantLimit:  LimitYakuman,
+			wantPoints: 32000,
+		},
+		{
+			name:       "four concealed triplets",
+			notation:   "11m222p333s444z55z1m",
+			context:    WinContext{Tsumo: true, SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuSuuankou},
+			wantLimit:  LimitYakuman,
+			wantPoints: 32000,
+			wantPay:    Payment{Ron: 32000, TsumoDealer: 16000, TsumoNonDealer: 8000},
+		},
+		{
+			name:       "big three dragons for the dealer",
+			notation:   "555666z77z123m99p7z",
+			context:    WinContext{SeatWind: East, RoundWind: East},
+			wantYaku:   []YakuID{YakuDaisangen},
+			wantLimit:  LimitYakuman,
+			wantPoints: 48000,
+		},
+		{
+			name:       "all honors and four concealed triplets",
+			notation:   "111222333z44z55z5z",
+			context:    WinContext{Tsumo: true, SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuTsuuiisou, YakuSuuankou, YakuShousuushii},
+			wantLimit:  LimitYakuman,
+			wantPoints: 96000,
+		},
+		{
+			name:       "nine gates",
+			notation:   "1112345678999p5p",
+			context:    WinContext{SeatWind: South, RoundWind: East},
+			wantYaku:   []YakuID{YakuChuuren},
+			wantLimit:  LimitYakuman,
+			wantPoints: 32000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wc := tt.context
+			if tt.dora != "" {
+				wc.DoraIndicators = mustParse(t, tt.dora)
+			}
+			if tt.ura != "" {
+				wc.UraIndicators = mustParse(t, tt.ura)
+			}
+			got, err := CalculateScore(mustParse(t, tt.notation), wc)
+			if err != nil {
+				t.Fatalf("CalculateScore(%s): %v", tt.notation, err)
+			}
+
+			ids := make([]YakuID, len(got.Yaku))
+			for n, y := range got.Yaku {
+				ids[n] = y.ID
+			}
+			if !slices.Equal(ids, tt.wantYaku) {
+				t.Errorf("yaku = %v, want %v", ids, tt.wantYaku)
+			}
+			if got.Han != tt.wantHan || got.Fu != tt.wantFu || got.Limit != tt.wantLimit {
+				t.Errorf("han %d fu %d limit %d, want %d %d %d", got.Han, got.Fu, got.Limit, tt.wantHan, tt.wantFu, tt.wantLimit)
+			}
+			if got.Points() != tt.wantPoints {
+				t.Errorf("Points() = %d, want %d", got.Points(), tt.wantPoints)
+			}
+			if tt.wantPay != (Payment{}) && got.Payment() != tt.wantPay {
+				t.Errorf("Payment() = %+v, want %+v", got.Payment(), tt.wantPay)
+			}
+		})
+	}
+}
+
+func TestCalculateScore_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		context  WinContext
+		dora     string
+		want     error
+	}{
+		{name: "no yaku", notation: "123m456p789s234m5z5z", context: WinContext{SeatWind: South, RoundWind: East}, want: ErrNoYaku},
+		{name: "dora is not a yaku", notation: "123m456p789s234m5z5z", context: WinContext{SeatWind: South, RoundWind: East}, dora: "4z", want: ErrNoYaku},
+		{name: "not a winning hand", notation: "123m456p789s23456m", context: WinContext{SeatWind: South, RoundWind: East}, want: ErrNotWinningHand},
+		{name: "13 tiles", notation: "123m456p789s2345m", context: WinContext{SeatWind: South, RoundWind: East}, want: ErrInvalidHandSize},
+		{name: "ippatsu without riichi", notation: "234m56799p34567s8s", context: WinContext{Ippatsu: true, SeatWind: South, RoundWind: East}, want: ErrInvalidWinContext},
+		{name: "ura dora without riichi", notation: "234m56799p34567s8s", context: WinContext{SeatWind: South, RoundWind: East, UraIndicators: Hand{{Kind: East}}}, want: ErrInvalidWinContext},
+		{name: "seat wind is not a wind", notation: "234m56799p34567s8s", context: WinContext{SeatWind: Haku, RoundWind: East}, want: ErrInvalidWinContext},
+		{name: "fifth copy in indicators", notation: "234m56799p34567s8s", context: WinContext{SeatWind: South, RoundWind: East}, dora: "999p", want: ErrInvalidWinContext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wc := tt.context
+			if tt.dora != "" {
+				wc.DoraIndicators = mustParse(t, tt.dora)
+			}
+			if _, err := CalculateScore(mustParse(t, tt.notation), wc); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoraFromIndicator(t *testing.T) {
+	tests := []struct{ indicator, want string }{
+		{"5p", "6p"},
+		{"9m", "1m"},
+		{"9s", "1s"},
+		{"4z", "1z"},
+		{"2z", "3z"},
+		{"7z", "5z"},
+		{"5z", "6z"},
+	}
+	for _, tt := range tests {
+		if got := DoraFromIndicator(mustKind(t, tt.indicator)); got != mustKind(t, tt.want) {
+			t.Errorf("DoraFromIndicator(%s) = %s, want %s", tt.indicator, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package mahjong
+
+// Shanten は手牌の形ごとの向聴数
+// -1 は和了形、0 は聴牌を表す
+type Shanten struct {
+	// Standard は4面子1雀頭の一般形の向聴数
+	Standard int
+	// SevenPairs は七対子の向聴数。13 枚未満の手牌では成り立たないため NotApplicable
+	SevenPairs int
+	// ThirteenOrphans は国士無双の向聴数。13 枚未満の手牌では成り立たないため NotApplicable
+	ThirteenOrphans int
+}
+
+// NotApplicable はその形の向聴数を数えられないことを表す
+const NotApplicable = 99
+
+// Min は最も小さい向聴数を返す
+func (s Shanten) Min() int {
+	return min(s.Standard, s.SevenPairs, s.ThirteenOrphans)
+}
+
+// CalculateShanten は牌の種類ごとの枚数から向聴数を求める
+// 枚数の合計は 3n+1 か 3n+2（14 枚以下）である必要がある
+func CalculateShanten(counts [NumKinds]int) Shanten {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	s := Shanten{
+		Standard:        standardShanten(counts, total/3),
+		SevenPairs:      NotApplicable,
+		ThirteenOrphans: NotApplicable,
+	}
+	if total >= HandSize {
+		s.SevenPairs = sevenPairsShanten(counts)
+		s.ThirteenOrphans = thirteenOrphansShanten(counts)
+	}
+	return s
+}
+
+// standardShanten は面子・搭子・雀頭の組み合わせを全て試して一般形の向聴数を求める
+// needed は作る面子の数（13・14 枚なら 4）で、向聴数は 2×needed − 2×面子 − 搭子 − 雀頭 となる
+// ただし搭子は面子と合わせて needed 個までしか数えない
+func standardShanten(counts [NumKinds]int, needed int) int {
+	s := &shantenSearch{counts: counts, needed: needed, best: 2 * needed}
+	s.search(0, 0, 0, false)
+	return s.best
+}
+
+type shantenSearch struct {
+	counts [NumKinds]int
+	needed int
+	best   int
+}
+
+func (s *shantenSearch) search(kind Kind, melds, partials int, pair bool) {
+	for kind < NumKinds && s.counts[kind] == 0 {
+		kind++
+	}
+	if kind == NumKinds {
+		shanten := 2*s.needed - 2*melds - min(partials, s.needed-melds)
+		if pair {
+			shanten--
+		}
+		s.best = min(s.best, shanten)
+		return
+	}
+
+	c := &s.counts
+	// 面子と搭子がそろっていれば、それ以上の搭子は向聴数を減らさない
+	canAddPartial := melds+partials < s.needed
+
+	// 面子（刻子・順子）
+	if c[kind] >= 3 {
+		c[kind] -= 3
+		s.search(kind, melds+1, partials, pair)
+		c[kind] += 3
+	}
+	if !kind.IsHonor() && kind.Number() <= 7 && c[kind+1] > 0 && c[kind+2] > 0 {
+		c[kind]--
+		c[kind+1]--
+		c[kind+2]--
+		s.search(kind, melds+1, partials, pair)
+		c[kind]++
+		c[kind+1]++
+		c[kind+2]++
+	}
+
+	// 雀頭と搭子（対子・両面・辺張・嵌張）
+	if c[kind] >= 2 {
+		c[kind] -= 2
+		if !pair {
+			s.search(kind, melds, partials, true)
+		}
+		if canAddPartial {
+			s.search(kind, melds, partials+1, pair)
+		}
+		c[kind] += 2
+	}
+	if canAddPartial && !kind.IsHonor() {
+		if kind.Number() <= 8 && c[kind+1] > 0 {
+			c[kind]--
+			c[kind+1]--
+			s.search(kind, melds, partials+1, pair)
+			c[kind]++
+			c[kind+1]++
+		}
+		if kind.Number() <= 7 && c[kind+2] > 0 {
+			c[kind]--
+			c[kind+2]--
+			s.search(kind, melds, partials+1, pair)
+			c[kind]++
+			c[kind+2]++
+		}
+	}
+
+	// 孤立牌として使わない
+	c[kind]--
+	s.search(kind, melds, partials, pair)
+	c[kind]++
+}
+
+// sevenPairsShanten は七対子の向聴数を求める
+// 同じ牌の4枚は2つの対子として数えないため、種類が足りなければその分だけ向聴数が増える
+func sevenPairsShanten(counts [NumKinds]int) int {
+	pairs, kinds := 0, 0
+	for _, count := range counts {
+		if count > 0 {
+			kinds++
+		}
+		if count >= 2 {
+			pairs++
+		}
+	}
+	return 6 - pairs + max(0, 7-kinds)
+}
+
+// thirteenOrphansShanten は国士無双の向聴数を求める
+func thirteenOrphansShanten(counts [NumKinds]int) int {
+	kinds, pair := 0, false
+	for kind := Kind(0); kind < NumKinds; kind++ {
+		if !kind.IsTerminalOrHonor() || counts[kind] == 0 {
+			continue
+		}
+		kinds++
+		if counts[kind] >= 2 {
+			pair = true
+		}
+	}
+	if pair {
+		return 12 - kinds
+	}
+	return 13 - kinds
+}
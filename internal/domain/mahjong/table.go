@@ -0,0 +1,533 @@
+package mahjong
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/google/uuid"
+)
+
+const (
+	MinTablePlayers = 3
+	MaxTablePlayers = 4
+	// RiichiDeposit は立直で供託する点
+	RiichiDeposit = 1000
+	// HonbaRon はロンで1本場ごとに加える点、HonbaTsumo はツモで1人あたり1本場ごとに加える点
+	HonbaRon   = 300
+	HonbaTsumo = 100
+	// NotenPenalty は流局時に聴牌していない人から聴牌した人へ移る点の合計（不聴罰符）
+	NotenPenalty = 3000
+)
+
+type TableID string
+
+// TableStatus は対局の状態
+type TableStatus string
+
+const (
+	TableStatusPlaying  TableStatus = "playing"
+	TableStatusFinished TableStatus = "finished"
+)
+
+// ParseTableStatus は識別子から TableStatus を復元する
+func ParseTableStatus(value string) (TableStatus, error) {
+	switch TableStatus(value) {
+	case TableStatusPlaying, TableStatusFinished:
+		return TableStatus(value), nil
+	default:
+		return "", ErrInvalidTableStatus
+	}
+}
+
+// TableRule は持ち点と順位点のルール
+type TableRule struct {
+	StartingPoints int
+	// ReturnPoints は返し（精算の基準点）。持ち点との差の合計がオカとしてトップに入る
+	ReturnPoints int
+	// Uma は順位ごとのウマ（点）。人数と同じ長さで合計は 0
+	Uma []int
+}
+
+// DefaultTableRule は人数に応じた既定のルールを返す
+// 4 人は 25000 点持ち 30000 点返しのウマ 10-20、3 人は 35000 点持ち 40000 点返しのウマ ±20
+func DefaultTableRule(players int) TableRule {
+	if players == MinTablePlayers {
+		return TableRule{StartingPoints: 35000, ReturnPoints: 40000, Uma: []int{20000, 0, -20000}}
+	}
+	return TableRule{StartingPoints: 25000, ReturnPoints: 30000, Uma: []int{20000, 10000, -10000, -20000}}
+}
+
+// oka はトップが受け取るオカを返す
+func (r TableRule) oka() int {
+	return (r.ReturnPoints - r.StartingPoints) * len(r.Uma)
+}
+
+// HandRecord は1局の結果。席は対局開始時の並び（起家から順に 0 始まり）で表す
+// 点は本場と供託を除いた和了の点で、本場と供託は対局の状態から計算する
+type HandRecord struct {
+	// Winner は和了した人の席。流局なら -1
+	Winner int
+	// Loser は放銃した人の席。ツモと流局なら -1
+	Loser int
+	// RonPoints はロン和了の点
+	RonPoints int
+	// TsumoNonDealer はツモ和了で子が1人あたり払う点、TsumoDealer は親が払う点（親のツモでは 0）
+	TsumoNonDealer int
+	TsumoDealer    int
+	// Riichi はこの局で立直した人の席
+	Riichi []int
+	// Tenpai は流局時に聴牌していた人の席
+	Tenpai []int
+}
+
+// Draw は流局かどうかを返す
+func (h HandRecord) Draw() bool {
+	return h.Winner < 0
+}
+
+// Tsumo はツモ和了かどうかを返す
+func (h HandRecord) Tsumo() bool {
+	return h.Winner >= 0 && h.Loser < 0
+}
+
+// Table は麻雀の対局。記録した局の結果から点数・親・本場・供託を計算する
+type Table struct {
+	id         TableID
+	guildID    discordid.GuildID
+	channelID  discordid.TextChannelID
+	players    []discordid.UserID
+	rule       TableRule
+	hands      []HandRecord
+	status     TableStatus
+	createdBy  discordid.UserID
+	createdAt  time.Time
+	finishedAt time.Time
+
+	// 以下は hands から計算する
+	points   []int
+	dealer   int
+	round    int
+	honba    int
+	deposits int
+}
+
+func (t *Table) ID() TableID {
+	return t.id
+}
+
+func (t *Table) GuildID() discordid.GuildID {
+	return t.guildID
+}
+
+// ChannelID は対局を記録するテキストチャンネル。1つのチャンネルで同時に進行できる対局は1つ
+func (t *Table) ChannelID() discordid.TextChannelID {
+	return t.channelID
+}
+
+// Players は起家から順に並べた参加者
+func (t *Table) Players() []discordid.UserID {
+	return t.players
+}
+
+func (t *Table) Rule() TableRule {
+	return t.rule
+}
+
+// Hands は記録した局の結果を古い順に返す
+func (t *Table) Hands() []HandRecord {
+	return t.hands
+}
+
+func (t *Table) Status() TableStatus {
+	return t.status
+}
+
+func (t *Table) CreatedBy() discordid.UserID {
+	return t.createdBy
+}
+
+func (t *Table) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// FinishedAt は対局を終えた時刻。進行中ならゼロ値
+func (t *Table) FinishedAt() time.Time {
+	return t.finishedAt
+}
+
+// Points は席ごとの現在の持ち点を返す
+func (t *Table) Points() []int {
+	return t.points
+}
+
+// Dealer は現在の親の席を返す
+func (t *Table) Dealer() int {
+	return t.dealer
+}
+
+// RoundWind は現在の場風を返す
+func (t *Table) RoundWind() Kind {
+	return East + Kind(t.round/len(t.players)%4)
+}
+
+// RoundNumber は場風の中での局の番号（東1局なら 1）を返す
+func (t *Table) RoundNumber() int {
+	return t.round%len(t.players) + 1
+}
+
+// Honba は現在の本場の数を返す
+func (t *Table) Honba() int {
+	return t.honba
+}
+
+// Deposits は場に残っている供託の立直棒の数を返す
+func (t *Table) Deposits() int {
+	return t.deposits
+}
+
+// Seat は参加者の席を返す。参加していなければ -1
+func (t *Table) Seat(userID discordid.UserID) int {
+	return slices.Index(t.players, userID)
+}
+
+// Record は局の結果を記録する。記録できるのは進行中の対局の参加者だけ
+func (t *Table) Record(userID discordid.UserID, hand HandRecord) error {
+	if err := t.checkPlaying(userID); err != nil {
+		return err
+	}
+	if err := t.apply(hand); err != nil {
+		return err
+	}
+	t.hands = append(t.hands, hand)
+	return nil
+}
+
+// Undo は最後に記録した局の結果を取り消す
+func (t *Table) Undo(userID discordid.UserID) error {
+	if err := t.checkPlaying(userID); err != nil {
+		return err
+	}
+	if len(t.hands) == 0 {
+		return ErrNoHandToUndo
+	}
+	return t.replay(t.hands[:len(t.hands)-1])
+}
+
+// Finish は対局を終える。残った供託は Results でトップに加える
+func (t *Table) Finish(userID discordid.UserID, now time.Time) error {
+	if err := t.checkPlaying(userID); err != nil {
+		return err
+	}
+	t.status = TableStatusFinished
+	t.finishedAt = now
+	return nil
+}
+
+func (t *Table) checkPlaying(userID discordid.UserID) error {
+	if t.status != TableStatusPlaying {
+		return ErrTableFinished
+	}
+	if t.Seat(userID) < 0 {
+		return ErrNotTablePlayer
+	}
+	return nil
+}
+
+// TableResult は参加者の順位と、ウマ・オカを含めた精算後の成績
+type TableResult struct {
+	Seat   int
+	UserID discordid.UserID
+	// Points は終了時の持ち点。トップは残った供託を受け取った後の点
+	Points int
+	// Placement は 1 始まりの順位。同点なら起家に近い席を上位とする
+	Placement int
+	// Score は (持ち点 − 返し) にウマと、トップならオカを加えた点。全員の合計は 0
+	Score int
+}
+
+// Results は現在の持ち点で精算した順位と成績を順位の順に返す
+func (t *Table) Results() []TableResult {
+	results := make([]TableResult, len(t.players))
+	for seat, userID := range t.players {
+		results[seat] = TableResult{Seat: seat, UserID: userID, Points: t.points[seat]}
+	}
+	slices.SortStableFunc(results, func(a, b TableResult) int {
+		return b.Points - a.Points
+	})
+	// 誰も和了せずに残った供託はトップが受け取る
+	results[0].Points += t.deposits * RiichiDeposit
+	for n := range results {
+		results[n].Placement = n + 1
+		results[n].Score = results[n].Points - t.rule.ReturnPoints + t.rule.Uma[n]
+	}
+	results[0].Score += t.rule.oka()
+	return results
+}
+
+// DealIns は席ごとの放銃の回数を返す
+func (t *Table) DealIns() []int {
+	dealIns := make([]int, len(t.players))
+	for _, hand := range t.hands {
+		if hand.Loser >= 0 {
+			dealIns[hand.Loser]++
+		}
+	}
+	return dealIns
+}
+
+// replay は持ち点から局の結果を順に適用し直す
+func (t *Table) replay(hands []HandRecord) error {
+	t.points = make([]int, len(t.players))
+	for seat := range t.points {
+		t.points[seat] = t.rule.StartingPoints
+	}
+	t.dealer, t.round, t.honba, t.deposits = 0, 0, 0, 0
+	for n, hand := range hands {
+		if err := t.apply(hand); err != nil {
+			return fmt.Errorf("hand %d: %w", n+1, err)
+		}
+	}
+	t.hands = slices.Clone(hands)
+	return nil
+}
+
+// apply は局の結果を持ち点・親・本場・供託に反映する
+// 検証に失敗した場合は何も変更しない
+func (t *Table) apply(hand HandRecord) error {
+	if err := t.validate(hand); err != nil {
+		return err
+	}
+
+	n := len(t.players)
+	delta := make([]int, n)
+	for _, seat := range hand.Riichi {
+		delta[seat] -= RiichiDeposit
+	}
+	deposits := t.deposits + len(hand.Riichi)
+
+	if hand.Draw() {
+		if tenpai := len(hand.Tenpai); tenpai > 0 && tenpai < n {
+			for seat := range n {
+				if slices.Contains(hand.Tenpai, seat) {
+					delta[seat] += NotenPenalty / tenpai
+				} else {
+					delta[seat] -= NotenPenalty / (n - tenpai)
+				}
+			}
+		}
+		t.addDelta(delta)
+		t.deposits = deposits
+		t.honba++
+		if !slices.Contains(hand.Tenpai, t.dealer) {
+			t.rotate()
+		}
+		return nil
+	}
+
+	winner := hand.Winner
+	if hand.Tsumo() {
+		for seat := range n {
+			if seat == winner {
+				continue
+			}
+			pay := hand.TsumoNonDealer
+			if seat == t.dealer {
+				pay = hand.TsumoDealer
+			}
+			pay += HonbaTsumo * t.honba
+			delta[seat] -= pay
+			delta[winner] += pay
+		}
+	} else {
+		pay := hand.RonPoints + HonbaRon*t.honba
+		delta[hand.Loser] -= pay
+		delta[winner] += pay
+	}
+	delta[winner] += deposits * RiichiDeposit
+	t.addDelta(delta)
+	t.deposits = 0
+	if winner == t.dealer {
+		t.honba++
+	} else {
+		t.honba = 0
+		t.rotate()
+	}
+	return nil
+}
+
+// validate は局の結果が対局の状態と矛盾しないかを確かめる
+func (t *Table) validate(hand HandRecord) error {
+	n := len(t.players)
+	validSeat := func(seat int) bool { return seat >= 0 && seat < n }
+	for _, seats := range [][]int{hand.Riichi, hand.Tenpai} {
+		for k, seat := range seats {
+			if !validSeat(seat) || slices.Contains(seats[:k], seat) {
+				return fmt.Errorf("%w: invalid seat %d", ErrInvalidHandRecord, seat+1)
+			}
+		}
+	}
+
+	switch {
+	case hand.Draw():
+		if hand.Loser >= 0 {
+			return fmt.Errorf("%w: deal-in without a winner", ErrInvalidHandRecord)
+		}
+	case !validSeat(hand.Winner):
+		return fmt.Errorf("%w: invalid winner %d", ErrInvalidHandRecord, hand.Winner+1)
+	case hand.Tsumo():
+		if hand.TsumoNonDealer <= 0 {
+			return fmt.Errorf("%w: tsumo without points", ErrInvalidHandRecord)
+		}
+		// 親のツモは全員が同じ点を払い、子のツモは親が多く払う
+		if (hand.Winner == t.dealer) != (hand.TsumoDealer == 0) {
+			return fmt.Errorf("%w: tsumo points do not match the dealer", ErrInvalidHandRecord)
+		}
+	default:
+		if !validSeat(hand.Loser) || hand.Loser == hand.Winner {
+			return fmt.Errorf("%w: invalid deal-in %d", ErrInvalidHandRecord, hand.Loser+1)
+		}
+		if hand.RonPoints <= 0 {
+			return fmt.Errorf("%w: ron without points", ErrInvalidHandRecord)
+		}
+	}
+	return nil
+}
+
+func (t *Table) addDelta(delta []int) {
+	for seat, d := range delta {
+		t.points[seat] += d
+	}
+}
+
+// rotate は親を次の席に移す
+func (t *Table) rotate() {
+	t.dealer = (t.dealer + 1) % len(t.players)
+	t.round++
+}
+
+func NewTable(guildID discordid.GuildID, channelID discordid.TextChannelID, players []discordid.UserID, createdBy discordid.UserID, now time.Time) (*Table, error) {
+	return RebuildTable(TableID(uuid.New().String()), guildID, channelID, players, DefaultTableRule(len(players)), nil, TableStatusPlaying, createdBy, now, time.Time{})
+}
+
+func RebuildTable(id TableID, guildID discordid.GuildID, channelID discordid.TextChannelID, players []discordid.UserID, rule TableRule, hands []HandRecord, status TableStatus, createdBy discordid.UserID, createdAt, finishedAt time.Time) (*Table, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if len(players) < MinTablePlayers || len(players) > MaxTablePlayers {
+		return nil, ErrInvalidPlayerCount
+	}
+	for n, player := range players {
+		if player == "" || slices.Contains(players[:n], player) {
+			return nil, ErrInvalidPlayerCount
+		}
+	}
+	if len(rule.Uma) != len(players) {
+		return nil, fmt.Errorf("%w: uma for %d players", ErrInvalidTableRule, len(rule.Uma))
+	}
+	if _, err := ParseTableStatus(string(status)); err != nil {
+		return nil, err
+	}
+
+	t := &Table{
+		id:         id,
+		guildID:    guildID,
+		channelID:  channelID,
+		players:    players,
+		rule:       rule,
+		status:     status,
+		createdBy:  createdBy,
+		createdAt:  createdAt,
+		finishedAt: finishedAt,
+	}
+	if err := t.replay(hands); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// TableStats は終えた対局から集計した参加者の成績
+// 3人と4人の対局は順位の重みが違うので、対局の人数ごとに分けて集計する
+type TableStats struct {
+	UserID discordid.UserID
+	// Players は集計した対局の人数
+	Players int
+	Games   int
+	// Placements は順位ごとの回数（1位が先頭）
+	Placements [MaxTablePlayers]int
+	// TotalScore は精算後の成績の合計
+	TotalScore int
+	// Hands は参加した局の数、DealIns はそのうち放銃した局の数
+	Hands   int
+	DealIns int
+}
+
+// PlacementRate は placement 位（1 始まり）になった割合を返す
+func (s TableStats) PlacementRate(placement int) float64 {
+	if s.Games == 0 || placement < 1 || placement > MaxTablePlayers {
+		return 0
+	}
+	return float64(s.Placements[placement-1]) / float64(s.Games)
+}
+
+// AverageScore は1対局あたりの精算後の成績の平均を返す
+func (s TableStats) AverageScore() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.TotalScore) / float64(s.Games)
+}
+
+// DealInRate は参加した局のうち放銃した割合を返す
+func (s TableStats) DealInRate() float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return float64(s.DealIns) / float64(s.Hands)
+}
+
+// AggregateTableStats は終えた対局の成績を対局の人数と参加者ごとに集計し、人数の多い順、同じ人数の中では平均成績の高い順に返す
+// 平均成績が同じなら対局数の多い順に並べる
+func AggregateTableStats(tables []*Table) []TableStats {
+	type key struct {
+		userID  discordid.UserID
+		players int
+	}
+	index := make(map[key]int)
+	var stats []TableStats
+	for _, table := range tables {
+		if table.Status() != TableStatusFinished {
+			continue
+		}
+		dealIns := table.DealIns()
+		players := len(table.Players())
+		for _, result := range table.Results() {
+			k := key{userID: result.UserID, players: players}
+			n, ok := index[k]
+			if !ok {
+				n = len(stats)
+				index[k] = n
+				stats = append(stats, TableStats{UserID: result.UserID, Players: players})
+			}
+			stats[n].Games++
+			stats[n].Placements[result.Placement-1]++
+			stats[n].TotalScore += result.Score
+			stats[n].Hands += len(table.Hands())
+			stats[n].DealIns += dealIns[result.Seat]
+		}
+	}
+	slices.SortStableFunc(stats, func(a, b TableStats) int {
+		if a.Players != b.Players {
+			return b.Players - a.Players
+		}
+		if a.AverageScore() != b.AverageScore() {
+			if a.AverageScore() > b.AverageScore() {
+				return -1
+			}
+			return 1
+		}
+		return b.Games - a.Games
+	})
+	return stats
+}
@@ -0,0 +1,258 @@
+package mahjong
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+var tablePlayers = []discordid.UserID{"a", "b", "c", "d"}
+
+func newTestTable(t *testing.T, players []discordid.UserID) *Table {
+	t.Helper()
+	table, err := NewTable("guild", "channel", players, players[0], time.Unix(0, 0))
+	if err != nil {
+		t.Fatalf("NewTable: %v", err)
+	}
+	return table
+}
+
+func recordHands(t *testing.T, table *Table, hands ...HandRecord) {
+	t.Helper()
+	for n, hand := range hands {
+		if err := table.Record(table.Players()[0], hand); err != nil {
+			t.Fatalf("Record hand %d: %v", n+1, err)
+		}
+	}
+}
+
+// sampleHands は東1局から東2局1本場までの3局
+var sampleHands = []HandRecord{
+	// 東1局: 親の立直のあと、南家が西家からロン 3900
+	{Winner: 1, Loser: 2, RonPoints: 3900, Riichi: []int{0}},
+	// 東2局: 親だけが聴牌で流局
+	{Winner: -1, Loser: -1, Tenpai: []int{1}},
+	// 東2局1本場: 北家のツモ 1000/2000
+	{Winner: 3, Loser: -1, TsumoNonDealer: 1000, TsumoDealer: 2000},
+}
+
+func TestTable_Record(t *testing.T) {
+	table := newTestTable(t, tablePlayers)
+	recordHands(t, table, sampleHands...)
+
+	if want := []int{21900, 30800, 19000, 28300}; !slices.Equal(table.Points(), want) {
+		t.Errorf("Points = %v, want %v", table.Points(), want)
+	}
+	if table.Dealer() != 2 || table.RoundWind() != East || table.RoundNumber() != 3 || table.Honba() != 0 || table.Deposits() != 0 {
+		t.Errorf("state = dealer %d %s%d honba %d deposits %d, want dealer 2 East3 honba 0 deposits 0",
+			table.Dealer(), table.RoundWind(), table.RoundNumber(), table.Honba(), table.Deposits())
+	}
+	if want := []int{0, 0, 1, 0}; !slices.Equal(table.DealIns(), want) {
+		t.Errorf("DealIns = %v, want %v", table.DealIns(), want)
+	}
+}
+
+func TestTable_RecordHonbaAndDeposits(t *testing.T) {
+	table := newTestTable(t, tablePlayers)
+	recordHands(t, table,
+		// 全員ノーテンで流局し、立直棒が1本残る
+		HandRecord{Winner: -1, Loser: -1, Riichi: []int{1}},
+		// 東2局1本場: 親のツモ 2000 オール
+		HandRecord{Winner: 1, Loser: -1, TsumoNonDealer: 2000},
+	)
+	if want := []int{22900, 31300, 22900, 22900}; !slices.Equal(table.Points(), want) {
+		t.Errorf("Points = %v, want %v", table.Points(), want)
+	}
+	if table.Dealer() != 1 || table.Honba() != 2 || table.Deposits() != 0 {
+		t.Errorf("state = dealer %d honba %d deposits %d, want 1 2 0", table.Dealer(), table.Honba(), table.Deposits())
+	}
+}
+
+func TestTable_RecordRoundWind(t *testing.T) {
+	table := newTestTable(t, tablePlayers[:3])
+	for range 3 {
+		recordHands(t, table, HandRecord{Winner: -1, Loser: -1})
+	}
+	if table.RoundWind() != South || table.RoundNumber() != 1 || table.Honba() != 3 {
+		t.Errorf("round = %s%d honba %d, want South1 honba 3", table.RoundWind(), table.RoundNumber(), table.Honba())
+	}
+}
+
+func TestTable_RecordInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		user discordid.UserID
+		hand HandRecord
+		want error
+	}{
+		{name: "not a player", user: "e", hand: HandRecord{Winner: -1, Loser: -1}, want: ErrNotTablePlayer},
+		{name: "winner out of range", user: "a", hand: HandRecord{Winner: 4, Loser: 0, RonPoints: 1000}, want: ErrInvalidHandRecord},
+		{name: "self deal-in", user: "a", hand: HandRecord{Winner: 1, Loser: 1, RonPoints: 1000}, want: ErrInvalidHandRecord},
+		{name: "ron without points", user: "a", hand: HandRecord{Winner: 1, Loser: 2}, want: ErrInvalidHandRecord},
+		{name: "dealer tsumo split", user: "a", hand: HandRecord{Winner: 0, Loser: -1, TsumoNonDealer: 1000, TsumoDealer: 2000}, want: ErrInvalidHandRecord},
+		{name: "non-dealer tsumo all", user: "a", hand: HandRecord{Winner: 1, Loser: -1, TsumoNonDealer: 2000}, want: ErrInvalidHandRecord},
+		{name: "deal-in on draw", user: "a", hand: HandRecord{Winner: -1, Loser: 2}, want: ErrInvalidHandRecord},
+		{name: "duplicate riichi", user: "a", hand: HandRecord{Winner: -1, Loser: -1, Riichi: []int{1, 1}}, want: ErrInvalidHandRecord},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := newTestTable(t, tablePlayers)
+			if err := table.Record(tt.user, tt.hand); !errors.Is(err, tt.want) {
+				t.Errorf("Record = %v, want %v", err, tt.want)
+			}
+			if len(table.Hands()) != 0 || table.Points()[0] != 25000 {
+				t.Errorf("table changed after a rejected hand: %d hands, points %v", len(table.Hands()), table.Points())
+			}
+		})
+	}
+}
+
+func TestTable_Undo(t *testing.T) {
+	table := newTestTable(t, tablePlayers)
+	if err := table.Undo("a"); !errors.Is(err, ErrNoHandToUndo) {
+		t.Errorf("Undo on an empty table = %v, want ErrNoHandToUndo", err)
+	}
+	recordHands(t, table, sampleHands...)
+	if err := table.Undo("b"); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if want := []int{23000, 32900, 20100, 24000}; !slices.Equal(table.Points(), want) {
+		t.Errorf("Points = %v, want %v", table.Points(), want)
+	}
+	if len(table.Hands()) != 2 || table.Dealer() != 1 || table.Honba() != 1 {
+		t.Errorf("state = %d hands dealer %d honba %d, want 2 1 1", len(table.Hands()), table.Dealer(), table.Honba())
+	}
+}
+
+func TestTable_Results(t *testing.T) {
+	table := newTestTable(t, tablePlayers)
+	recordHands(t, table, sampleHands...)
+
+	want := []TableResult{
+		{Seat: 1, UserID: "b", Points: 30800, Placement: 1, Score: 40800},
+		{Seat: 3, UserID: "d", Points: 28300, Placement: 2, Score: 8300},
+		{Seat: 0, UserID: "a", Points: 21900, Placement: 3, Score: -18100},
+		{Seat: 2, UserID: "c", Points: 19000, Placement: 4, Score: -31000},
+	}
+	if got := table.Results(); !slices.Equal(got, want) {
+		t.Errorf("Results = %+v, want %+v", got, want)
+	}
+
+	// 南家と北家の立直の後の流局で残った供託はトップが受け取り、成績の合計は 0 のまま
+	recordHands(t, table, HandRecord{Winner: -1, Loser: -1, Riichi: []int{1, 3}, Tenpai: []int{1, 3}})
+	if table.Deposits() != 2 {
+		t.Fatalf("Deposits = %d, want 2", table.Deposits())
+	}
+	results := table.Results()
+	if top := results[0]; top.Seat != 1 || top.Points != table.Points()[1]+2*RiichiDeposit {
+		t.Errorf("top = %+v, want seat 1 with the deposits", top)
+	}
+	total := 0
+	for _, result := range results {
+		total += result.Score
+	}
+	if total != 0 {
+		t.Errorf("sum of scores = %d, want 0", total)
+	}
+}
+
+func TestTable_ResultsTie(t *testing.T) {
+	table := newTestTable(t, tablePlayers[:3])
+	want := []TableResult{
+		{Seat: 0, UserID: "a", Points: 35000, Placement: 1, Score: 30000},
+		{Seat: 1, UserID: "b", Points: 35000, Placement: 2, Score: -5000},
+		{Seat: 2, UserID: "c", Points: 35000, Placement: 3, Score: -25000},
+	}
+	if got := table.Results(); !slices.Equal(got, want) {
+		t.Errorf("Results = %+v, want %+v", got, want)
+	}
+}
+
+func TestTable_Finish(t *testing.T) {
+	table := newTestTable(t, tablePlayers)
+	now := time.Unix(100, 0)
+	if err := table.Finish("e", now); !errors.Is(err, ErrNotTablePlayer) {
+		t.Errorf("Finish by a non-player = %v, want ErrNotTablePlayer", err)
+	}
+	if err := table.Finish("c", now); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if table.Status() != TableStatusFinished || !table.FinishedAt().Equal(now) {
+		t.Errorf("status = %s at %v, want finished at %v", table.Status(), table.FinishedAt(), now)
+	}
+	if err := table.Record("a", HandRecord{Winner: -1, Loser: -1}); !errors.Is(err, ErrTableFinished) {
+		t.Errorf("Record after finish = %v, want ErrTableFinished", err)
+	}
+}
+
+func TestNewTable_InvalidPlayers(t *testing.T) {
+	for _, players := range [][]discordid.UserID{
+		{"a", "b"},
+		{"a", "b", "c", "d", "e"},
+		{"a", "b", "a"},
+	} {
+		if _, err := NewTable("guild", "channel", players, "a", time.Unix(0, 0)); !errors.Is(err, ErrInvalidPlayerCount) {
+			t.Errorf("NewTable(%v) = %v, want ErrInvalidPlayerCount", players, err)
+		}
+	}
+}
+
+func TestRebuildTable_Replay(t *testing.T) {
+	table, err := RebuildTable("id", "guild", "channel", tablePlayers, DefaultTableRule(4), sampleHands, TableStatusFinished, "a", time.Unix(0, 0), time.Unix(100, 0))
+	if err != nil {
+		t.Fatalf("RebuildTable: %v", err)
+	}
+	if want := []int{21900, 30800, 19000, 28300}; !slices.Equal(table.Points(), want) {
+		t.Errorf("Points = %v, want %v", table.Points(), want)
+	}
+
+	invalid := append(slices.Clone(sampleHands), HandRecord{Winner: 0, Loser: 0, RonPoints: 1000})
+	if _, err := RebuildTable("id", "guild", "channel", tablePlayers, DefaultTableRule(4), invalid, TableStatusPlaying, "a", time.Unix(0, 0), time.Time{}); !errors.Is(err, ErrInvalidHandRecord) {
+		t.Errorf("RebuildTable with an invalid hand = %v, want ErrInvalidHandRecord", err)
+	}
+}
+
+func TestAggregateTableStats(t *testing.T) {
+	first := newTestTable(t, tablePlayers)
+	recordHands(t, first, sampleHands...)
+	second := newTestTable(t, []discordid.UserID{"c", "b", "e"})
+	recordHands(t, second, HandRecord{Winner: 1, Loser: 0, RonPoints: 8000})
+	playing := newTestTable(t, tablePlayers)
+	for _, table := range []*Table{first, second} {
+		if err := table.Finish(table.Players()[0], time.Unix(100, 0)); err != nil {
+			t.Fatalf("Finish: %v", err)
+		}
+	}
+
+	stats := AggregateTableStats([]*Table{first, second, playing})
+	if len(stats) != 7 {
+		t.Fatalf("len(stats) = %d, want 4 players of the 4-player game and 3 of the 3-player game", len(stats))
+	}
+	for n, stat := range stats {
+		if want := 4 - n/4; stat.Players != want {
+			t.Errorf("stats[%d].Players = %d, want %d", n, stat.Players, want)
+		}
+	}
+	// 4人の対局と3人の対局は別々に集計する。b: 4人で 1位 40800
+	b := stats[0]
+	if b.UserID != "b" || b.Games != 1 || b.Placements != [MaxTablePlayers]int{1} || b.TotalScore != 40800 {
+		t.Errorf("stats[0] = %+v, want b with a 4-player win", b)
+	}
+	if b.PlacementRate(1) != 1 || b.AverageScore() != 40800 || b.DealInRate() != 0 {
+		t.Errorf("b rates = %v %v %v, want 1 40800 0", b.PlacementRate(1), b.AverageScore(), b.DealInRate())
+	}
+	// b: 3人で 1位 38000
+	if b3 := stats[4]; b3.UserID != "b" || b3.Games != 1 || b3.TotalScore != 38000 {
+		t.Errorf("stats[4] = %+v, want b with a 3-player win", b3)
+	}
+	// c: 4人の 3 局で放銃 1 回、3人の 1 局で放銃 1 回
+	if c := stats[3]; c.UserID != "c" || c.Hands != 3 || c.DealIns != 1 {
+		t.Errorf("stats[3] = %+v, want c with 1 deal-in in 3 hands", c)
+	}
+	if c := stats[6]; c.UserID != "c" || c.Hands != 1 || c.DealIns != 1 || c.DealInRate() != 1 {
+		t.Errorf("stats[6] = %+v, want c with 1 deal-in in 1 hand", c)
+	}
+}
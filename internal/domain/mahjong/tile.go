@@ -0,0 +1,126 @@
+package mahjong
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Suit は牌の種類（萬子・筒子・索子・字牌）
+type Suit int
+
+const (
+	SuitMan Suit = iota
+	SuitPin
+	SuitSou
+	SuitHonor
+)
+
+// suitLetters は牌の表記で種類を表す文字（123m456p789s1234567z の m・p・s・z）
+const suitLetters = "mpsz"
+
+// Letter は牌の表記で種類を表す文字を返す
+func (s Suit) Letter() byte {
+	return suitLetters[s]
+}
+
+// Kind は赤ドラを区別しない牌の種類（34種）
+// 0〜8 が 1〜9 萬、9〜17 が 1〜9 筒、18〜26 が 1〜9 索、27〜33 が東南西北白發中
+type Kind int
+
+// NumKinds は牌の種類の数
+const NumKinds = 34
+
+// 字牌
+const (
+	East Kind = 27 + iota
+	South
+	West
+	North
+	Haku
+	Hatsu
+	Chun
+)
+
+// NewKind は種類と数字（字牌は 1〜7 で東南西北白發中）から牌の種類を返す
+func NewKind(suit Suit, number int) (Kind, error) {
+	maxNumber := 9
+	if suit == SuitHonor {
+		maxNumber = 7
+	}
+	if suit < SuitMan || suit > SuitHonor || number < 1 || number > maxNumber {
+		return 0, fmt.Errorf("%w: %d%c", ErrInvalidTile, number, suit.Letter())
+	}
+	return Kind(int(suit)*9 + number - 1), nil
+}
+
+// Suit は牌の種類（萬子・筒子・索子・字牌）を返す
+func (k Kind) Suit() Suit {
+	return Suit(k / 9)
+}
+
+// Number は数牌の数字を返す。字牌は 1〜7 で東南西北白發中を表す
+func (k Kind) Number() int {
+	return int(k%9) + 1
+}
+
+// IsHonor は字牌かどうかを返す
+func (k Kind) IsHonor() bool {
+	return k.Suit() == SuitHonor
+}
+
+// IsTerminalOrHonor は么九牌（一九牌と字牌）かどうかを返す
+func (k Kind) IsTerminalOrHonor() bool {
+	return k.IsHonor() || k.Number() == 1 || k.Number() == 9
+}
+
+// String は "1m" "7z" のような表記を返す
+func (k Kind) String() string {
+	return fmt.Sprintf("%d%c", k.Number(), k.Suit().Letter())
+}
+
+// Tile は1枚の牌
+type Tile struct {
+	Kind Kind
+	// Red は赤ドラ（赤い 5）かどうか
+	Red bool
+}
+
+// String は "1m" のような表記を返す。赤ドラは "0m" と表記する
+func (t Tile) String() string {
+	if t.Red {
+		return fmt.Sprintf("0%c", t.Kind.Suit().Letter())
+	}
+	return t.Kind.String()
+}
+
+// Hand は手牌
+type Hand []Tile
+
+// String は "123m406p789s11z" のように同じ種類の牌をまとめた表記を返す
+// 牌は並べ替えず、種類が変わるたびに種類を表す文字を付ける
+func (h Hand) String() string {
+	var b strings.Builder
+	for n, tile := range h {
+		s := tile.String()
+		b.WriteByte(s[0])
+		if n == len(h)-1 || h[n+1].Kind.Suit() != tile.Kind.Suit() {
+			b.WriteByte(s[1])
+		}
+	}
+	return b.String()
+}
+
+// CompareTiles は理牌の順（萬子・筒子・索子・字牌の順で数字の小さい順、赤ドラは同じ数字の先頭）で a と b を比べる
+// slices.SortFunc にそのまま渡せる
+func CompareTiles(a, b Tile) int {
+	switch {
+	case a.Kind != b.Kind:
+		return int(a.Kind) - int(b.Kind)
+	case a.Red == b.Red:
+		return 0
+	case a.Red:
+		return -1
+	default:
+		return 1
+	}
+}
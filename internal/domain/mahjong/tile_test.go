@@ -0,0 +1,50 @@
+package mahjong
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewKind(t *testing.T) {
+	tests := []struct {
+		suit    Suit
+		number  int
+		want    string
+		wantErr bool
+	}{
+		{suit: SuitMan, number: 1, want: "1m"},
+		{suit: SuitPin, number: 5, want: "5p"},
+		{suit: SuitSou, number: 9, want: "9s"},
+		{suit: SuitHonor, number: 7, want: "7z"},
+		{suit: SuitMan, number: 0, wantErr: true},
+		{suit: SuitHonor, number: 8, wantErr: true},
+	}
+	for _, tt := range tests {
+		kind, err := NewKind(tt.suit, tt.number)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidTile) {
+				t.Errorf("NewKind(%d, %d): expected ErrInvalidTile, got %v", tt.suit, tt.number, err)
+			}
+			continue
+		}
+		if err != nil || kind.String() != tt.want {
+			t.Errorf("NewKind(%d, %d) = %s, %v, want %s", tt.suit, tt.number, kind, err, tt.want)
+		}
+	}
+
+	if Chun.String() != "7z" || !East.IsHonor() || !Kind(8).IsTerminalOrHonor() || Kind(4).IsTerminalOrHonor() {
+		t.Error("unexpected honor or terminal classification")
+	}
+}
+
+func TestHandString(t *testing.T) {
+	hand := Hand{
+		{Kind: 0}, {Kind: 1}, {Kind: 2},
+		{Kind: 13, Red: true}, {Kind: 13},
+		{Kind: East}, {Kind: East},
+		{Kind: 8},
+	}
+	if got, want := hand.String(), "123m05p11z9m"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package mahjong
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+const (
+	// WallSize は山の牌の数（34種 × 4枚）
+	WallSize = NumKinds * 4
+	// HandSize は子の配牌の枚数
+	HandSize = 13
+	// DealerHandSize は親の配牌（第一ツモを含む）の枚数
+	DealerHandSize = 14
+)
+
+// DealOptions は配牌の条件
+type DealOptions struct {
+	// Tiles は配牌の枚数（HandSize か DealerHandSize）
+	Tiles int
+	// RedFives は各色の 5 を1枚ずつ赤ドラにするかどうか
+	RedFives bool
+}
+
+// DefaultDealOptions は赤ドラありの子の配牌の条件を返す
+func DefaultDealOptions() DealOptions {
+	return DealOptions{Tiles: HandSize, RedFives: true}
+}
+
+// NewWall は並べ替える前の 136 枚の山を返す
+func NewWall(redFives bool) []Tile {
+	wall := make([]Tile, 0, WallSize)
+	for kind := Kind(0); kind < NumKinds; kind++ {
+		for n := range 4 {
+			red := redFives && !kind.IsHonor() && kind.Number() == 5 && n == 0
+			wall = append(wall, Tile{Kind: kind, Red: red})
+		}
+	}
+	return wall
+}
+
+// Deal は山を混ぜて配牌を返す
+// 13 枚は理牌して返し、14 枚の場合は最後の1枚を第一ツモとして理牌せずに末尾に置く
+func Deal(rng *rand.Rand, options DealOptions) (Hand, error) {
+	if options.Tiles != HandSize && options.Tiles != DealerHandSize {
+		return nil, ErrInvalidTileCount
+	}
+
+	wall := NewWall(options.RedFives)
+	rng.Shuffle(len(wall), func(i, j int) {
+		wall[i], wall[j] = wall[j], wall[i]
+	})
+
+	hand := Hand(slices.Clone(wall[:options.Tiles]))
+	slices.SortFunc(hand[:HandSize], CompareTiles)
+	return hand, nil
+}
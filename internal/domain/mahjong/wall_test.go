@@ -0,0 +1,64 @@
+package mahjong
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestNewWall(t *testing.T) {
+	for _, redFives := range []bool{false, true} {
+		wall := NewWall(redFives)
+		if len(wall) != WallSize {
+			t.Fatalf("expected %d tiles, got %d", WallSize, len(wall))
+		}
+
+		var counts [NumKinds]int
+		reds := 0
+		for _, tile := range wall {
+			counts[tile.Kind]++
+			if tile.Red {
+				reds++
+				if tile.Kind.Number() != 5 || tile.Kind.IsHonor() {
+					t.Errorf("unexpected red tile: %s", tile.Kind)
+				}
+			}
+		}
+		for kind, count := range counts {
+			if count != 4 {
+				t.Errorf("expected 4 of %s, got %d", Kind(kind), count)
+			}
+		}
+		if want := map[bool]int{false: 0, true: 3}[redFives]; reds != want {
+			t.Errorf("redFives=%v: expected %d red fives, got %d", redFives, want, reds)
+		}
+	}
+}
+
+func TestDeal(t *testing.T) {
+	for _, size := range []int{HandSize, DealerHandSize} {
+		hand, err := Deal(rand.New(rand.NewPCG(1, 2)), DealOptions{Tiles: size, RedFives: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(hand) != size {
+			t.Fatalf("expected %d tiles, got %d", size, len(hand))
+		}
+		// 13 枚は理牌されている
+		if !slices.IsSortedFunc(hand[:HandSize], CompareTiles) {
+			t.Errorf("hand is not sorted: %s", hand)
+		}
+	}
+
+	// 同じシードなら同じ配牌になる
+	a, _ := Deal(rand.New(rand.NewPCG(7, 7)), DefaultDealOptions())
+	b, _ := Deal(rand.New(rand.NewPCG(7, 7)), DefaultDealOptions())
+	if a.String() != b.String() {
+		t.Errorf("expected the same hand, got %s and %s", a, b)
+	}
+
+	if _, err := Deal(rand.New(rand.NewPCG(1, 2)), DealOptions{Tiles: 12}); !errors.Is(err, ErrInvalidTileCount) {
+		t.Errorf("expected ErrInvalidTileCount, got %v", err)
+	}
+}
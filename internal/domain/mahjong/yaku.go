@@ -0,0 +1,482 @@
+package mahjong
+
+// YakuID は役の識別子
+type YakuID string
+
+// 1〜6 翻の役（門前の翻数）
+const (
+	YakuRiichi         YakuID = "riichi"
+	YakuIppatsu        YakuID = "ippatsu"
+	YakuMenzenTsumo    YakuID = "menzen_tsumo"
+	YakuPinfu          YakuID = "pinfu"
+	YakuTanyao         YakuID = "tanyao"
+	YakuIipeikou       YakuID = "iipeikou"
+	YakuHaku           YakuID = "haku"
+	YakuHatsu          YakuID = "hatsu"
+	YakuChun           YakuID = "chun"
+	YakuSeatWind       YakuID = "seat_wind"
+	YakuRoundWind      YakuID = "round_wind"
+	YakuChiitoitsu     YakuID = "chiitoitsu"
+	YakuIttsu          YakuID = "ittsu"
+	YakuSanshoku       YakuID = "sanshoku"
+	YakuSanshokuDoukou YakuID = "sanshoku_doukou"
+	YakuSanankou       YakuID = "sanankou"
+	YakuToitoi         YakuID = "toitoi"
+	YakuChanta         YakuID = "chanta"
+	YakuHonroutou      YakuID = "honroutou"
+	YakuShousangen     YakuID = "shousangen"
+	YakuRyanpeikou     YakuID = "ryanpeikou"
+	YakuJunchan        YakuID = "junchan"
+	YakuHonitsu        YakuID = "honitsu"
+	YakuChinitsu       YakuID = "chinitsu"
+)
+
+// 役満
+const (
+	YakuKokushi     YakuID = "kokushi"
+	YakuSuuankou    YakuID = "suuankou"
+	YakuDaisangen   YakuID = "daisangen"
+	YakuShousuushii YakuID = "shousuushii"
+	YakuDaisuushii  YakuID = "daisuushii"
+	YakuTsuuiisou   YakuID = "tsuuiisou"
+	YakuChinroutou  YakuID = "chinroutou"
+	YakuRyuuiisou   YakuID = "ryuuiisou"
+	YakuChuuren     YakuID = "chuuren"
+)
+
+// YakuIDs はすべての役の識別子。翻数の少ない順に並べ、役満を最後に置く
+var YakuIDs = []YakuID{
+	YakuRiichi, YakuIppatsu, YakuMenzenTsumo, YakuPinfu, YakuTanyao, YakuIipeikou,
+	YakuHaku, YakuHatsu, YakuChun, YakuSeatWind, YakuRoundWind,
+	YakuChiitoitsu, YakuIttsu, YakuSanshoku, YakuSanshokuDoukou, YakuSanankou, YakuToitoi, YakuChanta,
+	YakuHonroutou, YakuShousangen, YakuRyanpeikou, YakuJunchan, YakuHonitsu, YakuChinitsu,
+	YakuKokushi, YakuSuuankou, YakuDaisangen, YakuShousuushii, YakuDaisuushii,
+	YakuTsuuiisou, YakuChinroutou, YakuRyuuiisou, YakuChuuren,
+}
+
+// Yaku は成立した役と翻数
+type Yaku struct {
+	ID YakuID
+	// Han は翻数。役満では 0
+	Han int
+	// Yakuman は役満かどうか
+	Yakuman bool
+}
+
+func yaku(id YakuID, han int) Yaku {
+	return Yaku{ID: id, Han: han}
+}
+
+func yakuman(id YakuID) Yaku {
+	return Yaku{ID: id, Yakuman: true}
+}
+
+// wait は和了牌の待ちの形
+type wait int
+
+const (
+	waitRyanmen wait = iota
+	waitKanchan
+	waitPenchan
+	waitShanpon
+	waitTanki
+)
+
+// meld は面子。手牌はすべて門前のため、ロンで完成した刻子だけを明刻として扱う
+type meld struct {
+	sequence bool
+	// first は順子なら最も小さい牌、刻子ならその牌
+	first     Kind
+	concealed bool
+}
+
+func (m meld) hasTerminalOrHonor() bool {
+	if m.sequence {
+		return m.first.Number() == 1 || m.first.Number() == 7
+	}
+	return m.first.IsTerminalOrHonor()
+}
+
+// form は和了形を4面子1雀頭に分け、和了牌の待ちの形を決めたもの
+type form struct {
+	pair  Kind
+	melds []meld
+	wait  wait
+}
+
+// standardForms は和了形の4面子1雀頭への分け方と、和了牌で完成した面子の組み合わせをすべて返す
+// ロンで刻子が完成した場合は、その刻子を明刻とする
+func standardForms(counts [NumKinds]int, winning Kind, tsumo bool) []form {
+	var forms []form
+	for pair := Kind(0); pair < NumKinds; pair++ {
+		if counts[pair] < 2 {
+			continue
+		}
+		counts[pair] -= 2
+		for _, melds := range splitMelds(&counts, 0, nil) {
+			forms = append(forms, winningForms(pair, melds, winning, tsumo)...)
+		}
+		counts[pair] += 2
+	}
+	return forms
+}
+
+// splitMelds は残りの牌を面子だけに分ける方法をすべて返す
+func splitMelds(counts *[NumKinds]int, kind Kind, current []meld) [][]meld {
+	for kind < NumKinds && counts[kind] == 0 {
+		kind++
+	}
+	if kind == NumKinds {
+		return [][]meld{append([]meld(nil), current...)}
+	}
+
+	var result [][]meld
+	if counts[kind] >= 3 {
+		counts[kind] -= 3
+		result = append(result, splitMelds(counts, kind, append(current, meld{first: kind, concealed: true}))...)
+		counts[kind] += 3
+	}
+	if !kind.IsHonor() && kind.Number() <= 7 && counts[kind+1] > 0 && counts[kind+2] > 0 {
+		counts[kind]--
+		counts[kind+1]--
+		counts[kind+2]--
+		result = append(result, splitMelds(counts, kind, append(current, meld{sequence: true, first: kind, concealed: true}))...)
+		counts[kind]++
+		counts[kind+1]++
+		counts[kind+2]++
+	}
+	return result
+}
+
+// winningForms は和了牌を含む雀頭または面子ごとに待ちの形を決める
+func winningForms(pair Kind, melds []meld, winning Kind, tsumo bool) []form {
+	var forms []form
+	if pair == winning {
+		forms = append(forms, form{pair: pair, melds: melds, wait: waitTanki})
+	}
+	for n, m := range melds {
+		var w wait
+		switch {
+		case !m.sequence && m.first == winning:
+			w = waitShanpon
+		case m.sequence && m.first+1 == winning:
+			w = waitKanchan
+		case m.sequence && m.first == winning && m.first.Number() == 7,
+			m.sequence && m.first+2 == winning && m.first.Number() == 1:
+			w = waitPenchan
+		case m.sequence && (m.first == winning || m.first+2 == winning):
+			w = waitRyanmen
+		default:
+			continue
+		}
+		completed := append([]meld(nil), melds...)
+		if w == waitShanpon && !tsumo {
+			completed[n].concealed = false
+		}
+		forms = append(forms, form{pair: pair, melds: completed, wait: w})
+	}
+	return forms
+}
+
+// isValueKind は雀頭にすると符が付く役牌（三元牌・自風・場風）かどうかを返す
+func isValueKind(kind Kind, wc WinContext) bool {
+	return kind >= Haku || kind == wc.SeatWind || kind == wc.RoundWind
+}
+
+// handYakuman は面子の分け方によらず手牌の牌の種類だけで決まる役満を返す
+func handYakuman(counts [NumKinds]int) []Yaku {
+	var (
+		result                                     []Yaku
+		allHonors, allTerminals, allGreen, oneSuit = true, true, true, true
+		suit                                       = Suit(-1)
+	)
+	for kind := Kind(0); kind < NumKinds; kind++ {
+		if counts[kind] == 0 {
+			continue
+		}
+		if !kind.IsHonor() {
+			allHonors = false
+		}
+		if kind.IsHonor() || (kind.Number() != 1 && kind.Number() != 9) {
+			allTerminals = false
+		}
+		if !isGreen(kind) {
+			allGreen = false
+		}
+		if suit != Suit(-1) && kind.Suit() != suit {
+			oneSuit = false
+		}
+		suit = kind.Suit()
+	}
+
+	if allHonors {
+		result = append(result, yakuman(YakuTsuuiisou))
+	}
+	if allTerminals {
+		result = append(result, yakuman(YakuChinroutou))
+	}
+	if allGreen {
+		result = append(result, yakuman(YakuRyuuiisou))
+	}
+	if oneSuit && suit != SuitHonor && isNineGates(counts, suit) {
+		result = append(result, yakuman(YakuChuuren))
+	}
+	return result
+}
+
+// isGreen は緑一色に使える牌（23468 索と發）かどうかを返す
+func isGreen(kind Kind) bool {
+	if kind == Hatsu {
+		return true
+	}
+	if kind.Suit() != SuitSou {
+		return false
+	}
+	switch kind.Number() {
+	case 2, 3, 4, 6, 8:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNineGates は1種類の数牌だけの手牌が 1112345678999 に同じ種類の牌を1枚加えた形かを返す
+func isNineGates(counts [NumKinds]int, suit Suit) bool {
+	base := Kind(int(suit) * 9)
+	for n := range 9 {
+		need := 1
+		if n == 0 || n == 8 {
+			need = 3
+		}
+		if counts[base+Kind(n)] < need {
+			return false
+		}
+	}
+	return true
+}
+
+// handYaku は面子の分け方によらず決まる役を返す
+func handYaku(counts [NumKinds]int, wc WinContext) []Yaku {
+	var result []Yaku
+	if wc.Riichi {
+		result = append(result, yaku(YakuRiichi, 1))
+	}
+	if wc.Ippatsu {
+		result = append(result, yaku(YakuIppatsu, 1))
+	}
+	if wc.Tsumo {
+		result = append(result, yaku(YakuMenzenTsumo, 1))
+	}
+
+	simples, terminalsOrHonors, honors := true, true, false
+	suits := map[Suit]bool{}
+	for kind := Kind(0); kind < NumKinds; kind++ {
+		if counts[kind] == 0 {
+			continue
+		}
+		if kind.IsTerminalOrHonor() {
+			simples = false
+		} else {
+			terminalsOrHonors = false
+		}
+		if kind.IsHonor() {
+			honors = true
+		} else {
+			suits[kind.Suit()] = true
+		}
+	}
+	if simples {
+		result = append(result, yaku(YakuTanyao, 1))
+	}
+	if terminalsOrHonors {
+		result = append(result, yaku(YakuHonroutou, 2))
+	}
+	if len(suits) == 1 {
+		if honors {
+			result = append(result, yaku(YakuHonitsu, 3))
+		} else {
+			result = append(result, yaku(YakuChinitsu, 6))
+		}
+	}
+	return result
+}
+
+// formYakuman は面子の分け方で決まる役満を返す
+func formYakuman(f form) []Yaku {
+	var (
+		result                    []Yaku
+		concealed, dragons, winds int
+	)
+	for _, m := range f.melds {
+		if m.sequence {
+			continue
+		}
+		if m.concealed {
+			concealed++
+		}
+		switch {
+		case m.first >= Haku:
+			dragons++
+		case m.first.IsHonor():
+			winds++
+		}
+	}
+
+	if concealed == 4 {
+		result = append(result, yakuman(YakuSuuankou))
+	}
+	if dragons == 3 {
+		result = append(result, yakuman(YakuDaisangen))
+	}
+	switch {
+	case winds == 4:
+		result = append(result, yakuman(YakuDaisuushii))
+	case winds == 3 && f.pair >= East && f.pair <= North:
+		result = append(result, yakuman(YakuShousuushii))
+	}
+	return result
+}
+
+// formYaku は面子の分け方で決まる役を返す
+func formYaku(f form, wc WinContext) []Yaku {
+	var (
+		result                     []Yaku
+		sequences                  = map[Kind]int{}
+		triplets                   = map[Kind]bool{}
+		concealedTriplets, dragons int
+		allWithTerminals           = f.pair.IsTerminalOrHonor()
+		honors                     = f.pair.IsHonor()
+	)
+	for _, m := range f.melds {
+		if m.sequence {
+			sequences[m.first]++
+		} else {
+			triplets[m.first] = true
+			if m.concealed {
+				concealedTriplets++
+			}
+			if m.first >= Haku {
+				dragons++
+			}
+			if m.first.IsHonor() {
+				honors = true
+			}
+		}
+		if !m.hasTerminalOrHonor() {
+			allWithTerminals = false
+		}
+	}
+	numSequences := 0
+	for _, count := range sequences {
+		numSequences += count
+	}
+
+	if numSequences == 4 && f.wait == waitRyanmen && !isValueKind(f.pair, wc) {
+		result = append(result, yaku(YakuPinfu, 1))
+	}
+
+	peikou := 0
+	for _, count := range sequences {
+		peikou += count / 2
+	}
+	switch peikou {
+	case 2:
+		result = append(result, yaku(YakuRyanpeikou, 3))
+	case 1:
+		result = append(result, yaku(YakuIipeikou, 1))
+	}
+
+	for _, dragon := range []struct {
+		kind Kind
+		id   YakuID
+	}{{Haku, YakuHaku}, {Hatsu, YakuHatsu}, {Chun, YakuChun}} {
+		if triplets[dragon.kind] {
+			result = append(result, yaku(dragon.id, 1))
+		}
+	}
+	if triplets[wc.SeatWind] {
+		result = append(result, yaku(YakuSeatWind, 1))
+	}
+	if triplets[wc.RoundWind] {
+		result = append(result, yaku(YakuRoundWind, 1))
+	}
+
+	for suit := SuitMan; suit <= SuitSou; suit++ {
+		base := Kind(int(suit) * 9)
+		if sequences[base] > 0 && sequences[base+3] > 0 && sequences[base+6] > 0 {
+			result = append(result, yaku(YakuIttsu, 2))
+		}
+	}
+	for n := Kind(0); n < 9; n++ {
+		if n < 7 && sequences[n] > 0 && sequences[n+9] > 0 && sequences[n+18] > 0 {
+			result = append(result, yaku(YakuSanshoku, 2))
+		}
+		if triplets[n] && triplets[n+9] && triplets[n+18] {
+			result = append(result, yaku(YakuSanshokuDoukou, 2))
+		}
+	}
+
+	if concealedTriplets == 3 {
+		result = append(result, yaku(YakuSanankou, 2))
+	}
+	if numSequences == 0 {
+		result = append(result, yaku(YakuToitoi, 2))
+	}
+	// 面子がすべて刻子なら混老頭になり、全帯么九・純全帯么九は付かない
+	if allWithTerminals && numSequences > 0 {
+		if honors {
+			result = append(result, yaku(YakuChanta, 2))
+		} else {
+			result = append(result, yaku(YakuJunchan, 3))
+		}
+	}
+	if dragons == 2 && f.pair >= Haku {
+		result = append(result, yaku(YakuShousangen, 2))
+	}
+	return result
+}
+
+// formFu は4面子1雀頭の和了形の符を求める（10 符単位に切り上げる）
+func formFu(f form, wc WinContext, pinfu bool) int {
+	if pinfu {
+		if wc.Tsumo {
+			return 20
+		}
+		return 30
+	}
+
+	fu := 20
+	if wc.Tsumo {
+		fu += 2
+	} else {
+		// 門前ロン
+		fu += 10
+	}
+	for _, m := range f.melds {
+		if m.sequence {
+			continue
+		}
+		value := 2
+		if m.first.IsTerminalOrHonor() {
+			value *= 2
+		}
+		if m.concealed {
+			value *= 2
+		}
+		fu += value
+	}
+	if f.pair >= Haku {
+		fu += 2
+	}
+	if f.pair == wc.SeatWind {
+		fu += 2
+	}
+	if f.pair == wc.RoundWind {
+		fu += 2
+	}
+	switch f.wait {
+	case waitKanchan, waitPenchan, waitTanki:
+		fu += 2
+	}
+	return (fu + 9) / 10 * 10
+}
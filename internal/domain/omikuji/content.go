@@ -0,0 +1,134 @@
+package omikuji
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+)
+
+// data/ 以下のファイルを編集すれば、コードを変えずに項目を追加できる
+//   - categories.json : 願望・恋愛などの項目と、良・中・悪ごとの文言
+//   - lucky.json      : ラッキーカラー・アイテム・方角
+//
+//go:embed data/*.json
+var dataFS embed.FS
+
+var defaultContent = mustLoadDefaultContent()
+
+// LocalizedText はロケールごとの文言
+type LocalizedText map[i18n.Locale]string
+
+// In は指定したロケールの文言を返す。無ければ既定のロケールの文言を返す
+func (t LocalizedText) In(locale i18n.Locale) string {
+	if text, ok := t[locale]; ok && text != "" {
+		return text
+	}
+	return t[i18n.Default]
+}
+
+// Tier は項目別の運勢の良し悪し
+type Tier string
+
+const (
+	TierGood    Tier = "good"
+	TierNeutral Tier = "neutral"
+	TierBad     Tier = "bad"
+)
+
+// Tiers は良い順に並べたすべての Tier
+var Tiers = []Tier{TierGood, TierNeutral, TierBad}
+
+// Category は願望・恋愛などのおみくじの項目
+type Category struct {
+	ID       string                   `json:"id"`
+	Name     LocalizedText            `json:"name"`
+	Readings map[Tier][]LocalizedText `json:"readings"`
+}
+
+// Content はおみくじの項目とラッキーアイテムの一覧
+type Content struct {
+	Categories []Category      `json:"-"`
+	Colors     []LocalizedText `json:"colors"`
+	Items      []LocalizedText `json:"items"`
+	Directions []LocalizedText `json:"directions"`
+}
+
+// DefaultContent は埋め込まれたデータファイルから読み込んだ内容を返す
+func DefaultContent() *Content {
+	return defaultContent
+}
+
+// LoadContent は categories.json と lucky.json の内容を読み込み、検証する
+func LoadContent(categoriesJSON, luckyJSON []byte) (*Content, error) {
+	var content Content
+	if err := json.Unmarshal(luckyJSON, &content); err != nil {
+		return nil, fmt.Errorf("%w: lucky: %v", ErrInvalidContent, err)
+	}
+	if err := json.Unmarshal(categoriesJSON, &content.Categories); err != nil {
+		return nil, fmt.Errorf("%w: categories: %v", ErrInvalidContent, err)
+	}
+	if err := content.validate(); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// validate はすべての一覧が空でなく、すべての文言が全ロケール分そろっていることを確認する
+func (c *Content) validate() error {
+	if len(c.Categories) == 0 {
+		return fmt.Errorf("%w: no categories", ErrInvalidContent)
+	}
+	for _, category := range c.Categories {
+		if category.ID == "" {
+			return fmt.Errorf("%w: category without id", ErrInvalidContent)
+		}
+		if err := validateTexts("category "+category.ID+" name", []LocalizedText{category.Name}); err != nil {
+			return err
+		}
+		for _, tier := range Tiers {
+			if err := validateTexts("category "+category.ID+" "+string(tier), category.Readings[tier]); err != nil {
+				return err
+			}
+		}
+	}
+
+	lists := map[string][]LocalizedText{"colors": c.Colors, "items": c.Items, "directions": c.Directions}
+	for name, texts := range lists {
+		if err := validateTexts(name, texts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTexts(name string, texts []LocalizedText) error {
+	if len(texts) == 0 {
+		return fmt.Errorf("%w: %s is empty", ErrInvalidContent, name)
+	}
+	for n, text := range texts {
+		for _, locale := range i18n.Locales() {
+			if text[locale] == "" {
+				return fmt.Errorf("%w: %s[%d] has no %s text", ErrInvalidContent, name, n, locale)
+			}
+		}
+	}
+	return nil
+}
+
+func mustLoadDefaultContent() *Content {
+	categories, err := dataFS.ReadFile("data/categories.json")
+	if err != nil {
+		panic(fmt.Sprintf("omikuji: failed to read categories: %v", err))
+	}
+	lucky, err := dataFS.ReadFile("data/lucky.json")
+	if err != nil {
+		panic(fmt.Sprintf("omikuji: failed to read lucky items: %v", err))
+	}
+	content, err := LoadContent(categories, lucky)
+	if err != nil {
+		panic(fmt.Sprintf("omikuji: %v", err))
+	}
+	return content
+}
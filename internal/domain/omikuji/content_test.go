@@ -0,0 +1,112 @@
+package omikuji
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+)
+
+func TestDefaultContent(t *testing.T) {
+	content := DefaultContent()
+
+	var ids []string
+	for _, category := range content.Categories {
+		ids = append(ids, category.ID)
+	}
+	expected := []string{"wish", "love", "work", "health", "awaited_person"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected categories %v, got %v", expected, ids)
+	}
+}
+
+func TestLoadContentRejectsInvalidData(t *testing.T) {
+	lucky := []byte(`{"colors":[{"ja":"赤","en":"Red"}],"items":[{"ja":"傘","en":"Umbrella"}],"directions":[{"ja":"北","en":"North"}]}`)
+	category := func(good string) []byte {
+		return []byte(`[{"id":"wish","name":{"ja":"願望","en":"Wish"},"readings":{` +
+			`"good":[` + good + `],` +
+			`"neutral":[{"ja":"中","en":"Neutral"}],` +
+			`"bad":[{"ja":"悪","en":"Bad"}]}}]`)
+	}
+
+	if _, err := LoadContent(category(`{"ja":"良","en":"Good"}`), lucky); err != nil {
+		t.Fatalf("expected valid content, got %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		categories []byte
+		lucky      []byte
+	}{
+		{name: "missing translation", categories: category(`{"ja":"良"}`), lucky: lucky},
+		{name: "empty tier", categories: category(``), lucky: lucky},
+		{name: "no categories", categories: []byte(`[]`), lucky: lucky},
+		{name: "empty lucky list", categories: category(`{"ja":"良","en":"Good"}`), lucky: []byte(`{"colors":[]}`)},
+		{name: "broken json", categories: []byte(`[`), lucky: lucky},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadContent(tt.categories, tt.lucky); !errors.Is(err, ErrInvalidContent) {
+				t.Errorf("expected ErrInvalidContent, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReadIsDeterministic(t *testing.T) {
+	content := DefaultContent()
+
+	first := content.Read(Blessing, 12345)
+	second := content.Read(Blessing, 12345)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("expected the same reading for the same seed")
+	}
+
+	if len(first.Categories) != len(content.Categories) {
+		t.Errorf("expected %d category readings, got %d", len(content.Categories), len(first.Categories))
+	}
+	for _, c := range first.Categories {
+		if c.Text.In(i18n.Japanese) == "" || c.Text.In(i18n.English) == "" {
+			t.Errorf("expected localized text for %s", c.Category.ID)
+		}
+	}
+	if first.LuckyColor == nil || first.LuckyItem == nil || first.LuckyDirection == nil {
+		t.Error("expected lucky color, item and direction")
+	}
+
+	differs := false
+	for seed := uint64(0); seed < 20 && !differs; seed++ {
+		differs = !reflect.DeepEqual(content.Read(Blessing, seed), first)
+	}
+	if !differs {
+		t.Error("expected different seeds to produce different readings")
+	}
+}
+
+func TestReadFollowsLevel(t *testing.T) {
+	content := DefaultContent()
+
+	countGood := func(level FortuneLevel) int {
+		good := 0
+		for seed := uint64(0); seed < 2000; seed++ {
+			for _, c := range content.Read(level, seed).Categories {
+				if c.Tier == TierGood {
+					good++
+				}
+			}
+		}
+		return good
+	}
+
+	if great, bad := countGood(GreatBlessing), countGood(GreatBadLuck); great <= bad*3 {
+		t.Errorf("expected 大吉 to have far more good readings than 大凶, got %d vs %d", great, bad)
+	}
+}
+
+func TestLocalizedTextFallsBackToDefault(t *testing.T) {
+	text := LocalizedText{i18n.Japanese: "赤"}
+	if got := text.In(i18n.English); got != "赤" {
+		t.Errorf("expected fallback to Japanese, got %q", got)
+	}
+}
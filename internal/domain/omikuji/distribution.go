@@ -0,0 +1,131 @@
+package omikuji
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TotalPermille は確率分布の千分率の合計
+const TotalPermille = 1000
+
+// Weight は運勢レベルの出現確率（千分率）
+type Weight struct {
+	Level    FortuneLevel
+	Permille int
+}
+
+// Distribution は運勢レベルの確率分布。Levels の順にすべてのレベルを1つずつ持つ
+type Distribution []Weight
+
+// DefaultDistribution は標準の確率分布
+// 超大吉 1% / 大吉 10% / 中吉 20% / 小吉 20% / 吉 25% / 凶 20% / 大凶 4%
+var DefaultDistribution = Distribution{
+	{Level: UltraGreatBlessing, Permille: 10},
+	{Level: GreatBlessing, Permille: 100},
+	{Level: MiddleBlessing, Permille: 200},
+	{Level: SmallBlessing, Permille: 200},
+	{Level: Blessing, Permille: 250},
+	{Level: BadLuck, Permille: 200},
+	{Level: GreatBadLuck, Permille: 40},
+}
+
+// NewDistribution は Levels の順に並べた千分率から確率分布を作る
+func NewDistribution(permilles []int) (Distribution, error) {
+	if len(permilles) != len(Levels) {
+		return nil, fmt.Errorf("%w: expected %d weights, got %d", ErrInvalidDistribution, len(Levels), len(permilles))
+	}
+
+	d := make(Distribution, 0, len(Levels))
+	total := 0
+	for n, permille := range permilles {
+		if permille < 0 {
+			return nil, fmt.Errorf("%w: negative weight for %s", ErrInvalidDistribution, Levels[n])
+		}
+		total += permille
+		d = append(d, Weight{Level: Levels[n], Permille: permille})
+	}
+	if total != TotalPermille {
+		return nil, fmt.Errorf("%w: weights sum to %s%%, expected 100%%", ErrInvalidDistribution, formatPercent(total))
+	}
+	return d, nil
+}
+
+// ParseDistribution は "1/10/20/20/25/20/4" のような Levels の順のパーセント表記を読み込む
+// 区切りは "/"、","、空白のいずれでもよく、小数点以下は1桁（0.1% 単位）まで指定できる
+func ParseDistribution(s string) (Distribution, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '/' || r == ',' || r == ' ' || r == '　'
+	})
+
+	permilles := make([]int, 0, len(fields))
+	for _, field := range fields {
+		permille, err := parsePercent(strings.TrimSuffix(field, "%"))
+		if err != nil {
+			return nil, err
+		}
+		permilles = append(permilles, permille)
+	}
+	return NewDistribution(permilles)
+}
+
+// Permilles は Levels の順の千分率を返す
+func (d Distribution) Permilles() []int {
+	permilles := make([]int, 0, len(d))
+	for _, w := range d {
+		permilles = append(permilles, w.Permille)
+	}
+	return permilles
+}
+
+// Rate は運勢レベルの出現率（0〜1）を返す
+func (d Distribution) Rate(level FortuneLevel) float64 {
+	for _, w := range d {
+		if w.Level == level {
+			return float64(w.Permille) / TotalPermille
+		}
+	}
+	return 0
+}
+
+// Pick は 0〜999 の値に対応する運勢レベルを返す
+func (d Distribution) Pick(value int) FortuneLevel {
+	for _, w := range d {
+		if value < w.Permille {
+			return w.Level
+		}
+		value -= w.Permille
+	}
+	return d[len(d)-1].Level
+}
+
+// String は ParseDistribution で読み込める "1/10/20/20/25/20/4" 形式で返す
+func (d Distribution) String() string {
+	parts := make([]string, 0, len(d))
+	for _, w := range d {
+		parts = append(parts, formatPercent(w.Permille))
+	}
+	return strings.Join(parts, "/")
+}
+
+func parsePercent(s string) (int, error) {
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	if whole == "" || (hasFraction && len(fraction) != 1) {
+		return 0, fmt.Errorf("%w: invalid percentage %q", ErrInvalidDistribution, s)
+	}
+	permille, err := strconv.Atoi(whole + fraction)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid percentage %q", ErrInvalidDistribution, s)
+	}
+	if !hasFraction {
+		permille *= 10
+	}
+	return permille, nil
+}
+
+func formatPercent(permille int) string {
+	if permille%10 == 0 {
+		return strconv.Itoa(permille / 10)
+	}
+	return fmt.Sprintf("%d.%d", permille/10, permille%10)
+}
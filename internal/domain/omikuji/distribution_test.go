@@ -0,0 +1,99 @@
+package omikuji
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDefaultDistribution(t *testing.T) {
+	if _, err := NewDistribution(DefaultDistribution.Permilles()); err != nil {
+		t.Fatalf("expected default distribution to be valid, got %v", err)
+	}
+	if got := DefaultDistribution.String(); got != "1/10/20/20/25/20/4" {
+		t.Errorf("expected 1/10/20/20/25/20/4, got %s", got)
+	}
+	if DefaultDistribution.Rate(Blessing) != 0.25 {
+		t.Errorf("expected 吉 to be 25%%, got %v", DefaultDistribution.Rate(Blessing))
+	}
+}
+
+func TestParseDistribution(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []int
+		valid    bool
+	}{
+		{input: "1/10/20/20/25/20/4", expected: []int{10, 100, 200, 200, 250, 200, 40}, valid: true},
+		{input: "5, 30, 20, 20, 20, 5, 0", expected: []int{50, 300, 200, 200, 200, 50, 0}, valid: true},
+		{input: "0.5% 10.5% 20 20 25 20 4", expected: []int{5, 105, 200, 200, 250, 200, 40}, valid: true},
+		{input: "1/10/20/20/25/20/5"},
+		{input: "1/10/20/20/25/24"},
+		{input: "1/10/20/20/25/20/4/0"},
+		{input: "-1/11/20/20/25/20/5"},
+		{input: "1/10/20/20/25/20/x"},
+		{input: "1/10/20/20/25/20/3.95"},
+		{input: ""},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDistribution(tt.input)
+		if !tt.valid {
+			if !errors.Is(err, ErrInvalidDistribution) {
+				t.Errorf("ParseDistribution(%q): expected ErrInvalidDistribution, got %v", tt.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseDistribution(%q): unexpected error %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(d.Permilles(), tt.expected) {
+			t.Errorf("ParseDistribution(%q): expected %v, got %v", tt.input, tt.expected, d.Permilles())
+		}
+	}
+}
+
+func TestDistributionStringRoundTrip(t *testing.T) {
+	d, err := NewDistribution([]int{5, 105, 200, 200, 250, 200, 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.String() != "0.5/10.5/20/20/25/20/4" {
+		t.Errorf("unexpected string: %s", d.String())
+	}
+	parsed, err := ParseDistribution(d.String())
+	if err != nil || !reflect.DeepEqual(parsed, d) {
+		t.Errorf("expected round trip, got %v (%v)", parsed, err)
+	}
+}
+
+func TestDistributionPick(t *testing.T) {
+	tests := []struct {
+		value    int
+		expected FortuneLevel
+	}{
+		{value: 0, expected: UltraGreatBlessing},
+		{value: 9, expected: UltraGreatBlessing},
+		{value: 10, expected: GreatBlessing},
+		{value: 109, expected: GreatBlessing},
+		{value: 110, expected: MiddleBlessing},
+		{value: 310, expected: SmallBlessing},
+		{value: 510, expected: Blessing},
+		{value: 760, expected: BadLuck},
+		{value: 960, expected: GreatBadLuck},
+		{value: 999, expected: GreatBadLuck},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultDistribution.Pick(tt.value); got != tt.expected {
+			t.Errorf("Pick(%d): expected %s, got %s", tt.value, tt.expected, got)
+		}
+	}
+
+	// 確率 0 のレベルは選ばれない
+	noUltra, _ := NewDistribution([]int{0, 110, 200, 200, 250, 200, 40})
+	if got := noUltra.Pick(0); got != GreatBlessing {
+		t.Errorf("expected zero-weight level to be skipped, got %s", got)
+	}
+}
@@ -0,0 +1,154 @@
+package omikuji
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// Draw はユーザーがその日に最初に引いたおみくじの記録
+// 同じギルド・ユーザー・日付の記録は1件だけ保存する（DM の場合 guildID は空）
+type Draw struct {
+	guildID   discordid.GuildID
+	userID    discordid.UserID
+	date      time.Time
+	level     FortuneLevel
+	createdAt time.Time
+}
+
+func (d *Draw) GuildID() discordid.GuildID {
+	return d.guildID
+}
+
+func (d *Draw) UserID() discordid.UserID {
+	return d.userID
+}
+
+// Date はおみくじを引いた日付（UTC の 0 時で表す暦日）
+func (d *Draw) Date() time.Time {
+	return d.date
+}
+
+func (d *Draw) Level() FortuneLevel {
+	return d.level
+}
+
+func (d *Draw) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func NewDraw(guildID discordid.GuildID, userID discordid.UserID, date time.Time, level FortuneLevel) (*Draw, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+	if _, ok := levelCodes[level]; !ok {
+		return nil, ErrInvalidFortuneLevel
+	}
+	return &Draw{
+		guildID:   guildID,
+		userID:    userID,
+		date:      DateOf(date),
+		level:     level,
+		createdAt: time.Now(),
+	}, nil
+}
+
+func RebuildDraw(guildID discordid.GuildID, userID discordid.UserID, date time.Time, level FortuneLevel, createdAt time.Time) (*Draw, error) {
+	if userID == "" {
+		return nil, ErrInvalidUserID
+	}
+	if _, ok := levelCodes[level]; !ok {
+		return nil, ErrInvalidFortuneLevel
+	}
+	return &Draw{
+		guildID:   guildID,
+		userID:    userID,
+		date:      DateOf(date),
+		level:     level,
+		createdAt: createdAt,
+	}, nil
+}
+
+// DateOf は時刻の暦日を UTC の 0 時として返す
+// タイムゾーンを考慮した日付にするには、呼び出し側で In(loc) してから渡す
+func DateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// HistoryDay は履歴カレンダーの1日分
+type HistoryDay struct {
+	Date  time.Time
+	Level FortuneLevel
+	Drawn bool
+}
+
+// History は today までの days 日分の履歴を古い順に返す
+// おみくじを引かなかった日は Drawn が false になる
+func History(draws []*Draw, today time.Time, days int) []HistoryDay {
+	byDate := make(map[time.Time]FortuneLevel, len(draws))
+	for _, d := range draws {
+		byDate[d.date] = d.level
+	}
+
+	today = DateOf(today)
+	history := make([]HistoryDay, 0, days)
+	for n := days - 1; n >= 0; n-- {
+		date := today.AddDate(0, 0, -n)
+		level, ok := byDate[date]
+		history = append(history, HistoryDay{Date: date, Level: level, Drawn: ok})
+	}
+	return history
+}
+
+// Stats はユーザーのおみくじの統計
+type Stats struct {
+	Total  int
+	Counts map[FortuneLevel]int
+	// Streak は今日（今日まだ引いていなければ昨日）まで連続した吉以上の日数
+	Streak int
+}
+
+// Rate は運勢レベルの実際の出現率（0〜1）を返す
+func (s Stats) Rate(level FortuneLevel) float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Counts[level]) / float64(s.Total)
+}
+
+// CalculateStats は記録から運勢レベルの分布と連続記録を集計する
+func CalculateStats(draws []*Draw, today time.Time) Stats {
+	stats := Stats{Counts: make(map[FortuneLevel]int, len(Levels))}
+	byDate := make(map[time.Time]FortuneLevel, len(draws))
+	for _, d := range draws {
+		stats.Total++
+		stats.Counts[d.level]++
+		byDate[d.date] = d.level
+	}
+
+	date := DateOf(today)
+	if _, ok := byDate[date]; !ok {
+		// 今日まだ引いていないだけなら連続記録は途切れていない
+		date = date.AddDate(0, 0, -1)
+	}
+	for {
+		level, ok := byDate[date]
+		if !ok || !level.IsGood() {
+			break
+		}
+		stats.Streak++
+		date = date.AddDate(0, 0, -1)
+	}
+	return stats
+}
+
+// SortByLuck は運勢の良い順に並べ替える。同じ運勢なら先に引いた方を上位とする
+func SortByLuck(draws []*Draw) {
+	sort.SliceStable(draws, func(a, b int) bool {
+		if draws[a].level != draws[b].level {
+			return draws[a].level < draws[b].level
+		}
+		return draws[a].createdAt.Before(draws[b].createdAt)
+	})
+}
@@ -0,0 +1,150 @@
+package omikuji
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+func date(day int) time.Time {
+	return time.Date(2025, 3, day, 0, 0, 0, 0, time.UTC)
+}
+
+func mustDraw(t *testing.T, userID string, day int, level FortuneLevel) *Draw {
+	t.Helper()
+	d, err := RebuildDraw("g", discordid.UserID(userID), date(day), level, date(day).Add(time.Duration(day)*time.Minute))
+	if err != nil {
+		t.Fatalf("RebuildDraw: %v", err)
+	}
+	return d
+}
+
+func TestParseFortuneLevel(t *testing.T) {
+	for _, level := range Levels {
+		got, err := ParseFortuneLevel(level.Code())
+		if err != nil || got != level {
+			t.Errorf("ParseFortuneLevel(%q): expected %v, got %v (%v)", level.Code(), level, got, err)
+		}
+	}
+	if _, err := ParseFortuneLevel("unknown"); err != ErrInvalidFortuneLevel {
+		t.Errorf("expected ErrInvalidFortuneLevel, got %v", err)
+	}
+}
+
+func TestNewDrawNormalizesDate(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	d, err := NewDraw("g", "u", time.Date(2025, 3, 2, 1, 30, 0, 0, jst), Blessing)
+	if err != nil {
+		t.Fatalf("NewDraw: %v", err)
+	}
+	if !d.Date().Equal(date(2)) {
+		t.Errorf("expected date to be 2025-03-02, got %s", d.Date())
+	}
+
+	if _, err := NewDraw("g", "", date(2), Blessing); err != ErrInvalidUserID {
+		t.Errorf("expected ErrInvalidUserID, got %v", err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	draws := []*Draw{
+		mustDraw(t, "u", 1, GreatBlessing),
+		mustDraw(t, "u", 3, BadLuck),
+	}
+
+	history := History(draws, date(3), 4)
+
+	if len(history) != 4 {
+		t.Fatalf("expected 4 days, got %d", len(history))
+	}
+	if !history[0].Date.Equal(date(0)) || history[0].Drawn {
+		t.Errorf("expected first day to be an undrawn 2025-02-28, got %+v", history[0])
+	}
+	if !history[1].Drawn || history[1].Level != GreatBlessing {
+		t.Errorf("expected 大吉 on day 1, got %+v", history[1])
+	}
+	if history[2].Drawn {
+		t.Errorf("expected day 2 to be undrawn, got %+v", history[2])
+	}
+	if !history[3].Drawn || history[3].Level != BadLuck {
+		t.Errorf("expected 凶 on day 3, got %+v", history[3])
+	}
+}
+
+func TestCalculateStats(t *testing.T) {
+	tests := []struct {
+		name           string
+		draws          []FortuneLevel // 1日目から順の運勢（-1 は引かなかった日）
+		today          int
+		expectedStreak int
+	}{
+		{name: "no draws", today: 5, expectedStreak: 0},
+		{name: "streak through today", draws: []FortuneLevel{BadLuck, Blessing, GreatBlessing}, today: 3, expectedStreak: 2},
+		{name: "not drawn today yet", draws: []FortuneLevel{Blessing, Blessing}, today: 3, expectedStreak: 2},
+		{name: "gap breaks streak", draws: []FortuneLevel{Blessing, -1, Blessing}, today: 3, expectedStreak: 1},
+		{name: "bad luck today", draws: []FortuneLevel{Blessing, GreatBadLuck}, today: 2, expectedStreak: 0},
+		{name: "streak ended two days ago", draws: []FortuneLevel{Blessing}, today: 3, expectedStreak: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var draws []*Draw
+			for n, level := range tt.draws {
+				if level < 0 {
+					continue
+				}
+				draws = append(draws, mustDraw(t, "u", n+1, level))
+			}
+
+			stats := CalculateStats(draws, date(tt.today).Add(15*time.Hour))
+
+			if stats.Streak != tt.expectedStreak {
+				t.Errorf("expected streak %d, got %d", tt.expectedStreak, stats.Streak)
+			}
+			if stats.Total != len(draws) {
+				t.Errorf("expected total %d, got %d", len(draws), stats.Total)
+			}
+		})
+	}
+}
+
+func TestStatsRate(t *testing.T) {
+	draws := []*Draw{
+		mustDraw(t, "u", 1, Blessing),
+		mustDraw(t, "u", 2, Blessing),
+		mustDraw(t, "u", 3, BadLuck),
+		mustDraw(t, "u", 4, GreatBlessing),
+	}
+
+	stats := CalculateStats(draws, date(4))
+
+	if stats.Rate(Blessing) != 0.5 {
+		t.Errorf("expected 吉 rate 0.5, got %v", stats.Rate(Blessing))
+	}
+	if stats.Rate(GreatBadLuck) != 0 {
+		t.Errorf("expected 大凶 rate 0, got %v", stats.Rate(GreatBadLuck))
+	}
+	if (Stats{}).Rate(Blessing) != 0 {
+		t.Error("expected zero rate for empty stats")
+	}
+}
+
+func TestSortByLuck(t *testing.T) {
+	draws := []*Draw{
+		mustDraw(t, "a", 3, BadLuck),
+		mustDraw(t, "b", 2, GreatBlessing),
+		mustDraw(t, "c", 1, GreatBlessing),
+		mustDraw(t, "d", 4, Blessing),
+	}
+
+	SortByLuck(draws)
+
+	var order string
+	for _, d := range draws {
+		order += string(d.UserID())
+	}
+	if order != "cbda" {
+		t.Errorf("expected order cbda, got %s", order)
+	}
+}
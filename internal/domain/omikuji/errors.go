@@ -0,0 +1,16 @@
+package omikuji
+
+import "errors"
+
+var (
+	ErrInvalidFortuneLevel = errors.New("invalid fortune level")
+	ErrInvalidUserID       = errors.New("invalid User ID")
+	ErrInvalidContent      = errors.New("invalid omikuji content")
+	ErrInvalidDistribution = errors.New("invalid fortune distribution")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+	ErrInvalidDateRange    = errors.New("invalid date range")
+	ErrInvalidGuildID      = errors.New("invalid Guild ID")
+	ErrSettingsNotFound    = errors.New("omikuji settings not found")
+	ErrOverrideNotFound    = errors.New("omikuji override not found")
+	ErrDrawNotFound        = errors.New("omikuji draw not found")
+)
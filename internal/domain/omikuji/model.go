@@ -13,6 +13,48 @@ const (
 	GreatBadLuck                            // 大凶
 )
 
+// Levels は良い順に並べたすべての運勢レベル
+var Levels = []FortuneLevel{
+	UltraGreatBlessing,
+	GreatBlessing,
+	MiddleBlessing,
+	SmallBlessing,
+	Blessing,
+	BadLuck,
+	GreatBadLuck,
+}
+
+// levelCodes は永続化やメッセージカタログのキーに使う運勢レベルの識別子
+var levelCodes = map[FortuneLevel]string{
+	UltraGreatBlessing: "ultra_great_blessing",
+	GreatBlessing:      "great_blessing",
+	MiddleBlessing:     "middle_blessing",
+	SmallBlessing:      "small_blessing",
+	Blessing:           "blessing",
+	BadLuck:            "bad_luck",
+	GreatBadLuck:       "great_bad_luck",
+}
+
+// Code は運勢レベルの識別子を返す
+func (f FortuneLevel) Code() string {
+	return levelCodes[f]
+}
+
+// ParseFortuneLevel は識別子から運勢レベルを復元する
+func ParseFortuneLevel(code string) (FortuneLevel, error) {
+	for level, c := range levelCodes {
+		if c == code {
+			return level, nil
+		}
+	}
+	return 0, ErrInvalidFortuneLevel
+}
+
+// IsGood は吉以上の良い運勢かどうかを返す
+func (f FortuneLevel) IsGood() bool {
+	return f <= Blessing
+}
+
 // String はFortuneLevel型を日本語文字列に変換
 func (f FortuneLevel) String() string {
 	switch f {
@@ -36,37 +78,18 @@ func (f FortuneLevel) String() string {
 }
 
 // Fortune はおみくじの結果を表現するドメインエンティティ
+// 運勢レベルの表示名とメッセージはメッセージカタログ（msg.omikuji.*）で、
+// 項目別の運勢とラッキーアイテムはデータファイル（data/*.json）で管理する
 type Fortune struct {
 	Level   FortuneLevel
-	Message string
+	Reading Reading
 }
 
 // NewFortune はFortune型のコンストラクタ
-func NewFortune(level FortuneLevel) *Fortune {
+// 項目別の運勢とラッキーアイテムはシード値から決定的に導出する
+func NewFortune(level FortuneLevel, seed uint64, content *Content) *Fortune {
 	return &Fortune{
 		Level:   level,
-		Message: generateMessage(level),
-	}
-}
-
-// generateMessage はレベルに応じたメッセージを生成
-func generateMessage(level FortuneLevel) string {
-	switch level {
-	case UltraGreatBlessing:
-		return "素晴らしい運勢です！今日は何をやっても上手くいきそう！"
-	case GreatBlessing:
-		return "とても良い運勢です！"
-	case MiddleBlessing:
-		return "良い運勢です！"
-	case SmallBlessing:
-		return "まずまずの運勢です！"
-	case Blessing:
-		return "普通の運勢です！"
-	case BadLuck:
-		return "少し注意が必要かもしれません..."
-	case GreatBadLuck:
-		return "今日は慎重に行動しましょう..."
-	default:
-		return ""
+		Reading: content.Read(level, seed),
 	}
 }
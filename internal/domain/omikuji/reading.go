@@ -0,0 +1,80 @@
+package omikuji
+
+// tierWeights は運勢レベルごとの項目別の良・中・悪の出現確率（千分率）
+// 全体の運勢が良いほど項目別の運勢も良くなりやすい
+var tierWeights = map[FortuneLevel][3]int{
+	UltraGreatBlessing: {800, 150, 50},
+	GreatBlessing:      {650, 250, 100},
+	MiddleBlessing:     {500, 350, 150},
+	SmallBlessing:      {350, 450, 200},
+	Blessing:           {300, 450, 250},
+	BadLuck:            {150, 400, 450},
+	GreatBadLuck:       {50, 350, 600},
+}
+
+// ラッキーアイテムの導出に使うソルト（項目別の運勢と重ならない値）
+const (
+	saltColor uint64 = 1000 + iota
+	saltItem
+	saltDirection
+)
+
+// CategoryReading は項目別の運勢
+type CategoryReading struct {
+	Category Category
+	Tier     Tier
+	Text     LocalizedText
+}
+
+// Reading は運勢レベルに付随する項目別の運勢とラッキーアイテム
+type Reading struct {
+	Categories     []CategoryReading
+	LuckyColor     LocalizedText
+	LuckyItem      LocalizedText
+	LuckyDirection LocalizedText
+}
+
+// Read はシード値から項目別の運勢とラッキーアイテムを決定的に導出する
+// 同じ運勢レベルとシード値からは常に同じ結果になる
+func (c *Content) Read(level FortuneLevel, seed uint64) Reading {
+	reading := Reading{
+		Categories:     make([]CategoryReading, 0, len(c.Categories)),
+		LuckyColor:     pick(c.Colors, derive(seed, saltColor)),
+		LuckyItem:      pick(c.Items, derive(seed, saltItem)),
+		LuckyDirection: pick(c.Directions, derive(seed, saltDirection)),
+	}
+
+	for n, category := range c.Categories {
+		tier := chooseTier(level, derive(seed, uint64(2*n+1)))
+		reading.Categories = append(reading.Categories, CategoryReading{
+			Category: category,
+			Tier:     tier,
+			Text:     pick(category.Readings[tier], derive(seed, uint64(2*n+2))),
+		})
+	}
+	return reading
+}
+
+func chooseTier(level FortuneLevel, value uint64) Tier {
+	weights := tierWeights[level]
+	v := int(value % 1000)
+	for n, weight := range weights {
+		if v < weight {
+			return Tiers[n]
+		}
+		v -= weight
+	}
+	return TierBad
+}
+
+func pick(texts []LocalizedText, value uint64) LocalizedText {
+	return texts[value%uint64(len(texts))]
+}
+
+// derive はシード値とソルトから独立した値を導出する（SplitMix64）
+func derive(seed, salt uint64) uint64 {
+	z := seed + salt*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,38 @@
+package omikuji
+
+import (
+	"context"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+type Repository interface {
+	// Save は記録を保存する。同じギルド・ユーザー・日付の記録が既にあれば何もせず false を返す
+	Save(ctx context.Context, draw *Draw) (bool, error)
+	// Find はギルド・ユーザー・日付の記録を返す。無ければ ErrDrawNotFound を返す
+	Find(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID, date time.Time) (*Draw, error)
+	FindByUser(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) ([]*Draw, error)
+	FindByDate(ctx context.Context, guildID discordid.GuildID, date time.Time) ([]*Draw, error)
+}
+
+type Repositories interface {
+	OmikujiDraw(tx db.Tx) Repository
+}
+
+// SettingsRepository はギルドごとのおみくじの設定と差し替え設定を保存する
+type SettingsRepository interface {
+	FindSettings(ctx context.Context, guildID discordid.GuildID) (*GuildSettings, error)
+	SaveSettings(ctx context.Context, settings *GuildSettings) error
+	DeleteSettings(ctx context.Context, guildID discordid.GuildID) error
+
+	FindOverrides(ctx context.Context, guildID discordid.GuildID) ([]*Override, error)
+	SaveOverride(ctx context.Context, override *Override) error
+	DeleteOverride(ctx context.Context, guildID discordid.GuildID, id OverrideID) error
+	DeleteOverrides(ctx context.Context, guildID discordid.GuildID) error
+}
+
+type SettingsRepositories interface {
+	OmikujiSettings(tx db.Tx) SettingsRepository
+}
@@ -0,0 +1,227 @@
+package omikuji
+
+import (
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/google/uuid"
+)
+
+// DefaultTimezone はおみくじの日付の区切りに使う既定のタイムゾーン
+const DefaultTimezone = "Asia/Tokyo"
+
+// defaultLocation は DefaultTimezone のロケーション
+// tzdata が無い環境でも動くよう、読み込めない場合は固定の UTC+9 を使う
+var defaultLocation = loadDefaultLocation()
+
+func loadDefaultLocation() *time.Location {
+	if loc, err := time.LoadLocation(DefaultTimezone); err == nil {
+		return loc
+	}
+	return time.FixedZone(DefaultTimezone, 9*60*60)
+}
+
+// GuildSettings はギルドごとのおみくじの確率分布とタイムゾーン
+type GuildSettings struct {
+	guildID      discordid.GuildID
+	timezone     string
+	location     *time.Location
+	distribution Distribution
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+func (s *GuildSettings) GuildID() discordid.GuildID {
+	return s.guildID
+}
+
+// Timezone は IANA のタイムゾーン名
+func (s *GuildSettings) Timezone() string {
+	return s.timezone
+}
+
+func (s *GuildSettings) Location() *time.Location {
+	return s.location
+}
+
+func (s *GuildSettings) Distribution() Distribution {
+	return s.distribution
+}
+
+func (s *GuildSettings) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *GuildSettings) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// ChangeDistribution は確率分布を変更する
+func (s *GuildSettings) ChangeDistribution(distribution Distribution) error {
+	if _, err := NewDistribution(distribution.Permilles()); err != nil {
+		return err
+	}
+	s.distribution = distribution
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// ChangeTimezone はタイムゾーンを変更する
+func (s *GuildSettings) ChangeTimezone(timezone string) error {
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return err
+	}
+	s.timezone = timezone
+	s.location = loc
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// DefaultGuildSettings は設定が無いギルド（および DM）で使う既定の設定を返す
+// 既定の確率分布と Asia/Tokyo の日付の区切りは従来の動作と同じ
+func DefaultGuildSettings(guildID discordid.GuildID) *GuildSettings {
+	now := time.Now()
+	return &GuildSettings{
+		guildID:      guildID,
+		timezone:     DefaultTimezone,
+		location:     defaultLocation,
+		distribution: DefaultDistribution,
+		createdAt:    now,
+		updatedAt:    now,
+	}
+}
+
+func NewGuildSettings(guildID discordid.GuildID) (*GuildSettings, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	return DefaultGuildSettings(guildID), nil
+}
+
+func RebuildGuildSettings(guildID discordid.GuildID, timezone string, permilles []int, createdAt, updatedAt time.Time) (*GuildSettings, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	loc, err := loadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	distribution, err := NewDistribution(permilles)
+	if err != nil {
+		return nil, err
+	}
+	return &GuildSettings{
+		guildID:      guildID,
+		timezone:     timezone,
+		location:     loc,
+		distribution: distribution,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}, nil
+}
+
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == DefaultTimezone {
+		return defaultLocation, nil
+	}
+	// "Local" はホストの設定に依存するため受け付けない
+	if timezone == "" || timezone == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+type OverrideID string
+
+// Override は年始などの期間だけ確率分布を差し替える設定
+// 期間は開始日・終了日を含み、ギルドのタイムゾーンでの日付で判定する
+type Override struct {
+	id           OverrideID
+	guildID      discordid.GuildID
+	label        string
+	startDate    time.Time
+	endDate      time.Time
+	distribution Distribution
+	createdAt    time.Time
+}
+
+func (o *Override) ID() OverrideID {
+	return o.id
+}
+
+func (o *Override) GuildID() discordid.GuildID {
+	return o.guildID
+}
+
+func (o *Override) Label() string {
+	return o.label
+}
+
+func (o *Override) StartDate() time.Time {
+	return o.startDate
+}
+
+func (o *Override) EndDate() time.Time {
+	return o.endDate
+}
+
+func (o *Override) Distribution() Distribution {
+	return o.distribution
+}
+
+func (o *Override) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// Covers は指定した日付が期間内かどうかを返す
+func (o *Override) Covers(date time.Time) bool {
+	date = DateOf(date)
+	return !date.Before(o.startDate) && !date.After(o.endDate)
+}
+
+func NewOverride(guildID discordid.GuildID, label string, startDate, endDate time.Time, distribution Distribution) (*Override, error) {
+	return RebuildOverride(OverrideID(uuid.New().String()), guildID, label, startDate, endDate, distribution.Permilles(), time.Now())
+}
+
+func RebuildOverride(id OverrideID, guildID discordid.GuildID, label string, startDate, endDate time.Time, permilles []int, createdAt time.Time) (*Override, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	startDate, endDate = DateOf(startDate), DateOf(endDate)
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+	distribution, err := NewDistribution(permilles)
+	if err != nil {
+		return nil, err
+	}
+	return &Override{
+		id:           id,
+		guildID:      guildID,
+		label:        label,
+		startDate:    startDate,
+		endDate:      endDate,
+		distribution: distribution,
+		createdAt:    createdAt,
+	}, nil
+}
+
+// ResolveDistribution は指定した日付に使う確率分布を返す
+// 期間内の差し替え設定があればそれを（複数あれば最後に作成したものを）、無ければギルドの設定を使う
+func ResolveDistribution(settings *GuildSettings, overrides []*Override, date time.Time) Distribution {
+	var active *Override
+	for _, o := range overrides {
+		if o.Covers(date) && (active == nil || o.createdAt.After(active.createdAt)) {
+			active = o
+		}
+	}
+	if active != nil {
+		return active.distribution
+	}
+	return settings.distribution
+}
@@ -0,0 +1,81 @@
+package omikuji
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGuildSettings(t *testing.T) {
+	if _, err := NewGuildSettings(""); !errors.Is(err, ErrInvalidGuildID) {
+		t.Errorf("expected ErrInvalidGuildID, got %v", err)
+	}
+
+	s, err := NewGuildSettings("g")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Timezone() != DefaultTimezone || s.Distribution().String() != DefaultDistribution.String() {
+		t.Errorf("expected default settings, got %s %s", s.Timezone(), s.Distribution())
+	}
+
+	if err := s.ChangeTimezone("America/New_York"); err != nil {
+		t.Fatalf("ChangeTimezone: %v", err)
+	}
+	if s.Location().String() != "America/New_York" {
+		t.Errorf("expected America/New_York, got %s", s.Location())
+	}
+
+	for _, tz := range []string{"", "Local", "Mars/Olympus_Mons"} {
+		if err := s.ChangeTimezone(tz); !errors.Is(err, ErrInvalidTimezone) {
+			t.Errorf("ChangeTimezone(%q): expected ErrInvalidTimezone, got %v", tz, err)
+		}
+	}
+	if s.Timezone() != "America/New_York" {
+		t.Errorf("expected timezone to be unchanged after invalid input, got %s", s.Timezone())
+	}
+
+	if _, err := RebuildGuildSettings("g", "UTC", []int{1, 2, 3}, time.Now(), time.Now()); !errors.Is(err, ErrInvalidDistribution) {
+		t.Errorf("expected ErrInvalidDistribution, got %v", err)
+	}
+}
+
+func TestNewOverrideValidatesDateRange(t *testing.T) {
+	if _, err := NewOverride("g", "", date(5), date(4), DefaultDistribution); !errors.Is(err, ErrInvalidDateRange) {
+		t.Errorf("expected ErrInvalidDateRange, got %v", err)
+	}
+	if _, err := NewOverride("g", "", date(4), date(4), DefaultDistribution); err != nil {
+		t.Errorf("expected single-day override to be valid, got %v", err)
+	}
+}
+
+func TestResolveDistribution(t *testing.T) {
+	settings := DefaultGuildSettings("g")
+	newYear, _ := ParseDistribution("5/30/20/20/20/5/0")
+	lucky, _ := ParseDistribution("10/50/20/10/10/0/0")
+
+	first, _ := RebuildOverride("a", "g", "正月", date(1), date(3), newYear.Permilles(), date(1))
+	second, _ := RebuildOverride("b", "g", "特別", date(3), date(3), lucky.Permilles(), date(2))
+	overrides := []*Override{second, first}
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected Distribution
+	}{
+		{name: "before range", date: date(0), expected: DefaultDistribution},
+		{name: "start date", date: date(1), expected: newYear},
+		{name: "late on end date", date: date(2).Add(23 * time.Hour), expected: newYear},
+		{name: "overlap uses newest", date: date(3), expected: lucky},
+		{name: "after range", date: date(4), expected: DefaultDistribution},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveDistribution(settings, overrides, tt.date)
+			if got.String() != tt.expected.String() {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package ping
+
+import "time"
+
+// Quality は応答時間の評価
+type Quality int
+
+const (
+	Good Quality = iota // 良好
+	Fair                // やや遅い
+	Poor                // 遅い
+)
+
+const (
+	// GoodThreshold 未満の応答時間は良好とみなす
+	GoodThreshold = 150 * time.Millisecond
+	// FairThreshold 未満の応答時間はやや遅いとみなし、それ以上は遅いとみなす
+	FairThreshold = 400 * time.Millisecond
+)
+
+// Rate は応答時間を評価する
+func Rate(d time.Duration) Quality {
+	switch {
+	case d < GoodThreshold:
+		return Good
+	case d < FairThreshold:
+		return Fair
+	default:
+		return Poor
+	}
+}
+
+// Worst は複数の応答時間のうち最も悪い評価を返す
+func Worst(durations ...time.Duration) Quality {
+	worst := Good
+	for _, d := range durations {
+		worst = max(worst, Rate(d))
+	}
+	return worst
+}
+
+// Sample はある時点で計測したハートビートの遅延
+type Sample struct {
+	At      time.Time
+	Latency time.Duration
+}
+
+// History は直近のハートビート遅延を一定件数だけ保持するリングバッファ
+// 並行アクセスの保護は呼び出し側で行う
+type History struct {
+	samples []Sample
+	next    int
+	full    bool
+}
+
+// NewHistory は size 件まで保持する History を生成する
+func NewHistory(size int) *History {
+	return &History{samples: make([]Sample, size)}
+}
+
+// Add はサンプルを追加し、上限を超えた場合は最も古いサンプルを捨てる
+func (h *History) Add(sample Sample) {
+	if len(h.samples) == 0 {
+		return
+	}
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Samples は保持しているサンプルを古い順に返す
+func (h *History) Samples() []Sample {
+	if !h.full {
+		return append([]Sample(nil), h.samples[:h.next]...)
+	}
+	result := make([]Sample, 0, len(h.samples))
+	result = append(result, h.samples[h.next:]...)
+	return append(result, h.samples[:h.next]...)
+}
+
+// Summary はサンプルの最小・平均・最大を返す。サンプルが無い場合は ok が false
+func Summary(samples []Sample) (minimum, average, maximum time.Duration, ok bool) {
+	if len(samples) == 0 {
+		return 0, 0, 0, false
+	}
+	minimum, maximum = samples[0].Latency, samples[0].Latency
+	var total time.Duration
+	for _, s := range samples {
+		minimum = min(minimum, s.Latency)
+		maximum = max(maximum, s.Latency)
+		total += s.Latency
+	}
+	return minimum, total / time.Duration(len(samples)), maximum, true
+}
@@ -0,0 +1,61 @@
+package ping
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		latency  time.Duration
+		expected Quality
+	}{
+		{latency: 0, expected: Good},
+		{latency: GoodThreshold - time.Millisecond, expected: Good},
+		{latency: GoodThreshold, expected: Fair},
+		{latency: FairThreshold - time.Millisecond, expected: Fair},
+		{latency: FairThreshold, expected: Poor},
+		{latency: 3 * time.Second, expected: Poor},
+	}
+
+	for _, tt := range tests {
+		if got := Rate(tt.latency); got != tt.expected {
+			t.Errorf("Rate(%s): expected %d, got %d", tt.latency, tt.expected, got)
+		}
+	}
+
+	if got := Worst(10*time.Millisecond, FairThreshold, GoodThreshold); got != Poor {
+		t.Errorf("expected Worst to be Poor, got %d", got)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(3)
+	if len(h.Samples()) != 0 {
+		t.Fatal("expected empty history")
+	}
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for n := 1; n <= 5; n++ {
+		h.Add(Sample{At: base.Add(time.Duration(n) * time.Minute), Latency: time.Duration(n) * time.Millisecond})
+	}
+
+	samples := h.Samples()
+	if len(samples) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(samples))
+	}
+	for idx, expected := range []time.Duration{3, 4, 5} {
+		if samples[idx].Latency != expected*time.Millisecond {
+			t.Errorf("sample %d: expected %dms, got %s", idx, expected, samples[idx].Latency)
+		}
+	}
+
+	minimum, average, maximum, ok := Summary(samples)
+	if !ok || minimum != 3*time.Millisecond || average != 4*time.Millisecond || maximum != 5*time.Millisecond {
+		t.Errorf("unexpected summary: min=%s avg=%s max=%s ok=%v", minimum, average, maximum, ok)
+	}
+
+	if _, _, _, ok := Summary(nil); ok {
+		t.Error("expected ok=false for empty samples")
+	}
+}
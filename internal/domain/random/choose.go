@@ -0,0 +1,48 @@
+package random
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// MaxChoices は選択肢の最大数
+const MaxChoices = 100
+
+// ParseChoices はカンマ・読点・改行で区切った選択肢を返す。前後の空白と空の選択肢は除く
+func ParseChoices(text string) ([]string, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == '、' || r == '，' || r == '\n'
+	})
+	var choices []string
+	for _, field := range fields {
+		if choice := strings.TrimSpace(field); choice != "" {
+			choices = append(choices, choice)
+		}
+	}
+	if len(choices) == 0 {
+		return nil, ErrNoChoices
+	}
+	if len(choices) > MaxChoices {
+		return nil, fmt.Errorf("%w: more than %d", ErrTooManyChoices, MaxChoices)
+	}
+	return choices, nil
+}
+
+// Choose は choices から重複しないように count 個を選び、選んだ順に返す
+func Choose(rng *rand.Rand, choices []string, count int) ([]string, error) {
+	if len(choices) == 0 {
+		return nil, ErrNoChoices
+	}
+	if count < 1 || count > len(choices) {
+		return nil, fmt.Errorf("%w: %d of %d", ErrInvalidPickCount, count, len(choices))
+	}
+	picked := make([]string, len(choices))
+	copy(picked, choices)
+	// 先頭から count 個だけ Fisher-Yates で混ぜる
+	for n := range count {
+		k := n + rng.IntN(len(picked)-n)
+		picked[n], picked[k] = picked[k], picked[n]
+	}
+	return picked[:count], nil
+}
@@ -0,0 +1,45 @@
+package random
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseChoices(t *testing.T) {
+	got, err := ParseChoices(" ラーメン,カレー、 , 寿司 ")
+	if err != nil {
+		t.Fatalf("ParseChoices: %v", err)
+	}
+	if want := []string{"ラーメン", "カレー", "寿司"}; !slices.Equal(got, want) {
+		t.Errorf("ParseChoices = %q, want %q", got, want)
+	}
+	if _, err := ParseChoices(" , 、"); !errors.Is(err, ErrNoChoices) {
+		t.Errorf("ParseChoices of blanks = %v, want ErrNoChoices", err)
+	}
+}
+
+func TestChoose(t *testing.T) {
+	choices := []string{"a", "b", "c", "d", "e"}
+	for seed := range uint64(20) {
+		got, err := Choose(mustRand(t, seed), choices, 3)
+		if err != nil {
+			t.Fatalf("Choose: %v", err)
+		}
+		if len(got) != 3 || got[0] == got[1] || got[1] == got[2] || got[0] == got[2] {
+			t.Errorf("Choose = %q, want 3 distinct choices", got)
+		}
+		again, _ := Choose(mustRand(t, seed), choices, 3)
+		if !slices.Equal(got, again) {
+			t.Errorf("same seed gave %q and %q", got, again)
+		}
+	}
+	if !slices.Equal(choices, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("Choose modified the choices: %q", choices)
+	}
+	for _, count := range []int{0, 6} {
+		if _, err := Choose(mustRand(t, 0), choices, count); !errors.Is(err, ErrInvalidPickCount) {
+			t.Errorf("Choose(count %d) = %v, want ErrInvalidPickCount", count, err)
+		}
+	}
+}
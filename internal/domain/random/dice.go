@@ -0,0 +1,399 @@
+package random
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxExpressionLength はダイスの式の最大文字数
+	MaxExpressionLength = 100
+	// MaxDice は1つの式で振れるダイスの合計の数
+	MaxDice = 200
+	// MaxSides はダイスの面の数の上限
+	MaxSides = 1000
+	// maxNumber は式に書ける数の上限
+	maxNumber = 1_000_000
+	// maxValue は計算の途中と結果の絶対値の上限
+	maxValue = 1_000_000_000_000
+)
+
+// KeepMode は振ったダイスのうち合計に数えるものの選び方
+type KeepMode int
+
+const (
+	KeepAll KeepMode = iota
+	// KeepHighest は大きい目から Keep 個を数える（"4d6kh3"）
+	KeepHighest
+	// KeepLowest は小さい目から Keep 個を数える（"2d20kl1"）
+	KeepLowest
+)
+
+// DiceRoll は式の中の1つのダイスの項（"2d6" など）を振った結果
+type DiceRoll struct {
+	Count    int
+	Sides    int
+	KeepMode KeepMode
+	Keep     int
+	// Rolls は出た目を振った順に並べたもの
+	Rolls []int
+	// Kept は Rolls の各目を合計に数えたかどうか
+	Kept []bool
+	Sum  int
+}
+
+// Notation は項を "4d6kh3" のような表記で返す
+func (d DiceRoll) Notation() string {
+	notation := fmt.Sprintf("%dd%d", d.Count, d.Sides)
+	switch d.KeepMode {
+	case KeepHighest:
+		notation += fmt.Sprintf("kh%d", d.Keep)
+	case KeepLowest:
+		notation += fmt.Sprintf("kl%d", d.Keep)
+	}
+	return notation
+}
+
+// RollResult はダイスの式を振った結果
+type RollResult struct {
+	// Expression は空白を除いた小文字の式
+	Expression string
+	Total      int
+	// Dice は式に現れた順のダイスの項の結果
+	Dice []DiceRoll
+}
+
+// DiceExpression は構文解析したダイスの式
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | dice | "(" expr ")"
+//	dice    = [number] "d" (number | "%") [("kh" | "kl" | "k") number]
+type DiceExpression struct {
+	source string
+	root   node
+}
+
+// ParseDice はダイスの式を構文解析する。大文字と小文字は区別せず、項の間の空白は無視する
+func ParseDice(expression string) (*DiceExpression, error) {
+	if len(expression) > MaxExpressionLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidExpression, MaxExpressionLength)
+	}
+	p := &parser{source: strings.ToLower(expression)}
+	if p.peek() == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidExpression)
+	}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if c := p.peek(); c != 0 {
+		return nil, p.errorf("unexpected %q", c)
+	}
+	if p.dice > MaxDice {
+		return nil, fmt.Errorf("%w: more than %d dice", ErrTooManyDice, MaxDice)
+	}
+	return &DiceExpression{source: strings.Join(strings.Fields(p.source), ""), root: root}, nil
+}
+
+// String は空白を除いた小文字の式を返す
+func (e *DiceExpression) String() string {
+	return e.source
+}
+
+// Roll は rng でダイスを式の左から順に振り、式を計算する
+func (e *DiceExpression) Roll(rng *rand.Rand) (RollResult, error) {
+	ev := &evaluator{rng: rng}
+	total, err := ev.eval(e.root)
+	if err != nil {
+		return RollResult{}, err
+	}
+	return RollResult{Expression: e.source, Total: total, Dice: ev.dice}, nil
+}
+
+type node any
+
+type numberNode struct {
+	value int
+}
+
+type diceNode struct {
+	count    int
+	sides    int
+	keepMode KeepMode
+	keep     int
+}
+
+type negNode struct {
+	operand node
+}
+
+type binaryNode struct {
+	op          byte
+	left, right node
+}
+
+// parser は再帰下降でダイスの式を構文解析する
+type parser struct {
+	source string
+	pos    int
+	// dice は式に現れたダイスの数の合計
+	dice int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidExpression, fmt.Sprintf(format, args...), p.pos+1)
+}
+
+// peek は空白を読み飛ばして次の文字を返す。式の終わりなら 0
+func (p *parser) peek() byte {
+	for p.pos < len(p.source) && strings.IndexByte(" \t\n", p.source[p.pos]) >= 0 {
+		p.pos++
+	}
+	if p.pos < len(p.source) {
+		return p.source[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek() == '-' {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	switch c := p.peek(); {
+	case c == '(':
+		p.pos++
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("missing )")
+		}
+		p.pos++
+		return inner, nil
+	case c == 'd':
+		return p.parseDice(1)
+	case isDigit(c):
+		n, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() == 'd' {
+			return p.parseDice(n)
+		}
+		return numberNode{value: n}, nil
+	case c == 0:
+		return nil, p.errorf("unexpected end")
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+// parseDice は "d" から始まるダイスの項を読む。count は "d" の前に書かれた個数
+func (p *parser) parseDice(count int) (node, error) {
+	p.pos++ // "d"
+	if count < 1 || count > MaxDice {
+		return nil, fmt.Errorf("%w: %d dice in one roll", ErrTooManyDice, count)
+	}
+
+	var sides int
+	if p.peek() == '%' {
+		p.pos++
+		sides = 100
+	} else {
+		if !isDigit(p.peek()) {
+			return nil, p.errorf("missing number of sides")
+		}
+		var err error
+		if sides, err = p.parseNumber(); err != nil {
+			return nil, err
+		}
+	}
+	if sides < 2 || sides > MaxSides {
+		return nil, fmt.Errorf("%w: d%d", ErrInvalidDie, sides)
+	}
+
+	dice := diceNode{count: count, sides: sides}
+	if p.peek() == 'k' {
+		p.pos++
+		dice.keepMode = KeepHighest
+		switch p.peek() {
+		case 'h':
+			p.pos++
+		case 'l':
+			p.pos++
+			dice.keepMode = KeepLowest
+		}
+		if !isDigit(p.peek()) {
+			return nil, p.errorf("missing number of dice to keep")
+		}
+		keep, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		if keep < 1 || keep > count {
+			return nil, p.errorf("cannot keep %d of %d dice", keep, count)
+		}
+		dice.keep = keep
+	}
+
+	p.dice += count
+	return dice, nil
+}
+
+// parseNumber は数字の並びを読む。"2 3" を 23 と読まないよう、数字の間の空白は読み飛ばさない
+func (p *parser) parseNumber() (int, error) {
+	start := p.pos
+	for p.pos < len(p.source) && isDigit(p.source[p.pos]) {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.source[start:p.pos])
+	if err != nil || n > maxNumber {
+		return 0, fmt.Errorf("%w: number %s is too large", ErrInvalidExpression, p.source[start:p.pos])
+	}
+	return n, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// evaluator は構文木を計算し、振ったダイスを記録する
+type evaluator struct {
+	rng  *rand.Rand
+	dice []DiceRoll
+}
+
+func (ev *evaluator) eval(n node) (int, error) {
+	switch n := n.(type) {
+	case numberNode:
+		return n.value, nil
+	case diceNode:
+		return ev.roll(n), nil
+	case negNode:
+		v, err := ev.eval(n.operand)
+		return -v, err
+	case binaryNode:
+		left, err := ev.eval(n.left)
+		if err != nil {
+			return 0, err
+		}
+		right, err := ev.eval(n.right)
+		if err != nil {
+			return 0, err
+		}
+		return apply(n.op, left, right)
+	default:
+		return 0, fmt.Errorf("%w: unknown node %T", ErrInvalidExpression, n)
+	}
+}
+
+func (ev *evaluator) roll(n diceNode) int {
+	d := DiceRoll{Count: n.count, Sides: n.sides, KeepMode: n.keepMode, Keep: n.keep}
+	d.Rolls = make([]int, n.count)
+	for k := range d.Rolls {
+		d.Rolls[k] = ev.rng.IntN(n.sides) + 1
+	}
+
+	d.Kept = make([]bool, n.count)
+	order := make([]int, n.count)
+	for k := range order {
+		order[k] = k
+	}
+	// 同じ目なら先に振ったダイスを残す
+	slices.SortStableFunc(order, func(a, b int) int {
+		if n.keepMode == KeepLowest {
+			return d.Rolls[a] - d.Rolls[b]
+		}
+		return d.Rolls[b] - d.Rolls[a]
+	})
+	keep := n.count
+	if n.keepMode != KeepAll {
+		keep = n.keep
+	}
+	for _, k := range order[:keep] {
+		d.Kept[k] = true
+		d.Sum += d.Rolls[k]
+	}
+
+	ev.dice = append(ev.dice, d)
+	return d.Sum
+}
+
+// apply は二項演算を計算する。除算は 0 に向かって切り捨てる
+func apply(op byte, left, right int) (int, error) {
+	var v int
+	switch op {
+	case '+':
+		v = left + right
+	case '-':
+		v = left - right
+	case '*':
+		if left != 0 && abs(right) > maxValue/abs(left) {
+			return 0, ErrResultTooLarge
+		}
+		v = left * right
+	case '/':
+		if right == 0 {
+			return 0, ErrDivisionByZero
+		}
+		v = left / right
+	}
+	if abs(v) > maxValue {
+		return 0, ErrResultTooLarge
+	}
+	return v, nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,162 @@
+package random
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func mustRand(t *testing.T, seed uint64) *rand.Rand {
+	t.Helper()
+	rng, err := NewRand(seed)
+	if err != nil {
+		t.Fatalf("NewRand: %v", err)
+	}
+	return rng
+}
+
+func mustRoll(t *testing.T, expression string, seed uint64) RollResult {
+	t.Helper()
+	expr, err := ParseDice(expression)
+	if err != nil {
+		t.Fatalf("ParseDice(%q): %v", expression, err)
+	}
+	result, err := expr.Roll(mustRand(t, seed))
+	if err != nil {
+		t.Fatalf("Roll(%q): %v", expression, err)
+	}
+	return result
+}
+
+func TestParseDice_Arithmetic(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       int
+	}{
+		{"1+2*3", 7},
+		{"(1+2)*3", 9},
+		{"10 - 4 - 3", 3},
+		{"-3+10/3", 0},
+		{"2*-3", -6},
+		{"7/-2", -3},
+		{"--4", 4},
+		{"((2))", 2},
+	}
+	for _, tt := range tests {
+		if got := mustRoll(t, tt.expression, 0); got.Total != tt.want || len(got.Dice) != 0 {
+			t.Errorf("%q = %d with %d dice, want %d without dice", tt.expression, got.Total, len(got.Dice), tt.want)
+		}
+	}
+}
+
+func TestParseDice_Roll(t *testing.T) {
+	for seed := range uint64(50) {
+		got := mustRoll(t, "2d6+3", seed)
+		if got.Expression != "2d6+3" || len(got.Dice) != 1 {
+			t.Fatalf("2d6+3 = %+v, want one dice term", got)
+		}
+		d := got.Dice[0]
+		if d.Count != 2 || d.Sides != 6 || len(d.Rolls) != 2 {
+			t.Fatalf("dice = %+v, want 2d6", d)
+		}
+		for _, r := range d.Rolls {
+			if r < 1 || r > 6 {
+				t.Errorf("roll %d is out of 1..6", r)
+			}
+		}
+		if d.Sum != d.Rolls[0]+d.Rolls[1] || got.Total != d.Sum+3 {
+			t.Errorf("sum %d total %d for rolls %v", d.Sum, got.Total, d.Rolls)
+		}
+	}
+}
+
+func TestParseDice_Keep(t *testing.T) {
+	for seed := range uint64(50) {
+		high := mustRoll(t, "4d6kh3", seed).Dice[0]
+		sorted := slices.Sorted(slices.Values(high.Rolls))
+		if want := sorted[1] + sorted[2] + sorted[3]; high.Sum != want {
+			t.Errorf("4d6kh3 rolls %v sum %d, want %d", high.Rolls, high.Sum, want)
+		}
+		low := mustRoll(t, "2D20 kl1", seed).Dice[0]
+		if want := slices.Min(low.Rolls); low.Sum != want {
+			t.Errorf("2d20kl1 rolls %v sum %d, want %d", low.Rolls, low.Sum, want)
+		}
+		if kept := slices.Index(low.Kept, true); low.Rolls[kept] != low.Sum || slices.Index(low.Kept[kept+1:], true) >= 0 {
+			t.Errorf("2d20kl1 kept %v of %v, want only the lowest", low.Kept, low.Rolls)
+		}
+	}
+	if got := mustRoll(t, "4d6k3", 1).Dice[0].Notation(); got != "4d6kh3" {
+		t.Errorf("Notation = %q, want 4d6kh3", got)
+	}
+}
+
+func TestParseDice_Notation(t *testing.T) {
+	got := mustRoll(t, "d% + 3 d8", 7)
+	if got.Expression != "d%+3d8" || len(got.Dice) != 2 {
+		t.Fatalf("d%% + 3 d8 = %+v, want two dice terms", got)
+	}
+	if got.Dice[0].Notation() != "1d100" || got.Dice[1].Notation() != "3d8" {
+		t.Errorf("notations = %s %s, want 1d100 3d8", got.Dice[0].Notation(), got.Dice[1].Notation())
+	}
+}
+
+func TestParseDice_Reproducible(t *testing.T) {
+	a := mustRoll(t, "10d20+d4", 42)
+	b := mustRoll(t, "10d20+d4", 42)
+	if a.Total != b.Total || !slices.Equal(a.Dice[0].Rolls, b.Dice[0].Rolls) {
+		t.Errorf("same seed gave %v and %v", a.Dice[0].Rolls, b.Dice[0].Rolls)
+	}
+}
+
+func TestParseDice_Invalid(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       error
+	}{
+		{"", ErrInvalidExpression},
+		{"  ", ErrInvalidExpression},
+		{"2d", ErrInvalidExpression},
+		{"2d6 3", ErrInvalidExpression},
+		{"(1+2", ErrInvalidExpression},
+		{"2d6kh3", ErrInvalidExpression},
+		{"abc", ErrInvalidExpression},
+		{"1+", ErrInvalidExpression},
+		{"2d1", ErrInvalidDie},
+		{"d1001", ErrInvalidDie},
+		{"0d6", ErrTooManyDice},
+		{"201d6", ErrTooManyDice},
+		{"100d6+101d6", ErrTooManyDice},
+	}
+	for _, tt := range tests {
+		if _, err := ParseDice(tt.expression); !errors.Is(err, tt.want) {
+			t.Errorf("ParseDice(%q) = %v, want %v", tt.expression, err, tt.want)
+		}
+	}
+}
+
+func TestDiceExpression_RollErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       error
+	}{
+		{"1/0", ErrDivisionByZero},
+		{"d6/(1-1)", ErrDivisionByZero},
+		{"1000000*1000000*1000", ErrResultTooLarge},
+	}
+	for _, tt := range tests {
+		expr, err := ParseDice(tt.expression)
+		if err != nil {
+			t.Fatalf("ParseDice(%q): %v", tt.expression, err)
+		}
+		if _, err := expr.Roll(mustRand(t, 0)); !errors.Is(err, tt.want) {
+			t.Errorf("Roll(%q) = %v, want %v", tt.expression, err, tt.want)
+		}
+	}
+}
+
+func TestNewRand_InvalidSeed(t *testing.T) {
+	if _, err := NewRand(MaxSeed + 1); !errors.Is(err, ErrInvalidSeed) {
+		t.Errorf("NewRand(MaxSeed+1) = %v, want ErrInvalidSeed", err)
+	}
+}
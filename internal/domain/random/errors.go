@@ -0,0 +1,19 @@
+package random
+
+import "errors"
+
+var (
+	ErrInvalidExpression = errors.New("invalid dice expression")
+	ErrTooManyDice       = errors.New("too many dice")
+	ErrInvalidDie        = errors.New("invalid number of sides")
+	ErrDivisionByZero    = errors.New("division by zero")
+	ErrResultTooLarge    = errors.New("dice result is too large")
+	ErrNoChoices         = errors.New("no choices")
+	ErrTooManyChoices    = errors.New("too many choices")
+	ErrInvalidPickCount  = errors.New("invalid number of picks")
+	ErrInvalidTeamCount  = errors.New("invalid number of teams")
+	ErrNotEnoughMembers  = errors.New("not enough members for the teams")
+	ErrInvalidSeed       = errors.New("invalid seed")
+	ErrNotInVoiceChannel = errors.New("user is not in a voice channel")
+	ErrMoveNotPermitted  = errors.New("user is not permitted to move members")
+)
@@ -0,0 +1,22 @@
+package random
+
+import "math/rand/v2"
+
+// MaxSeed はシードの最大値。コマンドのオプションで指定しやすいよう 9 桁に収める
+const MaxSeed = 999_999_999
+
+// seedStream は PCG のストリーム。同じシードなら常に同じ結果になるよう固定する
+const seedStream = 0x72616e646f6d
+
+// NewRand はシードから乱数生成器を作る。同じシードなら常に同じ乱数列を返す
+func NewRand(seed uint64) (*rand.Rand, error) {
+	if seed > MaxSeed {
+		return nil, ErrInvalidSeed
+	}
+	return rand.New(rand.NewPCG(seed, seedStream)), nil
+}
+
+// RandomSeed はシードを指定しなかった場合に使うシードを選ぶ
+func RandomSeed() uint64 {
+	return rand.Uint64N(MaxSeed + 1)
+}
@@ -0,0 +1,37 @@
+package random
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+const (
+	MinTeams = 2
+	MaxTeams = 10
+)
+
+// SplitTeams は members を無作為に count チームに分ける。各チームの人数の差は 1 人以内になる
+// 同じシードなら、ボイスチャンネルのメンバーの並び順によらず同じチーム分けになるよう、ID の順に並べてから混ぜる
+func SplitTeams(rng *rand.Rand, members []discordid.UserID, count int) ([][]discordid.UserID, error) {
+	if count < MinTeams || count > MaxTeams {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidTeamCount, count)
+	}
+	if len(members) < count {
+		return nil, fmt.Errorf("%w: %d members for %d teams", ErrNotEnoughMembers, len(members), count)
+	}
+
+	shuffled := slices.Clone(members)
+	slices.Sort(shuffled)
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	teams := make([][]discordid.UserID, count)
+	for n, member := range shuffled {
+		teams[n%count] = append(teams[n%count], member)
+	}
+	return teams, nil
+}
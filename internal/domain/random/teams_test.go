@@ -0,0 +1,51 @@
+package random
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+func TestSplitTeams(t *testing.T) {
+	members := []discordid.UserID{"1", "2", "3", "4", "5", "6", "7"}
+	teams, err := SplitTeams(mustRand(t, 5), members, 3)
+	if err != nil {
+		t.Fatalf("SplitTeams: %v", err)
+	}
+	var all []discordid.UserID
+	for _, team := range teams {
+		if len(team) < 2 || len(team) > 3 {
+			t.Errorf("team %v has %d members, want 2 or 3", team, len(team))
+		}
+		all = append(all, team...)
+	}
+	slices.Sort(all)
+	if !slices.Equal(all, members) {
+		t.Errorf("teams %v do not contain every member once", teams)
+	}
+
+	// メンバーの並び順が違っても同じシードなら同じチーム分けになる
+	reversed := slices.Clone(members)
+	slices.Reverse(reversed)
+	again, err := SplitTeams(mustRand(t, 5), reversed, 3)
+	if err != nil {
+		t.Fatalf("SplitTeams: %v", err)
+	}
+	for n := range teams {
+		if !slices.Equal(teams[n], again[n]) {
+			t.Errorf("team %d = %v and %v for the same seed", n+1, teams[n], again[n])
+		}
+	}
+}
+
+func TestSplitTeams_Invalid(t *testing.T) {
+	members := []discordid.UserID{"1", "2", "3"}
+	if _, err := SplitTeams(mustRand(t, 0), members, 1); !errors.Is(err, ErrInvalidTeamCount) {
+		t.Errorf("SplitTeams(1 team) = %v, want ErrInvalidTeamCount", err)
+	}
+	if _, err := SplitTeams(mustRand(t, 0), members, 4); !errors.Is(err, ErrNotEnoughMembers) {
+		t.Errorf("SplitTeams(4 teams of 3) = %v, want ErrNotEnoughMembers", err)
+	}
+}
@@ -0,0 +1,16 @@
+package schedule
+
+import "errors"
+
+var (
+	ErrInvalidSpec      = errors.New("invalid schedule spec")
+	ErrNeverRuns        = errors.New("schedule spec never matches")
+	ErrTooFrequent      = errors.New("schedule runs too frequently")
+	ErrInvalidGuildID   = errors.New("invalid Guild ID")
+	ErrInvalidChannelID = errors.New("invalid Channel ID")
+	ErrInvalidLegend    = errors.New("invalid legend name")
+	ErrUnknownLegend    = errors.New("unknown legend")
+	ErrInvalidCatchUp   = errors.New("invalid catch-up policy")
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrTooManySchedules = errors.New("too many schedules")
+)
@@ -0,0 +1,210 @@
+package schedule
+
+import (
+	"slices"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxSchedulesPerGuild はギルドごとに登録できる予約投稿の上限
+	MaxSchedulesPerGuild = 10
+	// MinInterval は予約投稿の実行間隔の下限
+	MinInterval = time.Hour
+	// GracePeriod は実行時刻からの遅れを定刻の実行とみなす範囲
+	// これを超えて遅れた実行は停止中に逃したものとして CatchUp に従う
+	GracePeriod = 5 * time.Minute
+	// MaxCatchUpDelay は CatchUpLatest で逃した実行を後から投稿する範囲
+	// 長期間停止していた場合に古い予定を投稿しないようにする
+	MaxCatchUpDelay = 24 * time.Hour
+)
+
+// intervalSamples は MinInterval を確認するために調べる実行時刻の数
+// 間隔が1時間以上なら100回で丸4日以上になり、分と時のフィールドはすべて一巡する
+const intervalSamples = 100
+
+// CatchUp はボットの停止中に逃した実行の扱い
+type CatchUp string
+
+const (
+	// CatchUpSkip は逃した実行を投稿せず、次の予定から再開する
+	CatchUpSkip CatchUp = "skip"
+	// CatchUpLatest は MaxCatchUpDelay 以内に逃した実行があれば、再開時に1回だけ投稿する
+	CatchUpLatest CatchUp = "latest"
+)
+
+// CatchUps はすべての CatchUp
+var CatchUps = []CatchUp{CatchUpLatest, CatchUpSkip}
+
+// ParseCatchUp は識別子から CatchUp を復元する
+func ParseCatchUp(value string) (CatchUp, error) {
+	if !slices.Contains(CatchUps, CatchUp(value)) {
+		return "", ErrInvalidCatchUp
+	}
+	return CatchUp(value), nil
+}
+
+type ID string
+
+// Schedule はギルドのチャンネルに伝説エピソードを定期的に投稿する予約
+// 実行時刻はギルドのタイムゾーンで判定する
+type Schedule struct {
+	id        ID
+	guildID   discordid.GuildID
+	channelID discordid.TextChannelID
+	legend    string
+	spec      Spec
+	catchUp   CatchUp
+	nextRunAt time.Time
+	lastRunAt time.Time
+	createdBy discordid.UserID
+	createdAt time.Time
+}
+
+func (s *Schedule) ID() ID {
+	return s.id
+}
+
+func (s *Schedule) GuildID() discordid.GuildID {
+	return s.guildID
+}
+
+func (s *Schedule) ChannelID() discordid.TextChannelID {
+	return s.channelID
+}
+
+// Legend は投稿する伝説コマンドの名前
+func (s *Schedule) Legend() string {
+	return s.legend
+}
+
+func (s *Schedule) Spec() Spec {
+	return s.spec
+}
+
+func (s *Schedule) CatchUp() CatchUp {
+	return s.catchUp
+}
+
+// NextRunAt は次に実行する予定の時刻
+func (s *Schedule) NextRunAt() time.Time {
+	return s.nextRunAt
+}
+
+// LastRunAt は最後に投稿した時刻。まだ投稿していなければゼロ値
+func (s *Schedule) LastRunAt() time.Time {
+	return s.lastRunAt
+}
+
+func (s *Schedule) CreatedBy() discordid.UserID {
+	return s.createdBy
+}
+
+func (s *Schedule) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// Due は now の時点で実行の予定時刻を過ぎているかを返す
+func (s *Schedule) Due(now time.Time) bool {
+	return !now.Before(s.nextRunAt)
+}
+
+// Advance は予定時刻を過ぎた実行を処理し、次の予定時刻を now より後に進める
+// 投稿すべきなら true を返す。定刻（GracePeriod 以内の遅れ）の実行は常に投稿し、
+// それより前に逃した実行は CatchUp に従って最大1回だけ投稿する
+// now はギルドのタイムゾーンの時刻を渡す
+func (s *Schedule) Advance(now time.Time) bool {
+	if !s.Due(now) {
+		return false
+	}
+
+	// 逃した実行のうち最も新しいものを探す
+	latest := s.nextRunAt.In(now.Location())
+	for next := s.spec.Next(latest); !next.IsZero() && !next.After(now); next = s.spec.Next(next) {
+		latest = next
+	}
+
+	delay := now.Sub(latest)
+	post := delay <= GracePeriod || (s.catchUp == CatchUpLatest && delay <= MaxCatchUpDelay)
+
+	s.nextRunAt = s.spec.Next(now)
+	if post {
+		s.lastRunAt = now
+	}
+	return post
+}
+
+// Reschedule は次の予定時刻を now のタイムゾーンで計算し直す
+// ギルドのタイムゾーンが変わったときに使う。予定時刻を過ぎた実行は Advance に任せてそのままにする
+func (s *Schedule) Reschedule(now time.Time) {
+	if s.Due(now) {
+		return
+	}
+	s.nextRunAt = s.spec.Next(now)
+}
+
+func NewSchedule(guildID discordid.GuildID, channelID discordid.TextChannelID, legend, expr string, catchUp CatchUp, createdBy discordid.UserID, now time.Time) (*Schedule, error) {
+	spec, err := ParseSpec(expr)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkInterval(spec); err != nil {
+		return nil, err
+	}
+	return RebuildSchedule(ID(uuid.New().String()), guildID, channelID, legend, spec.String(), catchUp, spec.Next(now), time.Time{}, createdBy, now)
+}
+
+func RebuildSchedule(id ID, guildID discordid.GuildID, channelID discordid.TextChannelID, legend, expr string, catchUp CatchUp, nextRunAt, lastRunAt time.Time, createdBy discordid.UserID, createdAt time.Time) (*Schedule, error) {
+	if guildID == "" {
+		return nil, ErrInvalidGuildID
+	}
+	if channelID == "" {
+		return nil, ErrInvalidChannelID
+	}
+	if legend == "" {
+		return nil, ErrInvalidLegend
+	}
+	spec, err := ParseSpec(expr)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ParseCatchUp(string(catchUp)); err != nil {
+		return nil, err
+	}
+	if nextRunAt.IsZero() {
+		return nil, ErrNeverRuns
+	}
+	return &Schedule{
+		id:        id,
+		guildID:   guildID,
+		channelID: channelID,
+		legend:    legend,
+		spec:      spec,
+		catchUp:   catchUp,
+		nextRunAt: nextRunAt,
+		lastRunAt: lastRunAt,
+		createdBy: createdBy,
+		createdAt: createdAt,
+	}, nil
+}
+
+// checkInterval は実行間隔が MinInterval より短くならないかを確認する
+func checkInterval(spec Spec) error {
+	t := spec.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if t.IsZero() {
+		return ErrNeverRuns
+	}
+	for range intervalSamples {
+		next := spec.Next(t)
+		if next.IsZero() {
+			return nil
+		}
+		if next.Sub(t) < MinInterval {
+			return ErrTooFrequent
+		}
+		t = next
+	}
+	return nil
+}
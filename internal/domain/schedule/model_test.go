@@ -0,0 +1,128 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func TestNewSchedule(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, jst)
+	schedule, err := NewSchedule("guild", "channel", "yamada", "0  9 * * *", CatchUpLatest, "user", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.Spec().String() != "0 9 * * *" {
+		t.Errorf("expected normalized spec, got %q", schedule.Spec())
+	}
+	if want := time.Date(2026, 10, 19, 9, 0, 0, 0, jst); !schedule.NextRunAt().Equal(want) {
+		t.Errorf("NextRunAt() = %s, want %s", schedule.NextRunAt(), want)
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		catchUp CatchUp
+		want    error
+	}{
+		{name: "every minute", expr: "* * * * *", catchUp: CatchUpSkip, want: ErrTooFrequent},
+		{name: "twice an hour", expr: "0,30 9 * * *", catchUp: CatchUpSkip, want: ErrTooFrequent},
+		{name: "hourly", expr: "@hourly", catchUp: CatchUpSkip, want: nil},
+		{name: "never", expr: "0 0 31 4 *", catchUp: CatchUpSkip, want: ErrNeverRuns},
+		{name: "invalid spec", expr: "daily", catchUp: CatchUpSkip, want: ErrInvalidSpec},
+		{name: "invalid catch-up", expr: "@daily", catchUp: "all", want: ErrInvalidCatchUp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSchedule("guild", "channel", "yamada", tt.expr, tt.catchUp, "user", now); err != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestScheduleAdvance(t *testing.T) {
+	nextRunAt := time.Date(2026, 10, 19, 9, 0, 0, 0, jst) // 月曜日
+	tests := []struct {
+		name    string
+		expr    string
+		catchUp CatchUp
+		now     time.Time
+		want    bool
+		wantRun time.Time
+	}{
+		{
+			name:    "not due",
+			catchUp: CatchUpSkip,
+			now:     nextRunAt.Add(-time.Second),
+			want:    false,
+			wantRun: nextRunAt,
+		},
+		{
+			name:    "on time",
+			catchUp: CatchUpSkip,
+			now:     nextRunAt.Add(30 * time.Second),
+			want:    true,
+			wantRun: nextRunAt.AddDate(0, 0, 1),
+		},
+		{
+			name:    "missed with skip",
+			catchUp: CatchUpSkip,
+			now:     nextRunAt.Add(3 * time.Hour),
+			want:    false,
+			wantRun: nextRunAt.AddDate(0, 0, 1),
+		},
+		{
+			name:    "missed with latest",
+			catchUp: CatchUpLatest,
+			now:     nextRunAt.Add(3 * time.Hour),
+			want:    true,
+			wantRun: nextRunAt.AddDate(0, 0, 1),
+		},
+		{
+			name:    "missed several days with latest posts the latest run",
+			catchUp: CatchUpLatest,
+			now:     nextRunAt.AddDate(0, 0, 3).Add(2 * time.Hour),
+			want:    true,
+			wantRun: nextRunAt.AddDate(0, 0, 4),
+		},
+		{
+			name:    "missed several days with skip catches the run on time",
+			catchUp: CatchUpSkip,
+			now:     nextRunAt.AddDate(0, 0, 3).Add(time.Minute),
+			want:    true,
+			wantRun: nextRunAt.AddDate(0, 0, 4),
+		},
+		{
+			name:    "missed for too long with latest",
+			expr:    "0 9 * * 1",
+			catchUp: CatchUpLatest,
+			now:     nextRunAt.Add(MaxCatchUpDelay + time.Hour),
+			want:    false,
+			wantRun: nextRunAt.AddDate(0, 0, 7),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr := tt.expr
+			if expr == "" {
+				expr = "0 9 * * *"
+			}
+			// 予定時刻は UTC で保存されていても、ギルドのタイムゾーンで判定する
+			schedule, err := RebuildSchedule("id", "guild", "channel", "yamada", expr, tt.catchUp, nextRunAt.UTC(), time.Time{}, "user", nextRunAt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := schedule.Advance(tt.now); got != tt.want {
+				t.Errorf("Advance() = %v, want %v", got, tt.want)
+			}
+			if !schedule.NextRunAt().Equal(tt.wantRun) {
+				t.Errorf("NextRunAt() = %s, want %s", schedule.NextRunAt(), tt.wantRun)
+			}
+			if tt.want != !schedule.LastRunAt().IsZero() {
+				t.Errorf("LastRunAt() = %s, want set = %v", schedule.LastRunAt(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+type Repository interface {
+	FindByID(ctx context.Context, id ID) (*Schedule, error)
+	// FindByGuild はギルドの予約投稿を登録の古い順に返す
+	FindByGuild(ctx context.Context, guildID discordid.GuildID) ([]*Schedule, error)
+	// FindDue は実行の予定時刻が now 以前の予約投稿を予定時刻の古い順に返す
+	FindDue(ctx context.Context, now time.Time) ([]*Schedule, error)
+	Save(ctx context.Context, schedule *Schedule) error
+	Delete(ctx context.Context, guildID discordid.GuildID, id ID) error
+}
+
+type Repositories interface {
+	Schedule(tx db.Tx) Repository
+}
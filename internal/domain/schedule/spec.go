@@ -0,0 +1,177 @@
+package schedule
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// macros は cron の略記と、それが表す5つのフィールド
+var macros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// fieldBounds は分・時・日・月・曜日の各フィールドが取りうる値の範囲
+// 曜日は 0 と 7 のどちらも日曜日を表す
+var fieldBounds = [5]struct{ min, max int }{
+	{0, 59},
+	{0, 23},
+	{1, 31},
+	{1, 12},
+	{0, 7},
+}
+
+// maxSearchYears は次の実行時刻を探す範囲
+// 2月30日のように実在しない日付だけを指定した式で探し続けないようにする
+const maxSearchYears = 5
+
+// Spec は「分 時 日 月 曜日」の5つのフィールドからなる cron 形式の実行スケジュール
+// 各フィールドは *、数値、範囲（1-5）、間隔（*/15、0-30/10）とそれらのカンマ区切りを受け付ける
+type Spec struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+// ParseSpec は cron 形式の式を解析する
+// @hourly、@daily、@weekly、@monthly の略記も受け付ける
+func ParseSpec(expr string) (Spec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 1 {
+		if macro, ok := macros[strings.ToLower(fields[0])]; ok {
+			fields = strings.Fields(macro)
+		}
+	}
+	if len(fields) != 5 {
+		return Spec{}, ErrInvalidSpec
+	}
+
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseField(field, fieldBounds[i].min, fieldBounds[i].max)
+		if err != nil {
+			return Spec{}, err
+		}
+		sets[i] = set
+	}
+	// 7 は日曜日として扱う
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	return Spec{
+		expr:    strings.Join(strings.Fields(expr), " "),
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, ErrInvalidSpec
+			}
+			step = n
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = min, max
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = strconv.Atoi(from); err != nil {
+				return 0, ErrInvalidSpec
+			}
+			if end, err = strconv.Atoi(to); err != nil {
+				return 0, ErrInvalidSpec
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, ErrInvalidSpec
+			}
+			// "5/15" は 5 から最大値まで 15 おき
+			start, end = n, n
+			if hasStep {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, ErrInvalidSpec
+		}
+
+		for v := start; v <= end; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// String は正規化した式を返す
+func (s Spec) String() string {
+	return s.expr
+}
+
+// Next は after より後で式に一致する最初の時刻を返す
+// 時刻は after のタイムゾーンで判定する。一致する時刻が見つからなければゼロ値を返す
+func (s Spec) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay は日と曜日のフィールドに一致するかを返す
+// 一般的な cron と同じく、両方が指定されている場合はどちらか一方に一致すればよい
+func (s Spec) matchesDay(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<v) != 0
+}
@@ -0,0 +1,119 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSpec(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "0 9 * * *"},
+		{expr: "  0   9 * *  * "},
+		{expr: "*/15 * * * *"},
+		{expr: "0 9-18/3 * * 1-5"},
+		{expr: "30 8 1,15 * 7"},
+		{expr: "@daily"},
+		{expr: "0 9 * *", wantErr: true},
+		{expr: "60 9 * * *", wantErr: true},
+		{expr: "0 24 * * *", wantErr: true},
+		{expr: "0 9 0 * *", wantErr: true},
+		{expr: "0 9 * 13 *", wantErr: true},
+		{expr: "0 9 * * 8", wantErr: true},
+		{expr: "0 18-9 * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "a * * * *", wantErr: true},
+		{expr: "@yearly", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseSpec(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSpec(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecNext(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name  string
+		expr  string
+		after time.Time
+		want  time.Time
+	}{
+		{
+			name:  "later today",
+			expr:  "0 9 * * *",
+			after: time.Date(2026, 10, 19, 8, 30, 0, 0, tokyo),
+			want:  time.Date(2026, 10, 19, 9, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "exactly at a run is excluded",
+			expr:  "0 9 * * *",
+			after: time.Date(2026, 10, 19, 9, 0, 0, 0, tokyo),
+			want:  time.Date(2026, 10, 20, 9, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "weekdays only",
+			expr:  "0 9 * * 1-5",
+			after: time.Date(2026, 10, 23, 10, 0, 0, 0, tokyo), // 金曜日
+			want:  time.Date(2026, 10, 26, 9, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "sunday as 7",
+			expr:  "0 12 * * 7",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, tokyo),
+			want:  time.Date(2026, 10, 25, 12, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "day of month or day of week",
+			expr:  "0 0 1 * 3",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, tokyo),
+			want:  time.Date(2026, 10, 21, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "next year",
+			expr:  "0 0 1 1 *",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, tokyo),
+			want:  time.Date(2027, 1, 1, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "leap day",
+			expr:  "0 0 29 2 *",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, tokyo),
+			want:  time.Date(2028, 2, 29, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:  "never",
+			expr:  "0 0 30 2 *",
+			after: time.Date(2026, 10, 19, 0, 0, 0, 0, tokyo),
+			want:  time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseSpec(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := spec.Next(tt.after); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecNextUsesLocation(t *testing.T) {
+	spec, _ := ParseSpec("0 9 * * *")
+	after := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	// 同じ時刻でも、東京では 9 時 (UTC 0 時) を過ぎているので翌日になる
+	got := spec.Next(after.In(time.FixedZone("JST", 9*60*60)))
+	if want := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next() = %s, want %s", got.UTC(), want)
+	}
+}
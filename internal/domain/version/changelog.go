@@ -0,0 +1,50 @@
+package version
+
+import "strings"
+
+// ReleaseNotes は CHANGELOG（Keep a Changelog 形式）から指定したバージョンの節を取り出す
+// 見出しは "## [v1.2.3] - 2025-01-01" や "## v1.2.3" の形式を受け付け、先頭の "v" の有無は区別しない
+// 該当する節が無い場合は false を返す
+func ReleaseNotes(changelog, version string) (string, bool) {
+	target := normalizeVersion(version)
+	if target == "" {
+		return "", false
+	}
+
+	var (
+		section []string
+		found   bool
+	)
+	for _, line := range strings.Split(changelog, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			if found {
+				break
+			}
+			found = headingVersion(heading) == target
+			continue
+		}
+		if found {
+			section = append(section, line)
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	notes := strings.TrimSpace(strings.Join(section, "\n"))
+	return notes, notes != ""
+}
+
+// headingVersion は見出しからバージョン部分を取り出す
+func headingVersion(heading string) string {
+	fields := strings.Fields(heading)
+	if len(fields) == 0 {
+		return ""
+	}
+	return normalizeVersion(strings.Trim(fields[0], "[]"))
+}
+
+func normalizeVersion(version string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "v")
+}
@@ -0,0 +1,5 @@
+package version
+
+import "errors"
+
+var ErrAnnouncedVersionNotFound = errors.New("announced version not found")
@@ -0,0 +1,80 @@
+package version
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// DevelopVersion は ldflags でバージョンが埋め込まれていない開発ビルドのバージョン
+const DevelopVersion = "develop"
+
+// Dependency はバイナリに含まれる依存モジュール
+type Dependency struct {
+	Path    string
+	Version string
+}
+
+// BuildInfo は実行中のバイナリのビルド情報
+type BuildInfo struct {
+	Version      string
+	Revision     string
+	Modified     bool
+	CommitTime   time.Time
+	BuildTime    time.Time
+	GoVersion    string
+	Dependencies []Dependency
+}
+
+// NewBuildInfo は ldflags で埋め込まれた値と runtime/debug のビルド情報からビルド情報を組み立てる
+// buildTime は RFC 3339 形式で、解析できない場合はゼロ値になる
+// info が nil の場合（ビルド情報を読めない場合）はバージョンとビルド日時のみを持つ
+func NewBuildInfo(version, buildTime string, info *debug.BuildInfo) BuildInfo {
+	b := BuildInfo{Version: version}
+	if t, err := time.Parse(time.RFC3339, buildTime); err == nil {
+		b.BuildTime = t
+	}
+	if info == nil {
+		return b
+	}
+
+	b.GoVersion = info.GoVersion
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			b.Revision = setting.Value
+		case "vcs.modified":
+			b.Modified = setting.Value == "true"
+		case "vcs.time":
+			if t, err := time.Parse(time.RFC3339, setting.Value); err == nil {
+				b.CommitTime = t
+			}
+		}
+	}
+	for _, dep := range info.Deps {
+		// replace されている場合は実際に使われているモジュールを表示する
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		b.Dependencies = append(b.Dependencies, Dependency{Path: dep.Path, Version: dep.Version})
+	}
+	return b
+}
+
+// ShortRevision はコミットハッシュの先頭 7 文字を返す
+func (b BuildInfo) ShortRevision() string {
+	if len(b.Revision) > 7 {
+		return b.Revision[:7]
+	}
+	return b.Revision
+}
+
+// IsRelease はリリースビルド（バージョンが埋め込まれたビルド）かどうかを返す
+func (b BuildInfo) IsRelease() bool {
+	return b.Version != "" && b.Version != DevelopVersion
+}
+
+// ShouldAnnounce は前回告知したバージョンから変わっており、告知が必要かどうかを返す
+// 開発ビルドは起動のたびに告知しないよう対象外とする
+func ShouldAnnounce(current, previous string) bool {
+	return current != "" && current != DevelopVersion && current != previous
+}
@@ -0,0 +1,102 @@
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+	"time"
+)
+
+func TestNewBuildInfo(t *testing.T) {
+	info := &debug.BuildInfo{
+		GoVersion: "go1.24.0",
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+			{Key: "vcs.modified", Value: "true"},
+			{Key: "vcs.time", Value: "2025-01-02T03:04:05Z"},
+		},
+		Deps: []*debug.Module{
+			{Path: "github.com/bwmarrin/discordgo", Version: "v0.29.0"},
+			{Path: "example.com/old", Version: "v1.0.0", Replace: &debug.Module{Path: "example.com/new", Version: "v1.1.0"}},
+		},
+	}
+
+	b := NewBuildInfo("v1.2.3", "2025-01-03T00:00:00Z", info)
+
+	if b.Version != "v1.2.3" || b.GoVersion != "go1.24.0" {
+		t.Errorf("unexpected version info: %+v", b)
+	}
+	if b.ShortRevision() != "0123456" {
+		t.Errorf("expected short revision 0123456, got %s", b.ShortRevision())
+	}
+	if !b.Modified {
+		t.Error("expected modified to be true")
+	}
+	if !b.CommitTime.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected commit time: %s", b.CommitTime)
+	}
+	if !b.BuildTime.Equal(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected build time: %s", b.BuildTime)
+	}
+	if len(b.Dependencies) != 2 || b.Dependencies[1].Path != "example.com/new" {
+		t.Errorf("unexpected dependencies: %+v", b.Dependencies)
+	}
+}
+
+func TestNewBuildInfoWithoutDebugInfo(t *testing.T) {
+	b := NewBuildInfo(DevelopVersion, "", nil)
+
+	if b.IsRelease() {
+		t.Error("expected develop build not to be a release")
+	}
+	if !b.BuildTime.IsZero() || b.Revision != "" {
+		t.Errorf("expected empty build metadata, got %+v", b)
+	}
+}
+
+func TestShouldAnnounce(t *testing.T) {
+	tests := []struct {
+		current  string
+		previous string
+		expected bool
+	}{
+		{current: "v1.0.1", previous: "v1.0.0", expected: true},
+		{current: "v1.0.0", previous: "", expected: true},
+		{current: "v1.0.0", previous: "v1.0.0", expected: false},
+		{current: DevelopVersion, previous: "v1.0.0", expected: false},
+		{current: "", previous: "v1.0.0", expected: false},
+	}
+
+	for _, tt := range tests {
+		if got := ShouldAnnounce(tt.current, tt.previous); got != tt.expected {
+			t.Errorf("ShouldAnnounce(%q, %q): expected %v, got %v", tt.current, tt.previous, tt.expected, got)
+		}
+	}
+}
+
+func TestReleaseNotes(t *testing.T) {
+	changelog := "# Changelog\n\n" +
+		"## [Unreleased]\n\n- 作業中\n\n" +
+		"## [v1.1.0] - 2025-02-01\n\n### Added\n- 新機能\n\n" +
+		"## 1.0.0\n\n- 初回リリース\n\n" +
+		"## [v0.9.0]\n"
+
+	tests := []struct {
+		version  string
+		expected string
+		ok       bool
+	}{
+		{version: "v1.1.0", expected: "### Added\n- 新機能", ok: true},
+		{version: "1.1.0", expected: "### Added\n- 新機能", ok: true},
+		{version: "v1.0.0", expected: "- 初回リリース", ok: true},
+		{version: "v0.9.0", ok: false},
+		{version: "v2.0.0", ok: false},
+		{version: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ReleaseNotes(changelog, tt.version)
+		if ok != tt.ok || got != tt.expected {
+			t.Errorf("ReleaseNotes(%q): expected (%q, %v), got (%q, %v)", tt.version, tt.expected, tt.ok, got, ok)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package version
+
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+)
+
+// Repository は最後に告知したバージョンを保存する
+type Repository interface {
+	FindAnnouncedVersion(ctx context.Context) (string, error)
+	SaveAnnouncedVersion(ctx context.Context, version string) error
+}
+
+type Repositories interface {
+	AnnouncedVersion(tx db.Tx) Repository
+}
@@ -1,256 +0,0 @@
-package yamada
-
-import "github.com/aktnb/discord-bot-go/internal/domain/legend"
-
-// episodes は山田に関する嘘ニュース一覧
-var episodes = []string{
-	"山田、どんぐりたちの行進を目撃「一人、鳥に連れ去られていた」",
-	"山田、宇宙人との交信に成功「好物はたい焼きらしい」",
-	"山田、目からビームを出すことに成功「鍛錬方法は企業秘密」",
-	"山田、お腹が空かなくなる「克服した」",
-	"山田、宇宙一周旅行を計画「20年くらいかける予定」",
-	"山田、新しい季節「ごふ」を発見",
-	"山田、マイクロソフトと契約「およそ10億」",
-	"山田、太る",
-	"山田、空を飛ぶ「思ったより寒かった」",
-	"山田、新元素を発見「名前は『やまジウム』に決定」",
-	"山田、海底でコーヒーショップを開業「客は魚だけ」",
-	"山田、地球の重力を軽くする「ちょっとやりすぎた」",
-	"山田、夢の中でノーベル賞受賞「賞金は夢の中で使い切った」",
-	"山田、猫語を習得「猫の意見は手厳しい」",
-	"山田、山田を発見「別人だった」",
-	"山田、月に住み始める「家賃は格安」",
-	"山田、時間を止める「止まったまま帰れなくなる」",
-	"山田、新しい数字「じゅうぴゃく」を発明",
-	"山田、全人類の夢に同時出演「謝罪コメントを発表」",
-	"山田、鳥と和解「さきほど連れ去られた仲間も無事」",
-	"山田、一日で国家資格を100個取得「まだ余裕」",
-	"山田、太陽に直談判「少し涼しくなる」",
-	"山田、睡眠を攻略「完全に克服した」",
-	"山田、世界の果てに到達「普通の壁だった」",
-	"山田、自分を量産することに成功「全員が別の意見」",
-	"山田、牛乳を飲み続けた結果「どうなったかは企業秘密」",
-	"山田、現在地球に18人存在することが判明「本人も驚く」",
-	"山田、5億年後の未来から帰還「特に面白いことはなかった」",
-	"山田、毛細血管に電車を走らせる「定時運行を達成」",
-	"山田、「山田」という概念を特許申請",
-	"山田、雲を食べる「綿あめに似ていた」",
-	"山田、言語を新たに5つ発明「全部山田語」",
-	"山田、地下鉄の路線図を暗記「東京、大阪、ロンドン、宇宙」",
-	"山田、蚊に刺される「相手の方が重症」",
-	"山田、歩幅が一致した結果、地球を一周してしまう",
-	"山田、笑いが止まらなくなる「原因は不明」",
-	"山田、AI開発に着手「山田を学習させる予定」",
-	"山田、影に話しかける「影の方が先に話しかけてきた」",
-	"山田、水中で火をおこす「消し方は分からない」",
-	"山田、宇宙のどこかにもう一つの山田が存在すると発表",
-	"山田、虹の端を見つける「ただの壁の角だった」",
-	"山田、ピザを注文する「届いたのは3日後だった」",
-	"山田、深海魚と友達になる「相手は顔を忘れていた」",
-	"山田、夕焼けを瓶に詰める「翌朝には消えていた」",
-	"山田、地震を予知する「自分が原因だった」",
-	"山田、光速を超える「体が少し透ける」",
-	"山田、全ての素数を記憶する「最後の一つが見つからない」",
-	"山田、砂漠でラーメンを作る「具は砂だった」",
-	"山田、天気を操作する「晴れにするつもりが雪になる」",
-	"山田、龍と交渉する「どちらが本当の龍か揉める」",
-	"山田、恐竜の化石を発掘する「生きていた」",
-	"山田、宇宙最速の走者になる「帰り道に迷う」",
-	"山田、植物語を習得する「植物側は迷惑そう」",
-	"山田、全国の自動販売機を把握する「宇宙分は未調査」",
-	"山田、鏡の中に入る「出口を見つけるまで3日かかる」",
-	"山田、太陽系を掃除する「冥王星が邪魔だと判断」",
-	"山田、コーヒーを飲む「世界が少し傾く」",
-	"山田、空白を発明する「何もない」",
-	"山田、山に登る「山が先に降りてきた」",
-	"山田、人類初の海底マラソンを完走「水の抵抗を計算していなかった」",
-	"山田、インターネットを歩き回る「道に迷う」",
-	"山田、宇宙で焼き肉をする「煙で星が隠れる」",
-	"山田、タコの気持ちが理解できるようになる「怒っていることが多い」",
-	"山田、無限を数える「途中で飽きる」",
-	"山田、月面に庭を作る「植物は育たなかった」",
-	"山田、自分の名前を忘れる「山田で合っていた」",
-	"山田、ブラックホールを覗く「向こうも覗いていた」",
-	"山田、歴史を書き直す「面白くしすぎて誰も信じない」",
-	"山田、全ての言語を瞬時に翻訳できるようになる「山田語だけ不可能」",
-	"山田、木に話しかける「返事が来た」",
-	"山田、オーロラを手で触る「少しよれる」",
-	"山田、気圧を変える「耳が痛い」",
-	"山田、海を一口飲む「しょっぱかっただけ」",
-	"山田、リスと縄張り争いをする「引き分けに終わる」",
-	"山田、虫の言葉を解析する「全員が帰宅したがっていた」",
-	"山田、一つの点を10年間凝視する「まだ続けている」",
-	"山田、夕食を食べ忘れる「代わりに夕焼けを食べる」",
-	"山田、宇宙の端を折り返す「少し小さくなる」",
-	"山田、地球の自転を手で止める「一秒だけ」",
-	"山田、全ての石の名前を知っている「石も山田を知っている」",
-	"山田、氷山の下を確認する「予想の5倍だった」",
-	"山田、惑星を並べ直す「天文学者が困惑」",
-	"山田、音速で話す「誰も聞き取れない」",
-	"山田、海溝の底から手紙を送る「3年後に届く」",
-	"山田、自分の細胞に名前をつける「全員が山田」",
-	"山田、論文を毎秒1本提出する「全て採択される」",
-	"山田、電子レンジと会話する「かみ合わない」",
-	"山田、火山に砂糖を入れる「溶岩がキャラメル色になる」",
-	"山田、光を手で曲げる「物陰に入れなくなる」",
-	"山田、原子核に飛び込む「狭かった」",
-	"山田、地図に自分の家を中心として書き直す「世界地図が変わる」",
-	"山田、鍵をなくす「宇宙の法則が一時停止していた」",
-	"山田、川の流れを逆にする「魚が混乱」",
-	"山田、パスポートの国籍欄に「山田」と記入「通る」",
-	"山田、全国の信号機に挨拶する「全部青になる」",
-	"山田、雨粒を数える「途中でやめる」",
-	"山田、素粒子に話しかける「意外に礼儀正しかった」",
-	"山田、一週間眠り続ける「記録更新」",
-	"山田、自分の声をエコーでハモらせる「コンサートを開く」",
-	"山田、古代文明を発掘する「既知の文明だった」",
-	"山田、暗黒物質をつかむ「取れなかった」",
-	"山田、枯れた木を一晩で復活させる「木は特に感謝しない」",
-	"山田、空気を味わう「季節によって味が違う」",
-	"山田、星座に新しいものを追加する「名前は『山田座』」",
-	"山田、ひらがなを新たに3文字追加する「誰も覚えない」",
-	"山田、エベレストの山頂に看板を立てる「内容は『山田参上』」",
-	"山田、腸内細菌と仲良くなる「腸内が平和になる」",
-	"山田、自分の影を3つ増やす「増やし方は秘密」",
-	"山田、幻の第8音階を発見「音楽理論が書き直される」",
-	"山田、睡眠中に難問を解く「起きたら答えを忘れている」",
-	"山田、砂時計を逆にする「時間が戻る気がした」",
-	"山田、虫歯を説得して帰す「交渉成立」",
-	"山田、図書館の本を全部読む「感想は『まあまあ』」",
-	"山田、地層から自分の名前を見つける「億年前から記録されていた」",
-	"山田、クマに道を譲られる「お礼を言われる」",
-	"山田、ドーナツの穴を集める「瓶に入らない」",
-	"山田、夢の設計図を提出する「特許庁が困惑」",
-	"山田、消えかけた虹を補修する「材料は不明」",
-	"山田、1000年後の天気予報を立てる「大体晴れ」",
-	"山田、雪の結晶を素手で作る「全部同じ形になる」",
-	"山田、磁石を素手で引き離す「ちょっと痛い」",
-	"山田、蝶の羽ばたきが嵐になる前に止める「3件の台風を未然に防ぐ」",
-	"山田、地球に耳をあてる「音楽が聴こえた」",
-	"山田、電線の上を歩く「スズメに怒られる」",
-	"山田、生まれた年を変更する「手続き中」",
-	"山田、光の速さで謝る「相手がまだ怒る前に到着する」",
-	"山田、宇宙の音を録音する「真空なので何も取れない」",
-	"山田、感情に税金をかける「喜びが高額」",
-	"山田、夜明けを遅らせる「寝坊の言い訳に使う」",
-	"山田、雷を瓶に保存する「使い道は未定」",
-	"山田、百科事典の『山田』の項目を書き換える「全ページになる」",
-	"山田、流れ星に乗る「揺れが激しかった」",
-	"山田、砂糖で地図を作る「アリに食べられる」",
-	"山田、宇宙の法則に異議申し立てをする「審議中」",
-	"山田、彗星に手紙をくくりつける「宛先不明で返ってくる」",
-	"山田、方程式を体で解く「解けた」",
-	"山田、記憶を整理整頓する「3割が山田に関係なかった」",
-	"山田、新種のきのこを踏む「何も起きない」",
-	"山田、北極点に旗を立てる「すでに山田の旗があった」",
-	"山田、自分の誕生日を三回祝う「理由は不明」",
-	"山田、どんぐりを巡る「森の小さなレストランを発見」",
-	"山田、アリ専用のカートレース場を設立、今週末オープン「予算内で建設できた」",
-	"山田、来月末にアリ用カートレース場を閉場「利用者がこない」",
-	"山田、落ちていたガムを踏み、そのまま地面と同化する「米科学者が驚愕」",
-	"山田、将棋に新しい駒『山田』を追加する「強すぎて即日使用禁止」",
-	"山田、プールで泳いでいたら水が逃げる「プール側に非はない」",
-	"山田、手相を見てもらう「占い師が途中で引退」",
-	"山田、読んでいた本が勝手にページを戻す「続きを読ませたくないらしい」",
-	"山田、カレーを食べる「辛さのスケールが書き換えられる」",
-	"山田、エスカレーターに乗る「止まっていても上の階に着く」",
-	"山田、くしゃみをする「体が8cm浮く」",
-	"山田、財布を忘れたまま買い物を完了させる「店員は何も言わなかった」",
-	"山田、ロボットに間違えられる「本人も否定しない」",
-	"山田、温泉に入る「源泉が山田を避ける」",
-	"山田、音楽を聴く「曲が照れて途中で止まる」",
-	"山田、自分の体重を計る「はかりが混乱する」",
-	"山田、じゃんけんで144連勝「グーしか出していない」",
-	"山田、電柱に話しかける「電気代の相談に乗ってもらう」",
-	"山田、宝くじを当てる「当選金より印鑑代の方が高かった」",
-	"山田、毎朝新聞を読む「自分の名前が毎日どこかに載っている」",
-	"山田、コンビニのレジ袋を断る「エコすぎて表彰される」",
-	"山田、ジェットコースターに乗る「ジェットコースターの方が怖がる」",
-	"山田、傘を持たずに外出する「雨が山田を避ける」",
-	"山田、公園のベンチに座る「ベンチが少し沈む」",
-	"山田、水族館に行く「サメが挨拶してくる」",
-	"山田、スーパーで試食する「全種類が山田の好みに変わる」",
-	"山田、新しい運動を発明する「名前は『山田体操』」",
-	"山田、鏡の前で練習する「鏡がさきに笑う」",
-	"山田、タイムカプセルを埋める「掘り返したらすでに開封済みだった」",
-	"山田、バスに乗り遅れる「バスが引き返してくる」",
-	"山田、迷路を解く「壁の方が道を作る」",
-	"山田、日記をつける「日記が勝手に続きを書いている」",
-	"山田、風船を飛ばす「5年後に戻ってくる」",
-	"山田、スパゲッティをすする「麺が逃げる」",
-	"山田、川でつり糸を垂らす「魚が自分から針に刺さろうとする」",
-	"山田、階段を数える「毎回違う数になる」",
-	"山田、ピアノを弾く「聴衆が消える」",
-	"山田、ギターを弾く「弦が増える」",
-	"山田、博物館で展示品を触る「展示品が固まる」",
-	"山田、観葉植物を育てる「植物が丁寧なメモを残して枯れる」",
-	"山田、靴の紐を結ぶ「片方が勝手に解ける」",
-	"山田、目覚まし時計をセットする「時計が山田より先に起きて止める」",
-	"山田、パズルを完成させる「最後の1ピースが自分の体から出てくる」",
-	"山田、料理に挑戦する「完成品が分子構造ごと別物になる」",
-	"山田、自転車の空気を入れる「タイヤが空気を返してくる」",
-	"山田、シャボン玉を吹く「割れずに衛星になる」",
-	"山田、万歩計をつける「0歩でも1万歩を記録」",
-	"山田、口笛を吹く「近隣の犬が全員集合する」",
-	"山田、野球のボールを投げる「ボールが弧を描かずに届く」",
-	"山田、マラソンを完走する「ゴールが山田に合わせて移動していた」",
-	"山田、サッカーのシュートを放つ「ゴールが避ける」",
-	"山田、バスケのフリースローを放つ「ボールがリングを避けて入る」",
-	"山田、テニスのサーブを打つ「ラケットが礼を言って去る」",
-	"山田、将棋で対局する「相手の駒が山田側に移動してくる」",
-	"山田、チェスで対局する「駒が自己判断で動く」",
-	"山田、ボードゲームをする「サイコロが山田の望む数しか出ない」",
-	"山田、折り紙で鶴を折る「飛んでいく」",
-	"山田、本棚を整理する「本が勝手に並び直している」",
-	"山田、引き出しを閉める「必要な時に自動で開く」",
-	"山田、鍵をかける「どの鍵でも開く」",
-	"山田、パソコンを起動する「山田専用のOSが立ち上がる」",
-	"山田、プリンターを使う「用紙が先に逃げる」",
-	"山田、ヘッドフォンをつける「音楽が耳に直接届く」",
-	"山田、帽子をかぶる「帽子が自分で調整する」",
-	"山田、手袋をはめる「片方が自分で歩き去る」",
-	"山田、コートを着る「コートが生き返る」",
-	"山田、靴を磨く「翌朝には元の状態に戻っている」",
-	"山田、洗濯物を干す「洗濯物が自分でたたまれている」",
-	"山田、布団を干す「布団が空を飛ぶ」",
-	"山田、郵便ポストに手紙を投函する「返信が即日届く」",
-	"山田、宅配便を受け取る「頼んでいないものが入っている」",
-	"山田、エレベーターに乗る「行き先を言わなくても目的の階に止まる」",
-	"山田、回転寿司に行く「皿が全部山田の前で止まる」",
-	"山田、焼き肉を焼く「煙が文字を作る」",
-	"山田、鍋料理を作る「全員分が一人前で十分になる」",
-	"山田、お茶を飲む「急須が満タンに戻る」",
-	"山田、ケーキを切る「全ピースが同じ大きさになる」",
-	"山田、アイスを食べる「溶けない」",
-	"山田、ガムを噛む「風船が勝手に膨らむ」",
-	"山田、スープを冷ます「息が氷になる」",
-	"山田、おにぎりを握る「米が自動的に三角になる」",
-	"山田、お弁当を食べる「箸が頑張る」",
-	"山田、蕎麦を食べる「麺が計算された長さに切れている」",
-	"山田、うどんを食べる「麺が一本しか入っていないが十分な量」",
-	"山田、天丼を食べる「海老が山田に気を使って大きくなる」",
-	"山田、ラムネを飲む「ビー玉が返ってくる」",
-	"山田、みかんを剥く「一房も切れない」",
-	"山田、バナナを食べる「皮が丁寧に畳んである」",
-	"山田、スイカを切る「種が整列している」",
-	"山田、栗を剥く「自動で剥ける」",
-	"山田、グミを食べる「全部同じ硬さ」",
-	"山田、チョコレートを割る「綺麗に分かれる」",
-	"山田、プリンを食べる「カラメルが最後まで残る」",
-	"山田、わたあめを買う「量が増え続ける」",
-	"山田、たこ焼きを食べる「全部同じ温度」",
-	"山田、お好み焼きを焼く「ひっくり返さなくても焼ける」",
-	"山田、餃子を焼く「羽が完璧に焼き上がる」",
-	"山田、漬物を漬ける「翌朝には本場の味になる」",
-	"山田、味噌汁を作る「出汁が自動で出る」",
-	"山田、卵焼きを作る「巻かなくても巻いてある」",
-	"山田、白米を炊く「炊飯器が喋る」",
-	"山田、インスタント麺を作る「お湯が3分待たずに仕上げる」",
-	"山田、ホットケーキを焼く「ハートの形になる」",
-	"山田、クッキーを焼く「全部同じ形」",
-}
-
-// RandomEpisode はエピソードをランダムに1つ返す
-func RandomEpisode() legend.Episode {
-	return legend.Random(episodes)
-}
@@ -3,17 +3,19 @@ package catapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"time"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/cat"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/httpclient"
 )
 
-const (
-	baseURL        = "https://api.thecatapi.com/v1/images/search"
-	requestTimeout = 10 * time.Second
-)
+// DefaultBaseURL は The Cat API のベース URL
+const DefaultBaseURL = "https://api.thecatapi.com/v1"
 
 // apiResponse はCat APIのレスポンス構造
 type apiResponse struct {
@@ -23,48 +25,96 @@ type apiResponse struct {
 	Height int    `json:"height"`
 }
 
+// breedResponse はCat APIの猫種一覧のレスポンス構造
+type breedResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 type CatAPIClient struct {
-	httpClient *http.Client
+	client  *httpclient.Client
+	baseURL string
+	apiKey  string
 }
 
-func NewCatAPIClient() *CatAPIClient {
+// NewCatAPIClient は共通の HTTP クライアントで baseURL の Cat API を使うクライアントを生成する
+// apiKey が空でなければ x-api-key ヘッダーで送る（未指定でも利用できるが、取得できる枚数などが制限される）
+func NewCatAPIClient(client *httpclient.Client, baseURL, apiKey string) *CatAPIClient {
 	return &CatAPIClient{
-		httpClient: &http.Client{
-			Timeout: requestTimeout,
-		},
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
 	}
 }
 
-func (c *CatAPIClient) FetchRandomImage(ctx context.Context) (*cat.CatImage, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+func (c *CatAPIClient) FetchRandomImages(ctx context.Context, breedID string, count int) ([]*cat.CatImage, error) {
+	query := url.Values{"limit": {strconv.Itoa(count)}}
+	if breedID != "" {
+		query.Set("breed_ids", breedID)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, cat.ErrAPIUnavailable
+	var responses []apiResponse
+	if err := c.get(ctx, "/images/search?"+query.Encode(), &responses); err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 {
+		return nil, cat.ErrImageNotFound
+	}
+	// API キーが無いと limit より多く返ることがあるため切り詰める
+	if len(responses) > count {
+		responses = responses[:count]
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	images := make([]*cat.CatImage, len(responses))
+	for i, apiResp := range responses {
+		images[i] = &cat.CatImage{
+			ID:     apiResp.ID,
+			URL:    apiResp.URL,
+			Width:  apiResp.Width,
+			Height: apiResp.Height,
+		}
 	}
+	return images, nil
+}
 
-	var responses []apiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responses); err != nil {
-		return nil, cat.ErrInvalidResponse
+func (c *CatAPIClient) FetchBreeds(ctx context.Context) ([]cat.Breed, error) {
+	var responses []breedResponse
+	if err := c.get(ctx, "/breeds", &responses); err != nil {
+		return nil, err
 	}
 
-	if len(responses) == 0 {
-		return nil, cat.ErrImageNotFound
+	breeds := make([]cat.Breed, 0, len(responses))
+	for _, breed := range responses {
+		if breed.ID == "" || breed.Name == "" {
+			continue
+		}
+		breeds = append(breeds, cat.Breed{ID: breed.ID, Name: breed.Name})
 	}
+	return breeds, nil
+}
 
-	apiResp := responses[0]
-	return &cat.CatImage{
-		ID:     apiResp.ID,
-		URL:    apiResp.URL,
-		Width:  apiResp.Width,
-		Height: apiResp.Height,
-	}, nil
+// get は path に GET リクエストを送り、JSON のレスポンスを v に読み込む
+// 上流が落ちていて最近の同じリクエストの結果が残っていれば、それを読み込む
+func (c *CatAPIClient) get(ctx context.Context, path string, v any) error {
+	header := http.Header{}
+	if c.apiKey != "" {
+		header.Set("x-api-key", c.apiKey)
+	}
+
+	resp, err := c.client.Get(ctx, c.baseURL+path, header)
+	if errors.Is(err, httpclient.ErrUnavailable) {
+		return cat.ErrAPIUnavailable
+	}
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(resp.Body, v); err != nil {
+		return cat.ErrInvalidResponse
+	}
+	return nil
 }
@@ -0,0 +1,111 @@
+package catapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/cat"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/httpclient"
+)
+
+func TestFetchRandomImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/images/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("x-api-key"); got != "secret" {
+			t.Errorf("x-api-key = %q, want %q", got, "secret")
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want %q", got, "2")
+		}
+		if got := r.URL.Query().Get("breed_ids"); got != "abys" {
+			t.Errorf("breed_ids = %q, want %q", got, "abys")
+		}
+		// limit より多く返っても切り詰める
+		w.Write([]byte(`[{"id":"a","url":"https://example.com/a.jpg"},{"id":"b","url":"https://example.com/b.jpg"},{"id":"c","url":"https://example.com/c.jpg"}]`))
+	}))
+	defer server.Close()
+
+	images, err := NewCatAPIClient(newHTTPClient(), server.URL, "secret").FetchRandomImages(context.Background(), "abys", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(images) != 2 || images[0].URL != "https://example.com/a.jpg" || images[1].ID != "b" {
+		t.Errorf("unexpected images: %+v", images)
+	}
+}
+
+func TestFetchRandomImagesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "empty", status: http.StatusOK, body: `[]`, wantErr: cat.ErrImageNotFound},
+		{name: "broken json", status: http.StatusOK, body: `{`, wantErr: cat.ErrInvalidResponse},
+		{name: "server error", status: http.StatusInternalServerError, body: ``, wantErr: cat.ErrAPIUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewCatAPIClient(newHTTPClient(), server.URL, "").FetchRandomImages(context.Background(), "", 1)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFetchRandomImagesUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	_, err := NewCatAPIClient(newHTTPClient(), server.URL, "").FetchRandomImages(context.Background(), "", 1)
+	if !errors.Is(err, cat.ErrAPIUnavailable) {
+		t.Errorf("expected ErrAPIUnavailable, got %v", err)
+	}
+}
+
+func TestFetchBreeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/breeds" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("x-api-key"); got != "" {
+			t.Errorf("expected no x-api-key, got %q", got)
+		}
+		w.Write([]byte(`[{"id":"abys","name":"Abyssinian","origin":"Egypt"},{"id":"","name":"Unknown"},{"id":"beng","name":"Bengal"}]`))
+	}))
+	defer server.Close()
+
+	breeds, err := NewCatAPIClient(newHTTPClient(), server.URL+"/", "").FetchBreeds(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []cat.Breed{{ID: "abys", Name: "Abyssinian"}, {ID: "beng", Name: "Bengal"}}
+	if len(breeds) != len(want) || breeds[0] != want[0] || breeds[1] != want[1] {
+		t.Errorf("FetchBreeds() = %+v, want %+v", breeds, want)
+	}
+}
+
+// newHTTPClient は再試行の待ち時間が短く、キャッシュしない HTTP クライアントを返す
+func newHTTPClient() *httpclient.Client {
+	options := httpclient.DefaultOptions()
+	options.RetryBackoff = time.Millisecond
+	options.CacheSize = 0
+	return httpclient.New(options)
+}
@@ -0,0 +1,192 @@
+package chartimage
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/collatz"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	width  = 960
+	height = 540
+
+	// グラフ領域の余白（目盛りのラベルを描く分）
+	marginLeft   = 80
+	marginRight  = 24
+	marginTop    = 24
+	marginBottom = 40
+
+	// 縦軸・横軸の目盛りの数
+	ticks = 5
+)
+
+var (
+	backgroundColor = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	gridColor       = color.RGBA{0xE0, 0xE0, 0xE0, 0xFF}
+	axisColor       = color.RGBA{0x33, 0x33, 0x33, 0xFF}
+	lineColor       = color.RGBA{0x58, 0x65, 0xF2, 0xFF}
+	peakColor       = color.RGBA{0xED, 0x42, 0x45, 0xFF}
+)
+
+// CollatzChartRenderer は標準ライブラリと純 Go のフォントだけでコラッツ予想のグラフを描画する
+type CollatzChartRenderer struct{}
+
+func NewCollatzChartRenderer() *CollatzChartRenderer {
+	return &CollatzChartRenderer{}
+}
+
+// RenderPNG は横軸をステップ数、縦軸を値とした折れ線グラフを PNG 画像として描画する
+func (r *CollatzChartRenderer) RenderPNG(chart collatz.Chart) ([]byte, error) {
+	if len(chart.Values) == 0 {
+		return nil, fmt.Errorf("chart has no values")
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{backgroundColor}, image.Point{}, draw.Src)
+
+	p := newPlot(chart.Values)
+	p.drawGrid(img, chart.Scale)
+	p.drawTrajectory(img, chart.Values)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// plot はデータの座標をグラフ領域のピクセル座標に変換する
+type plot struct {
+	maxX       float64
+	minY, maxY float64
+}
+
+func newPlot(values []float64) plot {
+	p := plot{maxX: float64(len(values) - 1), minY: values[0], maxY: values[0]}
+	for _, v := range values {
+		p.minY = math.Min(p.minY, v)
+		p.maxY = math.Max(p.maxY, v)
+	}
+	// 値が1つしかない場合や変化が無い場合でもグラフ領域を潰さない
+	if p.maxX == 0 {
+		p.maxX = 1
+	}
+	if p.maxY == p.minY {
+		p.maxY = p.minY + 1
+	}
+	return p
+}
+
+func (p plot) x(step float64) int {
+	return marginLeft + int(math.Round(step/p.maxX*float64(width-marginLeft-marginRight)))
+}
+
+func (p plot) y(value float64) int {
+	return height - marginBottom - int(math.Round((value-p.minY)/(p.maxY-p.minY)*float64(height-marginTop-marginBottom)))
+}
+
+func (p plot) drawGrid(img *image.RGBA, scale collatz.Scale) {
+	for i := 0; i <= ticks; i++ {
+		// 縦軸の目盛り
+		value := p.minY + (p.maxY-p.minY)*float64(i)/ticks
+		y := p.y(value)
+		drawLine(img, marginLeft, y, width-marginRight, y, gridColor)
+		label := formatValue(value, scale)
+		drawText(img, marginLeft-8-font.MeasureString(basicfont.Face7x13, label).Round(), y+4, label)
+
+		// 横軸の目盛り
+		step := math.Round(p.maxX * float64(i) / ticks)
+		x := p.x(step)
+		drawLine(img, x, marginTop, x, height-marginBottom, gridColor)
+		label = strconv.FormatFloat(step, 'f', 0, 64)
+		drawText(img, x-font.MeasureString(basicfont.Face7x13, label).Round()/2, height-marginBottom+18, label)
+	}
+
+	drawLine(img, marginLeft, marginTop, marginLeft, height-marginBottom, axisColor)
+	drawLine(img, marginLeft, height-marginBottom, width-marginRight, height-marginBottom, axisColor)
+}
+
+func (p plot) drawTrajectory(img *image.RGBA, values []float64) {
+	peak := 0
+	for i := 1; i < len(values); i++ {
+		drawLine(img, p.x(float64(i-1)), p.y(values[i-1]), p.x(float64(i)), p.y(values[i]), lineColor)
+		if values[i] > values[peak] {
+			peak = i
+		}
+	}
+
+	// 最大値の位置に印を付ける
+	px, py := p.x(float64(peak)), p.y(values[peak])
+	for dx := -3; dx <= 3; dx++ {
+		for dy := -3; dy <= 3; dy++ {
+			img.Set(px+dx, py+dy, peakColor)
+		}
+	}
+}
+
+// formatValue は縦軸の目盛りのラベルを返す。対数スケールでは 10 の累乗で表す
+func formatValue(value float64, scale collatz.Scale) string {
+	if scale == collatz.LogScale {
+		return "10^" + strconv.FormatFloat(value, 'f', 1, 64)
+	}
+	if value >= 1e6 {
+		return strconv.FormatFloat(value, 'e', 2, 64)
+	}
+	return strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+// drawLine はブレゼンハムのアルゴリズムで線分を描く
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	e := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func drawText(img *image.RGBA, x, y int, text string) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(axisColor),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
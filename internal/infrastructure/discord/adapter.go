@@ -150,6 +150,18 @@ func (a *DiscordAdapter) GetGuildVoiceStates(ctx context.Context, guildID discor
 	return channelUsers, nil
 }
 
+func (a *DiscordAdapter) IsBot(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) (bool, error) {
+	member, err := a.session.State.Member(string(guildID), string(userID))
+	if err != nil {
+		// キャッシュに無ければ API から取得する
+		member, err = a.session.GuildMember(string(guildID), string(userID))
+		if err != nil {
+			return false, fmt.Errorf("failed to get guild member: %w", err)
+		}
+	}
+	return member.User != nil && member.User.Bot, nil
+}
+
 func (a *DiscordAdapter) GetTextChannelMembers(ctx context.Context, textChannelID discordid.TextChannelID) ([]discordid.UserID, error) {
 	channel, err := a.session.Channel(string(textChannelID))
 	if err != nil {
@@ -165,3 +177,30 @@ func (a *DiscordAdapter) GetTextChannelMembers(ctx context.Context, textChannelI
 
 	return userIDs, nil
 }
+
+func (a *DiscordAdapter) CanMoveMembers(ctx context.Context, userID discordid.UserID, voiceChannelID discordid.VoiceChannelID) (bool, error) {
+	permissions, err := a.session.State.UserChannelPermissions(string(userID), string(voiceChannelID))
+	if err != nil {
+		// キャッシュに無ければ API から取得する
+		permissions, err = a.session.UserChannelPermissions(string(userID), string(voiceChannelID))
+		if err != nil {
+			return false, fmt.Errorf("failed to get channel permissions: %w", err)
+		}
+	}
+	return permissions&discordgo.PermissionVoiceMoveMembers != 0, nil
+}
+
+func (a *DiscordAdapter) MoveMemberToVoiceChannel(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID, voiceChannelID discordid.VoiceChannelID) error {
+	channelID := string(voiceChannelID)
+	if err := a.session.GuildMemberMove(string(guildID), string(userID), &channelID); err != nil {
+		return fmt.Errorf("failed to move member to voice channel: %w", err)
+	}
+	return nil
+}
+
+func (a *DiscordAdapter) SendMessage(ctx context.Context, channelID discordid.TextChannelID, content string) error {
+	if _, err := a.session.ChannelMessageSend(string(channelID), content); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	return nil
+}
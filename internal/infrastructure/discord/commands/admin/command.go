@@ -0,0 +1,430 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	auditapp "github.com/aktnb/discord-bot-go/internal/application/audit"
+	"github.com/aktnb/discord-bot-go/internal/application/guildcommand"
+	appomikuji "github.com/aktnb/discord-bot-go/internal/application/omikuji"
+	appschedule "github.com/aktnb/discord-bot-go/internal/application/schedule"
+	"github.com/aktnb/discord-bot-go/internal/application/voicetext"
+	"github.com/aktnb/discord-bot-go/internal/domain/audit"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/aktnb/discord-bot-go/internal/shared/logging"
+	"github.com/bwmarrin/discordgo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Command はボットのオーナーだけが実行できる管理コマンド
+// 実行はオーナー以外による拒否も含めてすべて監査ログに記録する
+type Command struct {
+	ownerIDs      []string
+	registry      *commands.CommandRegistry
+	registrar     *commands.CommandRegistrar
+	guildCommands *guildcommand.Service
+	voiceText     *voicetext.Service
+	omikuji       *appomikuji.Service
+	schedules     *appschedule.Service
+	audit         *auditapp.Service
+	pool          *pgxpool.Pool
+	startedAt     time.Time
+}
+
+func NewAdminCommand(
+	ownerIDs []string,
+	registry *commands.CommandRegistry,
+	registrar *commands.CommandRegistrar,
+	guildCommands *guildcommand.Service,
+	voiceText *voicetext.Service,
+	omikuji *appomikuji.Service,
+	schedules *appschedule.Service,
+	audit *auditapp.Service,
+	pool *pgxpool.Pool,
+	startedAt time.Time,
+) *Command {
+	return &Command{
+		ownerIDs:      ownerIDs,
+		registry:      registry,
+		registrar:     registrar,
+		guildCommands: guildCommands,
+		voiceText:     voiceText,
+		omikuji:       omikuji,
+		schedules:     schedules,
+		audit:         audit,
+		pool:          pool,
+		startedAt:     startedAt,
+	}
+}
+
+func (c *Command) Name() string {
+	return "admin"
+}
+
+func (c *Command) ToDiscordCommand() *discordgo.ApplicationCommand {
+	commandOptions := []*discordgo.ApplicationCommandOption{
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "command",
+			Description:              commands.DefaultText("command.admin.option.command.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.command.description"),
+			Required:                 true,
+			Choices:                  c.guildCommandChoices(),
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "guild",
+			Description:              commands.DefaultText("command.admin.option.guild.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.guild.description"),
+		},
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.admin.name"),
+		Description:              commands.DefaultText("command.admin.description"),
+		DescriptionLocalizations: commands.Localizations("command.admin.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "stats",
+				Description:              commands.DefaultText("command.admin.stats.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.admin.stats.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:                     "loglevel",
+				Description:              commands.DefaultText("command.admin.loglevel.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.admin.loglevel.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionSubCommand,
+						Name:                     "set",
+						Description:              commands.DefaultText("command.admin.loglevel.set.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.admin.loglevel.set.description"),
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Type:                     discordgo.ApplicationCommandOptionString,
+								Name:                     "level",
+								Description:              commands.DefaultText("command.admin.option.level.description"),
+								DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.level.description"),
+								Required:                 true,
+								Choices: []*discordgo.ApplicationCommandOptionChoice{
+									{Name: "debug", Value: logging.LevelDebug.String()},
+									{Name: "info", Value: logging.LevelInfo.String()},
+									{Name: "warn", Value: logging.LevelWarn.String()},
+									{Name: "error", Value: logging.LevelError.String()},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:                     "voicetext",
+				Description:              commands.DefaultText("command.admin.voicetext.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.admin.voicetext.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionSubCommand,
+						Name:                     "sync-all",
+						Description:              commands.DefaultText("command.admin.voicetext.sync_all.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.admin.voicetext.sync_all.description"),
+					},
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:                     "commands",
+				Description:              commands.DefaultText("command.admin.commands.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.admin.commands.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionSubCommand,
+						Name:                     "enable",
+						Description:              commands.DefaultText("command.admin.commands.enable.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.admin.commands.enable.description"),
+						Options:                  commandOptions,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionSubCommand,
+						Name:                     "disable",
+						Description:              commands.DefaultText("command.admin.commands.disable.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.admin.commands.disable.description"),
+						Options:                  commandOptions,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionSubCommand,
+						Name:                     "resync",
+						Description:              commands.DefaultText("command.admin.commands.resync.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.admin.commands.resync.description"),
+					},
+				},
+			},
+			omikujiOptions(),
+		},
+	}
+}
+
+// guildCommandChoices は有効・無効を切り替えられるギルド固有コマンドの選択肢を返す
+func (c *Command) guildCommandChoices() []*discordgo.ApplicationCommandOptionChoice {
+	var names []string
+	for _, cmd := range c.registry.GetAllCommands() {
+		if _, ok := cmd.(commands.GuildSlashCommand); ok {
+			names = append(names, cmd.Name())
+		}
+	}
+	sort.Strings(names)
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(names))
+	for _, name := range names {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  name,
+			Value: name,
+		})
+	}
+	return choices
+}
+
+func (c *Command) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details: i18n.T(locale, "msg.admin.usage.details"),
+		Examples: []string{
+			"/admin stats",
+			"/admin loglevel set level:debug",
+			"/admin commands enable command:yamada",
+			"/admin commands disable command:yamada guild:123456789012345678",
+			"/admin commands resync",
+			"/admin voicetext sync-all",
+			"/admin omikuji show",
+			"/admin omikuji weights table:1/10/20/20/25/20/4",
+			"/admin omikuji timezone timezone:America/New_York",
+			"/admin omikuji override-add start:2026-01-01 end:2026-01-03 table:5/30/20/20/20/5/0 label:" + i18n.T(locale, "msg.admin.example.override_label"),
+		},
+	}
+}
+
+func (c *Command) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	action, options := subcommandPath(i.ApplicationCommandData().Options)
+	userID, _ := commands.InteractionUserID(i)
+
+	record := auditapp.RecordCommand{
+		UserID:    discordid.UserID(userID),
+		GuildID:   discordid.GuildID(i.GuildID),
+		Action:    action,
+		Arguments: formatArguments(options),
+	}
+
+	if !c.isOwner(userID) {
+		record.Outcome = audit.OutcomeDenied
+		c.recordAudit(ctx, record)
+		return respondEphemeral(s, i, commands.T(i, "msg.admin.owner_only"))
+	}
+
+	var err error
+	switch action {
+	case "stats":
+		err = c.handleStats(ctx, s, i)
+	case "loglevel set":
+		err = c.handleLogLevel(s, i, options)
+	case "commands enable", "commands disable":
+		err = c.handleCommandToggle(ctx, s, i, action, options)
+	case "commands resync":
+		err = c.handleResync(ctx, s, i)
+	case "voicetext sync-all":
+		err = c.handleVoiceTextSync(ctx, s, i)
+	case "omikuji show", "omikuji weights", "omikuji timezone", "omikuji override-add", "omikuji override-remove", "omikuji reset":
+		err = c.handleOmikuji(ctx, s, i, action, options)
+	default:
+		err = fmt.Errorf("unknown admin action: %s", action)
+	}
+
+	record.Outcome = audit.OutcomeSuccess
+	if err != nil {
+		record.Outcome = audit.OutcomeFailed
+		record.Detail = err.Error()
+	}
+	c.recordAudit(ctx, record)
+
+	return err
+}
+
+func (c *Command) isOwner(userID string) bool {
+	return userID != "" && slices.Contains(c.ownerIDs, userID)
+}
+
+// recordAudit は管理操作を監査ログに記録する。記録の失敗は操作自体の結果に影響させない
+func (c *Command) recordAudit(ctx context.Context, record auditapp.RecordCommand) {
+	if err := c.audit.Record(ctx, record); err != nil {
+		log.Printf("[ERROR] Failed to save audit log: %v", err)
+	}
+}
+
+func (c *Command) handleLogLevel(s *discordgo.Session, i *discordgo.InteractionCreate, options map[string]*discordgo.ApplicationCommandInteractionDataOption) error {
+	level, err := logging.ParseLevel(options["level"].StringValue())
+	if err != nil {
+		_ = respondEphemeral(s, i, commands.T(i, "msg.admin.loglevel_invalid"))
+		return err
+	}
+
+	previous := logging.CurrentLevel()
+	logging.SetLevel(level)
+	log.Printf("[WARN] Log level changed: %s -> %s", previous, level)
+
+	return respondEphemeral(s, i, commands.T(i, "msg.admin.loglevel_changed", previous, level))
+}
+
+func (c *Command) handleCommandToggle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, action string, options map[string]*discordgo.ApplicationCommandInteractionDataOption) error {
+	commandName := options["command"].StringValue()
+	guildID := discordid.GuildID(i.GuildID)
+	if opt, ok := options["guild"]; ok {
+		guildID = discordid.GuildID(opt.StringValue())
+	}
+
+	if guildID == "" {
+		_ = respondEphemeral(s, i, commands.T(i, "msg.admin.guild_required"))
+		return fmt.Errorf("guild is required outside of guilds")
+	}
+
+	cmd, ok := c.registry.GetCommand(commandName)
+	if !ok {
+		_ = respondEphemeral(s, i, commands.T(i, "msg.admin.unknown_command", commandName))
+		return fmt.Errorf("unknown command: %s", commandName)
+	}
+	if _, ok := cmd.(commands.GuildSlashCommand); !ok {
+		_ = respondEphemeral(s, i, commands.T(i, "msg.admin.global_command", commandName))
+		return fmt.Errorf("command %s is global", commandName)
+	}
+
+	// 設定の保存とコマンドの再登録に時間がかかる可能性があるため、応答を遅延させる
+	if err := deferEphemeral(s, i); err != nil {
+		return err
+	}
+
+	var err error
+	doneKey := "msg.admin.command_enabled"
+	if action == "commands enable" {
+		err = c.guildCommands.Enable(ctx, guildID, commandName)
+	} else {
+		doneKey = "msg.admin.command_disabled"
+		err = c.guildCommands.Disable(ctx, guildID, commandName)
+	}
+	if err != nil {
+		log.Printf("Error updating guild command setting: %v", err)
+		_ = followupEphemeral(s, i, commands.T(i, "msg.admin.save_failed"))
+		return err
+	}
+
+	if err := c.registrar.RegisterGuildCommands(ctx, guildID); err != nil {
+		log.Printf("Error re-registering guild commands: guild=%s err=%v", guildID, err)
+		_ = followupEphemeral(s, i, commands.T(i, doneKey, guildID, commandName)+"\n"+commands.T(i, "msg.admin.resync_failed"))
+		return err
+	}
+
+	return followupEphemeral(s, i, commands.T(i, doneKey, guildID, commandName))
+}
+
+func (c *Command) handleResync(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if err := deferEphemeral(s, i); err != nil {
+		return err
+	}
+
+	if err := c.registrar.RegisterApplicationCommands(ctx); err != nil {
+		log.Printf("Error re-registering application commands: %v", err)
+		_ = followupEphemeral(s, i, commands.T(i, "msg.admin.resync_failed"))
+		return err
+	}
+
+	return followupEphemeral(s, i, commands.T(i, "msg.admin.resync_done"))
+}
+
+func (c *Command) handleVoiceTextSync(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	// 同期は全ギルドのチャンネルを走査するため時間がかかる
+	if err := deferEphemeral(s, i); err != nil {
+		return err
+	}
+
+	if err := c.voiceText.SyncVoiceTextLinks(ctx); err != nil {
+		log.Printf("Error syncing voice-text links: %v", err)
+		_ = followupEphemeral(s, i, commands.T(i, "msg.admin.voicetext_sync_failed"))
+		return err
+	}
+
+	return followupEphemeral(s, i, commands.T(i, "msg.admin.voicetext_sync_done"))
+}
+
+// subcommandPath はサブコマンドグループとサブコマンドを辿り、
+// "commands enable" のようなパスと末端のオプションを返す
+func subcommandPath(options []*discordgo.ApplicationCommandInteractionDataOption) (string, map[string]*discordgo.ApplicationCommandInteractionDataOption) {
+	var path []string
+	for len(options) > 0 {
+		opt := options[0]
+		if opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup && opt.Type != discordgo.ApplicationCommandOptionSubCommand {
+			break
+		}
+		path = append(path, opt.Name)
+		options = opt.Options
+	}
+
+	leaf := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, opt := range options {
+		leaf[opt.Name] = opt
+	}
+	return strings.Join(path, " "), leaf
+}
+
+// formatArguments は監査ログ用にオプションを "name=value" 形式で並べる
+func formatArguments(options map[string]*discordgo.ApplicationCommandInteractionDataOption) string {
+	args := make([]string, 0, len(options))
+	for name, opt := range options {
+		args = append(args, fmt.Sprintf("%s=%v", name, opt.Value))
+	}
+	sort.Strings(args)
+	return strings.Join(args, " ")
+}
+
+func deferEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error deferring response: %v", err)
+	}
+	return err
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to admin: %v", err)
+	}
+	return err
+}
+
+func followupEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Printf("Error sending admin followup: %v", err)
+	}
+	return err
+}
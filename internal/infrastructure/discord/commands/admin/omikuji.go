@@ -0,0 +1,280 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	appomikuji "github.com/aktnb/discord-bot-go/internal/application/omikuji"
+	domainomikuji "github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// 差し替え設定の期間の表示形式
+	omikujiDateLayout = "2006-01-02"
+	// 入力補完の候補数の上限（Discord の仕様）
+	maxAutocompleteChoices = 25
+)
+
+// omikujiLevelKeys は確率分布の表示に使う運勢レベルの表示名のカタログキー
+var omikujiLevelKeys = map[domainomikuji.FortuneLevel]string{
+	domainomikuji.UltraGreatBlessing: "msg.omikuji.level.ultra_great_blessing",
+	domainomikuji.GreatBlessing:      "msg.omikuji.level.great_blessing",
+	domainomikuji.MiddleBlessing:     "msg.omikuji.level.middle_blessing",
+	domainomikuji.SmallBlessing:      "msg.omikuji.level.small_blessing",
+	domainomikuji.Blessing:           "msg.omikuji.level.blessing",
+	domainomikuji.BadLuck:            "msg.omikuji.level.bad_luck",
+	domainomikuji.GreatBadLuck:       "msg.omikuji.level.great_bad_luck",
+}
+
+// omikujiOptions は /admin omikuji サブコマンドグループの定義
+func omikujiOptions() *discordgo.ApplicationCommandOption {
+	guildOption := &discordgo.ApplicationCommandOption{
+		Type:                     discordgo.ApplicationCommandOptionString,
+		Name:                     "guild",
+		Description:              commands.DefaultText("command.admin.option.guild.description"),
+		DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.guild.description"),
+	}
+	tableOption := &discordgo.ApplicationCommandOption{
+		Type:                     discordgo.ApplicationCommandOptionString,
+		Name:                     "table",
+		Description:              commands.DefaultText("command.admin.option.table.description"),
+		DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.table.description"),
+		Required:                 true,
+	}
+
+	subcommand := func(name, key string, options ...*discordgo.ApplicationCommandOption) *discordgo.ApplicationCommandOption {
+		return &discordgo.ApplicationCommandOption{
+			Type:                     discordgo.ApplicationCommandOptionSubCommand,
+			Name:                     name,
+			Description:              commands.DefaultText(key),
+			DescriptionLocalizations: commands.OptionLocalizations(key),
+			Options:                  append(options, guildOption),
+		}
+	}
+
+	return &discordgo.ApplicationCommandOption{
+		Type:                     discordgo.ApplicationCommandOptionSubCommandGroup,
+		Name:                     "omikuji",
+		Description:              commands.DefaultText("command.admin.omikuji.description"),
+		DescriptionLocalizations: commands.OptionLocalizations("command.admin.omikuji.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			subcommand("show", "command.admin.omikuji.show.description"),
+			subcommand("weights", "command.admin.omikuji.weights.description", tableOption),
+			subcommand("timezone", "command.admin.omikuji.timezone.description", &discordgo.ApplicationCommandOption{
+				Type:                     discordgo.ApplicationCommandOptionString,
+				Name:                     "timezone",
+				Description:              commands.DefaultText("command.admin.option.timezone.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.timezone.description"),
+				Required:                 true,
+			}),
+			subcommand("override-add", "command.admin.omikuji.override_add.description",
+				&discordgo.ApplicationCommandOption{
+					Type:                     discordgo.ApplicationCommandOptionString,
+					Name:                     "start",
+					Description:              commands.DefaultText("command.admin.option.start.description"),
+					DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.start.description"),
+					Required:                 true,
+				},
+				&discordgo.ApplicationCommandOption{
+					Type:                     discordgo.ApplicationCommandOptionString,
+					Name:                     "end",
+					Description:              commands.DefaultText("command.admin.option.end.description"),
+					DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.end.description"),
+					Required:                 true,
+				},
+				tableOption,
+				&discordgo.ApplicationCommandOption{
+					Type:                     discordgo.ApplicationCommandOptionString,
+					Name:                     "label",
+					Description:              commands.DefaultText("command.admin.option.label.description"),
+					DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.label.description"),
+					MaxLength:                50,
+				},
+			),
+			subcommand("override-remove", "command.admin.omikuji.override_remove.description", &discordgo.ApplicationCommandOption{
+				Type:                     discordgo.ApplicationCommandOptionString,
+				Name:                     "override",
+				Description:              commands.DefaultText("command.admin.option.override.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.admin.option.override.description"),
+				Required:                 true,
+				Autocomplete:             true,
+			}),
+			subcommand("reset", "command.admin.omikuji.reset.description"),
+		},
+	}
+}
+
+// handleOmikuji は /admin omikuji の各サブコマンドを処理する
+func (c *Command) handleOmikuji(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, action string, options map[string]*discordgo.ApplicationCommandInteractionDataOption) error {
+	guildID := targetGuild(i, options)
+	if guildID == "" {
+		_ = respondEphemeral(s, i, commands.T(i, "msg.admin.guild_required"))
+		return fmt.Errorf("guild is required outside of guilds")
+	}
+
+	var err error
+	switch action {
+	case "omikuji show":
+		// 設定の表示だけは後段の共通処理で応答する
+	case "omikuji weights":
+		_, err = c.omikuji.ChangeDistribution(ctx, guildID, options["table"].StringValue())
+	case "omikuji timezone":
+		_, err = c.omikuji.ChangeTimezone(ctx, guildID, strings.TrimSpace(options["timezone"].StringValue()))
+	case "omikuji override-add":
+		var label string
+		if opt, ok := options["label"]; ok {
+			label = opt.StringValue()
+		}
+		_, err = c.omikuji.AddOverride(ctx, appomikuji.AddOverrideCommand{
+			GuildID:      guildID,
+			Label:        label,
+			StartDate:    options["start"].StringValue(),
+			EndDate:      options["end"].StringValue(),
+			Distribution: options["table"].StringValue(),
+		})
+	case "omikuji override-remove":
+		err = c.omikuji.RemoveOverride(ctx, guildID, domainomikuji.OverrideID(options["override"].StringValue()))
+	case "omikuji reset":
+		err = c.omikuji.ResetSettings(ctx, guildID)
+	default:
+		err = fmt.Errorf("unknown admin action: %s", action)
+	}
+	if err != nil {
+		log.Printf("Error updating omikuji settings: guild=%s err=%v", guildID, err)
+		_ = respondEphemeral(s, i, omikujiErrorMessage(i, err))
+		return err
+	}
+	if action == "omikuji timezone" || action == "omikuji reset" {
+		// 予約投稿の次の実行時刻は古いタイムゾーンで計算してあるので計算し直す
+		if err := c.schedules.RescheduleGuild(ctx, guildID); err != nil {
+			log.Printf("Error rescheduling schedules: guild=%s err=%v", guildID, err)
+		}
+	}
+
+	settings, overrides, err := c.omikuji.GuildSettings(ctx, guildID)
+	if err != nil {
+		log.Printf("Error loading omikuji settings: guild=%s err=%v", guildID, err)
+		_ = respondEphemeral(s, i, commands.T(i, "msg.admin.omikuji.load_failed"))
+		return err
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{omikujiSettingsEmbed(commands.Locale(i), settings, overrides)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to admin: %v", err)
+	}
+	return err
+}
+
+// HandleAutocomplete は削除する差し替え設定の候補を返す
+func (c *Command) HandleAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+
+	userID, _ := commands.InteractionUserID(i)
+	action, options := subcommandPath(i.ApplicationCommandData().Options)
+	if c.isOwner(userID) && action == "omikuji override-remove" {
+		if guildID := targetGuild(i, options); guildID != "" {
+			_, overrides, err := c.omikuji.GuildSettings(ctx, guildID)
+			if err != nil {
+				log.Printf("Error loading omikuji overrides: %v", err)
+				return err
+			}
+			for _, o := range overrides {
+				choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+					Name:  overrideSummary(o),
+					Value: string(o.ID()),
+				})
+				if len(choices) == maxAutocompleteChoices {
+					break
+				}
+			}
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+// omikujiSettingsEmbed はギルドのおみくじの設定と差し替え設定を表示する
+func omikujiSettingsEmbed(locale i18n.Locale, settings *domainomikuji.GuildSettings, overrides []*domainomikuji.Override) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: i18n.T(locale, "msg.admin.omikuji.title", settings.GuildID()),
+		Color: 0xD9333F,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(locale, "msg.admin.omikuji.timezone"), Value: settings.Timezone(), Inline: true},
+			{Name: i18n.T(locale, "msg.admin.omikuji.weights"), Value: formatDistribution(locale, settings.Distribution()), Inline: true},
+		},
+	}
+
+	value := i18n.T(locale, "msg.admin.omikuji.no_overrides")
+	if len(overrides) > 0 {
+		lines := make([]string, 0, len(overrides))
+		for _, o := range overrides {
+			lines = append(lines, fmt.Sprintf("`%s` %s", o.Distribution(), overrideSummary(o)))
+		}
+		value = strings.Join(lines, "\n")
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  i18n.T(locale, "msg.admin.omikuji.overrides"),
+		Value: value,
+	})
+	return embed
+}
+
+// formatDistribution は確率分布を運勢レベルごとに1行ずつ表示する
+func formatDistribution(locale i18n.Locale, d domainomikuji.Distribution) string {
+	lines := make([]string, 0, len(d))
+	for _, w := range d {
+		lines = append(lines, fmt.Sprintf("%s: %.1f%%", i18n.T(locale, omikujiLevelKeys[w.Level]), d.Rate(w.Level)*100))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// overrideSummary は差し替え設定を "ラベル 2026-01-01〜2026-01-03" のように要約する
+func overrideSummary(o *domainomikuji.Override) string {
+	summary := o.StartDate().Format(omikujiDateLayout) + "〜" + o.EndDate().Format(omikujiDateLayout)
+	if o.Label() != "" {
+		summary = o.Label() + " " + summary
+	}
+	return summary
+}
+
+// omikujiErrorMessage は設定変更の失敗理由を利用者向けのメッセージにする
+func omikujiErrorMessage(i *discordgo.InteractionCreate, err error) string {
+	switch {
+	case errors.Is(err, domainomikuji.ErrInvalidDistribution):
+		return commands.T(i, "msg.admin.omikuji.invalid_weights", err)
+	case errors.Is(err, domainomikuji.ErrInvalidTimezone):
+		return commands.T(i, "msg.admin.omikuji.invalid_timezone")
+	case errors.Is(err, domainomikuji.ErrInvalidDateRange):
+		return commands.T(i, "msg.admin.omikuji.invalid_date_range")
+	case errors.Is(err, domainomikuji.ErrOverrideNotFound):
+		return commands.T(i, "msg.admin.omikuji.override_not_found")
+	default:
+		return commands.T(i, "msg.admin.save_failed")
+	}
+}
+
+// targetGuild は guild オプションが指定されていればそのギルドを、無ければ実行したギルドを返す
+func targetGuild(i *discordgo.InteractionCreate, options map[string]*discordgo.ApplicationCommandInteractionDataOption) discordid.GuildID {
+	if opt, ok := options["guild"]; ok && opt.StringValue() != "" {
+		return discordid.GuildID(opt.StringValue())
+	}
+	return discordid.GuildID(i.GuildID)
+}
@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/logging"
+	"github.com/bwmarrin/discordgo"
+)
+
+// 統計情報の埋め込みの色
+const statsEmbedColor = 0x2ECC71
+
+func (c *Command) handleStats(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	poolStat := c.pool.Stat()
+
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   commands.T(i, "msg.admin.stats.uptime"),
+			Value:  formatDuration(time.Since(c.startedAt)),
+			Inline: true,
+		},
+		{
+			Name:   commands.T(i, "msg.admin.stats.guilds"),
+			Value:  fmt.Sprintf("%d", len(s.State.Guilds)),
+			Inline: true,
+		},
+		{
+			Name:   commands.T(i, "msg.admin.stats.latency"),
+			Value:  fmt.Sprintf("%d ms", s.HeartbeatLatency().Milliseconds()),
+			Inline: true,
+		},
+		{
+			Name:   commands.T(i, "msg.admin.stats.goroutines"),
+			Value:  fmt.Sprintf("%d", runtime.NumGoroutine()),
+			Inline: true,
+		},
+		{
+			Name:   commands.T(i, "msg.admin.stats.memory"),
+			Value:  commands.T(i, "msg.admin.stats.memory_value", formatBytes(mem.HeapAlloc), formatBytes(mem.Sys), mem.NumGC),
+			Inline: true,
+		},
+		{
+			Name:   commands.T(i, "msg.admin.stats.log_level"),
+			Value:  logging.CurrentLevel().String(),
+			Inline: true,
+		},
+		{
+			Name: commands.T(i, "msg.admin.stats.db_pool"),
+			Value: commands.T(i, "msg.admin.stats.db_pool_value",
+				poolStat.AcquiredConns(),
+				poolStat.IdleConns(),
+				poolStat.TotalConns(),
+				poolStat.MaxConns(),
+				poolStat.AcquireCount(),
+				poolStat.AcquireDuration().Milliseconds(),
+			),
+		},
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:     commands.T(i, "msg.admin.stats.title"),
+					Color:     statsEmbedColor,
+					Fields:    fields,
+					Timestamp: time.Now().Format(time.RFC3339),
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to admin stats: %v", err)
+	}
+	return err
+}
+
+// formatDuration は稼働時間を "1d 2h 3m 4s" の形式で返す
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days > 0 {
+		return fmt.Sprintf("%dd %s", days, d)
+	}
+	return d.String()
+}
+
+// formatBytes はバイト数を KiB / MiB / GiB 単位で返す
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -2,12 +2,24 @@ package cat
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 
 	appcat "github.com/aktnb/discord-bot-go/internal/application/cat"
+	"github.com/aktnb/discord-bot-go/internal/domain/cat"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+const (
+	maxAutocompleteChoices = 25
+	embedColor             = 0xF39C12
+)
+
 type CatCommand struct {
 	service *appcat.Service
 }
@@ -23,13 +35,100 @@ func (c *CatCommand) Name() string {
 }
 
 func (c *CatCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
+	minCount := 1.0
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name(),
-		Description: "ランダムな猫の画像を表示します",
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.cat.name"),
+		Description:              commands.DefaultText("command.cat.description"),
+		DescriptionLocalizations: commands.Localizations("command.cat.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionString,
+				Name:                     "breed",
+				Description:              commands.DefaultText("command.cat.option.breed.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.cat.option.breed.description"),
+				Autocomplete:             true,
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionInteger,
+				Name:                     "count",
+				Description:              commands.DefaultText("command.cat.option.count.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.cat.option.count.description"),
+				MinValue:                 &minCount,
+				MaxValue:                 cat.MaxImages,
+			},
+		},
 	}
 }
 
 func (c *CatCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var (
+		breedID string
+		count   = 1
+	)
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "breed":
+			breedID = option.StringValue()
+		case "count":
+			count = int(option.IntValue())
+		}
+	}
+	return c.respondImages(ctx, s, i, breedID, count)
+}
+
+// HandleComponent は「もう一度」ボタンで同じ条件の画像を新しいメッセージで表示する
+// CustomID は "cat:again:<枚数>:<猫種 ID>" の形式（猫種を指定しなければ ID は空）
+func (c *CatCommand) HandleComponent(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	parts := strings.SplitN(i.MessageComponentData().CustomID, ":", 4)
+	if len(parts) != 4 || parts[1] != "again" {
+		return fmt.Errorf("unknown cat component: %s", i.MessageComponentData().CustomID)
+	}
+	count, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return fmt.Errorf("invalid cat component: %s", i.MessageComponentData().CustomID)
+	}
+	return c.respondImages(ctx, s, i, parts[3], count)
+}
+
+// HandleAutocomplete は入力に一致する猫種を候補として返す
+func (c *CatCommand) HandleAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	var input string
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Focused {
+			input = option.StringValue()
+		}
+	}
+
+	breeds, err := c.service.SearchBreeds(ctx, input, maxAutocompleteChoices)
+	if err != nil {
+		log.Printf("Error listing cat breeds: %v", err)
+	}
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(breeds))
+	for _, breed := range breeds {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  breed.Name,
+			Value: breed.ID,
+		})
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+func (c *CatCommand) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details:  i18n.T(locale, "msg.cat.usage.details", cat.MaxImages),
+		Examples: []string{"/cat", "/cat breed:Bengal", "/cat count:4"},
+	}
+}
+
+// respondImages は猫画像を取得し、ギャラリー表示の埋め込みと「もう一度」ボタンで応答する
+func (c *CatCommand) respondImages(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, breedID string, count int) error {
 	// まず応答を遅延させる（API呼び出しに時間がかかる可能性があるため）
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
@@ -39,21 +138,55 @@ func (c *CatCommand) Handle(ctx context.Context, s *discordgo.Session, i *discor
 		return err
 	}
 
-	image, err := c.service.GetRandomCatImage(ctx)
+	images, err := c.service.GetRandomCatImages(ctx, breedID, count)
+	var title string
+	if err == nil && breedID != "" {
+		var breed cat.Breed
+		if breed, err = c.service.Breed(ctx, breedID); err == nil {
+			title = breed.Name
+		}
+	}
 	if err != nil {
-		log.Printf("Error fetching cat image: %v", err)
+		log.Printf("Error fetching cat images: %v", err)
+		content := commands.T(i, "msg.cat.fetch_failed")
+		if errors.Is(err, cat.ErrBreedNotFound) {
+			content = commands.T(i, "msg.cat.breed_not_found")
+		}
 		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: "猫の画像を取得できませんでした。もう一度お試しください。",
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
 		})
 		return err
 	}
 
-	// 画像URLを直接返す
+	// 同じ URL を持つ埋め込みは Discord で1つのギャラリーとしてまとめて表示される
+	embeds := make([]*discordgo.MessageEmbed, len(images))
+	for n, image := range images {
+		embeds[n] = &discordgo.MessageEmbed{
+			URL:   images[0].URL,
+			Color: embedColor,
+			Image: &discordgo.MessageEmbedImage{URL: image.URL},
+		}
+	}
+	embeds[0].Title = title
+
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-		Content: image.URL,
+		Embeds: embeds,
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    commands.T(i, "msg.cat.again"),
+						Style:    discordgo.SecondaryButton,
+						Emoji:    &discordgo.ComponentEmoji{Name: "🐱"},
+						CustomID: fmt.Sprintf("cat:again:%d:%s", count, breedID),
+					},
+				},
+			},
+		},
 	})
 	if err != nil {
-		log.Printf("Error sending cat image: %v", err)
+		log.Printf("Error sending cat images: %v", err)
 		return err
 	}
 
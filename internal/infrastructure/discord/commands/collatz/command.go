@@ -1,21 +1,29 @@
 package collatz
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
 
 	appcollatz "github.com/aktnb/discord-bot-go/internal/application/collatz"
+	"github.com/aktnb/discord-bot-go/internal/domain/collatz"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
 type CollatzCommand struct {
-	service *appcollatz.Service
+	service   *appcollatz.Service
+	paginator *commands.Paginator
 }
 
-func NewCollatzCommand(service *appcollatz.Service) *CollatzCommand {
+func NewCollatzCommand(service *appcollatz.Service, paginator *commands.Paginator) *CollatzCommand {
 	return &CollatzCommand{
-		service: service,
+		service:   service,
+		paginator: paginator,
 	}
 }
 
@@ -24,25 +32,104 @@ func (c *CollatzCommand) Name() string {
 }
 
 func (c *CollatzCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
+	numberOption := &discordgo.ApplicationCommandOption{
+		// int64 を超える値も受け付けるため文字列で受け取る
+		Type:                     discordgo.ApplicationCommandOptionString,
+		Name:                     "number",
+		Description:              commands.DefaultText("command.collatz.option.number.description"),
+		DescriptionLocalizations: commands.OptionLocalizations("command.collatz.option.number.description"),
+		Required:                 true,
+		MaxLength:                collatz.MaxStartDigits,
+	}
+	minValue := 1.0
+
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name(),
-		Description: "コラッツ予想をシミュレーションします",
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.collatz.name"),
+		Description:              commands.DefaultText("command.collatz.description"),
+		DescriptionLocalizations: commands.Localizations("command.collatz.description"),
 		Options: []*discordgo.ApplicationCommandOption{
 			{
-				Type:        discordgo.ApplicationCommandOptionInteger,
-				Name:        "number",
-				Description: "開始する正の整数",
-				Required:    true,
-				MinValue:    func() *float64 { v := 1.0; return &v }(),
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "sequence",
+				Description:              commands.DefaultText("command.collatz.sequence.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.collatz.sequence.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					numberOption,
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "chart",
+						Description:              commands.DefaultText("command.collatz.option.chart.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.collatz.option.chart.description"),
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{
+								Name:              commands.DefaultText("command.collatz.choice.linear"),
+								NameLocalizations: commands.OptionLocalizations("command.collatz.choice.linear"),
+								Value:             string(collatz.LinearScale),
+							},
+							{
+								Name:              commands.DefaultText("command.collatz.choice.log"),
+								NameLocalizations: commands.OptionLocalizations("command.collatz.choice.log"),
+								Value:             string(collatz.LogScale),
+							},
+						},
+					},
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "stats",
+				Description:              commands.DefaultText("command.collatz.stats.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.collatz.stats.description"),
+				Options:                  []*discordgo.ApplicationCommandOption{numberOption},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "range",
+				Description:              commands.DefaultText("command.collatz.range.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.collatz.range.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionInteger,
+						Name:                     "from",
+						Description:              commands.DefaultText("command.collatz.option.from.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.collatz.option.from.description"),
+						Required:                 true,
+						MinValue:                 &minValue,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionInteger,
+						Name:                     "to",
+						Description:              commands.DefaultText("command.collatz.option.to.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.collatz.option.to.description"),
+						Required:                 true,
+						MinValue:                 &minValue,
+					},
+				},
 			},
 		},
 	}
 }
 
+func (c *CollatzCommand) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details: i18n.T(locale, "msg.collatz.usage.details"),
+		Examples: []string{
+			"/collatz sequence number:27",
+			"/collatz sequence number:27 chart:log",
+			"/collatz stats number:12345678901234567890123",
+			"/collatz range from:1 to:10000",
+		},
+	}
+}
+
 func (c *CollatzCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	// パラメータ取得（Discord enforces required parameters）
-	options := i.ApplicationCommandData().Options
-	number := options[0].IntValue()
+	// サブコマンドとパラメータ取得（Discord enforces required parameters）
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(subcommand.Options))
+	for _, option := range subcommand.Options {
+		options[option.Name] = option
+	}
 
 	// 計算処理に時間がかかる可能性があるため、応答を遅延させる
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -53,35 +140,90 @@ func (c *CollatzCommand) Handle(ctx context.Context, s *discordgo.Session, i *di
 		return err
 	}
 
-	// コラッツ予想の計算
-	messages, err := c.service.Calculate(ctx, number)
+	locale := commands.Locale(i)
+	var text string
+	switch subcommand.Name {
+	case "sequence", "stats":
+		var start *big.Int
+		start, err = collatz.ParseStart(options["number"].StringValue())
+		if err != nil {
+			break
+		}
+		if chart, ok := options["chart"]; ok && subcommand.Name == "sequence" {
+			return c.sendChart(ctx, s, i, start, collatz.ParseScale(chart.StringValue()))
+		}
+		if subcommand.Name == "sequence" {
+			text, err = c.service.CalculateBig(ctx, locale, start)
+		} else {
+			text, err = c.service.Stats(ctx, locale, start)
+		}
+	case "range":
+		text, err = c.service.LongestInRange(ctx, locale, options["from"].IntValue(), options["to"].IntValue())
+	default:
+		err = fmt.Errorf("unknown collatz subcommand: %s", subcommand.Name)
+	}
+
 	if err != nil {
-		log.Printf("Error calculating collatz sequence: %v", err)
+		log.Printf("Error calculating collatz %s: %v", subcommand.Name, err)
 		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: fmt.Sprintf("エラーが発生しました: %v", err),
+			Content: errorMessage(i, err),
 		})
 		return err
 	}
 
-	// 最初のメッセージを送信（Followup）
-	if len(messages) > 0 {
+	// 長い計算過程はページ送りで表示する
+	return c.paginator.Followup(s, i, commands.TextPages(text, commands.MaxContentLength))
+}
+
+// sendChart は計算過程のグラフ画像と全計算過程のテキストファイルを添付して応答する
+func (c *CollatzCommand) sendChart(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, start *big.Int, scale collatz.Scale) error {
+	result, err := c.service.Chart(ctx, commands.Locale(i), start, scale)
+	if err != nil {
+		log.Printf("Error rendering collatz chart: %v", err)
 		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: messages[0],
+			Content: errorMessage(i, err),
 		})
-		if err != nil {
-			log.Printf("Error sending first message: %v", err)
-			return err
-		}
+		return err
 	}
 
-	// 残りのメッセージを通常のチャンネルメッセージとして送信
-	for idx, message := range messages[1:] {
-		_, err = s.ChannelMessageSend(i.ChannelID, message)
-		if err != nil {
-			log.Printf("Error sending message %d: %v", idx+2, err)
-			return err
-		}
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: result.Summary,
+		Files: []*discordgo.File{
+			{
+				Name:        "collatz-trajectory.png",
+				ContentType: "image/png",
+				Reader:      bytes.NewReader(result.Image),
+			},
+			{
+				Name:        "collatz-trajectory.txt",
+				ContentType: "text/plain; charset=utf-8",
+				Reader:      bytes.NewReader(result.Sequence),
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Error sending collatz chart: %v", err)
+		return err
 	}
-
 	return nil
 }
+
+// errorMessage は計算エラーに対応するユーザー向けのメッセージを返す
+func errorMessage(i *discordgo.InteractionCreate, err error) string {
+	switch {
+	case errors.Is(err, collatz.ErrInvalidStart):
+		return commands.T(i, "msg.collatz.invalid_start")
+	case errors.Is(err, collatz.ErrStartTooLarge):
+		return commands.T(i, "msg.collatz.start_too_large", appcollatz.MaxSequenceDigits, collatz.MaxStartDigits)
+	case errors.Is(err, collatz.ErrBudgetExceeded):
+		return commands.T(i, "msg.collatz.budget_exceeded")
+	case errors.Is(err, context.DeadlineExceeded):
+		return commands.T(i, "msg.collatz.timeout")
+	case errors.Is(err, collatz.ErrInvalidRange):
+		return commands.T(i, "msg.collatz.invalid_range")
+	case errors.Is(err, collatz.ErrRangeTooWide):
+		return commands.T(i, "msg.collatz.range_too_wide", appcollatz.MaxRangeWidth)
+	default:
+		return commands.T(i, "msg.collatz.error")
+	}
+}
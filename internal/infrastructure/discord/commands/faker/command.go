@@ -1,15 +1,16 @@
 package faker
 
 import (
-	appfaker "github.com/aktnb/discord-bot-go/internal/application/faker"
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
 	legendcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/legend"
 )
 
-func NewFakerCommand(service *appfaker.Service) *legendcmd.Command {
+func NewFakerCommand(service *applegend.Service) *legendcmd.Command {
 	return legendcmd.New(
+		service,
 		"faker",
-		"LOL プロプレイヤー Faker の伝説エピソードをランダムに紹介します",
-		"Faker",
-		service.GetRandomEpisode,
+		"command.faker.name",
+		"command.faker.description",
+		"msg.legend.faker.prefix",
 	)
 }
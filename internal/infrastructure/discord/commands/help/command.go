@@ -0,0 +1,271 @@
+package help
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// 一覧の1ページに表示するコマンド数
+	commandsPerPage = 8
+	// Discord の埋め込みの色（ボットのテーマカラー）
+	embedColor = 0x5865F2
+	// 入力補完の候補数の上限（Discord の仕様）
+	maxAutocompleteChoices = 25
+	// 埋め込みフィールドの値の文字数上限（Discord の仕様）
+	maxFieldValueLength = 1024
+)
+
+// descriptionLocales は i18n のロケールに対応する Discord のロケール
+var descriptionLocales = map[i18n.Locale]discordgo.Locale{
+	i18n.Japanese: discordgo.Japanese,
+	i18n.English:  discordgo.EnglishUS,
+}
+
+// Command はコマンドレジストリからヘルプを自動生成するコマンド
+type Command struct {
+	registrar *commands.CommandRegistrar
+	paginator *commands.Paginator
+}
+
+func NewHelpCommand(registrar *commands.CommandRegistrar, paginator *commands.Paginator) *Command {
+	return &Command{
+		registrar: registrar,
+		paginator: paginator,
+	}
+}
+
+func (c *Command) Name() string {
+	return "help"
+}
+
+func (c *Command) ToDiscordCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.help.name"),
+		Description:              commands.DefaultText("command.help.description"),
+		DescriptionLocalizations: commands.Localizations("command.help.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionString,
+				Name:                     "command",
+				Description:              commands.DefaultText("command.help.option.command.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.help.option.command.description"),
+				Autocomplete:             true,
+			},
+		},
+	}
+}
+
+func (c *Command) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details:  i18n.T(locale, "msg.help.usage.details"),
+		Examples: []string{"/help", "/help command:collatz"},
+	}
+}
+
+func (c *Command) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	available, err := c.registrar.AvailableCommands(ctx, discordid.GuildID(i.GuildID))
+	if err != nil {
+		log.Printf("Error listing available commands: %v", err)
+		return respondEphemeral(s, i, &discordgo.InteractionResponseData{
+			Content: commands.T(i, "msg.help.list_failed"),
+		})
+	}
+
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return c.paginator.RespondEphemeral(s, i, overviewPages(commands.Locale(i), available))
+	}
+
+	name := strings.TrimPrefix(options[0].StringValue(), "/")
+	for _, cmd := range available {
+		if cmd.Name() == name {
+			return respondEphemeral(s, i, &discordgo.InteractionResponseData{
+				Embeds: []*discordgo.MessageEmbed{detailEmbed(commands.Locale(i), cmd)},
+			})
+		}
+	}
+
+	return respondEphemeral(s, i, &discordgo.InteractionResponseData{
+		Content: commands.T(i, "msg.help.unknown_command", name),
+	})
+}
+
+// HandleAutocomplete は現在のギルドで使えるコマンド名を候補として返す
+func (c *Command) HandleAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	available, err := c.registrar.AvailableCommands(ctx, discordid.GuildID(i.GuildID))
+	if err != nil {
+		log.Printf("Error listing available commands: %v", err)
+		return err
+	}
+
+	var input string
+	if options := i.ApplicationCommandData().Options; len(options) > 0 {
+		input = strings.ToLower(strings.TrimPrefix(options[0].StringValue(), "/"))
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	for _, cmd := range available {
+		if !strings.Contains(cmd.Name(), input) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  "/" + cmd.Name(),
+			Value: cmd.Name(),
+		})
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+// overviewPages はコマンド一覧を commandsPerPage 件ずつのページに分ける
+func overviewPages(locale i18n.Locale, available []commands.SlashCommand) []commands.Page {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(available))
+	for _, cmd := range available {
+		def := cmd.ToDiscordCommand()
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  "/" + def.Name,
+			Value: localizedDescription(locale, def.Description, def.DescriptionLocalizations),
+		})
+	}
+
+	base := &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "msg.help.overview_title"),
+		Description: i18n.T(locale, "msg.help.overview_description"),
+		Color:       embedColor,
+	}
+	return commands.FieldPages(base, fields, commandsPerPage)
+}
+
+// detailEmbed はコマンドの説明、オプション、使い方をまとめた埋め込みを生成する
+func detailEmbed(locale i18n.Locale, cmd commands.SlashCommand) *discordgo.MessageEmbed {
+	def := cmd.ToDiscordCommand()
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "/" + def.Name,
+		Description: localizedDescription(locale, def.Description, def.DescriptionLocalizations),
+		Color:       embedColor,
+	}
+
+	if lines := optionLines(locale, "/"+def.Name, def.Options); len(lines) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "msg.help.options"),
+			Value: truncate(strings.Join(lines, "\n"), maxFieldValueLength),
+		})
+	}
+
+	if provider, ok := cmd.(commands.UsageProvider); ok {
+		usage := provider.Usage(locale)
+		if usage.Details != "" {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  i18n.T(locale, "msg.help.details"),
+				Value: usage.Details,
+			})
+		}
+		if len(usage.Examples) > 0 {
+			examples := make([]string, 0, len(usage.Examples))
+			for _, example := range usage.Examples {
+				examples = append(examples, "`"+example+"`")
+			}
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  i18n.T(locale, "msg.help.examples"),
+				Value: strings.Join(examples, "\n"),
+			})
+		}
+	}
+
+	return embed
+}
+
+// optionLines はオプションを1行ずつ整形する
+// サブコマンドは "/admin commands enable <command> [guild]" のように展開する
+func optionLines(locale i18n.Locale, path string, options []*discordgo.ApplicationCommandOption) []string {
+	var lines []string
+	var params []string
+	var paramLines []string
+
+	for _, opt := range options {
+		description := localizedOptionDescription(locale, opt)
+		switch opt.Type {
+		case discordgo.ApplicationCommandOptionSubCommandGroup:
+			lines = append(lines, optionLines(locale, path+" "+opt.Name, opt.Options)...)
+		case discordgo.ApplicationCommandOptionSubCommand:
+			usage := path + " " + opt.Name
+			for _, param := range opt.Options {
+				usage += " " + formatParam(param)
+			}
+			lines = append(lines, fmt.Sprintf("`%s` — %s", usage, description))
+		default:
+			params = append(params, formatParam(opt))
+			paramLines = append(paramLines, fmt.Sprintf("`%s` — %s", formatParam(opt), description))
+		}
+	}
+
+	if len(params) > 0 {
+		lines = append(lines, "`"+path+" "+strings.Join(params, " ")+"`")
+		lines = append(lines, paramLines...)
+	}
+	return lines
+}
+
+// formatParam は必須オプションを <name>、任意オプションを [name] と表記する
+func formatParam(opt *discordgo.ApplicationCommandOption) string {
+	if opt.Required {
+		return "<" + opt.Name + ">"
+	}
+	return "[" + opt.Name + "]"
+}
+
+func localizedDescription(locale i18n.Locale, fallback string, localizations *map[discordgo.Locale]string) string {
+	if localizations == nil {
+		return fallback
+	}
+	if text, ok := (*localizations)[descriptionLocales[locale]]; ok && text != "" {
+		return text
+	}
+	return fallback
+}
+
+func localizedOptionDescription(locale i18n.Locale, opt *discordgo.ApplicationCommandOption) string {
+	if text, ok := opt.DescriptionLocalizations[descriptionLocales[locale]]; ok && text != "" {
+		return text
+	}
+	return opt.Description
+}
+
+// truncate は文字列を rune 単位で上限までに切り詰める
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+	data.Flags = discordgo.MessageFlagsEphemeral
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+	if err != nil {
+		log.Printf("Error responding to help: %v", err)
+	}
+	return err
+}
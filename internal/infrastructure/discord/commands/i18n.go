@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// discordLocales は Localizations に設定する Discord のロケールとカタログの対応
+var discordLocales = map[discordgo.Locale]i18n.Locale{
+	discordgo.Japanese:  i18n.Japanese,
+	discordgo.EnglishUS: i18n.English,
+	discordgo.EnglishGB: i18n.English,
+}
+
+// Locale はインタラクションの応答に使うロケールを返す
+// ユーザーのクライアント言語を優先し、対応外であればギルドの言語を使う
+func Locale(i *discordgo.InteractionCreate) i18n.Locale {
+	var guildLocale string
+	if i.GuildLocale != nil {
+		guildLocale = string(*i.GuildLocale)
+	}
+	return i18n.Resolve(string(i.Locale), guildLocale)
+}
+
+// T はインタラクションのロケールでキーに対応する文字列を返す
+func T(i *discordgo.InteractionCreate, key string, args ...any) string {
+	return i18n.T(Locale(i), key, args...)
+}
+
+// DefaultText はコマンド定義の既定値として使うデフォルトロケールの文字列を返す
+func DefaultText(key string) string {
+	return i18n.T(i18n.Default, key)
+}
+
+// Localizations はコマンド定義の NameLocalizations / DescriptionLocalizations 用の翻訳を返す
+func Localizations(key string) *map[discordgo.Locale]string {
+	localizations := make(map[discordgo.Locale]string, len(discordLocales))
+	for discordLocale, locale := range discordLocales {
+		localizations[discordLocale] = i18n.T(locale, key)
+	}
+	return &localizations
+}
+
+// OptionLocalizations はオプションや選択肢の NameLocalizations / DescriptionLocalizations 用の翻訳を返す
+func OptionLocalizations(key string) map[discordgo.Locale]string {
+	return *Localizations(key)
+}
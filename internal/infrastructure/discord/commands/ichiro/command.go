@@ -1,15 +1,16 @@
 package ichiro
 
 import (
-	appichiro "github.com/aktnb/discord-bot-go/internal/application/ichiro"
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
 	legendcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/legend"
 )
 
-func NewIchiroCommand(service *appichiro.Service) *legendcmd.Command {
+func NewIchiroCommand(service *applegend.Service) *legendcmd.Command {
 	return legendcmd.New(
+		service,
 		"ichiro",
-		"全盛期のイチロー伝説をランダムに紹介します",
-		"イチロー",
-		service.GetRandomEpisode,
+		"command.ichiro.name",
+		"command.ichiro.description",
+		"msg.legend.ichiro.prefix",
 	)
 }
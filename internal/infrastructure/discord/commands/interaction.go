@@ -0,0 +1,32 @@
+package commands
+
+import "github.com/bwmarrin/discordgo"
+
+// InteractionUserID はインタラクションを実行したユーザーの ID を返す
+// ギルド内では Member.User、DM では User に格納されている
+func InteractionUserID(i *discordgo.InteractionCreate) (string, bool) {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID, true
+	}
+	if i.User != nil {
+		return i.User.ID, true
+	}
+	return "", false
+}
+
+// ModalValues はモーダルで入力された値をテキスト入力の CustomID ごとに返す
+func ModalValues(i *discordgo.InteractionCreate) map[string]string {
+	values := make(map[string]string)
+	for _, component := range i.ModalSubmitData().Components {
+		row, ok := component.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, c := range row.Components {
+			if input, ok := c.(*discordgo.TextInput); ok {
+				values[input.CustomID] = input.Value
+			}
+		}
+	}
+	return values
+}
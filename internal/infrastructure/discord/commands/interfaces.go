@@ -3,6 +3,8 @@ package commands
 import (
 	"context"
 
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -17,9 +19,60 @@ type SlashCommand interface {
 }
 
 // GuildSlashCommand はギルド固有のスラッシュコマンド
-// このインターフェースを実装するコマンドは、指定されたギルドにのみ登録される
+// このインターフェースを実装するコマンドは、有効化されたギルドにのみ登録される
+// 有効化の設定は guild_command_settings に永続化され、/admin commands で変更できる
 type GuildSlashCommand interface {
 	SlashCommand
-	// GuildIDs はコマンドを登録するギルド ID の一覧を返す
+	// GuildIDs はデフォルトでコマンドを登録するギルド ID の一覧を返す
+	// 永続化された設定がある場合はそちらが優先される
 	GuildIDs() []string
 }
+
+// DynamicCommandSource は実行時に定義されるギルド固有のコマンドを提供する
+// 定義は DB などに保存され、ギルドごとに同じ名前で別のコマンドを定義できる
+// 提供されたコマンドは CommandRegistry.RegisterSource で登録し、定義したギルドにだけ登録される
+type DynamicCommandSource interface {
+	// GuildCommands は全ギルドのコマンドをギルドごとに返す
+	GuildCommands(ctx context.Context) (map[discordid.GuildID][]SlashCommand, error)
+	// GuildCommand は指定したギルドで定義されたコマンドを返す。無ければ false を返す
+	GuildCommand(ctx context.Context, guildID discordid.GuildID, name string) (SlashCommand, bool, error)
+}
+
+// ComponentCommand はボタンなどのメッセージコンポーネントを処理するスラッシュコマンド
+// コンポーネントの CustomID は "<コマンド名>:<任意の値>" の形式とし、先頭のコマンド名でルーティングされる
+// ページ送りだけなら Paginator を使えばよく、このインターフェースを実装する必要はない
+type ComponentCommand interface {
+	SlashCommand
+	// HandleComponent はメッセージコンポーネントのインタラクションを処理する
+	HandleComponent(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error
+}
+
+// ModalCommand はモーダルの送信を処理するスラッシュコマンド
+// モーダルの CustomID も ComponentCommand と同じく "<コマンド名>:<任意の値>" の形式でルーティングされる
+type ModalCommand interface {
+	SlashCommand
+	// HandleModal はモーダル送信のインタラクションを処理する
+	HandleModal(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error
+}
+
+// AutocompleteCommand はオプションの入力補完を提供するスラッシュコマンド
+type AutocompleteCommand interface {
+	SlashCommand
+	// HandleAutocomplete は入力補完のインタラクションに候補を返す
+	HandleAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error
+}
+
+// Usage は /help に表示するコマンドの詳しい使い方
+type Usage struct {
+	// Details はオプションの一覧だけでは伝わらない補足説明
+	Details string
+	// Examples は入力例の一覧
+	Examples []string
+}
+
+// UsageProvider は /help に詳しい使い方を提供するスラッシュコマンド
+// 実装しないコマンドはコマンド定義の説明とオプションのみが表示される
+type UsageProvider interface {
+	// Usage はロケールに応じた使い方を返す
+	Usage(locale i18n.Locale) Usage
+}
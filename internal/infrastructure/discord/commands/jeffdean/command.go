@@ -1,15 +1,16 @@
 package jeffdean
 
 import (
-	appjeffdean "github.com/aktnb/discord-bot-go/internal/application/jeffdean"
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
 	legendcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/legend"
 )
 
-func NewJeffDeanCommand(service *appjeffdean.Service) *legendcmd.Command {
+func NewJeffDeanCommand(service *applegend.Service) *legendcmd.Command {
 	return legendcmd.New(
+		service,
 		"jeff-dean",
-		"Googleのエンジニア Jeff Dean の伝説をランダムに紹介します",
-		"Jeff Dean",
-		service.GetRandomFact,
+		"command.jeff_dean.name",
+		"command.jeff_dean.description",
+		"msg.legend.jeff_dean.prefix",
 	)
 }
@@ -2,29 +2,62 @@ package legend
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
+	"math/rand/v2"
+	"strings"
+	"time"
 
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
 	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
-type EpisodeGetter func(ctx context.Context) (legend.Episode, error)
+// maxSearchTextLength は検索結果に表示するエピソード1件あたりの最大文字数
+const maxSearchTextLength = 200
 
-// Command はエピソードをランダムに返す伝説コマンドの共通実装
+// EpisodeFormatter はエピソードを見出しを付けた表示用の文字列にする
+type EpisodeFormatter func(locale i18n.Locale, episode legend.Episode) string
+
+// Formatter は予約投稿などコマンドの応答以外でエピソードを表示できる伝説コマンド
+type Formatter interface {
+	FormatEpisode(locale i18n.Locale, episode legend.Episode) string
+}
+
+// Command はエピソードを返す伝説コマンドの共通実装
 type Command struct {
-	name        string
+	service        *applegend.Service
+	name           string
+	nameKey        string
+	descriptionKey string
+	prefixKey      string
+	// ギルドで定義された伝説はカタログのキーの代わりに定義された文言をそのまま使う
 	description string
 	prefix      string
-	getEpisode  EpisodeGetter
 }
 
-func New(name, description, prefix string, getter EpisodeGetter) *Command {
+// New は伝説コマンドを生成する
+// nameKey, descriptionKey, prefixKey はメッセージカタログのキーを指定する
+func New(service *applegend.Service, name, nameKey, descriptionKey, prefixKey string) *Command {
+	return &Command{
+		service:        service,
+		name:           name,
+		nameKey:        nameKey,
+		descriptionKey: descriptionKey,
+		prefixKey:      prefixKey,
+	}
+}
+
+// NewDefined はギルドで定義された伝説のコマンドを生成する
+func NewDefined(service *applegend.Service, definition *legend.Definition) *Command {
 	return &Command{
-		name:        name,
-		description: description,
-		prefix:      prefix,
-		getEpisode:  getter,
+		service:     service,
+		name:        definition.Name(),
+		description: definition.Description(),
+		prefix:      definition.Prefix(),
 	}
 }
 
@@ -33,29 +66,232 @@ func (c *Command) Name() string {
 }
 
 func (c *Command) ToDiscordCommand() *discordgo.ApplicationCommand {
+	if c.descriptionKey == "" {
+		return &discordgo.ApplicationCommand{
+			Name:        c.name,
+			Description: c.description,
+			Options:     EpisodeOptions(),
+		}
+	}
 	return &discordgo.ApplicationCommand{
-		Name:        c.name,
-		Description: c.description,
+		Name:                     c.name,
+		NameLocalizations:        commands.Localizations(c.nameKey),
+		Description:              commands.DefaultText(c.descriptionKey),
+		DescriptionLocalizations: commands.Localizations(c.descriptionKey),
+		Options:                  EpisodeOptions(),
+	}
+}
+
+func (c *Command) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details: i18n.T(locale, "msg.legend.episode_usage.details"),
+		Examples: []string{
+			"/" + c.name,
+			"/" + c.name + " number:3",
+			"/" + c.name + " search:" + i18n.T(locale, "msg.legend.example.search"),
+			"/" + c.name + " daily:True",
+		},
 	}
 }
 
 func (c *Command) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	episode, err := c.getEpisode(ctx)
+	return HandleEpisode(ctx, s, i, c.service, c.name, c.FormatEpisode)
+}
+
+// FormatEpisode はエピソードに「〇〇伝説 その1」の見出しを付ける
+// 文法から生成したエピソード（通し番号が 0）は通し番号の代わりに生成したことを示す
+func (c *Command) FormatEpisode(locale i18n.Locale, episode legend.Episode) string {
+	if episode.Number == 0 {
+		return i18n.T(locale, "msg.legend.generated_episode", c.prefixText(locale), episode.Text)
+	}
+	return i18n.T(locale, "msg.legend.episode", c.prefixText(locale), episode.Number, episode.Text)
+}
+
+func (c *Command) prefixText(locale i18n.Locale) string {
+	if c.prefixKey == "" {
+		return c.prefix
+	}
+	return i18n.T(locale, c.prefixKey)
+}
+
+// EpisodeOptions は伝説コマンドに共通のオプションを返す
+// どれも指定しなければ、ギルドで一巡するまで重複しないようにエピソードを選ぶ
+func EpisodeOptions() []*discordgo.ApplicationCommandOption {
+	minNumber := 1.0
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:                     discordgo.ApplicationCommandOptionInteger,
+			Name:                     "number",
+			Description:              commands.DefaultText("command.legend.episode.option.number.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.legend.episode.option.number.description"),
+			MinValue:                 &minNumber,
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "search",
+			Description:              commands.DefaultText("command.legend.episode.option.search.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.legend.episode.option.search.description"),
+			MaxLength:                100,
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionBoolean,
+			Name:                     "daily",
+			Description:              commands.DefaultText("command.legend.episode.option.daily.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.legend.episode.option.daily.description"),
+		},
+	}
+}
+
+// GenerateOptions は文法からエピソードを生成する伝説コマンドのオプションを返す
+// 文法（internal/domain/legend/data/grammars/<伝説の名前>.json）を用意した伝説コマンドで EpisodeOptions に加えて使う
+func GenerateOptions() []*discordgo.ApplicationCommandOption {
+	minSeed, maxSeed := 0.0, float64(legend.MaxSeed)
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:                     discordgo.ApplicationCommandOptionBoolean,
+			Name:                     "generate",
+			Description:              commands.DefaultText("command.legend.episode.option.generate.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.legend.episode.option.generate.description"),
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionInteger,
+			Name:                     "seed",
+			Description:              commands.DefaultText("command.legend.episode.option.seed.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.legend.episode.option.seed.description"),
+			MinValue:                 &minSeed,
+			MaxValue:                 maxSeed,
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "subject",
+			Description:              commands.DefaultText("command.legend.episode.option.subject.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.legend.episode.option.subject.description"),
+			MaxLength:                legend.MaxSubjectLength,
+		},
+	}
+}
+
+// HandleEpisode は EpisodeOptions と GenerateOptions の指定に応じてエピソードを取り出し、format で整形して応答する
+// 生成のオプション（generate、seed、subject）を指定した場合はそれを優先し、
+// それ以外で複数指定された場合は number、search、daily の順に優先する
+func HandleEpisode(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, service *applegend.Service, legendName string, format EpisodeFormatter) error {
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+	for _, option := range i.ApplicationCommandData().Options {
+		options[option.Name] = option
+	}
+	guildID := discordid.GuildID(i.GuildID)
+
+	var (
+		content string
+		err     error
+	)
+	switch {
+	case (options["generate"] != nil && options["generate"].BoolValue()) || options["seed"] != nil || options["subject"] != nil:
+		// シードを指定しなければ毎回ランダムに選び、再現できるよう応答に表示する
+		seed := rand.Uint64N(legend.MaxSeed + 1)
+		if options["seed"] != nil {
+			seed = uint64(options["seed"].IntValue())
+		}
+		var subject string
+		if options["subject"] != nil {
+			subject = options["subject"].StringValue()
+		}
+		var text string
+		text, err = service.GenerateEpisode(legendName, seed, subject)
+		if err == nil {
+			content = format(commands.Locale(i), legend.Episode{Text: text}) + "\n" + commands.T(i, "msg.legend.generated_seed", seed)
+		}
+	case options["number"] != nil:
+		var episode legend.Episode
+		episode, err = service.Episode(ctx, guildID, legendName, int(options["number"].IntValue()))
+		if err == nil {
+			content = format(commands.Locale(i), episode)
+		}
+	case options["search"] != nil:
+		query := options["search"].StringValue()
+		var matches []legend.Match
+		matches, err = service.SearchEpisodes(ctx, guildID, legendName, query)
+		if err == nil && len(matches) == 0 {
+			return respondEphemeral(s, i, commands.T(i, "msg.legend.search.no_results", query))
+		}
+		if err == nil {
+			content = formatMatches(i, query, matches)
+		}
+	case options["daily"] != nil && options["daily"].BoolValue():
+		var (
+			episode legend.Episode
+			today   time.Time
+		)
+		episode, today, err = service.EpisodeOfTheDay(ctx, guildID, legendName)
+		if err == nil {
+			content = commands.T(i, "msg.legend.daily", today.Format("2006-01-02")) + "\n" + format(commands.Locale(i), episode)
+		}
+	default:
+		var episode legend.Episode
+		episode, err = service.NextEpisode(ctx, guildID, legendName)
+		if err == nil {
+			content = format(commands.Locale(i), episode)
+		}
+	}
 	if err != nil {
-		log.Printf("Error getting %s episode: %v", c.name, err)
-		return err
+		log.Printf("Error getting %s episode: %v", legendName, err)
+		return RespondEpisodeError(s, i, err)
 	}
 
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("%s伝説 その%d\n> %s", c.prefix, episode.Number, episode.Text),
+			Content: content,
+			// 生成した主人公や投稿されたエピソードにメンションが含まれていても通知しない
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
 		},
 	})
 	if err != nil {
-		log.Printf("Error responding to %s: %v", c.name, err)
+		log.Printf("Error responding to %s: %v", legendName, err)
 		return err
 	}
 
 	return nil
 }
+
+// formatMatches は検索結果を通し番号付きの一覧にする
+func formatMatches(i *discordgo.InteractionCreate, query string, matches []legend.Match) string {
+	lines := []string{commands.T(i, "msg.legend.search.title", query, len(matches))}
+	for _, match := range matches {
+		text := match.Episode.Text
+		if runes := []rune(text); len(runes) > maxSearchTextLength {
+			text = string(runes[:maxSearchTextLength]) + "…"
+		}
+		lines = append(lines, commands.T(i, "msg.legend.search.item", match.Episode.Number, text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RespondEpisodeError はエピソードを取得できなかったことをユーザーに伝える
+// エピソードがまだ無い場合は投稿を案内する
+func RespondEpisodeError(s *discordgo.Session, i *discordgo.InteractionCreate, cause error) error {
+	content := commands.T(i, "msg.legend.load_failed")
+	switch {
+	case errors.Is(cause, legend.ErrNoEpisodes):
+		content = commands.T(i, "msg.legend.no_episodes")
+	case errors.Is(cause, legend.ErrEpisodeNotFound):
+		content = commands.T(i, "msg.legend.episode_not_found")
+	case errors.Is(cause, legend.ErrNoGrammar):
+		content = commands.T(i, "msg.legend.no_grammar")
+	case errors.Is(cause, legend.ErrInvalidSubject):
+		content = commands.T(i, "msg.legend.invalid_subject", legend.MaxSubjectLength)
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to legend: %v", err)
+		return err
+	}
+	return cause
+}
@@ -0,0 +1,494 @@
+package legend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// 審査キューの埋め込みの色
+	queueEmbedColor = 0xF1C40F
+	// 入力補完の候補数の上限（Discord の仕様）
+	maxAutocompleteChoices = 25
+)
+
+// ManageCommand は伝説エピソードの投稿・審査と、ギルドの伝説コマンドの定義を行うコマンド
+// 投稿は誰でもできる。審査はボットのオーナーがすべての伝説を、サーバーの管理者がそのギルドで定義した伝説を行う
+type ManageCommand struct {
+	service   *applegend.Service
+	ownerIDs  []string
+	registry  *commands.CommandRegistry
+	registrar *commands.CommandRegistrar
+}
+
+func NewLegendCommand(service *applegend.Service, ownerIDs []string, registry *commands.CommandRegistry, registrar *commands.CommandRegistrar) *ManageCommand {
+	return &ManageCommand{
+		service:   service,
+		ownerIDs:  ownerIDs,
+		registry:  registry,
+		registrar: registrar,
+	}
+}
+
+func (c *ManageCommand) Name() string {
+	return "legend"
+}
+
+func (c *ManageCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.legend.name"),
+		Description:              commands.DefaultText("command.legend.description"),
+		DescriptionLocalizations: commands.Localizations("command.legend.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "submit",
+				Description:              commands.DefaultText("command.legend.submit.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.legend.submit.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "legend",
+						Description:              commands.DefaultText("command.legend.option.legend.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.legend.option.legend.description"),
+						Required:                 true,
+						Autocomplete:             true,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "text",
+						Description:              commands.DefaultText("command.legend.option.text.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.legend.option.text.description"),
+						Required:                 true,
+						MaxLength:                legend.MaxTextLength,
+					},
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "queue",
+				Description:              commands.DefaultText("command.legend.queue.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.legend.queue.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "create",
+				Description:              commands.DefaultText("command.legend.create.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.legend.create.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "name",
+						Description:              commands.DefaultText("command.legend.option.name.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.legend.option.name.description"),
+						Required:                 true,
+						MaxLength:                legend.MaxNameLength,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "description",
+						Description:              commands.DefaultText("command.legend.option.description.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.legend.option.description.description"),
+						Required:                 true,
+						MaxLength:                legend.MaxDescriptionLength,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "prefix",
+						Description:              commands.DefaultText("command.legend.option.prefix.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.legend.option.prefix.description"),
+						Required:                 true,
+						MaxLength:                legend.MaxPrefixLength,
+					},
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "delete",
+				Description:              commands.DefaultText("command.legend.delete.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.legend.delete.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "name",
+						Description:              commands.DefaultText("command.legend.option.defined.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.legend.option.defined.description"),
+						Required:                 true,
+						Autocomplete:             true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *ManageCommand) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details: i18n.T(locale, "msg.legend.usage.details", legend.MaxDefinitionsPerGuild),
+		Examples: []string{
+			"/legend submit legend:faker text:" + i18n.T(locale, "msg.legend.example.text"),
+			"/legend queue",
+			"/legend create name:tanaka description:" + i18n.T(locale, "msg.legend.example.description") + " prefix:" + i18n.T(locale, "msg.legend.example.prefix"),
+		},
+	}
+}
+
+func (c *ManageCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	userID, ok := commands.InteractionUserID(i)
+	if !ok {
+		return fmt.Errorf("unable to get user ID")
+	}
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(subcommand.Options))
+	for _, option := range subcommand.Options {
+		options[option.Name] = option
+	}
+
+	switch subcommand.Name {
+	case "submit":
+		return c.handleSubmit(ctx, s, i, discordid.UserID(userID), options["legend"].StringValue(), options["text"].StringValue())
+	case "queue":
+		reviewer, ok := c.reviewer(i, userID)
+		if !ok {
+			return respondEphemeral(s, i, commands.T(i, "msg.legend.not_moderator"))
+		}
+		data, err := c.queuePage(ctx, commands.Locale(i), reviewer, "")
+		if err != nil {
+			log.Printf("Error loading legend queue: %v", err)
+			return respondEphemeral(s, i, commands.T(i, "msg.legend.load_failed"))
+		}
+		data.Flags = discordgo.MessageFlagsEphemeral
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: data,
+		})
+	case "create", "delete":
+		if _, ok := c.reviewer(i, userID); !ok || i.GuildID == "" {
+			return respondEphemeral(s, i, commands.T(i, "msg.legend.not_manager"))
+		}
+		if subcommand.Name == "create" {
+			return c.handleCreate(ctx, s, i, applegend.CreateLegendCommand{
+				GuildID:     discordid.GuildID(i.GuildID),
+				CreatedBy:   discordid.UserID(userID),
+				Name:        options["name"].StringValue(),
+				Description: options["description"].StringValue(),
+				Prefix:      options["prefix"].StringValue(),
+			})
+		}
+		return c.handleDelete(ctx, s, i, strings.TrimPrefix(options["name"].StringValue(), "/"))
+	default:
+		return fmt.Errorf("unknown legend subcommand: %s", subcommand.Name)
+	}
+}
+
+func (c *ManageCommand) handleSubmit(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, userID discordid.UserID, legendName, text string) error {
+	legendName = strings.TrimPrefix(legendName, "/")
+	entry, err := c.service.Submit(ctx, discordid.GuildID(i.GuildID), userID, legendName, text)
+	if err != nil {
+		log.Printf("Error submitting legend entry: %v", err)
+		return respondEphemeral(s, i, submitErrorMessage(i, legendName, err))
+	}
+
+	return respondEphemeral(s, i, commands.T(i, "msg.legend.submitted", entry.Legend().Name, entry.Text()))
+}
+
+func (c *ManageCommand) handleCreate(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, cmd applegend.CreateLegendCommand) error {
+	// ボットの他のコマンドと同じ名前は Discord 上で区別できないため使えない
+	name := strings.TrimPrefix(strings.TrimSpace(cmd.Name), "/")
+	if _, ok := c.registry.GetCommand(name); ok {
+		return respondEphemeral(s, i, commands.T(i, "msg.legend.exists", name))
+	}
+
+	// 定義の保存とコマンドの再登録に時間がかかる可能性があるため、応答を遅延させる
+	if err := deferEphemeral(s, i); err != nil {
+		return err
+	}
+
+	definition, err := c.service.CreateLegend(ctx, cmd)
+	if err != nil {
+		log.Printf("Error creating legend: %v", err)
+		_ = followupEphemeral(s, i, definitionErrorMessage(i, name, err))
+		return err
+	}
+
+	done := commands.T(i, "msg.legend.created", definition.Name(), definition.Prefix())
+	return c.syncGuildCommands(ctx, s, i, done)
+}
+
+func (c *ManageCommand) handleDelete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, name string) error {
+	if err := deferEphemeral(s, i); err != nil {
+		return err
+	}
+
+	if err := c.service.DeleteLegend(ctx, discordid.GuildID(i.GuildID), name); err != nil {
+		log.Printf("Error deleting legend: %v", err)
+		_ = followupEphemeral(s, i, definitionErrorMessage(i, name, err))
+		return err
+	}
+
+	return c.syncGuildCommands(ctx, s, i, commands.T(i, "msg.legend.deleted", name))
+}
+
+// syncGuildCommands は伝説コマンドの定義の変更をギルドのコマンドに反映し、結果を伝える
+func (c *ManageCommand) syncGuildCommands(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, done string) error {
+	guildID := discordid.GuildID(i.GuildID)
+	if err := c.registrar.RegisterGuildCommands(ctx, guildID); err != nil {
+		log.Printf("Error re-registering guild commands: guild=%s err=%v", guildID, err)
+		_ = followupEphemeral(s, i, done+"\n"+commands.T(i, "msg.legend.resync_failed"))
+		return err
+	}
+	return followupEphemeral(s, i, done)
+}
+
+// HandleComponent は審査キューの承認・却下ボタンを処理する
+// CustomID は "legend:approve:<エピソード ID>" または "legend:reject:<エピソード ID>" の形式
+func (c *ManageCommand) HandleComponent(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	parts := strings.Split(i.MessageComponentData().CustomID, ":")
+	if len(parts) != 3 {
+		return fmt.Errorf("unknown legend component: %s", i.MessageComponentData().CustomID)
+	}
+	action, id := parts[1], legend.EntryID(parts[2])
+
+	userID, _ := commands.InteractionUserID(i)
+	reviewer, ok := c.reviewer(i, userID)
+	if !ok {
+		return respondEphemeral(s, i, commands.T(i, "msg.legend.not_moderator"))
+	}
+
+	var (
+		entry *legend.Entry
+		err   error
+	)
+	switch action {
+	case "approve":
+		entry, err = c.service.Approve(ctx, id, reviewer)
+	case "reject":
+		entry, err = c.service.Reject(ctx, id, reviewer)
+	default:
+		return fmt.Errorf("unknown legend component action: %s", action)
+	}
+
+	var notice string
+	switch {
+	case errors.Is(err, legend.ErrAlreadyReviewed), errors.Is(err, legend.ErrEntryNotFound):
+		notice = commands.T(i, "msg.legend.already_reviewed")
+	case errors.Is(err, legend.ErrReviewForbidden):
+		return respondEphemeral(s, i, commands.T(i, "msg.legend.not_moderator"))
+	case err != nil:
+		log.Printf("Error reviewing legend entry %s: %v", id, err)
+		return respondEphemeral(s, i, commands.T(i, "msg.legend.review_failed"))
+	case entry.Status() == legend.StatusApproved:
+		notice = commands.T(i, "msg.legend.approved", entry.Legend().Name, entry.Number())
+	default:
+		notice = commands.T(i, "msg.legend.rejected", entry.Legend().Name)
+	}
+
+	// 審査が終わったら、同じメッセージに次の審査待ちを表示する
+	data, err := c.queuePage(ctx, commands.Locale(i), reviewer, notice)
+	if err != nil {
+		log.Printf("Error loading legend queue: %v", err)
+		return respondEphemeral(s, i, commands.T(i, "msg.legend.load_failed"))
+	}
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+}
+
+// HandleAutocomplete は伝説コマンドの名前を候補として返す
+// submit ではエピソードを投稿できる伝説を、delete ではそのギルドで定義された伝説を候補とする
+func (c *ManageCommand) HandleAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	subcommand := i.ApplicationCommandData().Options[0]
+	legends, err := c.service.Legends(ctx, discordid.GuildID(i.GuildID))
+	if err != nil {
+		log.Printf("Error listing legends: %v", err)
+		return err
+	}
+	if subcommand.Name == "delete" {
+		legends = slices.DeleteFunc(legends, func(name string) bool {
+			return slices.Contains(legend.Builtins, name)
+		})
+	}
+
+	var input string
+	for _, option := range subcommand.Options {
+		if option.Focused {
+			input = strings.ToLower(strings.TrimPrefix(option.StringValue(), "/"))
+		}
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	for _, name := range legends {
+		if !strings.Contains(name, input) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  "/" + name,
+			Value: name,
+		})
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+// queuePage は審査者が審査できる最も古い審査待ちのエピソードと承認・却下ボタンを生成する
+// notice があれば直前の審査結果として本文に表示する
+func (c *ManageCommand) queuePage(ctx context.Context, locale i18n.Locale, reviewer applegend.Reviewer, notice string) (*discordgo.InteractionResponseData, error) {
+	entry, remaining, err := c.service.NextPending(ctx, reviewer)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &discordgo.InteractionResponseData{
+		Content: notice,
+		// 審査待ちが無くなったらボタンを外す
+		Components: []discordgo.MessageComponent{},
+		Embeds:     []*discordgo.MessageEmbed{},
+	}
+	if entry == nil {
+		data.Content = strings.TrimSpace(notice + "\n" + i18n.T(locale, "msg.legend.queue.empty"))
+		return data, nil
+	}
+
+	author := i18n.T(locale, "msg.legend.queue.seed")
+	if entry.AuthorID() != "" {
+		author = "<@" + string(entry.AuthorID()) + ">"
+	}
+	data.Embeds = []*discordgo.MessageEmbed{
+		{
+			Title:       i18n.T(locale, "msg.legend.queue.title", remaining),
+			Description: entry.Text(),
+			Color:       queueEmbedColor,
+			Fields: []*discordgo.MessageEmbedField{
+				{Name: i18n.T(locale, "msg.legend.queue.legend"), Value: "/" + entry.Legend().Name, Inline: true},
+				{Name: i18n.T(locale, "msg.legend.queue.author"), Value: author, Inline: true},
+			},
+			Timestamp: entry.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		},
+	}
+	data.Components = []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    i18n.T(locale, "msg.legend.queue.approve"),
+					Style:    discordgo.SuccessButton,
+					CustomID: "legend:approve:" + string(entry.ID()),
+				},
+				discordgo.Button{
+					Label:    i18n.T(locale, "msg.legend.queue.reject"),
+					Style:    discordgo.DangerButton,
+					CustomID: "legend:reject:" + string(entry.ID()),
+				},
+			},
+		},
+	}
+	return data, nil
+}
+
+// reviewer はユーザーの審査の権限を返す
+// ボットのオーナーでもギルドのサーバー管理権限を持つメンバーでもなければ false を返す
+func (c *ManageCommand) reviewer(i *discordgo.InteractionCreate, userID string) (applegend.Reviewer, bool) {
+	reviewer := applegend.Reviewer{
+		UserID:  discordid.UserID(userID),
+		GuildID: discordid.GuildID(i.GuildID),
+		Owner:   userID != "" && slices.Contains(c.ownerIDs, userID),
+	}
+	if reviewer.Owner {
+		return reviewer, true
+	}
+	isManager := i.Member != nil && i.Member.Permissions&discordgo.PermissionManageGuild != 0
+	return reviewer, isManager
+}
+
+// submitErrorMessage は投稿エラーに対応するユーザー向けのメッセージを返す
+func submitErrorMessage(i *discordgo.InteractionCreate, legendName string, err error) string {
+	switch {
+	case errors.Is(err, legend.ErrUnknownLegend):
+		return commands.T(i, "msg.legend.unknown_legend", legendName)
+	case errors.Is(err, legend.ErrEmptyText):
+		return commands.T(i, "msg.legend.empty_text")
+	case errors.Is(err, legend.ErrTextTooLong):
+		return commands.T(i, "msg.legend.text_too_long", legend.MaxTextLength)
+	default:
+		return commands.T(i, "msg.legend.submit_failed")
+	}
+}
+
+// definitionErrorMessage は伝説コマンドの定義エラーに対応するユーザー向けのメッセージを返す
+func definitionErrorMessage(i *discordgo.InteractionCreate, name string, err error) string {
+	switch {
+	case errors.Is(err, legend.ErrInvalidLegend):
+		return commands.T(i, "msg.legend.invalid_name", legend.MaxNameLength)
+	case errors.Is(err, legend.ErrLegendExists):
+		return commands.T(i, "msg.legend.exists", name)
+	case errors.Is(err, legend.ErrInvalidDescription):
+		return commands.T(i, "msg.legend.invalid_description", legend.MaxDescriptionLength)
+	case errors.Is(err, legend.ErrInvalidPrefix):
+		return commands.T(i, "msg.legend.invalid_prefix", legend.MaxPrefixLength)
+	case errors.Is(err, legend.ErrTooManyDefinitions):
+		return commands.T(i, "msg.legend.too_many", legend.MaxDefinitionsPerGuild)
+	case errors.Is(err, legend.ErrDefinitionNotFound):
+		return commands.T(i, "msg.legend.not_defined", name)
+	default:
+		return commands.T(i, "msg.legend.save_failed")
+	}
+}
+
+func deferEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error deferring response: %v", err)
+	}
+	return err
+}
+
+func followupEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		log.Printf("Error sending legend followup: %v", err)
+	}
+	return err
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to legend: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package legend
+
+import (
+	"context"
+
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/domain/schedule"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/discord"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+)
+
+// Publisher は予約投稿の伝説エピソードをチャンネルに投稿する
+// エピソードはコマンドを実行した場合と同じくギルドのシャッフルバッグから選び、同じ見出しを付ける
+type Publisher struct {
+	service  *applegend.Service
+	registry *commands.CommandRegistry
+	discord  discord.DiscordPort
+}
+
+func NewPublisher(service *applegend.Service, registry *commands.CommandRegistry, discordPort discord.DiscordPort) *Publisher {
+	return &Publisher{
+		service:  service,
+		registry: registry,
+		discord:  discordPort,
+	}
+}
+
+func (p *Publisher) Publish(ctx context.Context, s *schedule.Schedule) error {
+	cmd, ok, err := p.registry.Resolve(ctx, s.GuildID(), s.Legend())
+	if err != nil {
+		return err
+	}
+	// 予約投稿の登録後に伝説コマンドの定義が削除された
+	formatter, isLegend := cmd.(Formatter)
+	if !ok || !isLegend {
+		return legend.ErrUnknownLegend
+	}
+
+	episode, err := p.service.NextEpisode(ctx, s.GuildID(), s.Legend())
+	if err != nil {
+		return err
+	}
+	return p.discord.SendMessage(ctx, s.ChannelID(), formatter.FormatEpisode(i18n.Default, episode))
+}
@@ -0,0 +1,48 @@
+package legend
+
+import (
+	"context"
+	"errors"
+
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+// Source はギルドで定義された伝説コマンドを提供する DynamicCommandSource
+type Source struct {
+	service *applegend.Service
+}
+
+func NewSource(service *applegend.Service) *Source {
+	return &Source{service: service}
+}
+
+func (s *Source) GuildCommands(ctx context.Context) (map[discordid.GuildID][]commands.SlashCommand, error) {
+	definitions, err := s.service.Definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	guildCmds := make(map[discordid.GuildID][]commands.SlashCommand)
+	for _, definition := range definitions {
+		guildCmds[definition.GuildID()] = append(guildCmds[definition.GuildID()], s.command(definition))
+	}
+	return guildCmds, nil
+}
+
+func (s *Source) GuildCommand(ctx context.Context, guildID discordid.GuildID, name string) (commands.SlashCommand, bool, error) {
+	definition, err := s.service.Definition(ctx, guildID, name)
+	if errors.Is(err, legend.ErrDefinitionNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return s.command(definition), true, nil
+}
+
+func (s *Source) command(definition *legend.Definition) *Command {
+	return NewDefined(s.service, definition)
+}
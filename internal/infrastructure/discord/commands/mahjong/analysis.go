@@ -0,0 +1,112 @@
+package mahjong
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	analysisEmbedColor = 0x2F6B45
+	// maxDiscards は分析結果に表示する打牌の候補の数
+	maxDiscards = 5
+)
+
+func (c *MahjongCommand) handleAnalyze(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	var notation string
+	for _, option := range options {
+		if option.Name == "hand" {
+			notation = option.StringValue()
+		}
+	}
+
+	analysis, err := c.service.AnalyzeNotation(notation)
+	if err != nil {
+		content := commands.T(i, "msg.mahjong.invalid_hand")
+		if errors.Is(err, mahjong.ErrInvalidHandSize) {
+			content = commands.T(i, "msg.mahjong.invalid_hand_size")
+		}
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: content,
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{analysisEmbed(commands.Locale(i), analysis)},
+		},
+	})
+}
+
+// analysisEmbed は手牌の分析結果の埋め込みを生成する
+// 3n+1 枚なら有効牌を、3n+2 枚なら向聴数と有効牌の多い順に打牌の候補を表示する
+func analysisEmbed(locale i18n.Locale, analysis mahjong.Analysis) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "msg.mahjong.analysis.title"),
+		Description: "`" + analysis.Hand.String() + "`",
+		Color:       analysisEmbedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name: i18n.T(locale, "msg.mahjong.analysis.shanten"),
+				Value: shantenText(locale, analysis.Shanten.Min()) + "\n" + i18n.T(locale, "msg.mahjong.analysis.shanten_detail",
+					shantenText(locale, analysis.Shanten.Standard),
+					shantenText(locale, analysis.Shanten.SevenPairs),
+					shantenText(locale, analysis.Shanten.ThirteenOrphans)),
+			},
+		},
+	}
+
+	if analysis.Discards == nil {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "msg.mahjong.analysis.ukeire"),
+			Value: ukeireText(locale, analysis.Ukeire, analysis.UkeireCount),
+		})
+		return embed
+	}
+
+	lines := make([]string, 0, maxDiscards)
+	for _, discard := range analysis.Discards[:min(maxDiscards, len(analysis.Discards))] {
+		lines = append(lines, i18n.T(locale, "msg.mahjong.analysis.discard",
+			discard.Kind.String(), shantenText(locale, discard.Shanten), ukeireText(locale, discard.Ukeire, discard.UkeireCount)))
+	}
+	embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+		Name:  i18n.T(locale, "msg.mahjong.analysis.discards"),
+		Value: strings.Join(lines, "\n"),
+	})
+	return embed
+}
+
+// shantenText は向聴数を「和了」「聴牌」「2向聴」のように表す
+func shantenText(locale i18n.Locale, shanten int) string {
+	switch {
+	case shanten == mahjong.NotApplicable:
+		return "-"
+	case shanten < 0:
+		return i18n.T(locale, "msg.mahjong.shanten.complete")
+	case shanten == 0:
+		return i18n.T(locale, "msg.mahjong.shanten.tenpai")
+	default:
+		return i18n.T(locale, "msg.mahjong.shanten.n", shanten)
+	}
+}
+
+// ukeireText は有効牌を「1m 4m（2種 7枚）」のように表す
+func ukeireText(locale i18n.Locale, acceptances []mahjong.Acceptance, total int) string {
+	if len(acceptances) == 0 {
+		return i18n.T(locale, "msg.mahjong.analysis.no_ukeire")
+	}
+	kinds := make([]string, len(acceptances))
+	for n, acceptance := range acceptances {
+		kinds[n] = acceptance.Kind.String()
+	}
+	return i18n.T(locale, "msg.mahjong.analysis.ukeire_value", strings.Join(kinds, " "), len(acceptances), total)
+}
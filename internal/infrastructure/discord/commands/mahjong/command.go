@@ -3,19 +3,31 @@ package mahjong
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	appmahjong "github.com/aktnb/discord-bot-go/internal/application/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+// maxNotationLength は analyze で受け付ける手牌の表記の最大文字数
+const maxNotationLength = 64
+
 type MahjongCommand struct {
 	service *appmahjong.Service
+	quiz    *appmahjong.QuizService
+	table   *appmahjong.TableService
 }
 
-func NewMahjongCommand(service *appmahjong.Service) *MahjongCommand {
+func NewMahjongCommand(service *appmahjong.Service, quiz *appmahjong.QuizService, table *appmahjong.TableService) *MahjongCommand {
 	return &MahjongCommand{
 		service: service,
+		quiz:    quiz,
+		table:   table,
 	}
 }
 
@@ -25,12 +37,160 @@ func (c *MahjongCommand) Name() string {
 
 func (c *MahjongCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name(),
-		Description: "ランダムな麻雀の配牌を表示します",
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.mahjong.name"),
+		Description:              commands.DefaultText("command.mahjong.description"),
+		DescriptionLocalizations: commands.Localizations("command.mahjong.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "deal",
+				Description:              commands.DefaultText("command.mahjong.deal.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.deal.description"),
+				Options:                  c.dealOptions(),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "analyze",
+				Description:              commands.DefaultText("command.mahjong.analyze.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.analyze.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "hand",
+						Description:              commands.DefaultText("command.mahjong.option.hand.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.hand.description"),
+						Required:                 true,
+						MaxLength:                maxNotationLength,
+					},
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "score",
+				Description:              commands.DefaultText("command.mahjong.score.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.score.description"),
+				Options:                  scoreOptions(),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "quiz",
+				Description:              commands.DefaultText("command.mahjong.quiz.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.quiz.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "ranking",
+				Description:              commands.DefaultText("command.mahjong.ranking.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.ranking.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommandGroup,
+				Name:                     "table",
+				Description:              commands.DefaultText("command.mahjong.table.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.table.description"),
+				Options:                  tableOptions(),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "stats",
+				Description:              commands.DefaultText("command.mahjong.stats.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.stats.description"),
+			},
+		},
 	}
 }
 
 func (c *MahjongCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	subcommand := i.ApplicationCommandData().Options[0]
+	switch subcommand.Name {
+	case "deal":
+		return c.handleDeal(ctx, s, i, subcommand.Options)
+	case "analyze":
+		return c.handleAnalyze(s, i, subcommand.Options)
+	case "score":
+		return c.handleScore(s, i, subcommand.Options)
+	case "quiz":
+		return c.handleQuiz(ctx, s, i)
+	case "ranking":
+		return c.handleRanking(ctx, s, i)
+	case "table":
+		return c.handleTable(ctx, s, i, subcommand.Options)
+	case "stats":
+		return c.handleStats(ctx, s, i)
+	default:
+		return fmt.Errorf("unknown mahjong subcommand: %s", subcommand.Name)
+	}
+}
+
+// HandleComponent は何切る問題と対局のメッセージのボタンを CustomID の2番目の値で振り分ける
+func (c *MahjongCommand) HandleComponent(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customID := i.MessageComponentData().CustomID
+	parts := strings.Split(customID, ":")
+	if len(parts) < 2 {
+		return fmt.Errorf("unknown mahjong component: %s", customID)
+	}
+	switch parts[1] {
+	case "quiz":
+		return c.handleQuizAnswer(ctx, s, i, parts)
+	case "table":
+		return c.handleTableComponent(ctx, s, i, parts)
+	default:
+		return fmt.Errorf("unknown mahjong component: %s", customID)
+	}
+}
+
+// dealOptions は deal の枚数と赤ドラのオプションを返す
+// 配牌の条件に対応しないバックエンドでは、指定しても効かないオプションを表示しない
+func (c *MahjongCommand) dealOptions() []*discordgo.ApplicationCommandOption {
+	if !c.service.SupportsDealOptions() {
+		return nil
+	}
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:                     discordgo.ApplicationCommandOptionInteger,
+			Name:                     "tiles",
+			Description:              commands.DefaultText("command.mahjong.option.tiles.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.tiles.description"),
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{
+					Name:              commands.DefaultText("command.mahjong.tiles.13"),
+					NameLocalizations: commands.OptionLocalizations("command.mahjong.tiles.13"),
+					Value:             mahjong.HandSize,
+				},
+				{
+					Name:              commands.DefaultText("command.mahjong.tiles.14"),
+					NameLocalizations: commands.OptionLocalizations("command.mahjong.tiles.14"),
+					Value:             mahjong.DealerHandSize,
+				},
+			},
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionBoolean,
+			Name:                     "red",
+			Description:              commands.DefaultText("command.mahjong.option.red.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.red.description"),
+		},
+	}
+}
+
+func (c *MahjongCommand) Usage(locale i18n.Locale) commands.Usage {
+	examples := []string{"/mahjong deal"}
+	if c.service.SupportsDealOptions() {
+		examples = append(examples, "/mahjong deal tiles:14 red:False")
+	}
+	return commands.Usage{
+		Details:  i18n.T(locale, "msg.mahjong.usage.details"),
+		Examples: append(examples, "/mahjong analyze hand:123m456p789s1122z", "/mahjong score hand:234m567p234s88s67s5s riichi:True dora:7s", "/mahjong quiz", "/mahjong ranking", "/mahjong table start", "/mahjong table start player1:@alice player2:@bob player3:@carol", "/mahjong table finish", "/mahjong stats"),
+	}
+}
+
+func (c *MahjongCommand) handleDeal(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	// バックエンドを切り替える前に登録したコマンドからは、効かないオプションが届くことがある
+	if len(options) > 0 && !c.service.SupportsDealOptions() {
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.deal_options_unsupported"))
+	}
+
 	// API呼び出しに時間がかかる可能性があるため、応答を遅延させる
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
@@ -40,19 +200,41 @@ func (c *MahjongCommand) Handle(ctx context.Context, s *discordgo.Session, i *di
 		return err
 	}
 
-	hand, err := c.service.GetRandomStartingHand(ctx)
+	dealOptions := mahjong.DefaultDealOptions()
+	for _, option := range options {
+		switch option.Name {
+		case "tiles":
+			dealOptions.Tiles = int(option.IntValue())
+		case "red":
+			dealOptions.RedFives = option.BoolValue()
+		}
+	}
+
+	hand, err := c.service.GetRandomStartingHand(ctx, dealOptions)
 	if err != nil {
 		log.Printf("Error fetching mahjong image: %v", err)
 		_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-			Content: "麻雀の配牌を取得できませんでした。もう一度お試しください。",
+			Content: commands.T(i, "msg.mahjong.fetch_failed"),
 		})
 		return err
 	}
 
+	// 牌が分かる場合は、配牌の分析を添える
+	var embeds []*discordgo.MessageEmbed
+	if hand.Tiles != nil {
+		analysis, err := c.service.Analyze(hand.Tiles)
+		if err != nil {
+			log.Printf("Error analyzing mahjong hand %s: %v", hand.Tiles, err)
+		} else {
+			embeds = append(embeds, analysisEmbed(commands.Locale(i), analysis))
+		}
+	}
+
 	// 画像バイナリデータをBytesReaderに変換してファイルとして添付
 	imageReader := bytes.NewReader(hand.ImageData)
 
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: embeds,
 		Files: []*discordgo.File{
 			{
 				Name:   "mahjong-starting-hand.png",
@@ -0,0 +1,33 @@
+package mahjong
+
+import (
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+)
+
+// 実行時に組み立てるカタログキーは TestSourceKeysExistInCatalogs で検査できないため、ここで組み立てて確かめる
+func TestDynamicKeysExistInCatalogs(t *testing.T) {
+	var keys []string
+	for _, wind := range winds {
+		keys = append(keys, "command.mahjong.wind."+wind.value)
+	}
+	for _, id := range mahjong.YakuIDs {
+		keys = append(keys, "msg.mahjong.yaku."+string(id))
+	}
+	for _, input := range handInputs {
+		keys = append(keys, "msg.mahjong.table.input."+input, "msg.mahjong.table.placeholder."+input)
+	}
+	for _, button := range tableButtons {
+		keys = append(keys, "msg.mahjong.table.button."+button.action)
+	}
+
+	for _, key := range keys {
+		for _, locale := range i18n.Locales() {
+			if !i18n.Has(locale, key) {
+				t.Errorf("key %q is missing in %s catalog", key, locale)
+			}
+		}
+	}
+}
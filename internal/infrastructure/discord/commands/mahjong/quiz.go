@@ -0,0 +1,214 @@
+package mahjong
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	quizCorrectColor   = 0x3BA55D
+	quizIncorrectColor = 0xED4245
+	rankingEmbedColor  = 0xF1C40F
+	quizImageName      = "mahjong-quiz.png"
+	// quizButtonsPerRow は1行に並べる打牌のボタンの数（Discord の上限は 5）
+	quizButtonsPerRow = 5
+)
+
+var rankMarks = []string{"🥇", "🥈", "🥉"}
+
+func (c *MahjongCommand) handleQuiz(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if i.GuildID == "" {
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.guild_only"))
+	}
+	userID, _ := commands.InteractionUserID(i)
+
+	start, err := c.quiz.StartQuiz(ctx, discordid.GuildID(i.GuildID), discordid.UserID(userID))
+	if err != nil {
+		log.Printf("Error starting mahjong quiz: %v", err)
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.quiz.failed"))
+	}
+
+	round := start.Round
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       commands.T(i, "msg.mahjong.quiz.title"),
+					Description: commands.T(i, "msg.mahjong.quiz.question", round.UserID(), round.Hand().String()),
+					Color:       analysisEmbedColor,
+					Image:       &discordgo.MessageEmbedImage{URL: "attachment://" + quizImageName},
+				},
+			},
+			Files: []*discordgo.File{
+				{
+					Name:        quizImageName,
+					ContentType: "image/png",
+					Reader:      bytes.NewReader(start.ImageData),
+				},
+			},
+			Components: quizButtons(round),
+			// 出題した人をメンションしても通知は飛ばさない
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
+
+// quizButtons は手牌の牌の種類ごとに打牌のボタンを理牌の順に並べる
+// CustomID は "mahjong:quiz:<問題 ID>:<牌の種類>" の形式
+func quizButtons(round *mahjong.QuizRound) []discordgo.MessageComponent {
+	var kinds []mahjong.Kind
+	for _, tile := range round.Hand() {
+		if !slices.Contains(kinds, tile.Kind) {
+			kinds = append(kinds, tile.Kind)
+		}
+	}
+	slices.Sort(kinds)
+
+	var rows []discordgo.MessageComponent
+	for chunk := range slices.Chunk(kinds, quizButtonsPerRow) {
+		buttons := make([]discordgo.MessageComponent, len(chunk))
+		for n, kind := range chunk {
+			buttons[n] = discordgo.Button{
+				Label:    kind.String(),
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("mahjong:quiz:%s:%d", round.ID(), kind),
+			}
+		}
+		rows = append(rows, discordgo.ActionsRow{Components: buttons})
+	}
+	return rows
+}
+
+// handleQuizAnswer は何切る問題の打牌のボタンを処理する
+// 出題した人の回答だけを採点し、問題のメッセージに結果を加えてボタンを外す
+func (c *MahjongCommand) handleQuizAnswer(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, parts []string) error {
+	customID := strings.Join(parts, ":")
+	if len(parts) != 4 {
+		return fmt.Errorf("invalid mahjong component: %s", customID)
+	}
+	kind, err := strconv.Atoi(parts[3])
+	if err != nil || kind < 0 || kind >= mahjong.NumKinds {
+		return fmt.Errorf("invalid mahjong component: %s", customID)
+	}
+	userID, _ := commands.InteractionUserID(i)
+
+	round, result, err := c.quiz.AnswerQuiz(ctx, mahjong.QuizRoundID(parts[2]), discordid.UserID(userID), mahjong.Kind(kind))
+	switch {
+	case errors.Is(err, mahjong.ErrNotQuizPlayer):
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.quiz.not_player"))
+	case errors.Is(err, mahjong.ErrAlreadyAnswered), errors.Is(err, mahjong.ErrQuizRoundNotFound):
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.quiz.already_answered"))
+	case err != nil:
+		log.Printf("Error answering mahjong quiz %s: %v", parts[2], err)
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.quiz.answer_failed"))
+	}
+
+	embeds := slices.Clone(i.Message.Embeds)
+	embeds = append(embeds, quizResultEmbed(commands.Locale(i), round, result))
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:          embeds,
+			Components:      []discordgo.MessageComponent{},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
+
+// quizResultEmbed は回答の採点結果と最善の打牌の埋め込みを生成する
+func quizResultEmbed(locale i18n.Locale, round *mahjong.QuizRound, result mahjong.QuizResult) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: i18n.T(locale, "msg.mahjong.quiz.incorrect"),
+		Color: quizIncorrectColor,
+	}
+	if result.Correct {
+		embed.Title = i18n.T(locale, "msg.mahjong.quiz.correct")
+		embed.Color = quizCorrectColor
+	}
+	embed.Description = i18n.T(locale, "msg.mahjong.quiz.points", round.UserID(), result.Points)
+
+	best := make([]string, len(result.Best))
+	for n, discard := range result.Best {
+		best[n] = discardText(locale, discard)
+	}
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{
+			Name:  i18n.T(locale, "msg.mahjong.quiz.chosen"),
+			Value: discardText(locale, result.Chosen),
+		},
+		{
+			Name:  i18n.T(locale, "msg.mahjong.quiz.best"),
+			Value: strings.Join(best, "\n"),
+		},
+	}
+	return embed
+}
+
+// discardText は打牌と、打牌した後の向聴数と有効牌を1行で表す
+func discardText(locale i18n.Locale, discard mahjong.Discard) string {
+	return i18n.T(locale, "msg.mahjong.analysis.discard",
+		discard.Kind.String(), shantenText(locale, discard.Shanten), ukeireText(locale, discard.Ukeire, discard.UkeireCount))
+}
+
+func (c *MahjongCommand) handleRanking(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if i.GuildID == "" {
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.guild_only"))
+	}
+
+	standings, err := c.quiz.Ranking(ctx, discordid.GuildID(i.GuildID))
+	if err != nil {
+		log.Printf("Error loading mahjong quiz ranking: %v", err)
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.ranking.load_failed"))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{rankingEmbed(commands.Locale(i), standings)},
+			// ランキングでメンバーをメンションしても通知は飛ばさない
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
+
+func rankingEmbed(locale i18n.Locale, standings []mahjong.QuizStanding) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: i18n.T(locale, "msg.mahjong.ranking.title"),
+		Color: rankingEmbedColor,
+	}
+	if len(standings) == 0 {
+		embed.Description = i18n.T(locale, "msg.mahjong.ranking.empty")
+		return embed
+	}
+
+	lines := make([]string, len(standings))
+	for n, standing := range standings {
+		lines[n] = i18n.T(locale, "msg.mahjong.ranking.line",
+			placementMark(n+1), standing.UserID, standing.Points, standing.Correct, standing.Answered, standing.Accuracy()*100)
+	}
+	embed.Description = strings.Join(lines, "\n")
+	return embed
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
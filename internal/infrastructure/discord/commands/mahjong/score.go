@@ -0,0 +1,267 @@
+package mahjong
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	appmahjong "github.com/aktnb/discord-bot-go/internal/application/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const scoreEmbedColor = 0xC0392B
+
+// winds は風の選択肢の値と牌の種類の対応
+var winds = []struct {
+	value string
+	kind  mahjong.Kind
+}{
+	{"east", mahjong.East},
+	{"south", mahjong.South},
+	{"west", mahjong.West},
+	{"north", mahjong.North},
+}
+
+// limitKeys は満貫以上の区分の表示名のキー
+var limitKeys = map[mahjong.Limit]string{
+	mahjong.LimitMangan:    "msg.mahjong.limit.mangan",
+	mahjong.LimitHaneman:   "msg.mahjong.limit.haneman",
+	mahjong.LimitBaiman:    "msg.mahjong.limit.baiman",
+	mahjong.LimitSanbaiman: "msg.mahjong.limit.sanbaiman",
+	mahjong.LimitYakuman:   "msg.mahjong.limit.yakuman",
+}
+
+// scoreOptions は score サブコマンドのオプションを返す
+func scoreOptions() []*discordgo.ApplicationCommandOption {
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "hand",
+			Description:              commands.DefaultText("command.mahjong.option.winning_hand.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.winning_hand.description"),
+			Required:                 true,
+			MaxLength:                maxNotationLength,
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "win",
+			Description:              commands.DefaultText("command.mahjong.option.win.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.win.description"),
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{
+					Name:              commands.DefaultText("command.mahjong.win.ron"),
+					NameLocalizations: commands.OptionLocalizations("command.mahjong.win.ron"),
+					Value:             "ron",
+				},
+				{
+					Name:              commands.DefaultText("command.mahjong.win.tsumo"),
+					NameLocalizations: commands.OptionLocalizations("command.mahjong.win.tsumo"),
+					Value:             "tsumo",
+				},
+			},
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionBoolean,
+			Name:                     "riichi",
+			Description:              commands.DefaultText("command.mahjong.option.riichi.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.riichi.description"),
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionBoolean,
+			Name:                     "ippatsu",
+			Description:              commands.DefaultText("command.mahjong.option.ippatsu.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.ippatsu.description"),
+		},
+		windOption("seat", "command.mahjong.option.seat.description"),
+		windOption("round", "command.mahjong.option.round.description"),
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "dora",
+			Description:              commands.DefaultText("command.mahjong.option.dora.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.dora.description"),
+			MaxLength:                maxNotationLength,
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionString,
+			Name:                     "ura",
+			Description:              commands.DefaultText("command.mahjong.option.ura.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.ura.description"),
+			MaxLength:                maxNotationLength,
+		},
+	}
+}
+
+func windOption(name, descriptionKey string) *discordgo.ApplicationCommandOption {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(winds))
+	for n, wind := range winds {
+		choices[n] = &discordgo.ApplicationCommandOptionChoice{
+			Name:              commands.DefaultText("command.mahjong.wind." + wind.value),
+			NameLocalizations: commands.OptionLocalizations("command.mahjong.wind." + wind.value),
+			Value:             wind.value,
+		}
+	}
+	return &discordgo.ApplicationCommandOption{
+		Type:                     discordgo.ApplicationCommandOptionString,
+		Name:                     name,
+		Description:              commands.DefaultText(descriptionKey),
+		DescriptionLocalizations: commands.OptionLocalizations(descriptionKey),
+		Choices:                  choices,
+	}
+}
+
+func windKind(value string) mahjong.Kind {
+	for _, wind := range winds {
+		if wind.value == value {
+			return wind.kind
+		}
+	}
+	return mahjong.East
+}
+
+func (c *MahjongCommand) handleScore(s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	cmd := appmahjong.ScoreCommand{SeatWind: mahjong.East, RoundWind: mahjong.East}
+	for _, option := range options {
+		switch option.Name {
+		case "hand":
+			cmd.Hand = option.StringValue()
+		case "win":
+			cmd.Tsumo = option.StringValue() == "tsumo"
+		case "riichi":
+			cmd.Riichi = option.BoolValue()
+		case "ippatsu":
+			cmd.Ippatsu = option.BoolValue()
+		case "seat":
+			cmd.SeatWind = windKind(option.StringValue())
+		case "round":
+			cmd.RoundWind = windKind(option.StringValue())
+		case "dora":
+			cmd.Dora = option.StringValue()
+		case "ura":
+			cmd.Ura = option.StringValue()
+		}
+	}
+
+	score, err := c.service.Score(cmd)
+	if err != nil {
+		return respondEphemeral(s, i, scoreErrorText(i, err))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{scoreEmbed(commands.Locale(i), cmd, score)},
+		},
+	})
+}
+
+func scoreErrorText(i *discordgo.InteractionCreate, err error) string {
+	switch {
+	case errors.Is(err, mahjong.ErrInvalidHandSize):
+		return commands.T(i, "msg.mahjong.score.hand_size")
+	case errors.Is(err, mahjong.ErrNotWinningHand):
+		return commands.T(i, "msg.mahjong.score.not_winning")
+	case errors.Is(err, mahjong.ErrNoYaku):
+		return commands.T(i, "msg.mahjong.score.no_yaku")
+	case errors.Is(err, mahjong.ErrInvalidWinContext):
+		return commands.T(i, "msg.mahjong.score.invalid_context")
+	default:
+		return commands.T(i, "msg.mahjong.invalid_hand")
+	}
+}
+
+// scoreEmbed は役と翻・符、親と子それぞれの支払いの埋め込みを生成する
+// 和了した人の支払いには印を付ける
+func scoreEmbed(locale i18n.Locale, cmd appmahjong.ScoreCommand, score mahjong.Score) *discordgo.MessageEmbed {
+	win := i18n.T(locale, "command.mahjong.win.ron")
+	if score.Tsumo {
+		win = i18n.T(locale, "command.mahjong.win.tsumo")
+	}
+
+	yakuLines := make([]string, 0, len(score.Yaku)+1)
+	for _, y := range score.Yaku {
+		name := i18n.T(locale, "msg.mahjong.yaku."+string(y.ID))
+		if y.Yakuman {
+			yakuLines = append(yakuLines, i18n.T(locale, "msg.mahjong.score.yakuman_line", name))
+		} else {
+			yakuLines = append(yakuLines, i18n.T(locale, "msg.mahjong.score.yaku_line", name, y.Han))
+		}
+	}
+	if dora := doraText(locale, score); dora != "" {
+		yakuLines = append(yakuLines, dora)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "msg.mahjong.score.title"),
+		Description: fmt.Sprintf("`%s` %s", cmd.Hand, win),
+		Color:       scoreEmbedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  i18n.T(locale, "msg.mahjong.score.yaku"),
+				Value: strings.Join(yakuLines, "\n"),
+			},
+			{
+				Name:  i18n.T(locale, "msg.mahjong.score.points"),
+				Value: pointsText(locale, score),
+			},
+			{
+				Name: i18n.T(locale, "msg.mahjong.score.payments"),
+				Value: paymentText(locale, "msg.mahjong.score.dealer", score.DealerPayment, score.Dealer) + "\n" +
+					paymentText(locale, "msg.mahjong.score.non_dealer", score.NonDealerPayment, !score.Dealer),
+			},
+		},
+	}
+	return embed
+}
+
+// doraText はドラ・赤ドラ・裏ドラの枚数を1行ずつ表す。役満やドラがなければ空文字列
+func doraText(locale i18n.Locale, score mahjong.Score) string {
+	var parts []string
+	for _, dora := range []struct {
+		key   string
+		count int
+	}{
+		{"msg.mahjong.score.dora", score.Dora},
+		{"msg.mahjong.score.red_dora", score.RedDora},
+		{"msg.mahjong.score.ura_dora", score.UraDora},
+	} {
+		if dora.count > 0 {
+			parts = append(parts, i18n.T(locale, dora.key, dora.count))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// pointsText は「3翻 30符 3900点」「満貫 8000点」のように翻・符と和了した人の点数を表す
+func pointsText(locale i18n.Locale, score mahjong.Score) string {
+	points := i18n.T(locale, "msg.mahjong.score.total", score.Points())
+	switch {
+	case score.Yakuman > 1:
+		return i18n.T(locale, "msg.mahjong.limit.multiple_yakuman", score.Yakuman) + " " + points
+	case score.Yakuman == 1:
+		return i18n.T(locale, "msg.mahjong.limit.yakuman") + " " + points
+	}
+
+	text := i18n.T(locale, "msg.mahjong.score.han_fu", score.Han, score.Fu)
+	if score.Limit == mahjong.LimitYakuman {
+		text += " " + i18n.T(locale, "msg.mahjong.limit.kazoe_yakuman")
+	} else if key, ok := limitKeys[score.Limit]; ok {
+		text += " " + i18n.T(locale, key)
+	}
+	return text + " " + points
+}
+
+// paymentText は親または子が和了した場合のロンとツモの支払いを1行で表す
+func paymentText(locale i18n.Locale, labelKey string, payment mahjong.Payment, winner bool) string {
+	tsumo := i18n.T(locale, "msg.mahjong.score.tsumo_all", payment.TsumoNonDealer)
+	if payment.TsumoDealer > 0 {
+		tsumo = i18n.T(locale, "msg.mahjong.score.tsumo_split", payment.TsumoNonDealer, payment.TsumoDealer)
+	}
+	line := i18n.T(locale, "msg.mahjong.score.payment", i18n.T(locale, labelKey), payment.Ron, tsumo)
+	if winner {
+		return "▶ " + line
+	}
+	return line
+}
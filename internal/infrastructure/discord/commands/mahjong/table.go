@@ -0,0 +1,410 @@
+package mahjong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	appmahjong "github.com/aktnb/discord-bot-go/internal/application/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	tableEmbedColor    = 0x1F8B4C
+	tableFinishedColor = 0x2C3E50
+	statsEmbedColor    = 0x9B59B6
+	// handInputMaxLength はモーダルの各入力欄の最大文字数
+	handInputMaxLength = 16
+)
+
+// handInputs はモーダルの入力欄の CustomID。流局ではどの欄も空でよいため、すべて任意入力にする
+var handInputs = []string{"winner", "loser", "points", "riichi", "tenpai"}
+
+// tableButtons は進行中の対局のメッセージに付けるボタンの操作と色
+var tableButtons = []struct {
+	action string
+	style  discordgo.ButtonStyle
+}{
+	{"record", discordgo.PrimaryButton},
+	{"undo", discordgo.SecondaryButton},
+	{"finish", discordgo.DangerButton},
+}
+
+// tableOptions は table サブコマンドグループのサブコマンドを返す
+func tableOptions() []*discordgo.ApplicationCommandOption {
+	// player1 から順に起家からの席順になる
+	players := make([]*discordgo.ApplicationCommandOption, mahjong.MaxTablePlayers)
+	for n := range players {
+		players[n] = &discordgo.ApplicationCommandOption{
+			Type:                     discordgo.ApplicationCommandOptionUser,
+			Name:                     fmt.Sprintf("player%d", n+1),
+			Description:              commands.DefaultText("command.mahjong.option.player.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.option.player.description"),
+		}
+	}
+	return []*discordgo.ApplicationCommandOption{
+		{
+			Type:                     discordgo.ApplicationCommandOptionSubCommand,
+			Name:                     "start",
+			Description:              commands.DefaultText("command.mahjong.table.start.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.table.start.description"),
+			Options:                  players,
+		},
+		{
+			Type:                     discordgo.ApplicationCommandOptionSubCommand,
+			Name:                     "finish",
+			Description:              commands.DefaultText("command.mahjong.table.finish.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.mahjong.table.finish.description"),
+		},
+	}
+}
+
+func (c *MahjongCommand) handleTable(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	if i.GuildID == "" {
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.guild_only"))
+	}
+	subcommand := options[0]
+	switch subcommand.Name {
+	case "start":
+		return c.handleTableStart(ctx, s, i, subcommand.Options)
+	case "finish":
+		return c.handleTableFinish(ctx, s, i)
+	default:
+		return fmt.Errorf("unknown mahjong table subcommand: %s", subcommand.Name)
+	}
+}
+
+func (c *MahjongCommand) handleTableStart(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, options []*discordgo.ApplicationCommandInteractionDataOption) error {
+	userID, _ := commands.InteractionUserID(i)
+	cmd := appmahjong.StartTableCommand{
+		GuildID:   discordid.GuildID(i.GuildID),
+		ChannelID: discordid.TextChannelID(i.ChannelID),
+		UserID:    discordid.UserID(userID),
+	}
+	// オプションは player1 から順に並ぶとは限らないため、番号の順に並べ直す
+	players := make([]discordid.UserID, mahjong.MaxTablePlayers)
+	for _, option := range options {
+		var n int
+		if _, err := fmt.Sscanf(option.Name, "player%d", &n); err == nil && n >= 1 && n <= len(players) {
+			players[n-1] = discordid.UserID(option.UserValue(nil).ID)
+		}
+	}
+	for _, player := range players {
+		if player != "" {
+			cmd.Players = append(cmd.Players, player)
+		}
+	}
+
+	table, err := c.table.StartTable(ctx, cmd)
+	if err != nil {
+		return respondEphemeral(s, i, tableErrorText(i, err))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: tableMessage(commands.Locale(i), table),
+	})
+}
+
+func (c *MahjongCommand) handleTableFinish(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	userID, _ := commands.InteractionUserID(i)
+	table, err := c.table.PlayingTable(ctx, discordid.GuildID(i.GuildID), discordid.TextChannelID(i.ChannelID))
+	if err == nil {
+		table, err = c.table.FinishTable(ctx, table.ID(), discordid.UserID(userID))
+	}
+	if err != nil {
+		return respondEphemeral(s, i, tableErrorText(i, err))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: tableMessage(commands.Locale(i), table),
+	})
+}
+
+// handleTableComponent は対局のメッセージのボタンを処理する
+// CustomID は "mahjong:table:<record|undo|finish>:<対局 ID>" の形式
+func (c *MahjongCommand) handleTableComponent(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, parts []string) error {
+	if len(parts) != 4 {
+		return fmt.Errorf("invalid mahjong table component: %s", strings.Join(parts, ":"))
+	}
+	id := mahjong.TableID(parts[3])
+	userID, _ := commands.InteractionUserID(i)
+
+	var (
+		table *mahjong.Table
+		err   error
+	)
+	switch parts[2] {
+	case "record":
+		return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseModal,
+			Data: handModal(commands.Locale(i), id),
+		})
+	case "undo":
+		table, err = c.table.UndoHand(ctx, id, discordid.UserID(userID))
+	case "finish":
+		table, err = c.table.FinishTable(ctx, id, discordid.UserID(userID))
+	default:
+		return fmt.Errorf("unknown mahjong table component: %s", strings.Join(parts, ":"))
+	}
+	if err != nil {
+		return respondEphemeral(s, i, tableErrorText(i, err))
+	}
+	return updateTableMessage(s, i, table)
+}
+
+// HandleModal は局の結果を入力したモーダルを処理し、対局のメッセージを更新する
+func (c *MahjongCommand) HandleModal(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	customID := i.ModalSubmitData().CustomID
+	parts := strings.Split(customID, ":")
+	if len(parts) != 4 || parts[1] != "table" || parts[2] != "hand" {
+		return fmt.Errorf("unknown mahjong modal: %s", customID)
+	}
+	userID, _ := commands.InteractionUserID(i)
+
+	values := commands.ModalValues(i)
+	table, err := c.table.RecordHand(ctx, mahjong.TableID(parts[3]), discordid.UserID(userID), appmahjong.RecordHandCommand{
+		Winner: values["winner"],
+		Loser:  values["loser"],
+		Points: values["points"],
+		Riichi: values["riichi"],
+		Tenpai: values["tenpai"],
+	})
+	if err != nil {
+		return respondEphemeral(s, i, tableErrorText(i, err))
+	}
+	return updateTableMessage(s, i, table)
+}
+
+// handModal は局の結果を入力するモーダルを生成する
+// CustomID は "mahjong:table:hand:<対局 ID>" の形式
+func handModal(locale i18n.Locale, id mahjong.TableID) *discordgo.InteractionResponseData {
+	rows := make([]discordgo.MessageComponent, len(handInputs))
+	for n, input := range handInputs {
+		rows[n] = discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    input,
+					Label:       i18n.T(locale, "msg.mahjong.table.input."+input),
+					Style:       discordgo.TextInputShort,
+					Placeholder: i18n.T(locale, "msg.mahjong.table.placeholder."+input),
+					MaxLength:   handInputMaxLength,
+				},
+			},
+		}
+	}
+	return &discordgo.InteractionResponseData{
+		CustomID:   "mahjong:table:hand:" + string(id),
+		Title:      i18n.T(locale, "msg.mahjong.table.modal_title"),
+		Components: rows,
+	}
+}
+
+func updateTableMessage(s *discordgo.Session, i *discordgo.InteractionCreate, table *mahjong.Table) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: tableMessage(commands.Locale(i), table),
+	})
+}
+
+func tableErrorText(i *discordgo.InteractionCreate, err error) string {
+	switch {
+	case errors.Is(err, mahjong.ErrNotInVoiceChannel):
+		return commands.T(i, "msg.mahjong.table.not_in_voice")
+	case errors.Is(err, mahjong.ErrInvalidPlayerCount):
+		return commands.T(i, "msg.mahjong.table.player_count")
+	case errors.Is(err, mahjong.ErrTableInProgress):
+		return commands.T(i, "msg.mahjong.table.in_progress")
+	case errors.Is(err, mahjong.ErrTableNotFound):
+		return commands.T(i, "msg.mahjong.table.not_found")
+	case errors.Is(err, mahjong.ErrTableFinished):
+		return commands.T(i, "msg.mahjong.table.finished")
+	case errors.Is(err, mahjong.ErrNotTablePlayer):
+		return commands.T(i, "msg.mahjong.table.not_player")
+	case errors.Is(err, mahjong.ErrNoHandToUndo):
+		return commands.T(i, "msg.mahjong.table.nothing_to_undo")
+	case errors.Is(err, mahjong.ErrInvalidHandRecord):
+		return commands.T(i, "msg.mahjong.table.invalid_hand")
+	default:
+		log.Printf("Error updating mahjong table: %v", err)
+		return commands.T(i, "msg.mahjong.table.failed")
+	}
+}
+
+// tableMessage は対局の状態のメッセージを生成する
+// 進行中なら記録・取り消し・終了のボタンを付け、終えた対局ではボタンを外して精算した成績を表示する
+func tableMessage(locale i18n.Locale, table *mahjong.Table) *discordgo.InteractionResponseData {
+	data := &discordgo.InteractionResponseData{
+		Components: []discordgo.MessageComponent{},
+		// 参加者をメンションしても通知は飛ばさない
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}
+	if table.Status() == mahjong.TableStatusFinished {
+		data.Embeds = []*discordgo.MessageEmbed{tableResultEmbed(locale, table)}
+		return data
+	}
+
+	data.Embeds = []*discordgo.MessageEmbed{tableEmbed(locale, table)}
+	buttons := make([]discordgo.MessageComponent, len(tableButtons))
+	for n, button := range tableButtons {
+		buttons[n] = tableButton(locale, table, button.action, button.style)
+	}
+	data.Components = []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+	return data
+}
+
+func tableButton(locale i18n.Locale, table *mahjong.Table, action string, style discordgo.ButtonStyle) discordgo.Button {
+	return discordgo.Button{
+		Label:    i18n.T(locale, "msg.mahjong.table.button."+action),
+		Style:    style,
+		CustomID: fmt.Sprintf("mahjong:table:%s:%s", action, table.ID()),
+	}
+}
+
+// tableEmbed は局・本場・供託と、席ごとの自風と持ち点の埋め込みを生成する
+func tableEmbed(locale i18n.Locale, table *mahjong.Table) *discordgo.MessageEmbed {
+	n := len(table.Players())
+	lines := make([]string, n)
+	for seat, userID := range table.Players() {
+		wind := mahjong.East + mahjong.Kind((seat-table.Dealer()+n)%n)
+		lines[seat] = i18n.T(locale, "msg.mahjong.table.seat", seat+1, windName(locale, wind), userID, table.Points()[seat])
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: i18n.T(locale, "msg.mahjong.table.title",
+			windName(locale, table.RoundWind()), table.RoundNumber(), table.Honba()),
+		Description: strings.Join(lines, "\n"),
+		Color:       tableEmbedColor,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(locale, "msg.mahjong.table.footer", len(table.Hands())),
+		},
+	}
+	if table.Deposits() > 0 {
+		embed.Fields = []*discordgo.MessageEmbedField{
+			{
+				Name:  i18n.T(locale, "msg.mahjong.table.deposits"),
+				Value: i18n.T(locale, "msg.mahjong.table.deposit_sticks", table.Deposits()),
+			},
+		}
+	}
+	return embed
+}
+
+// tableResultEmbed は終えた対局の順位と、ウマ・オカを含めた成績の埋め込みを生成する
+func tableResultEmbed(locale i18n.Locale, table *mahjong.Table) *discordgo.MessageEmbed {
+	results := table.Results()
+	lines := make([]string, len(results))
+	for n, result := range results {
+		lines[n] = i18n.T(locale, "msg.mahjong.table.result", placementMark(result.Placement), result.UserID, result.Points, scoreText(float64(result.Score)))
+	}
+	rule := table.Rule()
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "msg.mahjong.table.finished_title"),
+		Description: strings.Join(lines, "\n"),
+		Color:       tableFinishedColor,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(locale, "msg.mahjong.table.rule", rule.StartingPoints, rule.ReturnPoints, umaText(rule.Uma), len(table.Hands())),
+		},
+	}
+}
+
+func windName(locale i18n.Locale, kind mahjong.Kind) string {
+	for _, wind := range winds {
+		if wind.kind == kind {
+			return i18n.T(locale, "command.mahjong.wind."+wind.value)
+		}
+	}
+	return kind.String()
+}
+
+// placementMark は上位3人にメダル、それ以外に順位の番号を付ける
+func placementMark(placement int) string {
+	if placement <= len(rankMarks) {
+		return rankMarks[placement-1]
+	}
+	return fmt.Sprintf("%d.", placement)
+}
+
+// scoreText は点の成績を "+40.8" のように千点単位で表す
+func scoreText(score float64) string {
+	return fmt.Sprintf("%+.1f", score/1000)
+}
+
+// umaText はウマを "+20/+10/-10/-20" のように千点単位で表す
+func umaText(uma []int) string {
+	parts := make([]string, len(uma))
+	for n, points := range uma {
+		parts[n] = fmt.Sprintf("%+d", points/1000)
+	}
+	return strings.Join(parts, "/")
+}
+
+func (c *MahjongCommand) handleStats(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if i.GuildID == "" {
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.guild_only"))
+	}
+
+	stats, err := c.table.Stats(ctx, discordid.GuildID(i.GuildID))
+	if err != nil {
+		log.Printf("Error loading mahjong table stats: %v", err)
+		return respondEphemeral(s, i, commands.T(i, "msg.mahjong.stats.load_failed"))
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{statsEmbed(commands.Locale(i), stats)},
+			// 成績でメンバーをメンションしても通知は飛ばさない
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+}
+
+// statsEmbed は対局の人数ごとに、平均成績の高い順に RankingSize 人までの成績の埋め込みを生成する
+func statsEmbed(locale i18n.Locale, stats []mahjong.TableStats) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: i18n.T(locale, "msg.mahjong.stats.title"),
+		Color: statsEmbedColor,
+	}
+	if len(stats) == 0 {
+		embed.Description = i18n.T(locale, "msg.mahjong.stats.empty")
+		return embed
+	}
+
+	// stats は人数の多い順に並んでいるので、人数が変わるところで区切る
+	var sections []string
+	for start := 0; start < len(stats); {
+		players := stats[start].Players
+		end := start
+		for end < len(stats) && stats[end].Players == players {
+			end++
+		}
+		group := stats[start:end]
+		if len(group) > appmahjong.RankingSize {
+			group = group[:appmahjong.RankingSize]
+		}
+
+		lines := make([]string, 0, len(group)+1)
+		lines = append(lines, i18n.T(locale, "msg.mahjong.stats.players", players))
+		for n, stat := range group {
+			rates := make([]string, players)
+			for p := range players {
+				rates[p] = i18n.T(locale, "msg.mahjong.stats.placement", p+1, stat.PlacementRate(p+1)*100)
+			}
+			lines = append(lines, i18n.T(locale, "msg.mahjong.stats.line",
+				placementMark(n+1), stat.UserID, stat.Games, scoreText(stat.AverageScore()),
+				strings.Join(rates, " "), stat.DealInRate()*100))
+		}
+		sections = append(sections, strings.Join(lines, "\n\n"))
+		start = end
+	}
+	embed.Description = strings.Join(sections, "\n\n")
+	return embed
+}
@@ -6,9 +6,31 @@ import (
 	"log"
 
 	appomikuji "github.com/aktnb/discord-bot-go/internal/application/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+// levelText は運勢レベルごとの表示名・メッセージのカタログキーと絵文字、埋め込みの色
+type levelText struct {
+	nameKey    string
+	messageKey string
+	emoji      string
+	color      int
+}
+
+var levelTexts = map[omikuji.FortuneLevel]levelText{
+	omikuji.UltraGreatBlessing: {"msg.omikuji.level.ultra_great_blessing", "msg.omikuji.message.ultra_great_blessing", "🌟", 0xFFD700},
+	omikuji.GreatBlessing:      {"msg.omikuji.level.great_blessing", "msg.omikuji.message.great_blessing", "🎉", 0xE74C3C},
+	omikuji.MiddleBlessing:     {"msg.omikuji.level.middle_blessing", "msg.omikuji.message.middle_blessing", "😊", 0xE67E22},
+	omikuji.SmallBlessing:      {"msg.omikuji.level.small_blessing", "msg.omikuji.message.small_blessing", "🙂", 0xF1C40F},
+	omikuji.Blessing:           {"msg.omikuji.level.blessing", "msg.omikuji.message.blessing", "🍀", 0x2ECC71},
+	omikuji.BadLuck:            {"msg.omikuji.level.bad_luck", "msg.omikuji.message.bad_luck", "☁️", 0x95A5A6},
+	omikuji.GreatBadLuck:       {"msg.omikuji.level.great_bad_luck", "msg.omikuji.message.great_bad_luck", "⚡", 0x4A235A},
+}
+
 type OmikujiCommand struct {
 	service *appomikuji.Service
 }
@@ -25,54 +47,154 @@ func (c *OmikujiCommand) Name() string {
 
 func (c *OmikujiCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name(),
-		Description: "今日の運勢を占います（同じ日は同じ結果になります）",
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.omikuji.name"),
+		Description:              commands.DefaultText("command.omikuji.description"),
+		DescriptionLocalizations: commands.Localizations("command.omikuji.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "draw",
+				Description:              commands.DefaultText("command.omikuji.draw.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.omikuji.draw.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "history",
+				Description:              commands.DefaultText("command.omikuji.history.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.omikuji.history.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "stats",
+				Description:              commands.DefaultText("command.omikuji.stats.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.omikuji.stats.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "ranking",
+				Description:              commands.DefaultText("command.omikuji.ranking.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.omikuji.ranking.description"),
+			},
+		},
+	}
+}
+
+func (c *OmikujiCommand) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details:  i18n.T(locale, "msg.omikuji.usage.details"),
+		Examples: []string{"/omikuji draw", "/omikuji history", "/omikuji stats", "/omikuji ranking"},
 	}
 }
 
 func (c *OmikujiCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	// ユーザーID取得（nilチェック）
-	var userID string
-	if i.Member != nil && i.Member.User != nil {
-		userID = i.Member.User.ID
-	} else if i.User != nil {
-		userID = i.User.ID
-	} else {
+	userID, ok := commands.InteractionUserID(i)
+	if !ok {
 		log.Printf("Error: unable to get user ID from interaction")
 		return fmt.Errorf("unable to get user ID")
 	}
+	guildID := discordid.GuildID(i.GuildID)
+
+	subcommand := "draw"
+	if options := i.ApplicationCommandData().Options; len(options) > 0 {
+		subcommand = options[0].Name
+	}
+
+	switch subcommand {
+	case "draw":
+		return c.handleDraw(ctx, s, i, guildID, discordid.UserID(userID))
+	case "history":
+		return c.handleHistory(ctx, s, i, guildID, discordid.UserID(userID))
+	case "stats":
+		return c.handleStats(ctx, s, i, guildID, discordid.UserID(userID))
+	case "ranking":
+		return c.handleRanking(ctx, s, i, guildID)
+	default:
+		return fmt.Errorf("unknown omikuji subcommand: %s", subcommand)
+	}
+}
 
+func (c *OmikujiCommand) handleDraw(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, guildID discordid.GuildID, userID discordid.UserID) error {
 	// おみくじを引く
-	fortune, err := c.service.DrawFortune(ctx, userID)
+	fortune, err := c.service.DrawFortune(ctx, guildID, userID)
 	if err != nil {
 		log.Printf("Error drawing fortune: %v", err)
 		// ユーザーにエラーメッセージを返す
-		_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "おみくじを引けませんでした。もう一度お試しください。",
-			},
+		_ = respond(s, i, &discordgo.InteractionResponseData{
+			Content: commands.T(i, "msg.omikuji.draw_failed"),
 		})
 		return err
 	}
 
-	// レスポンスメッセージを作成
-	responseContent := fmt.Sprintf("🎴 **今日のおみくじ結果** 🎴\n\n**%s**\n\n%s",
-		fortune.Level.String(),
-		fortune.Message,
-	)
-
 	// 即座に応答
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	return respond(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{fortuneEmbed(commands.Locale(i), fortune)},
+	})
+}
+
+func (c *OmikujiCommand) handleHistory(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, guildID discordid.GuildID, userID discordid.UserID) error {
+	history, err := c.service.History(ctx, guildID, userID)
+	if err != nil {
+		log.Printf("Error loading omikuji history: %v", err)
+		_ = respond(s, i, &discordgo.InteractionResponseData{
+			Content: commands.T(i, "msg.omikuji.load_failed"),
+		})
+		return err
+	}
+
+	return respond(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{historyEmbed(commands.Locale(i), history)},
+	})
+}
+
+func (c *OmikujiCommand) handleStats(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, guildID discordid.GuildID, userID discordid.UserID) error {
+	stats, expected, err := c.service.Stats(ctx, guildID, userID)
+	if err != nil {
+		log.Printf("Error loading omikuji stats: %v", err)
+		_ = respond(s, i, &discordgo.InteractionResponseData{
+			Content: commands.T(i, "msg.omikuji.load_failed"),
+		})
+		return err
+	}
+
+	return respond(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{statsEmbed(commands.Locale(i), stats, expected)},
+	})
+}
+
+func (c *OmikujiCommand) handleRanking(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, guildID discordid.GuildID) error {
+	if guildID == "" {
+		return respond(s, i, &discordgo.InteractionResponseData{
+			Content: commands.T(i, "msg.omikuji.ranking.guild_only"),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		})
+	}
+
+	ranking, err := c.service.TodayRanking(ctx, guildID)
+	if err != nil {
+		log.Printf("Error loading omikuji ranking: %v", err)
+		_ = respond(s, i, &discordgo.InteractionResponseData{
+			Content: commands.T(i, "msg.omikuji.load_failed"),
+		})
+		return err
+	}
+
+	return respond(s, i, &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{rankingEmbed(commands.Locale(i), ranking)},
+		// ランキングでメンバーをメンションしても通知は飛ばさない
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+}
+
+func respond(s *discordgo.Session, i *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: responseContent,
-		},
+		Data: data,
 	})
 	if err != nil {
 		log.Printf("Error responding to omikuji: %v", err)
 		return err
 	}
-
 	return nil
 }
@@ -0,0 +1,165 @@
+package omikuji
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appomikuji "github.com/aktnb/discord-bot-go/internal/application/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// Discord の埋め込みの色（おみくじの朱色）
+	embedColor = 0xD9333F
+	// 履歴カレンダーでおみくじを引かなかった日
+	undrawnMark = "⬜"
+	// 履歴カレンダーで表示期間外の日（週の途中から始まる場合の空白）
+	outOfRangeMark = "▪️"
+)
+
+var rankMarks = []string{"🥇", "🥈", "🥉"}
+
+// tierMarks は項目別の運勢の良し悪しを表す記号
+var tierMarks = map[omikuji.Tier]string{
+	omikuji.TierGood:    "◎",
+	omikuji.TierNeutral: "○",
+	omikuji.TierBad:     "△",
+}
+
+// fortuneEmbed はおみくじの結果を運勢レベルの色の埋め込みで表示する
+func fortuneEmbed(locale i18n.Locale, fortune *omikuji.Fortune) *discordgo.MessageEmbed {
+	text := levelTexts[fortune.Level]
+	reading := fortune.Reading
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(reading.Categories)+3)
+	for _, c := range reading.Categories {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   tierMarks[c.Tier] + " " + c.Category.Name.In(locale),
+			Value:  c.Text.In(locale),
+			Inline: true,
+		})
+	}
+	fields = append(fields,
+		&discordgo.MessageEmbedField{Name: i18n.T(locale, "msg.omikuji.lucky_color"), Value: reading.LuckyColor.In(locale), Inline: true},
+		&discordgo.MessageEmbedField{Name: i18n.T(locale, "msg.omikuji.lucky_item"), Value: reading.LuckyItem.In(locale), Inline: true},
+		&discordgo.MessageEmbedField{Name: i18n.T(locale, "msg.omikuji.lucky_direction"), Value: reading.LuckyDirection.In(locale), Inline: true},
+	)
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "msg.omikuji.title"),
+		Description: i18n.T(locale, "msg.omikuji.result", text.emoji, i18n.T(locale, text.nameKey), i18n.T(locale, text.messageKey)),
+		Color:       text.color,
+		Fields:      fields,
+	}
+}
+
+// historyEmbed は履歴を月曜始まりのカレンダーとして表示する
+func historyEmbed(locale i18n.Locale, history []omikuji.HistoryDay) *discordgo.MessageEmbed {
+	var b strings.Builder
+	drawn := 0
+	for n, day := range history {
+		if n == 0 || day.Date.Weekday() == time.Monday {
+			if n > 0 {
+				b.WriteString("\n")
+			}
+			monday := day.Date.AddDate(0, 0, -daysSinceMonday(day.Date))
+			fmt.Fprintf(&b, "`%s` ", monday.Format("01/02"))
+			if n == 0 {
+				b.WriteString(strings.Repeat(outOfRangeMark, daysSinceMonday(day.Date)))
+			}
+		}
+		if day.Drawn {
+			drawn++
+			b.WriteString(levelTexts[day.Level].emoji)
+		} else {
+			b.WriteString(undrawnMark)
+		}
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       i18n.T(locale, "msg.omikuji.history.title", appomikuji.HistoryDays),
+		Description: b.String(),
+		Color:       embedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: i18n.T(locale, "msg.omikuji.history.legend"), Value: legend(locale)},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: i18n.T(locale, "msg.omikuji.history.footer", drawn, len(history)),
+		},
+	}
+}
+
+// statsEmbed は運勢ごとの出現率とギルドの確率分布による期待値、連続記録を表示する
+func statsEmbed(locale i18n.Locale, stats omikuji.Stats, expected omikuji.Distribution) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: i18n.T(locale, "msg.omikuji.stats.title"),
+		Color: embedColor,
+	}
+	if stats.Total == 0 {
+		embed.Description = i18n.T(locale, "msg.omikuji.stats.empty")
+		return embed
+	}
+
+	lines := make([]string, 0, len(omikuji.Levels))
+	for _, level := range omikuji.Levels {
+		lines = append(lines, i18n.T(locale, "msg.omikuji.stats.line",
+			levelTexts[level].emoji,
+			i18n.T(locale, levelTexts[level].nameKey),
+			stats.Counts[level],
+			stats.Rate(level)*100,
+			expected.Rate(level)*100,
+		))
+	}
+
+	embed.Description = i18n.T(locale, "msg.omikuji.stats.summary", stats.Total, stats.Streak)
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: i18n.T(locale, "msg.omikuji.stats.distribution"), Value: strings.Join(lines, "\n")},
+	}
+	return embed
+}
+
+// rankingEmbed はギルドの今日の運勢ランキングを表示する
+func rankingEmbed(locale i18n.Locale, ranking []*omikuji.Draw) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title: i18n.T(locale, "msg.omikuji.ranking.title"),
+		Color: embedColor,
+	}
+	if len(ranking) == 0 {
+		embed.Description = i18n.T(locale, "msg.omikuji.ranking.empty")
+		return embed
+	}
+
+	luckiest := ranking[0]
+	lines := []string{
+		i18n.T(locale, "msg.omikuji.ranking.luckiest", luckiest.UserID(), i18n.T(locale, levelTexts[luckiest.Level()].nameKey)),
+		"",
+	}
+	for n, draw := range ranking {
+		mark := fmt.Sprintf("%d.", n+1)
+		if n < len(rankMarks) {
+			mark = rankMarks[n]
+		}
+		text := levelTexts[draw.Level()]
+		lines = append(lines, fmt.Sprintf("%s <@%s> — %s %s", mark, draw.UserID(), text.emoji, i18n.T(locale, text.nameKey)))
+	}
+	embed.Description = strings.Join(lines, "\n")
+	return embed
+}
+
+// legend は絵文字と運勢の対応表を返す
+func legend(locale i18n.Locale) string {
+	items := make([]string, 0, len(omikuji.Levels)+1)
+	for _, level := range omikuji.Levels {
+		items = append(items, levelTexts[level].emoji+" "+i18n.T(locale, levelTexts[level].nameKey))
+	}
+	items = append(items, undrawnMark+" "+i18n.T(locale, "msg.omikuji.history.undrawn"))
+	return strings.Join(items, "　")
+}
+
+// daysSinceMonday は月曜日からの日数（月曜日は 0）を返す
+func daysSinceMonday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
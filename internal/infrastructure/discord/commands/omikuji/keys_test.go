@@ -0,0 +1,26 @@
+package omikuji
+
+import (
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+)
+
+// levelTexts は運勢レベルからカタログキーを引くため、すべてのレベルに表示名とメッセージがあることを確かめる
+func TestLevelKeysExistInCatalogs(t *testing.T) {
+	for _, level := range omikuji.Levels {
+		text, ok := levelTexts[level]
+		if !ok {
+			t.Errorf("level %s has no text", level.Code())
+			continue
+		}
+		for _, key := range []string{text.nameKey, text.messageKey} {
+			for _, locale := range i18n.Locales() {
+				if !i18n.Has(locale, key) {
+					t.Errorf("key %q is missing in %s catalog", key, locale)
+				}
+			}
+		}
+	}
+}
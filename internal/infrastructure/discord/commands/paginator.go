@@ -0,0 +1,309 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/google/uuid"
+)
+
+const (
+	// PaginatorPrefix はページ送りボタンの CustomID の接頭辞
+	// CustomID は "page:<セッション ID>:<ページ番号>" の形式で、コマンド名の代わりにこの接頭辞でルーティングされる
+	PaginatorPrefix = "page"
+
+	// MaxContentLength はメッセージ本文の文字数上限（Discord の仕様）
+	MaxContentLength = 2000
+	// MaxEmbedFields は埋め込み1件あたりのフィールド数の上限（Discord の仕様）
+	MaxEmbedFields = 25
+
+	// DefaultPaginatorTimeout はページ送りを受け付ける時間
+	// インタラクションのトークンの有効期限（15分）内にボタンを片付けられるよう、それより短くする
+	DefaultPaginatorTimeout = 10 * time.Minute
+)
+
+// Page はページ送りで表示する1ページ分の内容
+type Page struct {
+	Content string
+	Embed   *discordgo.MessageEmbed
+}
+
+// TextPages はテキストを limit 文字（rune 数ではなくバイト数）以内のページに分割する
+// 改行、空白の順に区切りを探し、見つからなければ文字の途中で切れない位置で分割する
+func TextPages(text string, limit int) []Page {
+	var pages []Page
+	for len(text) > limit {
+		cut := strings.LastIndex(text[:limit], "\n")
+		if cut <= 0 {
+			cut = strings.LastIndex(text[:limit], " ")
+		}
+		if cut <= 0 {
+			cut = limit
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+		}
+		pages = append(pages, Page{Content: text[:cut]})
+		text = strings.TrimLeft(text[cut:], "\n ")
+	}
+	if text != "" || len(pages) == 0 {
+		pages = append(pages, Page{Content: text})
+	}
+	return pages
+}
+
+// FieldPages は埋め込みのフィールドを perPage 件ずつのページに分割する
+// 各ページの埋め込みは base を複製し、フィールドだけを差し替えたもの
+func FieldPages(base *discordgo.MessageEmbed, fields []*discordgo.MessageEmbedField, perPage int) []Page {
+	perPage = min(max(perPage, 1), MaxEmbedFields)
+	totalPages := max((len(fields)+perPage-1)/perPage, 1)
+
+	pages := make([]Page, 0, totalPages)
+	for page := 0; page < totalPages; page++ {
+		start := page * perPage
+		end := min(start+perPage, len(fields))
+
+		embed := *base
+		embed.Fields = fields[start:end]
+		pages = append(pages, Page{Embed: &embed})
+	}
+	return pages
+}
+
+// paginatorSession は送信したページ送りメッセージの状態
+type paginatorSession struct {
+	pages   []Page
+	ownerID string
+	timer   *time.Timer
+}
+
+// Paginator は長い出力をページに分け、◀ ▶ ボタンでメッセージを書き換えて表示する
+// 状態はメモリ上に保持し、timeout を過ぎたセッションはボタンを外して破棄する
+type Paginator struct {
+	mu       sync.Mutex
+	sessions map[string]*paginatorSession
+	timeout  time.Duration
+}
+
+func NewPaginator(timeout time.Duration) *Paginator {
+	return &Paginator{
+		sessions: make(map[string]*paginatorSession),
+		timeout:  timeout,
+	}
+}
+
+// Respond はインタラクションへの応答として最初のページを送信する
+func (p *Paginator) Respond(s *discordgo.Session, i *discordgo.InteractionCreate, pages []Page) error {
+	return p.respond(s, i, pages, 0)
+}
+
+// RespondEphemeral は実行したユーザーにだけ見える応答として最初のページを送信する
+func (p *Paginator) RespondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, pages []Page) error {
+	return p.respond(s, i, pages, discordgo.MessageFlagsEphemeral)
+}
+
+func (p *Paginator) respond(s *discordgo.Session, i *discordgo.InteractionCreate, pages []Page, flags discordgo.MessageFlags) error {
+	id := p.start(i, pages, func(components []discordgo.MessageComponent) error {
+		_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Components: &components})
+		return err
+	})
+
+	data := pageData(id, pages, 0)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    data.Content,
+			Embeds:     data.Embeds,
+			Components: data.Components,
+			Flags:      flags,
+		},
+	})
+	if err != nil {
+		p.remove(id)
+		log.Printf("Error sending paginated response: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Followup は応答を遅延させたインタラクションに最初のページをフォローアップとして送信する
+func (p *Paginator) Followup(s *discordgo.Session, i *discordgo.InteractionCreate, pages []Page) error {
+	var messageID string
+	var mu sync.Mutex
+	id := p.start(i, pages, func(components []discordgo.MessageComponent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if messageID == "" {
+			return nil
+		}
+		_, err := s.FollowupMessageEdit(i.Interaction, messageID, &discordgo.WebhookEdit{Components: &components})
+		return err
+	})
+
+	data := pageData(id, pages, 0)
+	message, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content:    data.Content,
+		Embeds:     data.Embeds,
+		Components: data.Components,
+	})
+	if err != nil {
+		p.remove(id)
+		log.Printf("Error sending paginated followup: %v", err)
+		return err
+	}
+
+	mu.Lock()
+	messageID = message.ID
+	mu.Unlock()
+	return nil
+}
+
+// HandleComponent はページ送りボタンを処理する
+// 実行したユーザー以外の操作や期限切れのセッションには、本人にだけ見えるメッセージで応答する
+func (p *Paginator) HandleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	parts := strings.Split(i.MessageComponentData().CustomID, ":")
+	if len(parts) != 3 || parts[0] != PaginatorPrefix {
+		return fmt.Errorf("unknown paginator component: %s", i.MessageComponentData().CustomID)
+	}
+	id := parts[1]
+	page, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return fmt.Errorf("invalid page: %w", err)
+	}
+
+	p.mu.Lock()
+	session, ok := p.sessions[id]
+	p.mu.Unlock()
+
+	if !ok {
+		return respondPaginatorNotice(s, i, T(i, "msg.paginator.expired"))
+	}
+	if userID, _ := InteractionUserID(i); userID != session.ownerID {
+		return respondPaginatorNotice(s, i, T(i, "msg.paginator.not_owner"))
+	}
+
+	page = min(max(page, 0), len(session.pages)-1)
+	data := pageData(id, session.pages, page)
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    data.Content,
+			Embeds:     data.Embeds,
+			Components: data.Components,
+		},
+	})
+	if err != nil {
+		log.Printf("Error updating page: %v", err)
+		return err
+	}
+	return nil
+}
+
+// start はページが複数ある場合にセッションを登録し、その ID を返す
+// 期限が来ると clear でボタンを外してセッションを破棄する
+func (p *Paginator) start(i *discordgo.InteractionCreate, pages []Page, clear func([]discordgo.MessageComponent) error) string {
+	if len(pages) <= 1 {
+		return ""
+	}
+
+	id := uuid.New().String()
+	ownerID, _ := InteractionUserID(i)
+	session := &paginatorSession{pages: pages, ownerID: ownerID}
+
+	p.mu.Lock()
+	p.sessions[id] = session
+	session.timer = time.AfterFunc(p.timeout, func() {
+		if !p.remove(id) {
+			return
+		}
+		if err := clear([]discordgo.MessageComponent{}); err != nil {
+			log.Printf("Error removing page buttons: %v", err)
+		}
+	})
+	p.mu.Unlock()
+
+	return id
+}
+
+// remove はセッションを破棄し、破棄したかどうかを返す
+func (p *Paginator) remove(id string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	session, ok := p.sessions[id]
+	if !ok {
+		return false
+	}
+	session.timer.Stop()
+	delete(p.sessions, id)
+	return true
+}
+
+// pageMessage はページの内容とボタンを Discord のメッセージの形にしたもの
+type pageMessage struct {
+	Content    string
+	Embeds     []*discordgo.MessageEmbed
+	Components []discordgo.MessageComponent
+}
+
+// pageData は指定ページの内容と、セッションがあればページ送りボタンを返す
+func pageData(id string, pages []Page, page int) pageMessage {
+	var data pageMessage
+	if len(pages) == 0 {
+		return data
+	}
+
+	data.Content = pages[page].Content
+	if pages[page].Embed != nil {
+		data.Embeds = []*discordgo.MessageEmbed{pages[page].Embed}
+	}
+	if id == "" {
+		return data
+	}
+
+	data.Components = []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "◀",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%s:%s:%d", PaginatorPrefix, id, page-1),
+					Disabled: page == 0,
+				},
+				discordgo.Button{
+					Label:    fmt.Sprintf("%d/%d", page+1, len(pages)),
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%s:%s:current", PaginatorPrefix, id),
+					Disabled: true,
+				},
+				discordgo.Button{
+					Label:    "▶",
+					Style:    discordgo.SecondaryButton,
+					CustomID: fmt.Sprintf("%s:%s:%d", PaginatorPrefix, id, page+1),
+					Disabled: page == len(pages)-1,
+				},
+			},
+		},
+	}
+	return data
+}
+
+func respondPaginatorNotice(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to page button: %v", err)
+	}
+	return err
+}
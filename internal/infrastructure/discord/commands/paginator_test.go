@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestTextPages(t *testing.T) {
+	t.Run("short text", func(t *testing.T) {
+		pages := TextPages("hello", MaxContentLength)
+		if len(pages) != 1 || pages[0].Content != "hello" {
+			t.Errorf("TextPages() = %+v, want a single page", pages)
+		}
+	})
+
+	t.Run("splits at newlines", func(t *testing.T) {
+		text := strings.Repeat("line\n", 10)
+		pages := TextPages(text, 12)
+		for i, page := range pages {
+			if len(page.Content) > 12 {
+				t.Errorf("page %d exceeds limit: %q", i, page.Content)
+			}
+			if strings.HasPrefix(page.Content, "\n") {
+				t.Errorf("page %d starts with a separator: %q", i, page.Content)
+			}
+		}
+		if joined := strings.Join(contents(pages), "\n"); strings.Count(joined, "line") != 10 {
+			t.Errorf("pages lost content: %q", joined)
+		}
+	})
+
+	t.Run("splits at spaces", func(t *testing.T) {
+		pages := TextPages("27 → 82 → 41 → 124 → 62", 12)
+		for i, page := range pages {
+			if len(page.Content) > 12 {
+				t.Errorf("page %d exceeds limit: %q", i, page.Content)
+			}
+		}
+		if joined := strings.Join(contents(pages), " "); joined != "27 → 82 → 41 → 124 → 62" {
+			t.Errorf("pages joined = %q", joined)
+		}
+	})
+
+	t.Run("does not split runes", func(t *testing.T) {
+		pages := TextPages(strings.Repeat("あ", 100), 10)
+		for i, page := range pages {
+			if len(page.Content) > 10 || !utf8.ValidString(page.Content) {
+				t.Errorf("page %d is invalid: %q", i, page.Content)
+			}
+		}
+	})
+}
+
+func TestFieldPages(t *testing.T) {
+	base := &discordgo.MessageEmbed{Title: "title"}
+	fields := make([]*discordgo.MessageEmbedField, 7)
+	for i := range fields {
+		fields[i] = &discordgo.MessageEmbedField{Name: "field"}
+	}
+
+	pages := FieldPages(base, fields, 3)
+	if len(pages) != 3 {
+		t.Fatalf("FieldPages() returned %d pages, want 3", len(pages))
+	}
+	for i, want := range []int{3, 3, 1} {
+		if got := len(pages[i].Embed.Fields); got != want {
+			t.Errorf("page %d has %d fields, want %d", i, got, want)
+		}
+		if pages[i].Embed.Title != "title" {
+			t.Errorf("page %d lost the embed title", i)
+		}
+	}
+	if base.Fields != nil {
+		t.Error("FieldPages() should not modify the base embed")
+	}
+}
+
+func TestPageData(t *testing.T) {
+	pages := []Page{{Content: "1"}, {Content: "2"}, {Content: "3"}}
+
+	if data := pageData("", pages[:1], 0); len(data.Components) != 0 {
+		t.Error("a single page should not have buttons")
+	}
+
+	data := pageData("id", pages, 0)
+	buttons := data.Components[0].(discordgo.ActionsRow).Components
+	prev, next := buttons[0].(discordgo.Button), buttons[2].(discordgo.Button)
+	if !prev.Disabled || next.Disabled {
+		t.Errorf("first page: prev disabled = %v, next disabled = %v", prev.Disabled, next.Disabled)
+	}
+	if next.CustomID != "page:id:1" {
+		t.Errorf("next CustomID = %q, want %q", next.CustomID, "page:id:1")
+	}
+}
+
+func contents(pages []Page) []string {
+	result := make([]string, len(pages))
+	for i, page := range pages {
+		result[i] = page.Content
+	}
+	return result
+}
@@ -2,12 +2,27 @@ package ping
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/aktnb/discord-bot-go/internal/application/ping"
+	domainping "github.com/aktnb/discord-bot-go/internal/domain/ping"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
 	"github.com/bwmarrin/discordgo"
 )
 
+// qualityStyles は評価ごとの埋め込みの色と絵文字
+var qualityStyles = map[domainping.Quality]struct {
+	color int
+	emoji string
+}{
+	domainping.Good: {color: 0x2ECC71, emoji: "🟢"},
+	domainping.Fair: {color: 0xF1C40F, emoji: "🟡"},
+	domainping.Poor: {color: 0xE74C3C, emoji: "🔴"},
+}
+
 type PingCommand struct {
 	service *ping.Service
 }
@@ -24,23 +39,85 @@ func (c *PingCommand) Name() string {
 
 func (c *PingCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name(),
-		Description: "Pong!と応答します",
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.ping.name"),
+		Description:              commands.DefaultText("command.ping.description"),
+		DescriptionLocalizations: commands.Localizations("command.ping.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionBoolean,
+				Name:                     "detailed",
+				Description:              commands.DefaultText("command.ping.option.detailed.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.ping.option.detailed.description"),
+			},
+		},
 	}
 }
 
 func (c *PingCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	response, err := c.service.Ping(ctx)
+	var detailed bool
+	for _, opt := range i.ApplicationCommandData().Options {
+		if opt.Name == "detailed" {
+			detailed = opt.BoolValue()
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
 	if err != nil {
-		log.Printf("Error handling ping command: %v", err)
+		log.Printf("Error deferring response: %v", err)
 		return err
 	}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: response,
+	// 遅延応答を編集する REST API の往復時間を計測する
+	placeholder := commands.T(i, "msg.ping.measuring")
+	restStart := time.Now()
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Content: &placeholder}); err != nil {
+		log.Printf("Error editing ping response: %v", err)
+		return err
+	}
+	restLatency := time.Since(restStart)
+
+	gatewayLatency := s.HeartbeatLatency()
+
+	dbLatency, dbErr := c.service.MeasureDatabase(ctx)
+	if dbErr != nil {
+		log.Printf("Error measuring database latency: %v", dbErr)
+	}
+
+	measured := []time.Duration{gatewayLatency, restLatency}
+	dbValue := commands.T(i, "msg.ping.unavailable")
+	if dbErr == nil {
+		measured = append(measured, dbLatency)
+		dbValue = formatLatency(dbLatency)
+	}
+	quality := domainping.Worst(measured...)
+	if dbErr != nil {
+		quality = domainping.Poor
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: qualityStyles[quality].emoji + " Pong!",
+		Color: qualityStyles[quality].color,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: commands.T(i, "msg.ping.gateway"), Value: formatLatency(gatewayLatency), Inline: true},
+			{Name: commands.T(i, "msg.ping.rest"), Value: formatLatency(restLatency), Inline: true},
+			{Name: commands.T(i, "msg.ping.database"), Value: dbValue, Inline: true},
 		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: commands.T(i, "msg.ping.thresholds", domainping.GoodThreshold.Milliseconds(), domainping.FairThreshold.Milliseconds()),
+		},
+	}
+
+	if detailed {
+		embed.Fields = append(embed.Fields, c.historyField(i))
+	}
+
+	empty := ""
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &empty,
+		Embeds:  &[]*discordgo.MessageEmbed{embed},
 	})
 	if err != nil {
 		log.Printf("Error responding to ping: %v", err)
@@ -49,3 +126,34 @@ func (c *PingCommand) Handle(ctx context.Context, s *discordgo.Session, i *disco
 
 	return nil
 }
+
+// historyField はバックグラウンドで計測したハートビート遅延の履歴を整形する
+func (c *PingCommand) historyField(i *discordgo.InteractionCreate) *discordgo.MessageEmbedField {
+	samples := c.service.HeartbeatHistory()
+	minimum, average, maximum, ok := domainping.Summary(samples)
+	if !ok {
+		return &discordgo.MessageEmbedField{
+			Name:  commands.T(i, "msg.ping.history"),
+			Value: commands.T(i, "msg.ping.history_empty"),
+		}
+	}
+
+	var b strings.Builder
+	for _, sample := range samples {
+		fmt.Fprintf(&b, "`%s` %s %s\n",
+			sample.At.Format("15:04:05"),
+			qualityStyles[domainping.Rate(sample.Latency)].emoji,
+			formatLatency(sample.Latency),
+		)
+	}
+	b.WriteString(commands.T(i, "msg.ping.history_summary", formatLatency(minimum), formatLatency(average), formatLatency(maximum)))
+
+	return &discordgo.MessageEmbedField{
+		Name:  commands.T(i, "msg.ping.history"),
+		Value: b.String(),
+	}
+}
+
+func formatLatency(d time.Duration) string {
+	return fmt.Sprintf("%d ms", d.Milliseconds())
+}
@@ -0,0 +1,368 @@
+package random
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	apprandom "github.com/aktnb/discord-bot-go/internal/application/random"
+	"github.com/aktnb/discord-bot-go/internal/domain/random"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// maxItemsLength は choose で受け付ける選択肢の最大文字数
+	maxItemsLength = 1000
+	// maxTeamChannels は teams で指定できる移動先のボイスチャンネルの数
+	maxTeamChannels = 4
+)
+
+type RandomCommand struct {
+	service *apprandom.Service
+}
+
+func NewRandomCommand(service *apprandom.Service) *RandomCommand {
+	return &RandomCommand{service: service}
+}
+
+func (c *RandomCommand) Name() string {
+	return "random"
+}
+
+func (c *RandomCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
+	minCount, maxChoices := 1.0, float64(random.MaxChoices)
+	minTeams, maxTeams := float64(random.MinTeams), float64(random.MaxTeams)
+
+	teamsOptions := []*discordgo.ApplicationCommandOption{
+		{
+			Type:                     discordgo.ApplicationCommandOptionInteger,
+			Name:                     "count",
+			Description:              commands.DefaultText("command.random.option.teams.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.random.option.teams.description"),
+			Required:                 true,
+			MinValue:                 &minTeams,
+			MaxValue:                 maxTeams,
+		},
+	}
+	for n := 1; n <= maxTeamChannels; n++ {
+		teamsOptions = append(teamsOptions, &discordgo.ApplicationCommandOption{
+			Type:                     discordgo.ApplicationCommandOptionChannel,
+			Name:                     "channel" + strconv.Itoa(n),
+			Description:              commands.DefaultText("command.random.option.channel.description"),
+			DescriptionLocalizations: commands.OptionLocalizations("command.random.option.channel.description"),
+			ChannelTypes:             []discordgo.ChannelType{discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice},
+		})
+	}
+	teamsOptions = append(teamsOptions, seedOption())
+
+	return &discordgo.ApplicationCommand{
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.random.name"),
+		Description:              commands.DefaultText("command.random.description"),
+		DescriptionLocalizations: commands.Localizations("command.random.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "roll",
+				Description:              commands.DefaultText("command.random.roll.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.random.roll.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "expression",
+						Description:              commands.DefaultText("command.random.option.expression.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.random.option.expression.description"),
+						Required:                 true,
+						MaxLength:                random.MaxExpressionLength,
+					},
+					seedOption(),
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "choose",
+				Description:              commands.DefaultText("command.random.choose.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.random.choose.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "items",
+						Description:              commands.DefaultText("command.random.option.items.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.random.option.items.description"),
+						Required:                 true,
+						MaxLength:                maxItemsLength,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionInteger,
+						Name:                     "count",
+						Description:              commands.DefaultText("command.random.option.count.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.random.option.count.description"),
+						MinValue:                 &minCount,
+						MaxValue:                 maxChoices,
+					},
+					seedOption(),
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "teams",
+				Description:              commands.DefaultText("command.random.teams.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.random.teams.description"),
+				Options:                  teamsOptions,
+			},
+		},
+	}
+}
+
+// seedOption は結果を再現するためのシードのオプションを返す
+func seedOption() *discordgo.ApplicationCommandOption {
+	minSeed, maxSeed := 0.0, float64(random.MaxSeed)
+	return &discordgo.ApplicationCommandOption{
+		Type:                     discordgo.ApplicationCommandOptionInteger,
+		Name:                     "seed",
+		Description:              commands.DefaultText("command.random.option.seed.description"),
+		DescriptionLocalizations: commands.OptionLocalizations("command.random.option.seed.description"),
+		MinValue:                 &minSeed,
+		MaxValue:                 maxSeed,
+	}
+}
+
+func (c *RandomCommand) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details: i18n.T(locale, "msg.random.usage.details"),
+		Examples: []string{
+			"/random roll expression:2d6+3",
+			"/random roll expression:4d6kh3 seed:42",
+			"/random choose items:" + i18n.T(locale, "msg.random.example.items"),
+			"/random choose items:a,b,c,d count:2",
+			"/random teams count:2 channel1:#" + i18n.T(locale, "msg.random.example.team", "A") + " channel2:#" + i18n.T(locale, "msg.random.example.team", "B"),
+		},
+	}
+}
+
+func (c *RandomCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(subcommand.Options))
+	for _, option := range subcommand.Options {
+		options[option.Name] = option
+	}
+
+	// シードを指定しなければ毎回ランダムに選び、再現できるよう応答に表示する
+	seed := random.RandomSeed()
+	if option, ok := options["seed"]; ok {
+		seed = uint64(option.IntValue())
+	}
+
+	switch subcommand.Name {
+	case "roll":
+		return c.handleRoll(s, i, options["expression"].StringValue(), seed)
+	case "choose":
+		count := 1
+		if option, ok := options["count"]; ok {
+			count = int(option.IntValue())
+		}
+		return c.handleChoose(s, i, options["items"].StringValue(), count, seed)
+	case "teams":
+		return c.handleTeams(ctx, s, i, options, seed)
+	default:
+		return fmt.Errorf("unknown random subcommand: %s", subcommand.Name)
+	}
+}
+
+func (c *RandomCommand) handleRoll(s *discordgo.Session, i *discordgo.InteractionCreate, expression string, seed uint64) error {
+	result, err := c.service.Roll(expression, seed)
+	if err != nil {
+		log.Printf("Error rolling %q: %v", expression, err)
+		return respondEphemeral(s, i, errorMessage(i, err))
+	}
+
+	lines := []string{commands.T(i, "msg.random.roll.result", result.Expression, result.Total)}
+	for _, dice := range result.Dice {
+		lines = append(lines, diceLine(dice))
+	}
+	lines = append(lines, commands.T(i, "msg.random.seed", seed))
+	content := strings.Join(lines, "\n")
+	// ダイスが多く出目を表示しきれない場合は合計だけを表示する
+	if utf8.RuneCountInString(content) > commands.MaxContentLength {
+		content = strings.Join([]string{lines[0], commands.T(i, "msg.random.roll.too_many_to_show"), lines[len(lines)-1]}, "\n")
+	}
+	return respond(s, i, content)
+}
+
+// diceLine はダイスの項の出目を並べ、合計に数えなかった目に取り消し線を引く
+func diceLine(dice random.DiceRoll) string {
+	rolls := make([]string, len(dice.Rolls))
+	for n, roll := range dice.Rolls {
+		rolls[n] = strconv.Itoa(roll)
+		if !dice.Kept[n] {
+			rolls[n] = "~~" + rolls[n] + "~~"
+		}
+	}
+	return fmt.Sprintf("`%s` [%s] = %d", dice.Notation(), strings.Join(rolls, ", "), dice.Sum)
+}
+
+func (c *RandomCommand) handleChoose(s *discordgo.Session, i *discordgo.InteractionCreate, items string, count int, seed uint64) error {
+	picked, err := c.service.Choose(items, count, seed)
+	if err != nil {
+		log.Printf("Error choosing from %q: %v", items, err)
+		return respondEphemeral(s, i, errorMessage(i, err))
+	}
+
+	var lines []string
+	if len(picked) == 1 {
+		lines = append(lines, commands.T(i, "msg.random.choose.result", picked[0]))
+	} else {
+		lines = append(lines, commands.T(i, "msg.random.choose.title", len(picked)))
+		for n, choice := range picked {
+			lines = append(lines, commands.T(i, "msg.random.choose.item", n+1, choice))
+		}
+	}
+	lines = append(lines, commands.T(i, "msg.random.seed", seed))
+	return respond(s, i, strings.Join(lines, "\n"))
+}
+
+func (c *RandomCommand) handleTeams(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, options map[string]*discordgo.ApplicationCommandInteractionDataOption, seed uint64) error {
+	if i.GuildID == "" {
+		return respondEphemeral(s, i, commands.T(i, "msg.random.guild_only"))
+	}
+
+	userID, _ := commands.InteractionUserID(i)
+	cmd := apprandom.TeamsCommand{
+		GuildID:  discordid.GuildID(i.GuildID),
+		UserID:   discordid.UserID(userID),
+		Count:    int(options["count"].IntValue()),
+		Seed:     seed,
+		Channels: make([]discordid.VoiceChannelID, maxTeamChannels),
+	}
+	for n := range cmd.Channels {
+		if option, ok := options["channel"+strconv.Itoa(n+1)]; ok {
+			cmd.Channels[n] = discordid.VoiceChannelID(option.ChannelValue(nil).ID)
+		}
+	}
+
+	// 遅延させた応答は本人だけに表示できないので、移動できない場合は先に断る
+	if err := c.service.CheckMovePermission(ctx, cmd); err != nil {
+		log.Printf("Error checking random teams: %v", err)
+		return respondEphemeral(s, i, errorMessage(i, err))
+	}
+
+	// メンバーの移動に時間がかかる可能性があるため、応答を遅延させる
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		log.Printf("Error deferring response: %v", err)
+		return err
+	}
+
+	content := ""
+	result, err := c.service.Teams(ctx, cmd)
+	if err != nil {
+		log.Printf("Error splitting teams: %v", err)
+		content = errorMessage(i, err)
+	} else {
+		content = teamsText(i, result, seed)
+	}
+
+	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Content: content,
+		// チーム分けの結果でメンバーに通知しない
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Printf("Error sending random teams: %v", err)
+		return err
+	}
+	return nil
+}
+
+// teamsText はチームごとのメンバーと移動先を並べる
+func teamsText(i *discordgo.InteractionCreate, result *apprandom.TeamsResult, seed uint64) string {
+	members := 0
+	for _, team := range result.Teams {
+		members += len(team)
+	}
+	lines := []string{commands.T(i, "msg.random.teams.title", result.Source, members, len(result.Teams))}
+	for n, team := range result.Teams {
+		lines = append(lines, commands.T(i, "msg.random.teams.team", n+1, mentions(team)))
+		if result.Channels[n] != "" {
+			lines[len(lines)-1] += " " + commands.T(i, "msg.random.teams.moved", result.Channels[n])
+		}
+	}
+	if len(result.Failed) > 0 {
+		lines = append(lines, commands.T(i, "msg.random.teams.move_failed", mentions(result.Failed)))
+	}
+	lines = append(lines, commands.T(i, "msg.random.seed", seed))
+	return strings.Join(lines, "\n")
+}
+
+func mentions(users []discordid.UserID) string {
+	texts := make([]string, len(users))
+	for n, user := range users {
+		texts[n] = "<@" + string(user) + ">"
+	}
+	return strings.Join(texts, " ")
+}
+
+// errorMessage はエラーに対応するユーザー向けのメッセージを返す
+func errorMessage(i *discordgo.InteractionCreate, err error) string {
+	switch {
+	case errors.Is(err, random.ErrInvalidExpression):
+		return commands.T(i, "msg.random.invalid_expression")
+	case errors.Is(err, random.ErrTooManyDice):
+		return commands.T(i, "msg.random.too_many_dice", random.MaxDice)
+	case errors.Is(err, random.ErrInvalidDie):
+		return commands.T(i, "msg.random.invalid_die", random.MaxSides)
+	case errors.Is(err, random.ErrDivisionByZero):
+		return commands.T(i, "msg.random.division_by_zero")
+	case errors.Is(err, random.ErrResultTooLarge):
+		return commands.T(i, "msg.random.result_too_large")
+	case errors.Is(err, random.ErrNoChoices):
+		return commands.T(i, "msg.random.no_choices")
+	case errors.Is(err, random.ErrTooManyChoices):
+		return commands.T(i, "msg.random.too_many_choices", random.MaxChoices)
+	case errors.Is(err, random.ErrInvalidPickCount):
+		return commands.T(i, "msg.random.invalid_pick_count")
+	case errors.Is(err, random.ErrNotInVoiceChannel):
+		return commands.T(i, "msg.random.not_in_voice")
+	case errors.Is(err, random.ErrNotEnoughMembers):
+		return commands.T(i, "msg.random.not_enough_members")
+	case errors.Is(err, random.ErrMoveNotPermitted):
+		return commands.T(i, "msg.random.move_not_permitted")
+	default:
+		return commands.T(i, "msg.random.error")
+	}
+}
+
+func respond(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			// 選択肢にメンションが含まれていても通知しない
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to random: %v", err)
+		return err
+	}
+	return nil
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
@@ -1,38 +1,71 @@
 package commands
 
 import (
+	"context"
 	"log"
+	"slices"
+	"sort"
 
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
 	"github.com/bwmarrin/discordgo"
 )
 
+// GuildCommandProvider はギルド固有コマンドを登録するギルドと、登録済みのギルドを管理する
+type GuildCommandProvider interface {
+	// ResolveGuildIDs はデフォルトの登録先に設定を適用し、commandName を登録するギルドを返す
+	ResolveGuildIDs(ctx context.Context, commandName string, defaults []discordid.GuildID) ([]discordid.GuildID, error)
+	// ConfiguredGuildIDs は設定が存在するギルドを返す
+	ConfiguredGuildIDs(ctx context.Context) ([]discordid.GuildID, error)
+	// RegisteredGuildIDs はギルド固有コマンドを登録済みのギルドを返す
+	RegisteredGuildIDs(ctx context.Context) ([]discordid.GuildID, error)
+	// RecordRegistration はギルドにコマンドを登録したかどうかを記録する
+	RecordRegistration(ctx context.Context, guildID discordid.GuildID, registered bool) error
+}
+
 type CommandRegistrar struct {
-	session  *discordgo.Session
-	registry *CommandRegistry
+	session       *discordgo.Session
+	registry      *CommandRegistry
+	guildCommands GuildCommandProvider
 }
 
-func NewRegistrar(session *discordgo.Session, registry *CommandRegistry) *CommandRegistrar {
+func NewRegistrar(session *discordgo.Session, registry *CommandRegistry, guildCommands GuildCommandProvider) *CommandRegistrar {
 	return &CommandRegistrar{
-		session:  session,
-		registry: registry,
+		session:       session,
+		registry:      registry,
+		guildCommands: guildCommands,
 	}
 }
 
 // RegisterApplicationCommands はグローバルコマンドとギルド固有コマンドを登録する
-func (r *CommandRegistrar) RegisterApplicationCommands() error {
+func (r *CommandRegistrar) RegisterApplicationCommands(ctx context.Context) error {
 	var globalDefs []*discordgo.ApplicationCommand
-	guildDefs := make(map[string][]*discordgo.ApplicationCommand)
-
 	for _, cmd := range r.registry.GetAllCommands() {
-		if guildCmd, ok := cmd.(GuildSlashCommand); ok {
-			for _, guildID := range guildCmd.GuildIDs() {
-				guildDefs[guildID] = append(guildDefs[guildID], cmd.ToDiscordCommand())
-			}
-		} else {
+		if _, ok := cmd.(GuildSlashCommand); !ok {
 			globalDefs = append(globalDefs, cmd.ToDiscordCommand())
 		}
 	}
 
+	guildDefs, err := r.guildDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 設定上すべて無効化されたギルドや、以前は登録していたが登録するコマンドが無くなったギルドは、
+	// 以前の登録を消すために空で上書きする
+	configured, err := r.guildCommands.ConfiguredGuildIDs(ctx)
+	if err != nil {
+		return err
+	}
+	registered, err := r.guildCommands.RegisteredGuildIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, guildID := range slices.Concat(configured, registered) {
+		if _, ok := guildDefs[guildID]; !ok {
+			guildDefs[guildID] = []*discordgo.ApplicationCommand{}
+		}
+	}
+
 	if len(globalDefs) > 0 {
 		log.Printf("Registering %d application commands globally...", len(globalDefs))
 		if _, err := r.session.ApplicationCommandBulkOverwrite(r.session.State.User.ID, "", globalDefs); err != nil {
@@ -42,12 +75,119 @@ func (r *CommandRegistrar) RegisterApplicationCommands() error {
 	}
 
 	for guildID, defs := range guildDefs {
-		log.Printf("Registering %d application commands for guild %s...", len(defs), guildID)
-		if _, err := r.session.ApplicationCommandBulkOverwrite(r.session.State.User.ID, guildID, defs); err != nil {
+		if err := r.overwriteGuildCommands(ctx, guildID, defs); err != nil {
 			return err
 		}
-		log.Printf("Successfully registered %d application commands for guild %s", len(defs), guildID)
 	}
 
 	return nil
 }
+
+// RegisterGuildCommands は指定したギルドのギルド固有コマンドだけを再登録する
+// 有効なコマンドが無い場合は空で上書きし、登録済みのコマンドを削除する
+func (r *CommandRegistrar) RegisterGuildCommands(ctx context.Context, guildID discordid.GuildID) error {
+	guildDefs, err := r.guildDefinitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	defs, ok := guildDefs[guildID]
+	if !ok {
+		defs = []*discordgo.ApplicationCommand{}
+	}
+	return r.overwriteGuildCommands(ctx, guildID, defs)
+}
+
+// AvailableCommands は指定したギルドで利用できるコマンドを名前順に返す
+// グローバルコマンドに加え、そのギルドで有効化されたギルド固有コマンドを含む
+// guildID が空（DM）の場合はグローバルコマンドのみを返す
+func (r *CommandRegistrar) AvailableCommands(ctx context.Context, guildID discordid.GuildID) ([]SlashCommand, error) {
+	var available []SlashCommand
+	for _, cmd := range r.registry.GetAllCommands() {
+		guildCmd, ok := cmd.(GuildSlashCommand)
+		if !ok {
+			available = append(available, cmd)
+			continue
+		}
+		if guildID == "" {
+			continue
+		}
+
+		guildIDs, err := r.guildCommands.ResolveGuildIDs(ctx, cmd.Name(), toGuildIDs(guildCmd.GuildIDs()))
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(guildIDs, guildID) {
+			available = append(available, cmd)
+		}
+	}
+
+	if guildID != "" {
+		for _, source := range r.registry.GetSources() {
+			guildCmds, err := source.GuildCommands(ctx)
+			if err != nil {
+				return nil, err
+			}
+			available = append(available, guildCmds[guildID]...)
+		}
+	}
+
+	sort.Slice(available, func(a, b int) bool {
+		return available[a].Name() < available[b].Name()
+	})
+	return available, nil
+}
+
+// guildDefinitions はギルドごとに登録すべきギルド固有コマンドの定義を返す
+// GuildSlashCommand に加え、DynamicCommandSource が提供するコマンドを含む
+func (r *CommandRegistrar) guildDefinitions(ctx context.Context) (map[discordid.GuildID][]*discordgo.ApplicationCommand, error) {
+	guildDefs := make(map[discordid.GuildID][]*discordgo.ApplicationCommand)
+
+	for _, cmd := range r.registry.GetAllCommands() {
+		guildCmd, ok := cmd.(GuildSlashCommand)
+		if !ok {
+			continue
+		}
+
+		guildIDs, err := r.guildCommands.ResolveGuildIDs(ctx, cmd.Name(), toGuildIDs(guildCmd.GuildIDs()))
+		if err != nil {
+			return nil, err
+		}
+		for _, guildID := range guildIDs {
+			guildDefs[guildID] = append(guildDefs[guildID], cmd.ToDiscordCommand())
+		}
+	}
+
+	// 実行時に定義されたコマンドは、定義したギルドにだけ登録する
+	for _, source := range r.registry.GetSources() {
+		guildCmds, err := source.GuildCommands(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for guildID, cmds := range guildCmds {
+			for _, cmd := range cmds {
+				guildDefs[guildID] = append(guildDefs[guildID], cmd.ToDiscordCommand())
+			}
+		}
+	}
+
+	return guildDefs, nil
+}
+
+// overwriteGuildCommands はギルドのコマンドを defs で上書きし、登録済みかどうかを記録する
+func (r *CommandRegistrar) overwriteGuildCommands(ctx context.Context, guildID discordid.GuildID, defs []*discordgo.ApplicationCommand) error {
+	log.Printf("Registering %d application commands for guild %s...", len(defs), guildID)
+	if _, err := r.session.ApplicationCommandBulkOverwrite(r.session.State.User.ID, string(guildID), defs); err != nil {
+		return err
+	}
+	log.Printf("Successfully registered %d application commands for guild %s", len(defs), guildID)
+	return r.guildCommands.RecordRegistration(ctx, guildID, len(defs) > 0)
+}
+
+func toGuildIDs(ids []string) []discordid.GuildID {
+	guildIDs := make([]discordid.GuildID, 0, len(ids))
+	for _, id := range ids {
+		guildIDs = append(guildIDs, discordid.GuildID(id))
+	}
+	return guildIDs
+}
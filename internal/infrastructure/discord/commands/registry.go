@@ -1,9 +1,15 @@
 package commands
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/bwmarrin/discordgo"
+)
 
 type CommandRegistry struct {
 	commands map[string]SlashCommand
+	sources  []DynamicCommandSource
 }
 
 func NewCommandRegistry() *CommandRegistry {
@@ -23,6 +29,38 @@ func (r *CommandRegistry) GetCommand(name string) (SlashCommand, bool) {
 	return cmd, ok
 }
 
+// RegisterSource は実行時に定義されるコマンドの提供元を登録する
+func (r *CommandRegistry) RegisterSource(source DynamicCommandSource) {
+	r.sources = append(r.sources, source)
+}
+
+// GetSources は登録された動的なコマンドの提供元を返す
+func (r *CommandRegistry) GetSources() []DynamicCommandSource {
+	return r.sources
+}
+
+// Resolve はギルドで実行されたコマンドを名前から取得する
+// 静的に登録されたコマンドを優先し、無ければ動的なコマンドの提供元から探す
+func (r *CommandRegistry) Resolve(ctx context.Context, guildID discordid.GuildID, name string) (SlashCommand, bool, error) {
+	if cmd, ok := r.commands[name]; ok {
+		return cmd, true, nil
+	}
+	if guildID == "" {
+		return nil, false, nil
+	}
+
+	for _, source := range r.sources {
+		cmd, ok, err := source.GuildCommand(ctx, guildID, name)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return cmd, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
 // GetAllCommands は全てのコマンドを返す
 func (r *CommandRegistry) GetAllCommands() []SlashCommand {
 	cmds := make([]SlashCommand, 0, len(r.commands))
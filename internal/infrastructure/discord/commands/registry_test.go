@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/bwmarrin/discordgo"
+)
+
+type namedCommand string
+
+func (c namedCommand) Name() string {
+	return string(c)
+}
+
+func (c namedCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{Name: string(c)}
+}
+
+func (c namedCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return nil
+}
+
+// stubSource はギルドごとに固定のコマンドを提供する DynamicCommandSource
+type stubSource map[discordid.GuildID][]SlashCommand
+
+func (s stubSource) GuildCommands(ctx context.Context) (map[discordid.GuildID][]SlashCommand, error) {
+	return s, nil
+}
+
+func (s stubSource) GuildCommand(ctx context.Context, guildID discordid.GuildID, name string) (SlashCommand, bool, error) {
+	for _, cmd := range s[guildID] {
+		if cmd.Name() == name {
+			return cmd, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func TestRegistryResolve(t *testing.T) {
+	registry := NewCommandRegistry()
+	registry.Register(namedCommand("ping"))
+	registry.RegisterSource(stubSource{
+		"guild-a": {namedCommand("tanaka")},
+	})
+
+	tests := []struct {
+		name    string
+		guildID discordid.GuildID
+		command string
+		want    bool
+	}{
+		{name: "static command", guildID: "guild-a", command: "ping", want: true},
+		{name: "static command in DM", guildID: "", command: "ping", want: true},
+		{name: "dynamic command in its guild", guildID: "guild-a", command: "tanaka", want: true},
+		{name: "dynamic command in another guild", guildID: "guild-b", command: "tanaka", want: false},
+		{name: "dynamic command in DM", guildID: "", command: "tanaka", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, ok, err := registry.Resolve(context.Background(), tt.guildID, tt.command)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.want {
+				t.Fatalf("Resolve() found = %v, want %v", ok, tt.want)
+			}
+			if ok && cmd.Name() != tt.command {
+				t.Errorf("Resolve() = %s, want %s", cmd.Name(), tt.command)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	appschedule "github.com/aktnb/discord-bot-go/internal/application/schedule"
+	domainschedule "github.com/aktnb/discord-bot-go/internal/domain/schedule"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// 入力補完の候補数の上限（Discord の仕様）
+	maxAutocompleteChoices = 25
+	// 予約投稿の一覧の埋め込みの色
+	listEmbedColor = 0x3498DB
+	// cron 形式の式の最大文字数
+	maxSpecLength = 100
+)
+
+// Command は伝説エピソードの予約投稿を管理するコマンド
+// 予約投稿の登録・削除はサーバー管理権限を持つメンバーのみ行える
+type Command struct {
+	service *appschedule.Service
+	legends appschedule.LegendProvider
+}
+
+func NewScheduleCommand(service *appschedule.Service, legends appschedule.LegendProvider) *Command {
+	return &Command{
+		service: service,
+		legends: legends,
+	}
+}
+
+func (c *Command) Name() string {
+	return "schedule"
+}
+
+func (c *Command) ToDiscordCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.schedule.name"),
+		Description:              commands.DefaultText("command.schedule.description"),
+		DescriptionLocalizations: commands.Localizations("command.schedule.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "add",
+				Description:              commands.DefaultText("command.schedule.add.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.schedule.add.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionChannel,
+						Name:                     "channel",
+						Description:              commands.DefaultText("command.schedule.option.channel.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.schedule.option.channel.description"),
+						Required:                 true,
+						ChannelTypes:             []discordgo.ChannelType{discordgo.ChannelTypeGuildText, discordgo.ChannelTypeGuildNews},
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "legend",
+						Description:              commands.DefaultText("command.schedule.option.legend.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.schedule.option.legend.description"),
+						Required:                 true,
+						Autocomplete:             true,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "cron",
+						Description:              commands.DefaultText("command.schedule.option.cron.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.schedule.option.cron.description"),
+						Required:                 true,
+						MaxLength:                maxSpecLength,
+					},
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "catch_up",
+						Description:              commands.DefaultText("command.schedule.option.catch_up.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.schedule.option.catch_up.description"),
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{
+								Name:              commands.DefaultText("command.schedule.catch_up.latest"),
+								NameLocalizations: commands.OptionLocalizations("command.schedule.catch_up.latest"),
+								Value:             string(domainschedule.CatchUpLatest),
+							},
+							{
+								Name:              commands.DefaultText("command.schedule.catch_up.skip"),
+								NameLocalizations: commands.OptionLocalizations("command.schedule.catch_up.skip"),
+								Value:             string(domainschedule.CatchUpSkip),
+							},
+						},
+					},
+				},
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "list",
+				Description:              commands.DefaultText("command.schedule.list.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.schedule.list.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Name:                     "remove",
+				Description:              commands.DefaultText("command.schedule.remove.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.schedule.remove.description"),
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Name:                     "id",
+						Description:              commands.DefaultText("command.schedule.option.id.description"),
+						DescriptionLocalizations: commands.OptionLocalizations("command.schedule.option.id.description"),
+						Required:                 true,
+						Autocomplete:             true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *Command) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details: i18n.T(locale, "msg.schedule.usage.details", domainschedule.MaxSchedulesPerGuild),
+		Examples: []string{
+			"/schedule add channel:#general legend:yamada cron:0 9 * * *",
+			"/schedule add channel:#general legend:faker cron:30 12 * * 1-5 catch_up:skip",
+			"/schedule list",
+		},
+	}
+}
+
+func (c *Command) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if i.GuildID == "" || !isManager(i) {
+		return respondEphemeral(s, i, commands.T(i, "msg.schedule.not_manager"))
+	}
+	userID, ok := commands.InteractionUserID(i)
+	if !ok {
+		return fmt.Errorf("unable to get user ID")
+	}
+	guildID := discordid.GuildID(i.GuildID)
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(subcommand.Options))
+	for _, option := range subcommand.Options {
+		options[option.Name] = option
+	}
+
+	switch subcommand.Name {
+	case "add":
+		catchUp := domainschedule.CatchUpLatest
+		if option, ok := options["catch_up"]; ok {
+			catchUp = domainschedule.CatchUp(option.StringValue())
+		}
+		return c.handleAdd(ctx, s, i, appschedule.AddScheduleCommand{
+			GuildID:   guildID,
+			ChannelID: discordid.TextChannelID(options["channel"].Value.(string)),
+			CreatedBy: discordid.UserID(userID),
+			Legend:    strings.TrimPrefix(options["legend"].StringValue(), "/"),
+			Spec:      options["cron"].StringValue(),
+			CatchUp:   catchUp,
+		})
+	case "list":
+		return c.handleList(ctx, s, i, guildID)
+	case "remove":
+		id := domainschedule.ID(options["id"].StringValue())
+		if err := c.service.RemoveSchedule(ctx, guildID, id); err != nil {
+			log.Printf("Error removing schedule: guild=%s id=%s err=%v", guildID, id, err)
+			return respondEphemeral(s, i, errorMessage(i, err))
+		}
+		return respondEphemeral(s, i, commands.T(i, "msg.schedule.removed"))
+	default:
+		return fmt.Errorf("unknown schedule subcommand: %s", subcommand.Name)
+	}
+}
+
+func (c *Command) handleAdd(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, cmd appschedule.AddScheduleCommand) error {
+	created, err := c.service.AddSchedule(ctx, cmd)
+	if err != nil {
+		log.Printf("Error adding schedule: guild=%s err=%v", cmd.GuildID, err)
+		return respondEphemeral(s, i, errorMessage(i, err))
+	}
+
+	return respondEphemeral(s, i, commands.T(i, "msg.schedule.added",
+		created.Legend(),
+		created.ChannelID(),
+		created.Spec(),
+		created.NextRunAt().Unix(),
+	))
+}
+
+func (c *Command) handleList(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate, guildID discordid.GuildID) error {
+	schedules, err := c.service.Schedules(ctx, guildID)
+	if err != nil {
+		log.Printf("Error loading schedules: guild=%s err=%v", guildID, err)
+		return respondEphemeral(s, i, commands.T(i, "msg.schedule.load_failed"))
+	}
+	if len(schedules) == 0 {
+		return respondEphemeral(s, i, commands.T(i, "msg.schedule.empty"))
+	}
+
+	lines := make([]string, 0, len(schedules))
+	for _, sch := range schedules {
+		lines = append(lines, commands.T(i, "msg.schedule.item",
+			shortID(sch.ID()),
+			sch.Legend(),
+			sch.ChannelID(),
+			sch.Spec(),
+			catchUpLabel(commands.Locale(i), sch.CatchUp()),
+			sch.NextRunAt().Unix(),
+		))
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       commands.T(i, "msg.schedule.list_title", len(schedules), domainschedule.MaxSchedulesPerGuild),
+					Description: strings.Join(lines, "\n"),
+					Color:       listEmbedColor,
+				},
+			},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to schedule: %v", err)
+	}
+	return err
+}
+
+// HandleAutocomplete は add では投稿できる伝説コマンドを、remove では登録済みの予約投稿を候補として返す
+func (c *Command) HandleAutocomplete(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, maxAutocompleteChoices)
+	guildID := discordid.GuildID(i.GuildID)
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	var input string
+	for _, option := range subcommand.Options {
+		if option.Focused {
+			input = strings.ToLower(strings.TrimPrefix(option.StringValue(), "/"))
+		}
+	}
+
+	if guildID != "" && isManager(i) {
+		switch subcommand.Name {
+		case "add":
+			legends, err := c.legends.Legends(ctx, guildID)
+			if err != nil {
+				log.Printf("Error listing legends: %v", err)
+				return err
+			}
+			for _, name := range legends {
+				if !strings.Contains(name, input) {
+					continue
+				}
+				choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: "/" + name, Value: name})
+				if len(choices) == maxAutocompleteChoices {
+					break
+				}
+			}
+		case "remove":
+			schedules, err := c.service.Schedules(ctx, guildID)
+			if err != nil {
+				log.Printf("Error loading schedules: %v", err)
+				return err
+			}
+			for _, sch := range schedules {
+				summary := scheduleSummary(sch)
+				if !strings.Contains(strings.ToLower(summary), input) {
+					continue
+				}
+				choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: summary, Value: string(sch.ID())})
+				if len(choices) == maxAutocompleteChoices {
+					break
+				}
+			}
+		}
+	}
+
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+}
+
+// isManager はサーバー管理権限を持つメンバーかどうかを返す
+func isManager(i *discordgo.InteractionCreate) bool {
+	return i.Member != nil && i.Member.Permissions&discordgo.PermissionManageGuild != 0
+}
+
+// shortID は一覧に表示する予約投稿の ID の先頭部分
+func shortID(id domainschedule.ID) string {
+	if len(id) > 8 {
+		return string(id[:8])
+	}
+	return string(id)
+}
+
+// scheduleSummary は入力補完の候補に表示する予約投稿の概要
+func scheduleSummary(s *domainschedule.Schedule) string {
+	return fmt.Sprintf("%s /%s %s", shortID(s.ID()), s.Legend(), s.Spec())
+}
+
+func catchUpLabel(locale i18n.Locale, catchUp domainschedule.CatchUp) string {
+	return i18n.T(locale, "command.schedule.catch_up."+string(catchUp))
+}
+
+// errorMessage は予約投稿の登録・削除の失敗理由を利用者向けのメッセージにする
+func errorMessage(i *discordgo.InteractionCreate, err error) string {
+	switch {
+	case errors.Is(err, domainschedule.ErrInvalidSpec):
+		return commands.T(i, "msg.schedule.invalid_spec")
+	case errors.Is(err, domainschedule.ErrNeverRuns):
+		return commands.T(i, "msg.schedule.never_runs")
+	case errors.Is(err, domainschedule.ErrTooFrequent):
+		return commands.T(i, "msg.schedule.too_frequent", int(domainschedule.MinInterval.Minutes()))
+	case errors.Is(err, domainschedule.ErrUnknownLegend):
+		return commands.T(i, "msg.schedule.unknown_legend")
+	case errors.Is(err, domainschedule.ErrInvalidCatchUp):
+		return commands.T(i, "msg.schedule.invalid_catch_up")
+	case errors.Is(err, domainschedule.ErrTooManySchedules):
+		return commands.T(i, "msg.schedule.too_many", domainschedule.MaxSchedulesPerGuild)
+	case errors.Is(err, domainschedule.ErrScheduleNotFound):
+		return commands.T(i, "msg.schedule.not_found")
+	default:
+		return commands.T(i, "msg.schedule.save_failed")
+	}
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to schedule: %v", err)
+	}
+	return err
+}
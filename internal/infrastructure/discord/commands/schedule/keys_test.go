@@ -0,0 +1,20 @@
+package schedule
+
+import (
+	"testing"
+
+	domainschedule "github.com/aktnb/discord-bot-go/internal/domain/schedule"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
+)
+
+// catchUpLabel はカタログキーを実行時に組み立てるため、すべての CatchUp について確かめる
+func TestCatchUpKeysExistInCatalogs(t *testing.T) {
+	for _, catchUp := range domainschedule.CatchUps {
+		key := "command.schedule.catch_up." + string(catchUp)
+		for _, locale := range i18n.Locales() {
+			if !i18n.Has(locale, key) {
+				t.Errorf("key %q is missing in %s catalog", key, locale)
+			}
+		}
+	}
+}
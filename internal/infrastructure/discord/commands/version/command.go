@@ -2,12 +2,27 @@ package version
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/aktnb/discord-bot-go/internal/application/version"
+	domainversion "github.com/aktnb/discord-bot-go/internal/domain/version"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+const (
+	// Discord の埋め込みの色（ボットのテーマカラー）
+	embedColor = 0x5865F2
+	// 埋め込みフィールドの値の文字数上限（Discord の仕様）
+	maxFieldValueLength = 1024
+	// 埋め込みの説明文の文字数上限（Discord の仕様）
+	maxDescriptionLength = 4096
+)
+
 type VersionCommand struct {
 	service *version.Service
 }
@@ -22,22 +37,87 @@ func (c *VersionCommand) Name() string {
 
 func (c *VersionCommand) ToDiscordCommand() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name(),
-		Description: "ボットのバージョンを表示します",
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.version.name"),
+		Description:              commands.DefaultText("command.version.description"),
+		DescriptionLocalizations: commands.Localizations("command.version.description"),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:                     discordgo.ApplicationCommandOptionBoolean,
+				Name:                     "changelog",
+				Description:              commands.DefaultText("command.version.option.changelog.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.version.option.changelog.description"),
+			},
+			{
+				Type:                     discordgo.ApplicationCommandOptionBoolean,
+				Name:                     "dependencies",
+				Description:              commands.DefaultText("command.version.option.dependencies.description"),
+				DescriptionLocalizations: commands.OptionLocalizations("command.version.option.dependencies.description"),
+			},
+		},
+	}
+}
+
+func (c *VersionCommand) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details:  i18n.T(locale, "msg.version.usage.details"),
+		Examples: []string{"/version", "/version changelog:True", "/version dependencies:True"},
 	}
 }
 
 func (c *VersionCommand) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	v, err := c.service.Version(ctx)
-	if err != nil {
-		log.Printf("Error handling version command: %v", err)
-		return err
+	var showChangelog, showDependencies bool
+	for _, opt := range i.ApplicationCommandData().Options {
+		switch opt.Name {
+		case "changelog":
+			showChangelog = opt.BoolValue()
+		case "dependencies":
+			showDependencies = opt.BoolValue()
+		}
+	}
+
+	info := c.service.BuildInfo()
+	unknown := commands.T(i, "msg.version.unknown")
+
+	revision := unknown
+	if info.Revision != "" {
+		revision = "`" + info.ShortRevision() + "`"
+		if info.Modified {
+			revision += " " + commands.T(i, "msg.version.modified")
+		}
+	}
+	goVersion := unknown
+	if info.GoVersion != "" {
+		goVersion = info.GoVersion
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: info.Version,
+		Color: embedColor,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: commands.T(i, "msg.version.revision"), Value: revision, Inline: true},
+			{Name: commands.T(i, "msg.version.commit_time"), Value: formatTime(info.CommitTime, unknown), Inline: true},
+			{Name: commands.T(i, "msg.version.build_time"), Value: formatTime(info.BuildTime, unknown), Inline: true},
+			{Name: commands.T(i, "msg.version.go_version"), Value: goVersion, Inline: true},
+		},
 	}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	if showDependencies {
+		embed.Fields = append(embed.Fields, dependenciesField(i, info.Dependencies))
+	}
+
+	if showChangelog {
+		if notes, ok := c.service.ReleaseNotes(); ok {
+			embed.Description = truncate(notes, maxDescriptionLength)
+		} else {
+			embed.Description = commands.T(i, "msg.version.changelog_missing", info.Version)
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: v,
+			Embeds: []*discordgo.MessageEmbed{embed},
 		},
 	})
 	if err != nil {
@@ -47,3 +127,36 @@ func (c *VersionCommand) Handle(ctx context.Context, s *discordgo.Session, i *di
 
 	return nil
 }
+
+// dependenciesField は依存モジュールの一覧を1行ずつ整形する
+func dependenciesField(i *discordgo.InteractionCreate, deps []domainversion.Dependency) *discordgo.MessageEmbedField {
+	value := commands.T(i, "msg.version.unknown")
+	if len(deps) > 0 {
+		lines := make([]string, 0, len(deps))
+		for _, dep := range deps {
+			lines = append(lines, fmt.Sprintf("`%s` %s", dep.Path, dep.Version))
+		}
+		value = truncate(strings.Join(lines, "\n"), maxFieldValueLength)
+	}
+	return &discordgo.MessageEmbedField{
+		Name:  commands.T(i, "msg.version.dependencies", len(deps)),
+		Value: value,
+	}
+}
+
+// formatTime は Discord のタイムスタンプ記法で日時を表示する（閲覧者のタイムゾーンで表示される）
+func formatTime(t time.Time, fallback string) string {
+	if t.IsZero() {
+		return fallback
+	}
+	return fmt.Sprintf("<t:%d:f>", t.Unix())
+}
+
+// truncate は文字列を rune 単位で上限までに切り詰める
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
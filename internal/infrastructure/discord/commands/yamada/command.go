@@ -2,21 +2,21 @@ package yamada
 
 import (
 	"context"
-	"fmt"
-	"log"
 
-	appyamada "github.com/aktnb/discord-bot-go/internal/application/yamada"
+	applegend "github.com/aktnb/discord-bot-go/internal/application/legend"
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	legendcmd "github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands/legend"
+	"github.com/aktnb/discord-bot-go/internal/shared/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
-const guildID = "1128971828644294666"
-
 // Command は山田嘘ニュースコマンド
 type Command struct {
-	service *appyamada.Service
+	service *applegend.Service
 }
 
-func NewYamadaCommand(service *appyamada.Service) *Command {
+func NewYamadaCommand(service *applegend.Service) *Command {
 	return &Command{service: service}
 }
 
@@ -24,35 +24,41 @@ func (c *Command) Name() string {
 	return "yamada"
 }
 
-// GuildIDs はコマンドを登録するギルド ID 一覧を返す
+// GuildIDs はデフォルトの登録先ギルドを返す
+// 山田速報は身内向けのため、登録先は /admin commands enable で個別に有効化する
 func (c *Command) GuildIDs() []string {
-	return []string{guildID}
+	return nil
 }
 
 func (c *Command) ToDiscordCommand() *discordgo.ApplicationCommand {
 	return &discordgo.ApplicationCommand{
-		Name:        c.Name(),
-		Description: "山田に関する最新ニュースをお届けします",
+		Name:                     c.Name(),
+		NameLocalizations:        commands.Localizations("command.yamada.name"),
+		Description:              commands.DefaultText("command.yamada.description"),
+		DescriptionLocalizations: commands.Localizations("command.yamada.description"),
+		Options:                  append(legendcmd.EpisodeOptions(), legendcmd.GenerateOptions()...),
 	}
 }
 
 func (c *Command) Handle(ctx context.Context, s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	episode, err := c.service.GetRandomEpisode(ctx)
-	if err != nil {
-		log.Printf("Error getting yamada episode: %v", err)
-		return err
-	}
+	return legendcmd.HandleEpisode(ctx, s, i, c.service, c.Name(), c.FormatEpisode)
+}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: fmt.Sprintf("【山田速報】\n> %s", episode.Text),
+// FormatEpisode はエピソードに【山田速報】の見出しを付ける
+func (c *Command) FormatEpisode(locale i18n.Locale, episode legend.Episode) string {
+	return i18n.T(locale, "msg.yamada.news", episode.Text)
+}
+
+func (c *Command) Usage(locale i18n.Locale) commands.Usage {
+	return commands.Usage{
+		Details: i18n.T(locale, "msg.legend.episode_usage.details") + "\n" + i18n.T(locale, "msg.legend.generate_usage.details"),
+		Examples: []string{
+			"/yamada",
+			"/yamada number:3",
+			"/yamada search:" + i18n.T(locale, "msg.legend.example.search"),
+			"/yamada daily:True",
+			"/yamada generate:True",
+			"/yamada subject:" + i18n.T(locale, "msg.legend.example.subject") + " seed:12345",
 		},
-	})
-	if err != nil {
-		log.Printf("Error responding to yamada: %v", err)
-		return err
 	}
-
-	return nil
 }
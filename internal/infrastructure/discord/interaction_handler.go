@@ -3,18 +3,22 @@ package discord
 import (
 	"context"
 	"log"
+	"strings"
 
 	"github.com/aktnb/discord-bot-go/internal/infrastructure/discord/commands"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
 	"github.com/bwmarrin/discordgo"
 )
 
 type InteractionCreateHandler struct {
-	registry *commands.CommandRegistry
+	registry  *commands.CommandRegistry
+	paginator *commands.Paginator
 }
 
-func NewInteractionCreateHandler(registry *commands.CommandRegistry) *InteractionCreateHandler {
+func NewInteractionCreateHandler(registry *commands.CommandRegistry, paginator *commands.Paginator) *InteractionCreateHandler {
 	return &InteractionCreateHandler{
-		registry: registry,
+		registry:  registry,
+		paginator: paginator,
 	}
 }
 
@@ -23,6 +27,12 @@ func (h *InteractionCreateHandler) Handle() func(*discordgo.Session, *discordgo.
 		switch i.Type {
 		case discordgo.InteractionApplicationCommand:
 			h.routeApplicationCommand(s, i)
+		case discordgo.InteractionApplicationCommandAutocomplete:
+			h.routeAutocomplete(s, i)
+		case discordgo.InteractionMessageComponent:
+			h.routeMessageComponent(s, i)
+		case discordgo.InteractionModalSubmit:
+			h.routeModalSubmit(s, i)
 		default:
 			log.Printf("Unsupported interaction type: %v", i.Type)
 		}
@@ -32,7 +42,7 @@ func (h *InteractionCreateHandler) Handle() func(*discordgo.Session, *discordgo.
 func (h *InteractionCreateHandler) routeApplicationCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	commandName := i.ApplicationCommandData().Name
 
-	cmd, ok := h.registry.GetCommand(commandName)
+	cmd, ok := h.resolve(i, commandName)
 	if !ok {
 		log.Printf("Unknown command: %s", commandName)
 		return
@@ -42,3 +52,83 @@ func (h *InteractionCreateHandler) routeApplicationCommand(s *discordgo.Session,
 		log.Printf("Error handling command %s: %v", commandName, err)
 	}
 }
+
+func (h *InteractionCreateHandler) routeAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	commandName := i.ApplicationCommandData().Name
+
+	cmd, ok := h.resolve(i, commandName)
+	if !ok {
+		log.Printf("Unknown command for autocomplete: %s", commandName)
+		return
+	}
+
+	autocompleteCmd, ok := cmd.(commands.AutocompleteCommand)
+	if !ok {
+		log.Printf("Command %s does not support autocomplete", commandName)
+		return
+	}
+
+	if err := autocompleteCmd.HandleAutocomplete(context.Background(), s, i); err != nil {
+		log.Printf("Error handling autocomplete %s: %v", commandName, err)
+	}
+}
+
+func (h *InteractionCreateHandler) routeMessageComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	commandName, _, _ := strings.Cut(customID, ":")
+
+	// ページ送りボタンはコマンドによらず共通の Paginator で処理する
+	if commandName == commands.PaginatorPrefix {
+		if err := h.paginator.HandleComponent(s, i); err != nil {
+			log.Printf("Error handling page component %s: %v", customID, err)
+		}
+		return
+	}
+
+	cmd, ok := h.resolve(i, commandName)
+	if !ok {
+		log.Printf("Unknown component: %s", customID)
+		return
+	}
+
+	componentCmd, ok := cmd.(commands.ComponentCommand)
+	if !ok {
+		log.Printf("Command %s does not handle components", commandName)
+		return
+	}
+
+	if err := componentCmd.HandleComponent(context.Background(), s, i); err != nil {
+		log.Printf("Error handling component %s: %v", customID, err)
+	}
+}
+
+func (h *InteractionCreateHandler) routeModalSubmit(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.ModalSubmitData().CustomID
+	commandName, _, _ := strings.Cut(customID, ":")
+
+	cmd, ok := h.resolve(i, commandName)
+	if !ok {
+		log.Printf("Unknown modal: %s", customID)
+		return
+	}
+
+	modalCmd, ok := cmd.(commands.ModalCommand)
+	if !ok {
+		log.Printf("Command %s does not handle modals", commandName)
+		return
+	}
+
+	if err := modalCmd.HandleModal(context.Background(), s, i); err != nil {
+		log.Printf("Error handling modal %s: %v", customID, err)
+	}
+}
+
+// resolve はインタラクションが発生したギルドで commandName が指すコマンドを返す
+func (h *InteractionCreateHandler) resolve(i *discordgo.InteractionCreate, commandName string) (commands.SlashCommand, bool) {
+	cmd, ok, err := h.registry.Resolve(context.Background(), discordid.GuildID(i.GuildID), commandName)
+	if err != nil {
+		log.Printf("Error resolving command %s: %v", commandName, err)
+		return nil, false
+	}
+	return cmd, ok
+}
@@ -26,7 +26,7 @@ func (h *ReadyHandler) Handle() func(*discordgo.Session, *discordgo.Ready) {
 		log.Println("Bot is ready.")
 
 		// Register application commands
-		if err := h.registrar.RegisterApplicationCommands(); err != nil {
+		if err := h.registrar.RegisterApplicationCommands(context.Background()); err != nil {
 			log.Printf("Warning: command registration failed: %v", err)
 			// コマンド登録失敗は警告のみで続行
 		}
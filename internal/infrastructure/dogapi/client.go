@@ -3,62 +3,130 @@ package dogapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"time"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/dog"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/httpclient"
 )
 
-const (
-	baseURL        = "https://dog.ceo/api/breeds/image/random"
-	requestTimeout = 10 * time.Second
-)
+// DefaultBaseURL は Dog API のベース URL
+const DefaultBaseURL = "https://dog.ceo/api"
 
 // apiResponse はDog APIのレスポンス構造
-type apiResponse struct {
-	Message string `json:"message"`
+// message の型はエンドポイントによって異なる
+type apiResponse[T any] struct {
+	Message T      `json:"message"`
 	Status  string `json:"status"`
 }
 
 type DogAPIClient struct {
-	httpClient *http.Client
+	client  *httpclient.Client
+	baseURL string
+	apiKey  string
 }
 
-func NewDogAPIClient() *DogAPIClient {
+// NewDogAPIClient は共通の HTTP クライアントで baseURL の Dog API を使うクライアントを生成する
+// apiKey が空でなければ x-api-key ヘッダーで送る（dog.ceo はキーが無くても利用できる）
+func NewDogAPIClient(client *httpclient.Client, baseURL, apiKey string) *DogAPIClient {
 	return &DogAPIClient{
-		httpClient: &http.Client{
-			Timeout: requestTimeout,
-		},
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
 	}
 }
 
-func (c *DogAPIClient) FetchRandomImage(ctx context.Context) (*dog.DogImage, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// FetchRandomImages はランダムな犬画像を返す
+// 犬種の ID は "hound" や、サブ犬種を含む "hound/afghan" の形式
+func (c *DogAPIClient) FetchRandomImages(ctx context.Context, breedID string, count int) ([]*dog.DogImage, error) {
+	path := "/breeds/image/random/" + strconv.Itoa(count)
+	if breedID != "" {
+		path = "/breed/" + breedID + "/images/random/" + strconv.Itoa(count)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, dog.ErrAPIUnavailable
+	var apiResp apiResponse[[]string]
+	if err := c.get(ctx, path, &apiResp); err != nil {
+		return nil, err
+	}
+	if apiResp.Status != "success" || len(apiResp.Message) == 0 {
+		return nil, dog.ErrImageNotFound
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	images := make([]*dog.DogImage, 0, len(apiResp.Message))
+	for _, imageURL := range apiResp.Message {
+		if imageURL != "" {
+			images = append(images, &dog.DogImage{URL: imageURL})
+		}
 	}
+	return images, nil
+}
 
-	var apiResp apiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+// FetchBreeds は犬種の一覧を名前順に返す
+// サブ犬種がある犬種はサブ犬種ごとに分け、"Afghan Hound" のように名前を付ける
+func (c *DogAPIClient) FetchBreeds(ctx context.Context) ([]dog.Breed, error) {
+	var apiResp apiResponse[map[string][]string]
+	if err := c.get(ctx, "/breeds/list/all", &apiResp); err != nil {
+		return nil, err
+	}
+	if apiResp.Status != "success" {
 		return nil, dog.ErrInvalidResponse
 	}
 
-	if apiResp.Status != "success" || apiResp.Message == "" {
-		return nil, dog.ErrImageNotFound
+	var breeds []dog.Breed
+	for breed, subBreeds := range apiResp.Message {
+		if len(subBreeds) == 0 {
+			breeds = append(breeds, dog.Breed{ID: breed, Name: titleCase(breed)})
+			continue
+		}
+		for _, sub := range subBreeds {
+			breeds = append(breeds, dog.Breed{ID: breed + "/" + sub, Name: titleCase(sub + " " + breed)})
+		}
+	}
+	sort.Slice(breeds, func(i, j int) bool {
+		return breeds[i].Name < breeds[j].Name
+	})
+	return breeds, nil
+}
+
+// get は path に GET リクエストを送り、JSON のレスポンスを v に読み込む
+// 上流が落ちていて最近の同じリクエストの結果が残っていれば、それを読み込む
+func (c *DogAPIClient) get(ctx context.Context, path string, v any) error {
+	header := http.Header{}
+	if c.apiKey != "" {
+		header.Set("x-api-key", c.apiKey)
 	}
 
-	return &dog.DogImage{
-		URL: apiResp.Message,
-	}, nil
+	resp, err := c.client.Get(ctx, c.baseURL+path, header)
+	if errors.Is(err, httpclient.ErrUnavailable) {
+		return dog.ErrAPIUnavailable
+	}
+	if err != nil {
+		return err
+	}
+
+	// 存在しない犬種を指定すると 404 が返る
+	if resp.StatusCode == http.StatusNotFound {
+		return dog.ErrBreedNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(resp.Body, v); err != nil {
+		return dog.ErrInvalidResponse
+	}
+	return nil
+}
+
+// titleCase は空白で区切った各単語の先頭を大文字にする
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
 }
@@ -0,0 +1,106 @@
+package dogapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/dog"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/httpclient"
+)
+
+func TestFetchRandomImages(t *testing.T) {
+	tests := []struct {
+		name     string
+		breedID  string
+		wantPath string
+	}{
+		{name: "any breed", breedID: "", wantPath: "/breeds/image/random/2"},
+		{name: "breed", breedID: "shiba", wantPath: "/breed/shiba/images/random/2"},
+		{name: "sub-breed", breedID: "hound/afghan", wantPath: "/breed/hound/afghan/images/random/2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				if got := r.Header.Get("x-api-key"); got != "secret" {
+					t.Errorf("x-api-key = %q, want %q", got, "secret")
+				}
+				w.Write([]byte(`{"message":["https://example.com/a.jpg","https://example.com/b.jpg"],"status":"success"}`))
+			}))
+			defer server.Close()
+
+			images, err := NewDogAPIClient(newHTTPClient(), server.URL, "secret").FetchRandomImages(context.Background(), tt.breedID, 2)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(images) != 2 || images[1].URL != "https://example.com/b.jpg" {
+				t.Errorf("unexpected images: %+v", images)
+			}
+		})
+	}
+}
+
+func TestFetchRandomImagesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "unknown breed", status: http.StatusNotFound, body: `{"status":"error","message":"Breed not found"}`, wantErr: dog.ErrBreedNotFound},
+		{name: "empty", status: http.StatusOK, body: `{"message":[],"status":"success"}`, wantErr: dog.ErrImageNotFound},
+		{name: "broken json", status: http.StatusOK, body: `{`, wantErr: dog.ErrInvalidResponse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewDogAPIClient(newHTTPClient(), server.URL, "").FetchRandomImages(context.Background(), "", 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFetchBreeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/breeds/list/all" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"message":{"shiba":[],"hound":["basset","afghan"]},"status":"success"}`))
+	}))
+	defer server.Close()
+
+	breeds, err := NewDogAPIClient(newHTTPClient(), server.URL, "").FetchBreeds(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []dog.Breed{
+		{ID: "hound/afghan", Name: "Afghan Hound"},
+		{ID: "hound/basset", Name: "Basset Hound"},
+		{ID: "shiba", Name: "Shiba"},
+	}
+	if !slices.Equal(breeds, want) {
+		t.Errorf("FetchBreeds() = %+v, want %+v", breeds, want)
+	}
+}
+
+// newHTTPClient は再試行の待ち時間が短く、キャッシュしない HTTP クライアントを返す
+func newHTTPClient() *httpclient.Client {
+	options := httpclient.DefaultOptions()
+	options.RetryBackoff = time.Millisecond
+	options.CacheSize = 0
+	return httpclient.New(options)
+}
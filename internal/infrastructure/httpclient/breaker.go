@@ -0,0 +1,101 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// State は回路遮断器の状態
+type State int
+
+const (
+	// StateClosed は通常どおりリクエストを送る状態
+	StateClosed State = iota
+	// StateOpen は失敗が続いたため、一定時間リクエストを送らずに失敗させる状態
+	StateOpen
+	// StateHalfOpen は待ち時間が過ぎ、1件だけ試しにリクエストを送って回復を確かめる状態
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// breaker はホストごとの回路遮断器
+// threshold 回続けて失敗すると cooldown の間リクエストを止める
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	// probing は半開状態で試しのリクエストを送っている最中かどうか
+	probing bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow はリクエストを送ってよいかを返す
+// 開状態で待ち時間が過ぎていれば半開状態に移り、1件だけ許可する
+func (b *breaker) allow(now time.Time) (ok bool, from, to State) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from = b.state
+	switch b.state {
+	case StateOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false, from, b.state
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return true, from, b.state
+	case StateHalfOpen:
+		if b.probing {
+			return false, from, b.state
+		}
+		b.probing = true
+		return true, from, b.state
+	default:
+		return true, from, b.state
+	}
+}
+
+// record はリクエストの結果を記録し、状態の変化を返す
+func (b *breaker) record(success bool, now time.Time) (from, to State) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from = b.state
+	b.probing = false
+	if success {
+		b.state, b.failures = StateClosed, 0
+		return from, b.state
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state, b.openedAt = StateOpen, now
+	}
+	return from, b.state
+}
+
+// cancel は結果を記録せずに試行を終える
+func (b *breaker) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,56 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBreaker(3, time.Minute)
+
+	allow := func(want bool) {
+		t.Helper()
+		if ok, _, _ := b.allow(now); ok != want {
+			t.Fatalf("allow() = %v, want %v (state %s)", ok, want, b.state)
+		}
+	}
+
+	// 閾値に達するまでは閉じたまま
+	for range 2 {
+		allow(true)
+		b.record(false, now)
+	}
+	allow(true)
+	// 成功すると失敗の回数を数え直す
+	b.record(true, now)
+	for range 2 {
+		allow(true)
+		b.record(false, now)
+	}
+	allow(true)
+	if from, to := b.record(false, now); from != StateClosed || to != StateOpen {
+		t.Fatalf("expected closed -> open, got %s -> %s", from, to)
+	}
+
+	// 待ち時間の間は送らない
+	allow(false)
+	now = now.Add(time.Minute)
+	// 待ち時間が過ぎたら1件だけ試す
+	if ok, from, to := b.allow(now); !ok || from != StateOpen || to != StateHalfOpen {
+		t.Fatalf("expected a probe in half-open, got ok=%v %s -> %s", ok, from, to)
+	}
+	allow(false)
+	// 試しのリクエストが失敗したら再び開く
+	if _, to := b.record(false, now); to != StateOpen {
+		t.Fatalf("expected open after failed probe, got %s", to)
+	}
+	allow(false)
+
+	now = now.Add(time.Minute)
+	allow(true)
+	if _, to := b.record(true, now); to != StateClosed {
+		t.Fatalf("expected closed after successful probe, got %s", to)
+	}
+	allow(true)
+}
@@ -0,0 +1,65 @@
+package httpclient
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// cachedResponse は成功したレスポンスの写し
+type cachedResponse struct {
+	key    string
+	header http.Header
+	body   []byte
+}
+
+// lruCache は最近成功したレスポンスを URL ごとに最大 size 件保持する
+// 上流が落ちているときの代替の応答に使う
+type lruCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newLRUCache(size int) *lruCache {
+	return &lruCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cachedResponse), true
+}
+
+func (c *lruCache) put(entry *cachedResponse) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
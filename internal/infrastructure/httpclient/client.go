@@ -0,0 +1,260 @@
+// Package httpclient は外部 API を呼び出すための共通の HTTP クライアントを提供する
+//
+// ホストごとの回路遮断器、GET の再試行、User-Agent の設定、計測用のフックと、
+// 上流が落ちているときに最近成功したレスポンスで代わりに応答するキャッシュを備える
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnavailable は上流に接続できないか、再試行してもエラーが返ったことを表す
+	ErrUnavailable = errors.New("upstream is unavailable")
+	// ErrCircuitOpen は回路遮断器が開いていてリクエストを送らなかったことを表す
+	ErrCircuitOpen = fmt.Errorf("%w: circuit breaker is open", ErrUnavailable)
+)
+
+// maxBodySize は読み込むレスポンスの本文の上限
+const maxBodySize = 10 << 20
+
+// Options はクライアントの設定
+type Options struct {
+	// UserAgent はリクエストに付ける User-Agent
+	UserAgent string
+	// Timeout は1回の試行のタイムアウト
+	Timeout time.Duration
+	// MaxRetries は接続エラーや 5xx・429 のときに再試行する回数
+	MaxRetries int
+	// RetryBackoff は最初の再試行までの待ち時間。再試行のたびに2倍にする
+	RetryBackoff time.Duration
+	// FailureThreshold は回路遮断器を開くまでに続けて失敗する回数
+	FailureThreshold int
+	// OpenDuration は回路遮断器を開いてから試しにリクエストを送るまでの時間
+	OpenDuration time.Duration
+	// CacheSize は代わりの応答に使うために保持するレスポンスの件数。0 なら保持しない
+	CacheSize int
+	// Hooks は計測用のフック
+	Hooks Hooks
+}
+
+// DefaultOptions は既定の設定を返す
+func DefaultOptions() Options {
+	return Options{
+		UserAgent:        "discord-bot-go",
+		Timeout:          10 * time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     200 * time.Millisecond,
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+		CacheSize:        64,
+	}
+}
+
+// RequestEvent は1回の試行の結果
+type RequestEvent struct {
+	Method  string
+	Host    string
+	Attempt int
+	// StatusCode はレスポンスのステータスコード。接続できなかった場合は 0
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// Hooks は計測用のフック。nil のフックは呼ばない
+type Hooks struct {
+	// OnRequest は試行ごとに呼ばれる
+	OnRequest func(RequestEvent)
+	// OnStateChange は回路遮断器の状態が変わったときに呼ばれる
+	OnStateChange func(host string, from, to State)
+	// OnFallback はキャッシュしたレスポンスで代わりに応答したときに呼ばれる
+	OnFallback func(rawURL string, cause error)
+}
+
+// LoggingHooks はリクエストをデバッグログに、回路遮断器の状態の変化と代わりの応答を警告ログに出力するフックを返す
+func LoggingHooks() Hooks {
+	return Hooks{
+		OnRequest: func(e RequestEvent) {
+			log.Printf("[DEBUG] HTTP %s %s attempt=%d status=%d duration=%s err=%v", e.Method, e.Host, e.Attempt, e.StatusCode, e.Duration, e.Err)
+		},
+		OnStateChange: func(host string, from, to State) {
+			log.Printf("[WARN] Circuit breaker for %s changed: %s -> %s", host, from, to)
+		},
+		OnFallback: func(rawURL string, cause error) {
+			log.Printf("[WARN] Serving cached response for %s: %v", rawURL, cause)
+		},
+	}
+}
+
+// Response は読み込み済みのレスポンス
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Stale は上流に接続できず、キャッシュしたレスポンスで代わりに応答したかどうか
+	Stale bool
+}
+
+// Client は外部 API 用の HTTP クライアント
+type Client struct {
+	httpClient *http.Client
+	options    Options
+	cache      *lruCache
+	now        func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+func New(options Options) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: options.Timeout},
+		options:    options,
+		cache:      newLRUCache(options.CacheSize),
+		now:        time.Now,
+		breakers:   make(map[string]*breaker),
+	}
+}
+
+// Get は rawURL に GET リクエストを送り、本文を読み込んだレスポンスを返す
+// 接続エラーと 5xx・429 は再試行し、それでも失敗した場合やホストの回路遮断器が開いている場合は、
+// 同じ URL で最近成功したレスポンスがあればそれを返す（Stale が true になる）。無ければ ErrUnavailable を返す
+// 4xx などのそれ以外のステータスはそのまま返す
+func (c *Client) Get(ctx context.Context, rawURL string, header http.Header) (*Response, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+
+	resp, err := c.getWithRetry(ctx, parsed, header)
+	if err == nil {
+		if resp.StatusCode == http.StatusOK {
+			c.cache.put(&cachedResponse{key: rawURL, header: resp.Header, body: resp.Body})
+		}
+		return resp, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+
+	if cached, ok := c.cache.get(rawURL); ok {
+		if c.options.Hooks.OnFallback != nil {
+			c.options.Hooks.OnFallback(rawURL, err)
+		}
+		return &Response{StatusCode: http.StatusOK, Header: cached.header, Body: cached.body, Stale: true}, nil
+	}
+	return nil, err
+}
+
+func (c *Client) getWithRetry(ctx context.Context, u *url.URL, header http.Header) (*Response, error) {
+	b := c.breaker(u.Host)
+	backoff := c.options.RetryBackoff
+
+	var lastErr error
+	for attempt := 1; attempt <= c.options.MaxRetries+1; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		ok, from, to := b.allow(c.now())
+		c.stateChanged(u.Host, from, to)
+		if !ok {
+			return nil, ErrCircuitOpen
+		}
+
+		resp, err := c.do(ctx, u, header, attempt)
+		// 呼び出し元の取り消しは上流の失敗として数えない
+		if ctx.Err() != nil {
+			b.cancel()
+			return nil, ctx.Err()
+		}
+		if err == nil && !retryable(resp.StatusCode) {
+			from, to = b.record(true, c.now())
+			c.stateChanged(u.Host, from, to)
+			return resp, nil
+		}
+		from, to = b.record(false, c.now())
+		c.stateChanged(u.Host, from, to)
+
+		if err != nil {
+			lastErr = fmt.Errorf("%w: %v", ErrUnavailable, err)
+		} else {
+			lastErr = fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+		}
+	}
+	return nil, lastErr
+}
+
+// do は1回の試行を行い、本文を読み込む
+func (c *Client) do(ctx context.Context, u *url.URL, header http.Header, attempt int) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if c.options.UserAgent != "" {
+		req.Header.Set("User-Agent", c.options.UserAgent)
+	}
+
+	started := c.now()
+	resp, err := c.httpClient.Do(req)
+	var result *Response
+	if err == nil {
+		var body []byte
+		body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+		resp.Body.Close()
+		result = &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+	}
+
+	if c.options.Hooks.OnRequest != nil {
+		event := RequestEvent{Method: http.MethodGet, Host: u.Host, Attempt: attempt, Err: err, Duration: c.now().Sub(started)}
+		if result != nil {
+			event.StatusCode = result.StatusCode
+		}
+		c.options.Hooks.OnRequest(event)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// breaker はホストの回路遮断器を返す
+func (c *Client) breaker(host string) *breaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[host]
+	if !ok {
+		b = newBreaker(c.options.FailureThreshold, c.options.OpenDuration)
+		c.breakers[host] = b
+	}
+	return b
+}
+
+func (c *Client) stateChanged(host string, from, to State) {
+	if from != to && c.options.Hooks.OnStateChange != nil {
+		c.options.Hooks.OnStateChange(host, from, to)
+	}
+}
+
+// retryable は再試行すべきステータスコードかを返す
+func retryable(status int) bool {
+	return status >= http.StatusInternalServerError || status == http.StatusTooManyRequests
+}
@@ -0,0 +1,151 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testOptions() Options {
+	options := DefaultOptions()
+	options.UserAgent = "test-agent"
+	options.RetryBackoff = time.Millisecond
+	options.FailureThreshold = 3
+	options.OpenDuration = time.Hour
+	return options
+}
+
+func TestGetRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != "test-agent" {
+			t.Errorf("User-Agent = %q, want %q", got, "test-agent")
+		}
+		if got := r.Header.Get("x-api-key"); got != "secret" {
+			t.Errorf("x-api-key = %q, want %q", got, "secret")
+		}
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var events []RequestEvent
+	options := testOptions()
+	options.Hooks.OnRequest = func(e RequestEvent) { events = append(events, e) }
+
+	resp, err := New(options).Get(context.Background(), server.URL, http.Header{"X-Api-Key": {"secret"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(resp.Body) != "ok" || resp.Stale {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if len(events) != 3 || events[0].StatusCode != http.StatusServiceUnavailable || events[2].Attempt != 3 {
+		t.Errorf("unexpected events: %+v", events)
+	}
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	resp, err := New(testOptions()).Get(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || calls.Load() != 1 {
+		t.Errorf("expected a single 404, got status=%d calls=%d", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestGetFallsBackToCache(t *testing.T) {
+	var down atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	var fallbacks int
+	options := testOptions()
+	options.Hooks.OnFallback = func(string, error) { fallbacks++ }
+	client := New(options)
+	ctx := context.Background()
+
+	if _, err := client.Get(ctx, server.URL+"/a", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	down.Store(true)
+	resp, err := client.Get(ctx, server.URL+"/a", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Stale || string(resp.Body) != "image" || fallbacks != 1 {
+		t.Errorf("expected cached response, got %+v (fallbacks=%d)", resp, fallbacks)
+	}
+
+	// キャッシュが無ければエラーになる
+	if _, err := client.Get(ctx, server.URL+"/b", nil); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
+
+func TestGetOpensCircuit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var changes []State
+	options := testOptions()
+	options.Hooks.OnStateChange = func(host string, from, to State) { changes = append(changes, to) }
+	client := New(options)
+
+	// 再試行を含めて3回続けて失敗すると回路が開く
+	if _, err := client.Get(context.Background(), server.URL, nil); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable, got %v", err)
+	}
+	if _, err := client.Get(context.Background(), server.URL, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls before the circuit opened, got %d", calls.Load())
+	}
+	if len(changes) != 1 || changes[0] != StateOpen {
+		t.Errorf("unexpected state changes: %v", changes)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
+	cache.put(&cachedResponse{key: "a"})
+	cache.put(&cachedResponse{key: "b"})
+	cache.get("a")
+	cache.put(&cachedResponse{key: "c"})
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+}
@@ -2,54 +2,52 @@ package mahjongapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"time"
 
 	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/httpclient"
 )
 
-const (
-	baseURL        = "https://mahjong-api.vercel.app/api/starting-hand"
-	requestTimeout = 10 * time.Second
-)
+// DefaultURL は配牌画像を返す API の URL
+const DefaultURL = "https://mahjong-api.vercel.app/api/starting-hand"
 
 type MahjongAPIClient struct {
-	httpClient *http.Client
+	client *httpclient.Client
+	url    string
 }
 
-func NewMahjongAPIClient() *MahjongAPIClient {
+// NewMahjongAPIClient は共通の HTTP クライアントで url の API を使うクライアントを生成する
+func NewMahjongAPIClient(client *httpclient.Client, url string) *MahjongAPIClient {
 	return &MahjongAPIClient{
-		httpClient: &http.Client{
-			Timeout: requestTimeout,
-		},
+		client: client,
+		url:    url,
 	}
 }
 
-func (c *MahjongAPIClient) FetchRandomStartingHand(ctx context.Context) (*mahjong.MahjongStartingHand, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+// SupportsDealOptions は API が配牌の条件を指定できないので false を返す
+func (c *MahjongAPIClient) SupportsDealOptions() bool {
+	return false
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+// FetchRandomStartingHand は配牌画像を返す
+// API は配牌の条件を指定できないため options は無視し、牌も分からないため Tiles は nil になる
+// 上流が落ちていれば、最近取得した配牌画像を代わりに返す
+func (c *MahjongAPIClient) FetchRandomStartingHand(ctx context.Context, options mahjong.DealOptions) (*mahjong.MahjongStartingHand, error) {
+	resp, err := c.client.Get(ctx, c.url, nil)
+	if errors.Is(err, httpclient.ErrUnavailable) {
 		return nil, mahjong.ErrAPIUnavailable
 	}
-	defer resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
 
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	// 画像バイナリデータ全体を読み込む
-	imageData, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if len(imageData) == 0 {
+	if len(resp.Body) == 0 {
 		return nil, mahjong.ErrImageNotFound
 	}
 
@@ -60,7 +58,7 @@ func (c *MahjongAPIClient) FetchRandomStartingHand(ctx context.Context) (*mahjon
 	}
 
 	return &mahjong.MahjongStartingHand{
-		ImageData:   imageData,
+		ImageData:   resp.Body,
 		ContentType: contentType,
 	}, nil
 }
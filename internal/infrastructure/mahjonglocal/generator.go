@@ -0,0 +1,50 @@
+// Package mahjonglocal は外部 API を使わずに配牌を生成し、画像を描画する
+package mahjonglocal
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/infrastructure/tileimage"
+)
+
+// Generator は 136 枚の山を混ぜて配牌し、埋め込んだ牌の画像で描画する
+type Generator struct {
+	renderer *tileimage.Renderer
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func NewGenerator(renderer *tileimage.Renderer) *Generator {
+	return &Generator{
+		renderer: renderer,
+		rng:      rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// SupportsDealOptions は山から配るので、枚数と赤ドラの指定に従う
+func (g *Generator) SupportsDealOptions() bool {
+	return true
+}
+
+func (g *Generator) FetchRandomStartingHand(ctx context.Context, options mahjong.DealOptions) (*mahjong.MahjongStartingHand, error) {
+	g.mu.Lock()
+	hand, err := mahjong.Deal(g.rng, options)
+	g.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	imageData, err := g.renderer.RenderPNG(hand)
+	if err != nil {
+		return nil, err
+	}
+	return &mahjong.MahjongStartingHand{
+		ImageData:   imageData,
+		ContentType: "image/png",
+		Tiles:       hand,
+	}, nil
+}
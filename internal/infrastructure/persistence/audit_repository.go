@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/audit"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+)
+
+type AuditEntryRepositoryFactory struct{}
+
+func NewAuditEntryRepositoryFactory() *AuditEntryRepositoryFactory {
+	return &AuditEntryRepositoryFactory{}
+}
+
+func (f *AuditEntryRepositoryFactory) AuditEntry(tx db.Tx) audit.Repository {
+	return NewAuditEntryRepository(&tx)
+}
+
+type AuditEntryRepository struct {
+	tx db.Tx
+}
+
+func NewAuditEntryRepository(tx *db.Tx) *AuditEntryRepository {
+	return &AuditEntryRepository{
+		tx: *tx,
+	}
+}
+
+func (r *AuditEntryRepository) Save(ctx context.Context, entry *audit.Entry) error {
+	query := `
+		INSERT INTO admin_audit_logs (id, user_id, guild_id, action, arguments, outcome, detail, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.tx.Exec(ctx, query,
+		string(entry.ID()),
+		string(entry.UserID()),
+		string(entry.GuildID()),
+		entry.Action(),
+		entry.Arguments(),
+		string(entry.Outcome()),
+		entry.Detail(),
+		entry.CreatedAt(),
+	)
+	return err
+}
@@ -0,0 +1,194 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/guildcommand"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type GuildCommandSettingRepositoryFactory struct{}
+
+func NewGuildCommandSettingRepositoryFactory() *GuildCommandSettingRepositoryFactory {
+	return &GuildCommandSettingRepositoryFactory{}
+}
+
+func (f *GuildCommandSettingRepositoryFactory) GuildCommandSetting(tx db.Tx) guildcommand.Repository {
+	return NewGuildCommandSettingRepository(&tx)
+}
+
+type GuildCommandSettingRepository struct {
+	tx db.Tx
+}
+
+func NewGuildCommandSettingRepository(tx *db.Tx) *GuildCommandSettingRepository {
+	return &GuildCommandSettingRepository{
+		tx: *tx,
+	}
+}
+
+func (r *GuildCommandSettingRepository) Find(ctx context.Context, guildID discordid.GuildID, commandName string) (*guildcommand.Setting, error) {
+	query := `
+		SELECT guild_id, command_name, enabled, created_at, updated_at
+		FROM guild_command_settings
+		WHERE guild_id = $1 AND command_name = $2
+	`
+
+	setting, err := scanGuildCommandSetting(r.tx.QueryRow(ctx, query, string(guildID), commandName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, guildcommand.ErrSettingNotFound
+		}
+		return nil, err
+	}
+	return setting, nil
+}
+
+func (r *GuildCommandSettingRepository) FindByCommand(ctx context.Context, commandName string) ([]*guildcommand.Setting, error) {
+	query := `
+		SELECT guild_id, command_name, enabled, created_at, updated_at
+		FROM guild_command_settings
+		WHERE command_name = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.tx.Query(ctx, query, commandName)
+	if err != nil {
+		return nil, err
+	}
+	return collectGuildCommandSettings(rows)
+}
+
+func (r *GuildCommandSettingRepository) FindAll(ctx context.Context) ([]*guildcommand.Setting, error) {
+	query := `
+		SELECT guild_id, command_name, enabled, created_at, updated_at
+		FROM guild_command_settings
+		ORDER BY created_at
+	`
+
+	rows, err := r.tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return collectGuildCommandSettings(rows)
+}
+
+func (r *GuildCommandSettingRepository) Save(ctx context.Context, setting *guildcommand.Setting) error {
+	query := `
+		INSERT INTO guild_command_settings (guild_id, command_name, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (guild_id, command_name) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.tx.Exec(ctx, query,
+		string(setting.GuildID()),
+		setting.CommandName(),
+		setting.Enabled(),
+		setting.CreatedAt(),
+		setting.UpdatedAt(),
+	)
+	return err
+}
+
+func scanGuildCommandSetting(row db.Row) (*guildcommand.Setting, error) {
+	var (
+		dbGuildID     string
+		dbCommandName string
+		dbEnabled     bool
+		dbCreatedAt   time.Time
+		dbUpdatedAt   time.Time
+	)
+
+	if err := row.Scan(&dbGuildID, &dbCommandName, &dbEnabled, &dbCreatedAt, &dbUpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return guildcommand.RebuildSetting(
+		discordid.GuildID(dbGuildID),
+		dbCommandName,
+		dbEnabled,
+		dbCreatedAt,
+		dbUpdatedAt,
+	)
+}
+
+func collectGuildCommandSettings(rows db.Rows) ([]*guildcommand.Setting, error) {
+	defer rows.Close()
+
+	var settings []*guildcommand.Setting
+	for rows.Next() {
+		setting, err := scanGuildCommandSetting(rows)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, setting)
+	}
+
+	return settings, rows.Err()
+}
+
+func (f *GuildCommandSettingRepositoryFactory) GuildCommandRegistration(tx db.Tx) guildcommand.RegistrationRepository {
+	return NewGuildCommandRegistrationRepository(&tx)
+}
+
+type GuildCommandRegistrationRepository struct {
+	tx db.Tx
+}
+
+func NewGuildCommandRegistrationRepository(tx *db.Tx) *GuildCommandRegistrationRepository {
+	return &GuildCommandRegistrationRepository{
+		tx: *tx,
+	}
+}
+
+func (r *GuildCommandRegistrationRepository) FindAll(ctx context.Context) ([]discordid.GuildID, error) {
+	query := `
+		SELECT guild_id
+		FROM guild_command_registrations
+		ORDER BY registered_at
+	`
+
+	rows, err := r.tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var guildIDs []discordid.GuildID
+	for rows.Next() {
+		var dbGuildID string
+		if err := rows.Scan(&dbGuildID); err != nil {
+			return nil, err
+		}
+		guildIDs = append(guildIDs, discordid.GuildID(dbGuildID))
+	}
+
+	return guildIDs, rows.Err()
+}
+
+func (r *GuildCommandRegistrationRepository) Save(ctx context.Context, guildID discordid.GuildID) error {
+	query := `
+		INSERT INTO guild_command_registrations (guild_id)
+		VALUES ($1)
+		ON CONFLICT (guild_id) DO NOTHING
+	`
+
+	_, err := r.tx.Exec(ctx, query, string(guildID))
+	return err
+}
+
+func (r *GuildCommandRegistrationRepository) Delete(ctx context.Context, guildID discordid.GuildID) error {
+	query := `
+		DELETE FROM guild_command_registrations
+		WHERE guild_id = $1
+	`
+
+	_, err := r.tx.Exec(ctx, query, string(guildID))
+	return err
+}
@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type LegendDefinitionRepositoryFactory struct{}
+
+func NewLegendDefinitionRepositoryFactory() *LegendDefinitionRepositoryFactory {
+	return &LegendDefinitionRepositoryFactory{}
+}
+
+func (f *LegendDefinitionRepositoryFactory) LegendDefinition(tx db.Tx) legend.DefinitionRepository {
+	return NewLegendDefinitionRepository(&tx)
+}
+
+type LegendDefinitionRepository struct {
+	tx db.Tx
+}
+
+func NewLegendDefinitionRepository(tx *db.Tx) *LegendDefinitionRepository {
+	return &LegendDefinitionRepository{
+		tx: *tx,
+	}
+}
+
+func (r *LegendDefinitionRepository) FindAll(ctx context.Context) ([]*legend.Definition, error) {
+	query := `
+		SELECT guild_id, name, description, prefix, created_by, created_at
+		FROM legend_definitions
+		ORDER BY guild_id, name
+	`
+
+	rows, err := r.tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return collectLegendDefinitions(rows)
+}
+
+func (r *LegendDefinitionRepository) FindByGuild(ctx context.Context, guildID discordid.GuildID) ([]*legend.Definition, error) {
+	query := `
+		SELECT guild_id, name, description, prefix, created_by, created_at
+		FROM legend_definitions
+		WHERE guild_id = $1
+		ORDER BY name
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(guildID))
+	if err != nil {
+		return nil, err
+	}
+	return collectLegendDefinitions(rows)
+}
+
+func (r *LegendDefinitionRepository) FindByName(ctx context.Context, guildID discordid.GuildID, name string) (*legend.Definition, error) {
+	query := `
+		SELECT guild_id, name, description, prefix, created_by, created_at
+		FROM legend_definitions
+		WHERE guild_id = $1 AND name = $2
+	`
+
+	definition, err := scanLegendDefinition(r.tx.QueryRow(ctx, query, string(guildID), name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, legend.ErrDefinitionNotFound
+		}
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (r *LegendDefinitionRepository) Create(ctx context.Context, definition *legend.Definition) error {
+	query := `
+		INSERT INTO legend_definitions (guild_id, name, description, prefix, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (guild_id, name) DO NOTHING
+	`
+
+	tag, err := r.tx.Exec(ctx, query,
+		string(definition.GuildID()),
+		definition.Name(),
+		definition.Description(),
+		definition.Prefix(),
+		string(definition.CreatedBy()),
+		definition.CreatedAt(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return legend.ErrLegendExists
+	}
+	return nil
+}
+
+func (r *LegendDefinitionRepository) Delete(ctx context.Context, guildID discordid.GuildID, name string) error {
+	query := `
+		DELETE FROM legend_definitions
+		WHERE guild_id = $1 AND name = $2
+	`
+
+	tag, err := r.tx.Exec(ctx, query, string(guildID), name)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return legend.ErrDefinitionNotFound
+	}
+	return nil
+}
+
+func scanLegendDefinition(row db.Row) (*legend.Definition, error) {
+	var (
+		dbGuildID     string
+		dbName        string
+		dbDescription string
+		dbPrefix      string
+		dbCreatedBy   string
+		dbCreatedAt   time.Time
+	)
+
+	if err := row.Scan(&dbGuildID, &dbName, &dbDescription, &dbPrefix, &dbCreatedBy, &dbCreatedAt); err != nil {
+		return nil, err
+	}
+
+	return legend.RebuildDefinition(
+		discordid.GuildID(dbGuildID),
+		dbName,
+		dbDescription,
+		dbPrefix,
+		discordid.UserID(dbCreatedBy),
+		dbCreatedAt,
+	)
+}
+
+func collectLegendDefinitions(rows db.Rows) ([]*legend.Definition, error) {
+	defer rows.Close()
+
+	var definitions []*legend.Definition
+	for rows.Next() {
+		definition, err := scanLegendDefinition(rows)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, rows.Err()
+}
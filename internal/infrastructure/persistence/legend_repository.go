@@ -0,0 +1,198 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type LegendEntryRepositoryFactory struct{}
+
+func NewLegendEntryRepositoryFactory() *LegendEntryRepositoryFactory {
+	return &LegendEntryRepositoryFactory{}
+}
+
+func (f *LegendEntryRepositoryFactory) LegendEntry(tx db.Tx) legend.Repository {
+	return NewLegendEntryRepository(&tx)
+}
+
+type LegendEntryRepository struct {
+	tx db.Tx
+}
+
+func NewLegendEntryRepository(tx *db.Tx) *LegendEntryRepository {
+	return &LegendEntryRepository{
+		tx: *tx,
+	}
+}
+
+func (r *LegendEntryRepository) FindByID(ctx context.Context, id legend.EntryID) (*legend.Entry, error) {
+	query := `
+		SELECT id, legend_guild_id, legend, number, text, status, author_id, guild_id, reviewer_id, created_at, reviewed_at
+		FROM legend_entries
+		WHERE id = $1
+	`
+
+	entry, err := scanLegendEntry(r.tx.QueryRow(ctx, query, string(id)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, legend.ErrEntryNotFound
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (r *LegendEntryRepository) FindApproved(ctx context.Context, ref legend.Ref) ([]*legend.Entry, error) {
+	query := `
+		SELECT id, legend_guild_id, legend, number, text, status, author_id, guild_id, reviewer_id, created_at, reviewed_at
+		FROM legend_entries
+		WHERE legend_guild_id = $1 AND legend = $2 AND status = $3
+		ORDER BY number
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(ref.GuildID), ref.Name, string(legend.StatusApproved))
+	if err != nil {
+		return nil, err
+	}
+	return collectLegendEntries(rows)
+}
+
+func (r *LegendEntryRepository) FindPending(ctx context.Context) ([]*legend.Entry, error) {
+	query := `
+		SELECT id, legend_guild_id, legend, number, text, status, author_id, guild_id, reviewer_id, created_at, reviewed_at
+		FROM legend_entries
+		WHERE status = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(legend.StatusPending))
+	if err != nil {
+		return nil, err
+	}
+	return collectLegendEntries(rows)
+}
+
+func (r *LegendEntryRepository) MaxNumber(ctx context.Context, ref legend.Ref) (int, error) {
+	query := `
+		SELECT COALESCE(MAX(number), 0)
+		FROM legend_entries
+		WHERE legend_guild_id = $1 AND legend = $2
+	`
+
+	var number int
+	if err := r.tx.QueryRow(ctx, query, string(ref.GuildID), ref.Name).Scan(&number); err != nil {
+		return 0, err
+	}
+	return number, nil
+}
+
+func (r *LegendEntryRepository) Save(ctx context.Context, entry *legend.Entry) error {
+	query := `
+		INSERT INTO legend_entries (id, legend_guild_id, legend, number, text, status, author_id, guild_id, reviewer_id, created_at, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET
+			number = EXCLUDED.number,
+			status = EXCLUDED.status,
+			reviewer_id = EXCLUDED.reviewer_id,
+			reviewed_at = EXCLUDED.reviewed_at
+	`
+
+	var number *int
+	if entry.Number() > 0 {
+		n := entry.Number()
+		number = &n
+	}
+	var reviewedAt *time.Time
+	if !entry.ReviewedAt().IsZero() {
+		t := entry.ReviewedAt()
+		reviewedAt = &t
+	}
+
+	_, err := r.tx.Exec(ctx, query,
+		string(entry.ID()),
+		string(entry.Legend().GuildID),
+		entry.Legend().Name,
+		number,
+		entry.Text(),
+		string(entry.Status()),
+		string(entry.AuthorID()),
+		string(entry.GuildID()),
+		string(entry.ReviewerID()),
+		entry.CreatedAt(),
+		reviewedAt,
+	)
+	return err
+}
+
+func (r *LegendEntryRepository) DeleteByLegend(ctx context.Context, ref legend.Ref) error {
+	query := `
+		DELETE FROM legend_entries
+		WHERE legend_guild_id = $1 AND legend = $2
+	`
+
+	_, err := r.tx.Exec(ctx, query, string(ref.GuildID), ref.Name)
+	return err
+}
+
+func scanLegendEntry(row db.Row) (*legend.Entry, error) {
+	var (
+		dbID            string
+		dbLegendGuildID string
+		dbLegend        string
+		dbNumber        *int
+		dbText          string
+		dbStatus        string
+		dbAuthorID      string
+		dbGuildID       string
+		dbReviewerID    string
+		dbCreatedAt     time.Time
+		dbReviewedAt    *time.Time
+	)
+
+	if err := row.Scan(&dbID, &dbLegendGuildID, &dbLegend, &dbNumber, &dbText, &dbStatus, &dbAuthorID, &dbGuildID, &dbReviewerID, &dbCreatedAt, &dbReviewedAt); err != nil {
+		return nil, err
+	}
+
+	var number int
+	if dbNumber != nil {
+		number = *dbNumber
+	}
+	var reviewedAt time.Time
+	if dbReviewedAt != nil {
+		reviewedAt = *dbReviewedAt
+	}
+
+	return legend.RebuildEntry(
+		legend.EntryID(dbID),
+		legend.Ref{GuildID: discordid.GuildID(dbLegendGuildID), Name: dbLegend},
+		number,
+		dbText,
+		legend.Status(dbStatus),
+		discordid.UserID(dbAuthorID),
+		discordid.GuildID(dbGuildID),
+		discordid.UserID(dbReviewerID),
+		dbCreatedAt,
+		reviewedAt,
+	)
+}
+
+func collectLegendEntries(rows db.Rows) ([]*legend.Entry, error) {
+	defer rows.Close()
+
+	var entries []*legend.Entry
+	for rows.Next() {
+		entry, err := scanLegendEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/legend"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type LegendRotationRepositoryFactory struct{}
+
+func NewLegendRotationRepositoryFactory() *LegendRotationRepositoryFactory {
+	return &LegendRotationRepositoryFactory{}
+}
+
+func (f *LegendRotationRepositoryFactory) LegendRotation(tx db.Tx) legend.RotationRepository {
+	return NewLegendRotationRepository(&tx)
+}
+
+type LegendRotationRepository struct {
+	tx db.Tx
+}
+
+func NewLegendRotationRepository(tx *db.Tx) *LegendRotationRepository {
+	return &LegendRotationRepository{
+		tx: *tx,
+	}
+}
+
+func (r *LegendRotationRepository) Find(ctx context.Context, guildID discordid.GuildID, ref legend.Ref) (*legend.Rotation, error) {
+	query := `
+		SELECT seen, updated_at
+		FROM legend_rotations
+		WHERE guild_id = $1 AND legend_guild_id = $2 AND legend = $3
+	`
+
+	var (
+		dbSeen      []int
+		dbUpdatedAt time.Time
+	)
+	err := r.tx.QueryRow(ctx, query, string(guildID), string(ref.GuildID), ref.Name).Scan(&dbSeen, &dbUpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, legend.ErrRotationNotFound
+		}
+		return nil, err
+	}
+
+	return legend.RebuildRotation(guildID, ref, dbSeen, dbUpdatedAt)
+}
+
+func (r *LegendRotationRepository) Save(ctx context.Context, rotation *legend.Rotation) error {
+	query := `
+		INSERT INTO legend_rotations (guild_id, legend_guild_id, legend, seen, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (guild_id, legend_guild_id, legend) DO UPDATE SET
+			seen = EXCLUDED.seen,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	seen := rotation.Seen()
+	if seen == nil {
+		seen = []int{}
+	}
+	_, err := r.tx.Exec(ctx, query,
+		string(rotation.GuildID()),
+		string(rotation.Legend().GuildID),
+		rotation.Legend().Name,
+		seen,
+		rotation.UpdatedAt(),
+	)
+	return err
+}
+
+func (r *LegendRotationRepository) DeleteByLegend(ctx context.Context, ref legend.Ref) error {
+	query := `
+		DELETE FROM legend_rotations
+		WHERE legend_guild_id = $1 AND legend = $2
+	`
+
+	_, err := r.tx.Exec(ctx, query, string(ref.GuildID), ref.Name)
+	return err
+}
@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type MahjongQuizRepositoryFactory struct{}
+
+func NewMahjongQuizRepositoryFactory() *MahjongQuizRepositoryFactory {
+	return &MahjongQuizRepositoryFactory{}
+}
+
+func (f *MahjongQuizRepositoryFactory) QuizRound(tx db.Tx) mahjong.QuizRepository {
+	return NewMahjongQuizRepository(&tx)
+}
+
+type MahjongQuizRepository struct {
+	tx db.Tx
+}
+
+func NewMahjongQuizRepository(tx *db.Tx) *MahjongQuizRepository {
+	return &MahjongQuizRepository{
+		tx: *tx,
+	}
+}
+
+func (r *MahjongQuizRepository) FindByID(ctx context.Context, id mahjong.QuizRoundID) (*mahjong.QuizRound, error) {
+	query := `
+		SELECT id, guild_id, user_id, hand, discard, points, correct, answered_at, created_at
+		FROM mahjong_quiz_rounds
+		WHERE id = $1
+	`
+
+	round, err := scanQuizRound(r.tx.QueryRow(ctx, query, string(id)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, mahjong.ErrQuizRoundNotFound
+		}
+		return nil, err
+	}
+	return round, nil
+}
+
+func (r *MahjongQuizRepository) Save(ctx context.Context, round *mahjong.QuizRound) error {
+	query := `
+		INSERT INTO mahjong_quiz_rounds (id, guild_id, user_id, hand, discard, points, correct, answered_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			discard = EXCLUDED.discard,
+			points = EXCLUDED.points,
+			correct = EXCLUDED.correct,
+			answered_at = EXCLUDED.answered_at
+	`
+
+	var (
+		discard    *int
+		answeredAt *time.Time
+	)
+	if round.Answered() {
+		kind := int(round.Discard())
+		discard = &kind
+		t := round.AnsweredAt()
+		answeredAt = &t
+	}
+
+	_, err := r.tx.Exec(ctx, query,
+		string(round.ID()),
+		string(round.GuildID()),
+		string(round.UserID()),
+		round.Hand().String(),
+		discard,
+		round.Points(),
+		round.Correct(),
+		answeredAt,
+		round.CreatedAt(),
+	)
+	return err
+}
+
+func (r *MahjongQuizRepository) Ranking(ctx context.Context, guildID discordid.GuildID, limit int) ([]mahjong.QuizStanding, error) {
+	query := `
+		SELECT user_id, SUM(points), COUNT(*), COUNT(*) FILTER (WHERE correct)
+		FROM mahjong_quiz_rounds
+		WHERE guild_id = $1 AND answered_at IS NOT NULL
+		GROUP BY user_id
+		ORDER BY SUM(points) DESC, COUNT(*) FILTER (WHERE correct) DESC, user_id
+		LIMIT $2
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(guildID), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var standings []mahjong.QuizStanding
+	for rows.Next() {
+		var (
+			dbUserID string
+			standing mahjong.QuizStanding
+		)
+		if err := rows.Scan(&dbUserID, &standing.Points, &standing.Answered, &standing.Correct); err != nil {
+			return nil, err
+		}
+		standing.UserID = discordid.UserID(dbUserID)
+		standings = append(standings, standing)
+	}
+
+	return standings, rows.Err()
+}
+
+func scanQuizRound(row db.Row) (*mahjong.QuizRound, error) {
+	var (
+		dbID         string
+		dbGuildID    string
+		dbUserID     string
+		dbHand       string
+		dbDiscard    *int
+		dbPoints     int
+		dbCorrect    bool
+		dbAnsweredAt *time.Time
+		dbCreatedAt  time.Time
+	)
+
+	if err := row.Scan(&dbID, &dbGuildID, &dbUserID, &dbHand, &dbDiscard, &dbPoints, &dbCorrect, &dbAnsweredAt, &dbCreatedAt); err != nil {
+		return nil, err
+	}
+
+	hand, err := mahjong.ParseHand(dbHand)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		discard    mahjong.Kind
+		answeredAt time.Time
+	)
+	if dbDiscard != nil {
+		discard = mahjong.Kind(*dbDiscard)
+	}
+	if dbAnsweredAt != nil {
+		answeredAt = *dbAnsweredAt
+	}
+
+	return mahjong.RebuildQuizRound(
+		mahjong.QuizRoundID(dbID),
+		discordid.GuildID(dbGuildID),
+		discordid.UserID(dbUserID),
+		hand,
+		dbCreatedAt,
+		discard,
+		dbPoints,
+		dbCorrect,
+		answeredAt,
+	)
+}
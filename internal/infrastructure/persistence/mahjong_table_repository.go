@@ -0,0 +1,260 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+)
+
+type MahjongTableRepositoryFactory struct{}
+
+func NewMahjongTableRepositoryFactory() *MahjongTableRepositoryFactory {
+	return &MahjongTableRepositoryFactory{}
+}
+
+func (f *MahjongTableRepositoryFactory) Table(tx db.Tx) mahjong.TableRepository {
+	return NewMahjongTableRepository(&tx)
+}
+
+type MahjongTableRepository struct {
+	tx db.Tx
+}
+
+func NewMahjongTableRepository(tx *db.Tx) *MahjongTableRepository {
+	return &MahjongTableRepository{
+		tx: *tx,
+	}
+}
+
+const selectMahjongTables = `
+	SELECT id, guild_id, channel_id, players, starting_points, return_points, uma, status, created_by, created_at, finished_at
+	FROM mahjong_tables
+`
+
+// tableRow は局の結果を読み込む前の対局の行
+type tableRow struct {
+	id             string
+	guildID        string
+	channelID      string
+	players        []string
+	startingPoints int
+	returnPoints   int
+	uma            []int
+	status         string
+	createdBy      string
+	createdAt      time.Time
+	finishedAt     *time.Time
+}
+
+func (r *MahjongTableRepository) FindByID(ctx context.Context, id mahjong.TableID) (*mahjong.Table, error) {
+	return r.findOne(ctx, selectMahjongTables+`WHERE id = $1`, string(id))
+}
+
+func (r *MahjongTableRepository) FindPlayingByChannel(ctx context.Context, guildID discordid.GuildID, channelID discordid.TextChannelID) (*mahjong.Table, error) {
+	return r.findOne(ctx, selectMahjongTables+`WHERE guild_id = $1 AND channel_id = $2 AND status = 'playing'`, string(guildID), string(channelID))
+}
+
+func (r *MahjongTableRepository) FindFinishedByGuild(ctx context.Context, guildID discordid.GuildID) ([]*mahjong.Table, error) {
+	rows, err := r.tx.Query(ctx, selectMahjongTables+`WHERE guild_id = $1 AND status = 'finished' ORDER BY finished_at`, string(guildID))
+	if err != nil {
+		return nil, err
+	}
+	tableRows, err := collectTableRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	return r.rebuild(ctx, tableRows)
+}
+
+func (r *MahjongTableRepository) Save(ctx context.Context, table *mahjong.Table) error {
+	query := `
+		INSERT INTO mahjong_tables (id, guild_id, channel_id, players, starting_points, return_points, uma, status, created_by, created_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			finished_at = EXCLUDED.finished_at
+	`
+
+	players := make([]string, len(table.Players()))
+	for n, player := range table.Players() {
+		players[n] = string(player)
+	}
+	var finishedAt *time.Time
+	if !table.FinishedAt().IsZero() {
+		t := table.FinishedAt()
+		finishedAt = &t
+	}
+
+	rule := table.Rule()
+	if _, err := r.tx.Exec(ctx, query,
+		string(table.ID()),
+		string(table.GuildID()),
+		string(table.ChannelID()),
+		players,
+		rule.StartingPoints,
+		rule.ReturnPoints,
+		rule.Uma,
+		string(table.Status()),
+		string(table.CreatedBy()),
+		table.CreatedAt(),
+		finishedAt,
+	); err != nil {
+		return err
+	}
+
+	// 取り消しに対応するため、局の結果は保存し直す
+	if _, err := r.tx.Exec(ctx, `DELETE FROM mahjong_table_hands WHERE table_id = $1`, string(table.ID())); err != nil {
+		return err
+	}
+	for n, hand := range table.Hands() {
+		if _, err := r.tx.Exec(ctx, `
+			INSERT INTO mahjong_table_hands (table_id, number, winner, loser, ron_points, tsumo_non_dealer, tsumo_dealer, riichi, tenpai)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`,
+			string(table.ID()),
+			n+1,
+			nullableSeat(hand.Winner),
+			nullableSeat(hand.Loser),
+			hand.RonPoints,
+			hand.TsumoNonDealer,
+			hand.TsumoDealer,
+			nonNilSeats(hand.Riichi),
+			nonNilSeats(hand.Tenpai),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MahjongTableRepository) findOne(ctx context.Context, query string, args ...any) (*mahjong.Table, error) {
+	rows, err := r.tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	tableRows, err := collectTableRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(tableRows) == 0 {
+		return nil, mahjong.ErrTableNotFound
+	}
+	tables, err := r.rebuild(ctx, tableRows)
+	if err != nil {
+		return nil, err
+	}
+	return tables[0], nil
+}
+
+// rebuild は対局の行に局の結果を読み込んで対局を復元する
+func (r *MahjongTableRepository) rebuild(ctx context.Context, tableRows []tableRow) ([]*mahjong.Table, error) {
+	if len(tableRows) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(tableRows))
+	for n, row := range tableRows {
+		ids[n] = row.id
+	}
+
+	query := `
+		SELECT table_id, winner, loser, ron_points, tsumo_non_dealer, tsumo_dealer, riichi, tenpai
+		FROM mahjong_table_hands
+		WHERE table_id = ANY($1)
+		ORDER BY table_id, number
+	`
+	rows, err := r.tx.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hands := make(map[string][]mahjong.HandRecord, len(tableRows))
+	for rows.Next() {
+		var (
+			dbTableID string
+			dbWinner  *int
+			dbLoser   *int
+			hand      mahjong.HandRecord
+		)
+		if err := rows.Scan(&dbTableID, &dbWinner, &dbLoser, &hand.RonPoints, &hand.TsumoNonDealer, &hand.TsumoDealer, &hand.Riichi, &hand.Tenpai); err != nil {
+			return nil, err
+		}
+		hand.Winner, hand.Loser = seatOrNone(dbWinner), seatOrNone(dbLoser)
+		hands[dbTableID] = append(hands[dbTableID], hand)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tables := make([]*mahjong.Table, 0, len(tableRows))
+	for _, row := range tableRows {
+		players := make([]discordid.UserID, len(row.players))
+		for n, player := range row.players {
+			players[n] = discordid.UserID(player)
+		}
+		var finishedAt time.Time
+		if row.finishedAt != nil {
+			finishedAt = *row.finishedAt
+		}
+
+		table, err := mahjong.RebuildTable(
+			mahjong.TableID(row.id),
+			discordid.GuildID(row.guildID),
+			discordid.TextChannelID(row.channelID),
+			players,
+			mahjong.TableRule{StartingPoints: row.startingPoints, ReturnPoints: row.returnPoints, Uma: row.uma},
+			hands[row.id],
+			mahjong.TableStatus(row.status),
+			discordid.UserID(row.createdBy),
+			row.createdAt,
+			finishedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+func collectTableRows(rows db.Rows) ([]tableRow, error) {
+	defer rows.Close()
+
+	var tableRows []tableRow
+	for rows.Next() {
+		var row tableRow
+		if err := rows.Scan(
+			&row.id, &row.guildID, &row.channelID, &row.players, &row.startingPoints, &row.returnPoints,
+			&row.uma, &row.status, &row.createdBy, &row.createdAt, &row.finishedAt,
+		); err != nil {
+			return nil, err
+		}
+		tableRows = append(tableRows, row)
+	}
+	return tableRows, rows.Err()
+}
+
+// nullableSeat は席がなければ NULL として保存する
+func nullableSeat(seat int) *int {
+	if seat < 0 {
+		return nil
+	}
+	return &seat
+}
+
+func seatOrNone(seat *int) int {
+	if seat == nil {
+		return -1
+	}
+	return *seat
+}
+
+func nonNilSeats(seats []int) []int {
+	if seats == nil {
+		return []int{}
+	}
+	return seats
+}
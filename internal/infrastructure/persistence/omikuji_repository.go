@@ -0,0 +1,141 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type OmikujiDrawRepositoryFactory struct{}
+
+func NewOmikujiDrawRepositoryFactory() *OmikujiDrawRepositoryFactory {
+	return &OmikujiDrawRepositoryFactory{}
+}
+
+func (f *OmikujiDrawRepositoryFactory) OmikujiDraw(tx db.Tx) omikuji.Repository {
+	return NewOmikujiDrawRepository(&tx)
+}
+
+type OmikujiDrawRepository struct {
+	tx db.Tx
+}
+
+func NewOmikujiDrawRepository(tx *db.Tx) *OmikujiDrawRepository {
+	return &OmikujiDrawRepository{
+		tx: *tx,
+	}
+}
+
+func (r *OmikujiDrawRepository) Save(ctx context.Context, draw *omikuji.Draw) (bool, error) {
+	query := `
+		INSERT INTO omikuji_draws (guild_id, user_id, draw_date, level, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (guild_id, user_id, draw_date) DO NOTHING
+	`
+
+	tag, err := r.tx.Exec(ctx, query,
+		string(draw.GuildID()),
+		string(draw.UserID()),
+		draw.Date(),
+		draw.Level().Code(),
+		draw.CreatedAt(),
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *OmikujiDrawRepository) Find(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID, date time.Time) (*omikuji.Draw, error) {
+	query := `
+		SELECT guild_id, user_id, draw_date, level, created_at
+		FROM omikuji_draws
+		WHERE guild_id = $1 AND user_id = $2 AND draw_date = $3
+	`
+
+	draw, err := scanOmikujiDraw(r.tx.QueryRow(ctx, query, string(guildID), string(userID), omikuji.DateOf(date)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, omikuji.ErrDrawNotFound
+		}
+		return nil, err
+	}
+	return draw, nil
+}
+
+func (r *OmikujiDrawRepository) FindByUser(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) ([]*omikuji.Draw, error) {
+	query := `
+		SELECT guild_id, user_id, draw_date, level, created_at
+		FROM omikuji_draws
+		WHERE guild_id = $1 AND user_id = $2
+		ORDER BY draw_date
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(guildID), string(userID))
+	if err != nil {
+		return nil, err
+	}
+	return collectOmikujiDraws(rows)
+}
+
+func (r *OmikujiDrawRepository) FindByDate(ctx context.Context, guildID discordid.GuildID, date time.Time) ([]*omikuji.Draw, error) {
+	query := `
+		SELECT guild_id, user_id, draw_date, level, created_at
+		FROM omikuji_draws
+		WHERE guild_id = $1 AND draw_date = $2
+		ORDER BY created_at
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(guildID), omikuji.DateOf(date))
+	if err != nil {
+		return nil, err
+	}
+	return collectOmikujiDraws(rows)
+}
+
+func scanOmikujiDraw(row db.Row) (*omikuji.Draw, error) {
+	var (
+		dbGuildID   string
+		dbUserID    string
+		dbDrawDate  time.Time
+		dbLevel     string
+		dbCreatedAt time.Time
+	)
+
+	if err := row.Scan(&dbGuildID, &dbUserID, &dbDrawDate, &dbLevel, &dbCreatedAt); err != nil {
+		return nil, err
+	}
+
+	level, err := omikuji.ParseFortuneLevel(dbLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return omikuji.RebuildDraw(
+		discordid.GuildID(dbGuildID),
+		discordid.UserID(dbUserID),
+		dbDrawDate,
+		level,
+		dbCreatedAt,
+	)
+}
+
+func collectOmikujiDraws(rows db.Rows) ([]*omikuji.Draw, error) {
+	defer rows.Close()
+
+	var draws []*omikuji.Draw
+	for rows.Next() {
+		draw, err := scanOmikujiDraw(rows)
+		if err != nil {
+			return nil, err
+		}
+		draws = append(draws, draw)
+	}
+
+	return draws, rows.Err()
+}
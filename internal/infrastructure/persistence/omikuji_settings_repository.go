@@ -0,0 +1,185 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/omikuji"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type OmikujiSettingsRepositoryFactory struct{}
+
+func NewOmikujiSettingsRepositoryFactory() *OmikujiSettingsRepositoryFactory {
+	return &OmikujiSettingsRepositoryFactory{}
+}
+
+func (f *OmikujiSettingsRepositoryFactory) OmikujiSettings(tx db.Tx) omikuji.SettingsRepository {
+	return NewOmikujiSettingsRepository(&tx)
+}
+
+type OmikujiSettingsRepository struct {
+	tx db.Tx
+}
+
+func NewOmikujiSettingsRepository(tx *db.Tx) *OmikujiSettingsRepository {
+	return &OmikujiSettingsRepository{
+		tx: *tx,
+	}
+}
+
+func (r *OmikujiSettingsRepository) FindSettings(ctx context.Context, guildID discordid.GuildID) (*omikuji.GuildSettings, error) {
+	query := `
+		SELECT guild_id, timezone, weights, created_at, updated_at
+		FROM omikuji_guild_settings
+		WHERE guild_id = $1
+	`
+
+	var (
+		dbGuildID   string
+		dbTimezone  string
+		dbWeights   []int
+		dbCreatedAt time.Time
+		dbUpdatedAt time.Time
+	)
+
+	err := r.tx.QueryRow(ctx, query, string(guildID)).Scan(&dbGuildID, &dbTimezone, &dbWeights, &dbCreatedAt, &dbUpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, omikuji.ErrSettingsNotFound
+		}
+		return nil, err
+	}
+
+	return omikuji.RebuildGuildSettings(
+		discordid.GuildID(dbGuildID),
+		dbTimezone,
+		dbWeights,
+		dbCreatedAt,
+		dbUpdatedAt,
+	)
+}
+
+func (r *OmikujiSettingsRepository) SaveSettings(ctx context.Context, settings *omikuji.GuildSettings) error {
+	query := `
+		INSERT INTO omikuji_guild_settings (guild_id, timezone, weights, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (guild_id) DO UPDATE SET
+			timezone = EXCLUDED.timezone,
+			weights = EXCLUDED.weights,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.tx.Exec(ctx, query,
+		string(settings.GuildID()),
+		settings.Timezone(),
+		settings.Distribution().Permilles(),
+		settings.CreatedAt(),
+		settings.UpdatedAt(),
+	)
+	return err
+}
+
+func (r *OmikujiSettingsRepository) DeleteSettings(ctx context.Context, guildID discordid.GuildID) error {
+	query := `
+		DELETE FROM omikuji_guild_settings
+		WHERE guild_id = $1
+	`
+
+	_, err := r.tx.Exec(ctx, query, string(guildID))
+	return err
+}
+
+func (r *OmikujiSettingsRepository) FindOverrides(ctx context.Context, guildID discordid.GuildID) ([]*omikuji.Override, error) {
+	query := `
+		SELECT id, guild_id, label, start_date, end_date, weights, created_at
+		FROM omikuji_weight_overrides
+		WHERE guild_id = $1
+		ORDER BY start_date, created_at
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(guildID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides []*omikuji.Override
+	for rows.Next() {
+		var (
+			dbID        string
+			dbGuildID   string
+			dbLabel     string
+			dbStartDate time.Time
+			dbEndDate   time.Time
+			dbWeights   []int
+			dbCreatedAt time.Time
+		)
+		if err := rows.Scan(&dbID, &dbGuildID, &dbLabel, &dbStartDate, &dbEndDate, &dbWeights, &dbCreatedAt); err != nil {
+			return nil, err
+		}
+
+		override, err := omikuji.RebuildOverride(
+			omikuji.OverrideID(dbID),
+			discordid.GuildID(dbGuildID),
+			dbLabel,
+			dbStartDate,
+			dbEndDate,
+			dbWeights,
+			dbCreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+
+	return overrides, rows.Err()
+}
+
+func (r *OmikujiSettingsRepository) SaveOverride(ctx context.Context, override *omikuji.Override) error {
+	query := `
+		INSERT INTO omikuji_weight_overrides (id, guild_id, label, start_date, end_date, weights, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.tx.Exec(ctx, query,
+		string(override.ID()),
+		string(override.GuildID()),
+		override.Label(),
+		override.StartDate(),
+		override.EndDate(),
+		override.Distribution().Permilles(),
+		override.CreatedAt(),
+	)
+	return err
+}
+
+func (r *OmikujiSettingsRepository) DeleteOverride(ctx context.Context, guildID discordid.GuildID, id omikuji.OverrideID) error {
+	query := `
+		DELETE FROM omikuji_weight_overrides
+		WHERE guild_id = $1 AND id = $2
+	`
+
+	tag, err := r.tx.Exec(ctx, query, string(guildID), string(id))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return omikuji.ErrOverrideNotFound
+	}
+	return nil
+}
+
+func (r *OmikujiSettingsRepository) DeleteOverrides(ctx context.Context, guildID discordid.GuildID) error {
+	query := `
+		DELETE FROM omikuji_weight_overrides
+		WHERE guild_id = $1
+	`
+
+	_, err := r.tx.Exec(ctx, query, string(guildID))
+	return err
+}
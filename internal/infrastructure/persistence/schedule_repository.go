@@ -0,0 +1,177 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/schedule"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/aktnb/discord-bot-go/internal/shared/discordid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ScheduleRepositoryFactory struct{}
+
+func NewScheduleRepositoryFactory() *ScheduleRepositoryFactory {
+	return &ScheduleRepositoryFactory{}
+}
+
+func (f *ScheduleRepositoryFactory) Schedule(tx db.Tx) schedule.Repository {
+	return NewScheduleRepository(&tx)
+}
+
+type ScheduleRepository struct {
+	tx db.Tx
+}
+
+func NewScheduleRepository(tx *db.Tx) *ScheduleRepository {
+	return &ScheduleRepository{
+		tx: *tx,
+	}
+}
+
+func (r *ScheduleRepository) FindByID(ctx context.Context, id schedule.ID) (*schedule.Schedule, error) {
+	query := `
+		SELECT id, guild_id, channel_id, legend, spec, catch_up, next_run_at, last_run_at, created_by, created_at
+		FROM legend_schedules
+		WHERE id = $1
+	`
+
+	s, err := scanSchedule(r.tx.QueryRow(ctx, query, string(id)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, schedule.ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *ScheduleRepository) FindByGuild(ctx context.Context, guildID discordid.GuildID) ([]*schedule.Schedule, error) {
+	query := `
+		SELECT id, guild_id, channel_id, legend, spec, catch_up, next_run_at, last_run_at, created_by, created_at
+		FROM legend_schedules
+		WHERE guild_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.tx.Query(ctx, query, string(guildID))
+	if err != nil {
+		return nil, err
+	}
+	return collectSchedules(rows)
+}
+
+func (r *ScheduleRepository) FindDue(ctx context.Context, now time.Time) ([]*schedule.Schedule, error) {
+	query := `
+		SELECT id, guild_id, channel_id, legend, spec, catch_up, next_run_at, last_run_at, created_by, created_at
+		FROM legend_schedules
+		WHERE next_run_at <= $1
+		ORDER BY next_run_at, id
+	`
+
+	rows, err := r.tx.Query(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	return collectSchedules(rows)
+}
+
+func (r *ScheduleRepository) Save(ctx context.Context, s *schedule.Schedule) error {
+	query := `
+		INSERT INTO legend_schedules (id, guild_id, channel_id, legend, spec, catch_up, next_run_at, last_run_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			next_run_at = EXCLUDED.next_run_at,
+			last_run_at = EXCLUDED.last_run_at
+	`
+
+	var lastRunAt *time.Time
+	if !s.LastRunAt().IsZero() {
+		t := s.LastRunAt()
+		lastRunAt = &t
+	}
+
+	_, err := r.tx.Exec(ctx, query,
+		string(s.ID()),
+		string(s.GuildID()),
+		string(s.ChannelID()),
+		s.Legend(),
+		s.Spec().String(),
+		string(s.CatchUp()),
+		s.NextRunAt(),
+		lastRunAt,
+		string(s.CreatedBy()),
+		s.CreatedAt(),
+	)
+	return err
+}
+
+func (r *ScheduleRepository) Delete(ctx context.Context, guildID discordid.GuildID, id schedule.ID) error {
+	query := `
+		DELETE FROM legend_schedules
+		WHERE guild_id = $1 AND id = $2
+	`
+
+	tag, err := r.tx.Exec(ctx, query, string(guildID), string(id))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return schedule.ErrScheduleNotFound
+	}
+	return nil
+}
+
+func scanSchedule(row db.Row) (*schedule.Schedule, error) {
+	var (
+		dbID        string
+		dbGuildID   string
+		dbChannelID string
+		dbLegend    string
+		dbSpec      string
+		dbCatchUp   string
+		dbNextRunAt time.Time
+		dbLastRunAt *time.Time
+		dbCreatedBy string
+		dbCreatedAt time.Time
+	)
+
+	if err := row.Scan(&dbID, &dbGuildID, &dbChannelID, &dbLegend, &dbSpec, &dbCatchUp, &dbNextRunAt, &dbLastRunAt, &dbCreatedBy, &dbCreatedAt); err != nil {
+		return nil, err
+	}
+
+	var lastRunAt time.Time
+	if dbLastRunAt != nil {
+		lastRunAt = *dbLastRunAt
+	}
+
+	return schedule.RebuildSchedule(
+		schedule.ID(dbID),
+		discordid.GuildID(dbGuildID),
+		discordid.TextChannelID(dbChannelID),
+		dbLegend,
+		dbSpec,
+		schedule.CatchUp(dbCatchUp),
+		dbNextRunAt,
+		lastRunAt,
+		discordid.UserID(dbCreatedBy),
+		dbCreatedAt,
+	)
+}
+
+func collectSchedules(rows db.Rows) ([]*schedule.Schedule, error) {
+	defer rows.Close()
+
+	var schedules []*schedule.Schedule
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+
+	return schedules, rows.Err()
+}
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/version"
+	"github.com/aktnb/discord-bot-go/internal/interfaces/db"
+	"github.com/jackc/pgx/v5"
+)
+
+// announcedVersionKey は bot_metadata に保存する告知済みバージョンのキー
+const announcedVersionKey = "announced_version"
+
+type AnnouncedVersionRepositoryFactory struct{}
+
+func NewAnnouncedVersionRepositoryFactory() *AnnouncedVersionRepositoryFactory {
+	return &AnnouncedVersionRepositoryFactory{}
+}
+
+func (f *AnnouncedVersionRepositoryFactory) AnnouncedVersion(tx db.Tx) version.Repository {
+	return NewAnnouncedVersionRepository(&tx)
+}
+
+type AnnouncedVersionRepository struct {
+	tx db.Tx
+}
+
+func NewAnnouncedVersionRepository(tx *db.Tx) *AnnouncedVersionRepository {
+	return &AnnouncedVersionRepository{
+		tx: *tx,
+	}
+}
+
+func (r *AnnouncedVersionRepository) FindAnnouncedVersion(ctx context.Context) (string, error) {
+	query := `
+		SELECT value
+		FROM bot_metadata
+		WHERE key = $1
+	`
+
+	var value string
+	if err := r.tx.QueryRow(ctx, query, announcedVersionKey).Scan(&value); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", version.ErrAnnouncedVersionNotFound
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+func (r *AnnouncedVersionRepository) SaveAnnouncedVersion(ctx context.Context, v string) error {
+	query := `
+		INSERT INTO bot_metadata (key, value, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET value = EXCLUDED.value, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.tx.Exec(ctx, query, announcedVersionKey, v)
+	return err
+}
@@ -0,0 +1,232 @@
+// spritegen は牌の画像（sprites/*.png）を生成する
+//
+// 画像はリポジトリに含めて埋め込むため、通常は実行する必要はない。
+// 見た目を変えるときに tileimage パッケージで go generate を実行する
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	width  = 40
+	height = 56
+	// thickness は牌の厚みとして下に見せる部分の高さ
+	thickness = 4
+	// samples は縁を滑らかにするための1ピクセルあたりの標本数（一辺）
+	samples = 4
+)
+
+var (
+	edgeColor  = color.RGBA{0xB4, 0xA8, 0x8E, 0xFF}
+	faceColor  = color.RGBA{0xFB, 0xF7, 0xEC, 0xFF}
+	blackColor = color.RGBA{0x22, 0x22, 0x22, 0xFF}
+	redColor   = color.RGBA{0xD0, 0x31, 0x2D, 0xFF}
+	blueColor  = color.RGBA{0x23, 0x57, 0xA5, 0xFF}
+	greenColor = color.RGBA{0x22, 0x80, 0x3A, 0xFF}
+)
+
+// pipLayouts は筒子・索子の数字ごとの模様の中心（牌の表面に対する割合）
+var pipLayouts = [][][2]float64{
+	1: {{0.5, 0.5}},
+	2: {{0.5, 0.27}, {0.5, 0.73}},
+	3: {{0.27, 0.2}, {0.5, 0.5}, {0.73, 0.8}},
+	4: {{0.3, 0.27}, {0.7, 0.27}, {0.3, 0.73}, {0.7, 0.73}},
+	5: {{0.28, 0.22}, {0.72, 0.22}, {0.5, 0.5}, {0.28, 0.78}, {0.72, 0.78}},
+	6: {{0.3, 0.18}, {0.7, 0.18}, {0.3, 0.5}, {0.7, 0.5}, {0.3, 0.82}, {0.7, 0.82}},
+	7: {{0.24, 0.13}, {0.5, 0.24}, {0.76, 0.35}, {0.3, 0.62}, {0.7, 0.62}, {0.3, 0.86}, {0.7, 0.86}},
+	8: {{0.3, 0.13}, {0.7, 0.13}, {0.3, 0.38}, {0.7, 0.38}, {0.3, 0.62}, {0.7, 0.62}, {0.3, 0.87}, {0.7, 0.87}},
+	9: {{0.22, 0.18}, {0.5, 0.18}, {0.78, 0.18}, {0.22, 0.5}, {0.5, 0.5}, {0.78, 0.5}, {0.22, 0.82}, {0.5, 0.82}, {0.78, 0.82}},
+}
+
+func main() {
+	out := flag.String("out", "sprites", "output directory")
+	flag.Parse()
+
+	parsed, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		log.Fatal(err)
+	}
+	large := mustFace(parsed, 26)
+	small := mustFace(parsed, 14)
+
+	sprites := map[string]*image.RGBA{}
+	for number := 1; number <= 9; number++ {
+		sprites[fmt.Sprintf("%dm", number)] = manzu(number, false, large, small)
+		sprites[fmt.Sprintf("%dp", number)] = pinzu(number, false)
+		sprites[fmt.Sprintf("%ds", number)] = souzu(number, false)
+	}
+	sprites["0m"] = manzu(5, true, large, small)
+	sprites["0p"] = pinzu(5, true)
+	sprites["0s"] = souzu(5, true)
+	for number, letter := range []string{"E", "S", "W", "N"} {
+		sprites[fmt.Sprintf("%dz", number+1)] = letterTile(letter, blackColor, large)
+	}
+	sprites["5z"] = haku()
+	sprites["6z"] = letterTile("F", greenColor, large)
+	sprites["7z"] = letterTile("C", redColor, large)
+
+	for name, img := range sprites {
+		if err := writePNG(filepath.Join(*out, name+".png"), img); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func mustFace(parsed *opentype.Font, size float64) font.Face {
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return face
+}
+
+// blank は何も描いていない牌を返す
+func blank() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fill(img, edgeColor, func(x, y float64) bool {
+		return inRoundRect(x, y, 0, 0, width, height, 5)
+	})
+	fill(img, faceColor, func(x, y float64) bool {
+		return inRoundRect(x, y, 1, 1, width-1, height-thickness, 4)
+	})
+	return img
+}
+
+// facePoint は牌の表面に対する割合の座標をピクセルの座標に変換する
+func facePoint(p [2]float64) (float64, float64) {
+	const margin = 4
+	faceWidth, faceHeight := float64(width-2-2*margin), float64(height-thickness-1-2*margin)
+	return 1 + margin + p[0]*faceWidth, 1 + margin + p[1]*faceHeight
+}
+
+func pinzu(number int, red bool) *image.RGBA {
+	img := blank()
+	radius := 4.5
+	if number == 1 {
+		radius = 11
+	}
+	for n, p := range pipLayouts[number] {
+		cx, cy := facePoint(p)
+		c := blueColor
+		// 1 と 5 の中央、赤ドラは赤くする
+		if red || (number == 5 && n == 2) || number == 1 {
+			c = redColor
+		}
+		fill(img, c, func(x, y float64) bool { return math.Hypot(x-cx, y-cy) <= radius })
+		fill(img, faceColor, func(x, y float64) bool { return math.Hypot(x-cx, y-cy) <= radius*0.45 })
+	}
+	return img
+}
+
+func souzu(number int, red bool) *image.RGBA {
+	img := blank()
+	halfWidth, halfHeight := 2.5, 5.5
+	if number == 1 {
+		halfWidth, halfHeight = 5, 14
+	}
+	for n, p := range pipLayouts[number] {
+		cx, cy := facePoint(p)
+		c := greenColor
+		if red || (number == 5 && n == 2) || number == 1 {
+			c = redColor
+		}
+		fill(img, c, func(x, y float64) bool {
+			return inRoundRect(x, y, cx-halfWidth, cy-halfHeight, cx+halfWidth, cy+halfHeight, halfWidth)
+		})
+		// 竹の節
+		fill(img, faceColor, func(x, y float64) bool {
+			return math.Abs(y-cy) <= 0.6 && math.Abs(x-cx) <= halfWidth
+		})
+	}
+	return img
+}
+
+func manzu(number int, red bool, large, small font.Face) *image.RGBA {
+	img := blank()
+	c := blackColor
+	if red {
+		c = redColor
+	}
+	drawText(img, fmt.Sprint(number), c, large, height*0.45)
+	drawText(img, "m", redColor, small, height*0.78)
+	return img
+}
+
+func letterTile(letter string, c color.Color, face font.Face) *image.RGBA {
+	img := blank()
+	drawText(img, letter, c, face, (height-thickness)/2+1)
+	return img
+}
+
+func haku() *image.RGBA {
+	img := blank()
+	fill(img, blueColor, func(x, y float64) bool {
+		outer := inRoundRect(x, y, 8, 10, width-8, height-thickness-9, 3)
+		inner := inRoundRect(x, y, 11, 13, width-11, height-thickness-12, 2)
+		return outer && !inner
+	})
+	return img
+}
+
+// drawText は文字列を横方向の中央、縦方向の centerY を中心に描く
+func drawText(img *image.RGBA, text string, c color.Color, face font.Face, centerY float64) {
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face}
+	bounds, advance := d.BoundString(text)
+	textHeight := (bounds.Max.Y - bounds.Min.Y).Round()
+	x := (width - advance.Round()) / 2
+	y := int(centerY) + textHeight/2 - bounds.Max.Y.Round()
+	d.Dot = fixed.P(x, y)
+	d.DrawString(text)
+}
+
+// fill は inside を満たす部分を c で塗る。縁は標本を取って滑らかにする
+func fill(img *image.RGBA, c color.Color, inside func(x, y float64) bool) {
+	mask := image.NewAlpha(img.Bounds())
+	for py := 0; py < height; py++ {
+		for px := 0; px < width; px++ {
+			hits := 0
+			for sy := 0; sy < samples; sy++ {
+				for sx := 0; sx < samples; sx++ {
+					if inside(float64(px)+(float64(sx)+0.5)/samples, float64(py)+(float64(sy)+0.5)/samples) {
+						hits++
+					}
+				}
+			}
+			mask.SetAlpha(px, py, color.Alpha{A: uint8(hits * 255 / (samples * samples))})
+		}
+	}
+	draw.DrawMask(img, img.Bounds(), image.NewUniform(c), image.Point{}, mask, image.Point{}, draw.Over)
+}
+
+func inRoundRect(x, y, minX, minY, maxX, maxY, radius float64) bool {
+	if x < minX || x > maxX || y < minY || y > maxY {
+		return false
+	}
+	cx := math.Max(minX+radius, math.Min(x, maxX-radius))
+	cy := math.Max(minY+radius, math.Min(y, maxY-radius))
+	return math.Hypot(x-cx, y-cy) <= radius
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return png.Encode(f, img)
+}
@@ -0,0 +1,95 @@
+// Package tileimage は埋め込んだ牌の画像を並べて手牌の画像を描画する
+package tileimage
+
+//go:generate go run ./internal/spritegen -out sprites
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+)
+
+const (
+	// padding は手牌の周りの余白
+	padding = 12
+	// tileGap は牌と牌の間隔
+	tileGap = 2
+	// drawGap は第一ツモ（14 枚目）の前に空ける間隔
+	drawGap = 12
+)
+
+var backgroundColor = color.RGBA{0x2F, 0x6B, 0x45, 0xFF}
+
+// spriteFS は牌の画像（sprites/<牌の表記>.png、赤ドラは 0m などの表記）
+//
+//go:embed sprites/*.png
+var spriteFS embed.FS
+
+// Renderer は手牌を PNG 画像として描画する
+type Renderer struct {
+	sprites map[string]image.Image
+	size    image.Point
+}
+
+// NewRenderer は埋め込んだ牌の画像を読み込む
+func NewRenderer() (*Renderer, error) {
+	entries, err := spriteFS.ReadDir("sprites")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Renderer{sprites: make(map[string]image.Image, len(entries))}
+	for _, entry := range entries {
+		data, err := spriteFS.ReadFile("sprites/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode sprite %s: %w", entry.Name(), err)
+		}
+		r.sprites[entry.Name()[:len(entry.Name())-len(".png")]] = img
+		r.size = img.Bounds().Size()
+	}
+	return r, nil
+}
+
+// RenderPNG は手牌を左から順に並べた PNG 画像を描画する
+// 14 枚の場合は最後の1枚を第一ツモとして少し離して置く
+func (r *Renderer) RenderPNG(hand mahjong.Hand) ([]byte, error) {
+	if len(hand) == 0 {
+		return nil, fmt.Errorf("hand has no tiles")
+	}
+
+	width := 2*padding + len(hand)*r.size.X + (len(hand)-1)*tileGap
+	if len(hand) == mahjong.DealerHandSize {
+		width += drawGap
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width, 2*padding+r.size.Y))
+	draw.Draw(img, img.Bounds(), &image.Uniform{backgroundColor}, image.Point{}, draw.Src)
+
+	x := padding
+	for n, tile := range hand {
+		sprite, ok := r.sprites[tile.String()]
+		if !ok {
+			return nil, fmt.Errorf("no sprite for tile %s", tile)
+		}
+		if n == mahjong.HandSize {
+			x += drawGap
+		}
+		draw.Draw(img, image.Rect(x, padding, x+r.size.X, padding+r.size.Y), sprite, image.Point{}, draw.Over)
+		x += r.size.X + tileGap
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,51 @@
+package tileimage
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/aktnb/discord-bot-go/internal/domain/mahjong"
+)
+
+func TestNewRendererHasAllSprites(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tile := range mahjong.NewWall(true) {
+		if _, ok := r.sprites[tile.String()]; !ok {
+			t.Errorf("no sprite for %s", tile)
+		}
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wall := mahjong.NewWall(true)
+	for _, size := range []int{mahjong.HandSize, mahjong.DealerHandSize} {
+		data, err := r.RenderPNG(mahjong.Hand(wall[:size]))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("failed to decode png: %v", err)
+		}
+		want := 2*padding + size*r.size.X + (size-1)*tileGap
+		if size == mahjong.DealerHandSize {
+			want += drawGap
+		}
+		if img.Bounds().Dx() != want {
+			t.Errorf("width = %d, want %d", img.Bounds().Dx(), want)
+		}
+	}
+
+	if _, err := r.RenderPNG(nil); err == nil {
+		t.Error("expected error for an empty hand")
+	}
+}
@@ -20,5 +20,12 @@ type DiscordPort interface {
 
 	GetGuilds(ctx context.Context) ([]discordid.GuildID, error)
 	GetGuildVoiceStates(ctx context.Context, guildID discordid.GuildID) (map[discordid.VoiceChannelID][]discordid.UserID, error)
+	// IsBot はギルドのメンバーがボットのアカウントかを返す
+	IsBot(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID) (bool, error)
 	GetTextChannelMembers(ctx context.Context, textChannelID discordid.TextChannelID) ([]discordid.UserID, error)
+	// CanMoveMembers はメンバーがボイスチャンネルでメンバーを移動する権限を持つかを返す。チャンネルの権限の上書きも反映する
+	CanMoveMembers(ctx context.Context, userID discordid.UserID, voiceChannelID discordid.VoiceChannelID) (bool, error)
+	MoveMemberToVoiceChannel(ctx context.Context, guildID discordid.GuildID, userID discordid.UserID, voiceChannelID discordid.VoiceChannelID) error
+
+	SendMessage(ctx context.Context, channelID discordid.TextChannelID, content string) error
 }
@@ -0,0 +1,103 @@
+// Package cache は外部から取得した値を一定時間保持するキャッシュを提供する
+package cache
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// LoadTimeout は1回の読み込みに許す時間
+// 読み込みは呼び出した人の ctx から切り離して行うので、この時間で打ち切る
+const LoadTimeout = 30 * time.Second
+
+// Value は load で読み込んだ値を ttl の間保持する
+// 期限切れ後の読み込みに失敗した場合は、古い値があればそれを返す
+// 読み込みは同時に1つだけ行い、読み込み中は古い値があればそれを返し、無ければ読み込みの完了を待つ
+type Value[T any] struct {
+	ttl     time.Duration
+	timeout time.Duration
+	load    func(ctx context.Context) (T, error)
+	now     func() time.Time
+
+	mu       sync.Mutex
+	value    T
+	loaded   bool
+	loadedAt time.Time
+	// loading は実行中の読み込み。読み込んでいなければ nil
+	loading *loadCall[T]
+}
+
+// loadCall は1回の読み込みの結果を、完了を待っている呼び出しに渡す
+type loadCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func NewValue[T any](ttl time.Duration, load func(ctx context.Context) (T, error)) *Value[T] {
+	return &Value[T]{
+		ttl:     ttl,
+		timeout: LoadTimeout,
+		load:    load,
+		now:     time.Now,
+	}
+}
+
+// Get は保持している値を返す。値が無いか期限が切れていれば読み込み直す
+// 読み込みの間はロックを保持せず、呼び出しは ctx が終わればそれ以上待たない
+func (v *Value[T]) Get(ctx context.Context) (T, error) {
+	v.mu.Lock()
+	now := v.now()
+	if v.loaded && now.Sub(v.loadedAt) < v.ttl {
+		value := v.value
+		v.mu.Unlock()
+		return value, nil
+	}
+
+	call := v.loading
+	if call == nil {
+		call = &loadCall[T]{done: make(chan struct{})}
+		v.loading = call
+		go v.run(ctx, call, now)
+	} else if v.loaded {
+		// 他の呼び出しが読み込み中なので、古い値を返す
+		value := v.value
+		v.mu.Unlock()
+		return value, nil
+	}
+	v.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// run は読み込みを行い、結果を保持して待っている呼び出しに渡す
+// 最初に呼び出した人が待つのをやめても他の呼び出しの読み込みが失敗しないよう、ctx の取り消しは引き継がない
+func (v *Value[T]) run(ctx context.Context, call *loadCall[T], now time.Time) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), v.timeout)
+	defer cancel()
+	value, err := v.load(ctx)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.loading = nil
+	defer close(call.done)
+	if err != nil {
+		if v.loaded {
+			log.Printf("Failed to refresh cached value, serving stale value: %v", err)
+			call.value = v.value
+			return
+		}
+		call.err = err
+		return
+	}
+	v.value, v.loaded, v.loadedAt = value, true, now
+	call.value = value
+}
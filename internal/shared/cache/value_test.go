@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestValueGet(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var (
+		loads   int
+		loadErr error
+	)
+	v := NewValue(time.Hour, func(ctx context.Context) (int, error) {
+		if loadErr != nil {
+			return 0, loadErr
+		}
+		loads++
+		return loads, nil
+	})
+	v.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	get := func(want int) {
+		t.Helper()
+		got, err := v.Get(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("Get() = %d, want %d", got, want)
+		}
+	}
+
+	get(1)
+	// 期限内は読み込み直さない
+	now = now.Add(59 * time.Minute)
+	get(1)
+	// 期限が切れたら読み込み直す
+	now = now.Add(time.Minute)
+	get(2)
+	// 読み込みに失敗しても古い値を返す
+	now = now.Add(2 * time.Hour)
+	loadErr = errors.New("unavailable")
+	get(2)
+}
+
+func TestValueGetError(t *testing.T) {
+	loadErr := errors.New("unavailable")
+	v := NewValue(time.Hour, func(ctx context.Context) ([]string, error) {
+		return nil, loadErr
+	})
+	if _, err := v.Get(context.Background()); !errors.Is(err, loadErr) {
+		t.Errorf("expected %v, got %v", loadErr, err)
+	}
+}
+
+func TestValueGetConcurrent(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var (
+		mu      sync.Mutex
+		loads   int
+		started = make(chan struct{}, 1)
+		release = make(chan struct{})
+	)
+	v := NewValue(time.Hour, func(ctx context.Context) (int, error) {
+		mu.Lock()
+		loads++
+		n := loads
+		mu.Unlock()
+		started <- struct{}{}
+		<-release
+		return n, nil
+	})
+	v.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// 値が無いうちの同時の呼び出しは、1回の読み込みの完了を待つ
+	results := make(chan int, 3)
+	for range 3 {
+		go func() {
+			got, _ := v.Get(ctx)
+			results <- got
+		}()
+	}
+	<-started
+	close(release)
+	for range 3 {
+		if got := <-results; got != 1 {
+			t.Errorf("Get() = %d, want 1", got)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("loaded %d times, want 1", loads)
+	}
+
+	// 読み込み直している間は、他の呼び出しに古い値を返す
+	release = make(chan struct{})
+	now = now.Add(2 * time.Hour)
+	refreshed := make(chan int)
+	go func() {
+		got, _ := v.Get(ctx)
+		refreshed <- got
+	}()
+	<-started
+	if got, err := v.Get(ctx); err != nil || got != 1 {
+		t.Errorf("Get() during refresh = %d, %v, want the stale 1", got, err)
+	}
+	close(release)
+	if got := <-refreshed; got != 2 {
+		t.Errorf("refreshed Get() = %d, want 2", got)
+	}
+}
+
+func TestValueGetCancelledCaller(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	v := NewValue(time.Hour, func(ctx context.Context) (string, error) {
+		close(started)
+		<-release
+		// 最初に呼び出した人が取り消しても、読み込みの ctx は取り消されない
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		return "loaded", nil
+	})
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := v.Get(first)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan string)
+	go func() {
+		got, err := v.Get(context.Background())
+		if err != nil {
+			t.Errorf("second Get() error: %v", err)
+		}
+		second <- got
+	}()
+
+	// 取り消した呼び出しだけが待つのをやめる
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first Get() = %v, want context.Canceled", err)
+	}
+	close(release)
+	if got := <-second; got != "loaded" {
+		t.Errorf("second Get() = %q, want loaded", got)
+	}
+}
@@ -0,0 +1,98 @@
+// Package i18n はユーザー向け文字列のメッセージカタログを提供する
+//
+// カタログは locales/<locale>.json に埋め込まれ、キーは次の 2 系統に分かれる
+//   - command.* : スラッシュコマンドの名前・説明（Discord の Localizations 用）
+//   - msg.*     : コマンドの応答メッセージ
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Locale はボットが対応する言語
+type Locale string
+
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+
+	// Default は対応外のロケールやキーが見つからない場合に使う言語
+	Default = Japanese
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[Locale]map[string]string {
+	result := make(map[Locale]map[string]string)
+	for _, locale := range Locales() {
+		data, err := localeFS.ReadFile("locales/" + string(locale) + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: failed to read catalog %s: %v", locale, err))
+		}
+		catalog := make(map[string]string)
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: failed to parse catalog %s: %v", locale, err))
+		}
+		result[locale] = catalog
+	}
+	return result
+}
+
+// Locales は対応している全ロケールを返す
+func Locales() []Locale {
+	return []Locale{Japanese, English}
+}
+
+// Resolve は Discord のロケール文字列（"ja", "en-US" など）を優先順に評価し、
+// 最初に対応しているロケールを返す。どれにも対応していなければ Default を返す
+func Resolve(tags ...string) Locale {
+	for _, tag := range tags {
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		switch base {
+		case "ja":
+			return Japanese
+		case "en":
+			return English
+		}
+	}
+	return Default
+}
+
+// T はキーに対応する文字列を返す。args があれば fmt.Sprintf で埋め込む
+// 指定ロケールにキーが無い場合は Default にフォールバックし、それも無ければキーをそのまま返す
+func T(locale Locale, key string, args ...any) string {
+	text, ok := catalogs[locale][key]
+	if !ok {
+		text, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Has はロケールのカタログにキーが存在するかを返す
+func Has(locale Locale, key string) bool {
+	_, ok := catalogs[locale][key]
+	return ok
+}
+
+// Keys はロケールのカタログに含まれる全キーをソートして返す
+func Keys(locale Locale) []string {
+	keys := make([]string, 0, len(catalogs[locale]))
+	for key := range catalogs[locale] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
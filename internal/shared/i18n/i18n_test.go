@@ -0,0 +1,96 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for _, locale := range Locales() {
+		for _, other := range Locales() {
+			if locale == other {
+				continue
+			}
+			for _, key := range Keys(locale) {
+				if !Has(other, key) {
+					t.Errorf("key %q exists in %s but is missing in %s", key, locale, other)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalogsHaveNoEmptyValues(t *testing.T) {
+	for _, locale := range Locales() {
+		for _, key := range Keys(locale) {
+			if T(locale, key) == "" {
+				t.Errorf("key %q has empty value in %s", key, locale)
+			}
+		}
+	}
+}
+
+// keyLiteral はソースコード中のカタログキーの文字列リテラルにマッチする
+var keyLiteral = regexp.MustCompile(`"((?:command|msg)\.[a-z0-9_]+(?:\.[a-z0-9_]+)*)"`)
+
+func TestSourceKeysExistInCatalogs(t *testing.T) {
+	root := filepath.Join("..", "..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range keyLiteral.FindAllStringSubmatch(string(src), -1) {
+			key := match[1]
+			for _, locale := range Locales() {
+				if !Has(locale, key) {
+					t.Errorf("%s: key %q is missing in %s catalog", path, key, locale)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk source tree: %v", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected Locale
+	}{
+		{name: "japanese", tags: []string{"ja"}, expected: Japanese},
+		{name: "english us", tags: []string{"en-US"}, expected: English},
+		{name: "english gb", tags: []string{"en-GB"}, expected: English},
+		{name: "unsupported falls back to guild locale", tags: []string{"fr", "en-US"}, expected: English},
+		{name: "unsupported falls back to default", tags: []string{"fr", ""}, expected: Default},
+		{name: "no tags", expected: Default},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(tt.tags...); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTFallsBackToKey(t *testing.T) {
+	if got := T(English, "msg.unknown.key"); got != "msg.unknown.key" {
+		t.Errorf("expected key to be returned, got %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+// Package logging は標準 log パッケージの出力をログレベルで絞り込む
+//
+// このリポジトリのログは "[INFO]" "[WARN]" "[ERROR]" などのタグをメッセージに含める慣習のため、
+// 出力時にタグを判定して現在のレベル未満の行を捨てる
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// Level はログレベル
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel は "debug" "info" "warn" "error" をログレベルに変換する
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level: %s", s)
+	}
+}
+
+var current atomic.Int32
+
+func init() {
+	current.Store(int32(LevelInfo))
+}
+
+// SetLevel は出力する最小のログレベルを変更する
+func SetLevel(level Level) {
+	current.Store(int32(level))
+}
+
+// CurrentLevel は現在のログレベルを返す
+func CurrentLevel() Level {
+	return Level(current.Load())
+}
+
+// levelTags はログ行に含まれるタグとレベルの対応
+var levelTags = []struct {
+	tag   []byte
+	level Level
+}{
+	{[]byte("[DEBUG]"), LevelDebug},
+	{[]byte("[INFO]"), LevelInfo},
+	{[]byte("[WARN]"), LevelWarn},
+	{[]byte("[ERROR]"), LevelError},
+	// タグの無い既存のログは先頭の単語で判定する
+	{[]byte(" Error "), LevelError},
+	{[]byte(" Warning: "), LevelWarn},
+}
+
+// lineLevel はログ行のレベルを判定する。判定できない行は INFO とみなす
+func lineLevel(line []byte) Level {
+	for _, t := range levelTags {
+		if bytes.Contains(line, t.tag) {
+			return t.level
+		}
+	}
+	return LevelInfo
+}
+
+type filterWriter struct {
+	out io.Writer
+}
+
+// NewFilterWriter は現在のログレベル未満の行を捨てる io.Writer を返す
+// log.SetOutput に渡して使う。log パッケージは1行ずつ Write を呼ぶ
+func NewFilterWriter(out io.Writer) io.Writer {
+	return &filterWriter{out: out}
+}
+
+func (w *filterWriter) Write(p []byte) (int, error) {
+	if lineLevel(p) < CurrentLevel() {
+		return len(p), nil
+	}
+	return w.out.Write(p)
+}
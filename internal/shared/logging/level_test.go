@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestFilterWriter(t *testing.T) {
+	defer SetLevel(LevelInfo)
+
+	var buf bytes.Buffer
+	logger := log.New(NewFilterWriter(&buf), "", log.LstdFlags)
+
+	tests := []struct {
+		name    string
+		level   Level
+		message string
+		written bool
+	}{
+		{name: "info at info", level: LevelInfo, message: "[INFO] Sync started", written: true},
+		{name: "debug at info", level: LevelInfo, message: "[DEBUG] details", written: false},
+		{name: "untagged at info", level: LevelInfo, message: "Bot is ready.", written: true},
+		{name: "untagged at warn", level: LevelWarn, message: "Bot is ready.", written: false},
+		{name: "untagged error at warn", level: LevelWarn, message: "Error handling command ping: boom", written: true},
+		{name: "untagged warning at warn", level: LevelWarn, message: "Warning: sync failed", written: true},
+		{name: "warn at error", level: LevelError, message: "[WARN] Guild not found", written: false},
+		{name: "error at error", level: LevelError, message: "[ERROR] Failed", written: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			SetLevel(tt.level)
+			logger.Print(tt.message)
+			if got := buf.Len() > 0; got != tt.written {
+				t.Errorf("expected written=%v, got %v", tt.written, got)
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for input, expected := range map[string]Level{
+		"debug": LevelDebug,
+		"INFO":  LevelInfo,
+		"":      LevelInfo,
+		"warn":  LevelWarn,
+		"error": LevelError,
+	} {
+		got, err := ParseLevel(input)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", input, err)
+		}
+		if got != expected {
+			t.Errorf("expected %s for %q, got %s", expected, input, got)
+		}
+	}
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}